@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// addLine is one parsed line of `countdown add` input.
+type addLine struct {
+	Num   int
+	Event Event
+	Err   error
+}
+
+// parseAddLines reads "name<delim>date" lines from r. Blank lines and lines
+// starting with # are skipped. The name ends at the first delimiter, so the
+// date may contain it but the name may not.
+func parseAddLines(r io.Reader, delim string, cfg Config, now time.Time) ([]addLine, error) {
+	var lines []addLine
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		e, err := parseAddLine(text, delim, cfg, now)
+		lines = append(lines, addLine{Num: n, Event: e, Err: err})
+	}
+	return lines, sc.Err()
+}
+
+func parseAddLine(text, delim string, cfg Config, now time.Time) (Event, error) {
+	name, date, ok := strings.Cut(text, delim)
+	name, date = strings.TrimSpace(name), strings.TrimSpace(date)
+	if !ok || date == "" {
+		return Event{}, fmt.Errorf("want name%sdate", delim)
+	}
+	if name == "" {
+		return Event{}, fmt.Errorf("no name before %q", delim)
+	}
+	ts, err := parseDateInput(date, now)
+	if err != nil {
+		return Event{}, err
+	}
+	if err := cfg.checkYear(ts); err != nil {
+		return Event{}, err
+	}
+	return Event{Name: name, Time: ts.Unix(), AllDay: isAllDayInput(date)}, nil
+}
+
+// addEvents adds the parsed events that are not in events already, placing
+// each after those at the same time as the form does. It reports for each
+// line whether it was added.
+func addEvents(events []Event, lines []addLine, now time.Time) ([]Event, []bool) {
+	added := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.Err != nil || hasSharedEvent(events, l.Event) {
+			continue
+		}
+		e := l.Event
+		for _, existing := range events {
+			if existing.Time == e.Time && existing.Order >= e.Order {
+				e.Order = existing.Order + 1
+			}
+		}
+		e.Created = now.Unix()
+		events = append(events, e)
+		added[i] = true
+	}
+	sortEventsByTime(events)
+	return events, added
+}
+
+// runAdd adds events from its arguments, or from standard input with "-",
+// one "name|date" per line, and saves them all at once.
+func runAdd(args []string) int {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	delim := fs.String("delimiter", "|", "separates the name from the date")
+	strict := fs.Bool("strict", false, "add nothing if any line is invalid")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), `usage: countdown add [flags] "name|date"... or - to read lines from standard input`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 || *delim == "" {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "add: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+	now := time.Now()
+	var input io.Reader = strings.NewReader(strings.Join(fs.Args(), "\n"))
+	if fs.NArg() == 1 && fs.Arg(0) == "-" {
+		input = os.Stdin
+	}
+	lines, err := parseAddLines(input, *delim, cfg, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "add: %v\n", err)
+		return 1
+	}
+
+	invalid := 0
+	for _, l := range lines {
+		if l.Err != nil {
+			invalid++
+		}
+	}
+	if *strict && invalid > 0 {
+		for _, l := range lines {
+			if l.Err != nil {
+				fmt.Fprintf(os.Stderr, "line %d: %v\n", l.Num, l.Err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "add: %d invalid lines, nothing added\n", invalid)
+		return 1
+	}
+
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "add: %v\n", err)
+		return 1
+	}
+	before := len(events)
+	events, added := addEvents(events, lines, now)
+	if len(events) > before {
+		if err := writeEventsFile(events); err != nil {
+			fmt.Fprintf(os.Stderr, "add: %v\n", err)
+			return 1
+		}
+	}
+	count, duplicates := 0, 0
+	for i, l := range lines {
+		switch {
+		case l.Err != nil:
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", l.Num, l.Err)
+		case added[i]:
+			count++
+			fmt.Printf("line %d: added %q at %s\n", l.Num, l.Event.Name, time.Unix(l.Event.Time, 0).Format(cfg.dateTimeLayout()))
+		default:
+			duplicates++
+			fmt.Printf("line %d: already have %q\n", l.Num, l.Event.Name)
+		}
+	}
+	fmt.Printf("Added %d events, skipped %d duplicates and %d invalid lines\n", count, duplicates, invalid)
+	return 0
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAddLines(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	input := strings.Join([]string{
+		"Conf talk|2026-09-10 14:00",
+		"",
+		"# from the team calendar",
+		"No date here",
+		"Party | tomorrow",
+		"|2026-09-10",
+		"Battle|1066-10-14",
+		"Typo|2026-13-01",
+	}, "\n")
+
+	lines, err := parseAddLines(strings.NewReader(input), "|", defaultConfig(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, l := range lines {
+		if l.Err != nil {
+			got = append(got, "error")
+		} else {
+			got = append(got, l.Event.Name+" "+time.Unix(l.Event.Time, 0).UTC().Format(inputTimeFormMinutes))
+		}
+	}
+	expected := []string{
+		"Conf talk 2026-09-10 14:00",
+		"error",
+		"Party 2026-06-02 00:00",
+		"error",
+		"Battle 1066-10-14 00:00",
+		"error",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected lines:\n%s\nExpected:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+	if lines[0].Event.AllDay || !lines[4].Event.AllDay {
+		t.Error("Expected only dates without a time to make all-day events")
+	}
+	if lines[1].Num != 4 || lines[5].Num != 8 {
+		t.Errorf("Expected line numbers to count skipped lines, got %d and %d", lines[1].Num, lines[5].Num)
+	}
+
+	cfg := defaultConfig()
+	cfg.MinYear = 1900
+	if _, err := parseAddLine("Battle|1066-10-14", "|", cfg, now); err == nil {
+		t.Error("Expected the year range to apply")
+	}
+	if l, err := parseAddLine("Launch\t2026-07-01", "\t", cfg, now); err != nil || l.Name != "Launch" {
+		t.Errorf("Expected a tab delimiter to work, got %+v, %v", l, err)
+	}
+}
+
+func TestRunAdd(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	existing := Event{Name: "Launch", Time: time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local).Unix()}
+	if err := writeEventsFile([]Event{existing}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	if code := runAdd([]string{"--strict", "Review|2030-01-01", "Broken"}); code != 1 {
+		t.Errorf("Expected --strict to fail, got %d", code)
+	}
+	if events, _ := readEventsFile(); len(events) != 1 {
+		t.Errorf("Expected --strict to add nothing, got %d events", len(events))
+	}
+
+	if code := runAdd([]string{"Review|2030-01-01", "Broken", "Launch|2030-01-01"}); code != 0 {
+		t.Errorf("Expected a partial batch to succeed, got %d", code)
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := storedNames(events); got != "Launch,Review" {
+		t.Errorf("Expected Review added after Launch once, got %s", got)
+	}
+	if events[1].Order != 1 || events[1].Created == 0 {
+		t.Errorf("Expected Review ordered after Launch with a creation time, got %+v", events[1])
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// isAllDayInput reports whether s is a date without a time, which makes
+// the event an all-day one.
+func isAllDayInput(s string) bool {
+	_, err := time.ParseInLocation(inputTimeFormShort, strings.TrimSpace(s), time.Local)
+	return err == nil
+}
+
+// formDateValue is e's date as the form shows it for editing: without a
+// time for all-day events, so saving keeps them all-day.
+func formDateValue(e Event) string {
+	if e.AllDay {
+		return time.Unix(e.Time, 0).Format(inputTimeFormShort)
+	}
+	return time.Unix(e.Time, 0).Format(inputTimeFormLong)
+}
+
+// eventTime is the time of day of e as the details show it.
+func (c Config) eventTime(e Event) string {
+	if e.AllDay {
+		return tr("allday.all_day")
+	}
+	return time.Unix(e.Time, 0).Format(c.timeLayout())
+}
+
+// eventDateTime is e's date and time, or only its date if it is all-day.
+func (c Config) eventDateTime(e Event) string {
+	if e.AllDay {
+		return time.Unix(e.Time, 0).Format(c.dateLayout())
+	}
+	return time.Unix(e.Time, 0).Format(c.dateTimeLayout())
+}
+
+// passed reports whether e is over at now. An all-day event lasts until
+// the end of its day rather than passing at midnight.
+func (e Event) passed(now time.Time) bool {
+	if e.AllDay {
+		return daysUntil(e.Time, now) < 0
+	}
+	return e.Time < now.Unix()
+}
+
+// urgencyColor is getUrgencyColor, except that an all-day event stays at
+// its most urgent all through its day.
+func (e Event) urgencyColor(now time.Time) string {
+	if e.AllDay && isToday(e.Time, now) {
+		return cUrgency6
+	}
+	return getUrgencyColor(e.Time, now)
+}
+
+// allDayCountdown counts whole days to an all-day event on the day of ts:
+// "today!", "tomorrow", "in 3 days", "yesterday" or "3 days ago".
+func allDayCountdown(ts int64, now time.Time) string {
+	switch days := daysUntil(ts, now); {
+	case days == 0:
+		return tr("countdown.today")
+	case days == 1:
+		return tr("allday.tomorrow")
+	case days == -1:
+		return tr("allday.yesterday")
+	case days > 1:
+		return trn("allday.in_days", days)
+	default:
+		return trn("countdown.since", -days)
+	}
+}
+
+// allDayCountdown is countdown for all-day events.
+func (d eventDelegate) allDayCountdown(e Event) string {
+	return lipgloss.NewStyle().Foreground(paletteColor(e.urgencyColor(d.now))).Render(allDayCountdown(e.Time, d.now))
+}
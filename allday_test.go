@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+func TestAllDayCountdown(t *testing.T) {
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	day := func(d int) int64 { return time.Date(2026, 3, 10+d, 0, 0, 0, 0, time.UTC).Unix() }
+	tests := []struct {
+		ts   int64
+		want string
+	}{
+		{day(0), "today!"},
+		{day(1), "tomorrow"},
+		{day(3), "in 3 days"},
+		{day(-1), "yesterday"},
+		{day(-5), "5 days ago"},
+	}
+	for _, tt := range tests {
+		if got := allDayCountdown(tt.ts, now); got != tt.want {
+			t.Errorf("allDayCountdown(%s) = %q, want %q", time.Unix(tt.ts, 0).UTC().Format(inputTimeFormShort), got, tt.want)
+		}
+	}
+}
+
+func TestEventPassed(t *testing.T) {
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.Local)
+	midnight := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local).Unix()
+	if (Event{Time: midnight, AllDay: true}).passed(now) {
+		t.Error("Expected an all-day event to last all through its day")
+	}
+	if !(Event{Time: midnight}).passed(now) {
+		t.Error("Expected a timed event at midnight to have passed")
+	}
+	if !(Event{Time: midnight - secondsPerDay, AllDay: true}).passed(now) {
+		t.Error("Expected yesterday's all-day event to have passed")
+	}
+}
+
+func TestAllDayEvents(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 9, 20, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+	send(tea.WindowSizeMsg{Width: 140, Height: 40})
+
+	add := func(name, date string) {
+		t.Helper()
+		m.openForm(showInput)
+		m.inputs[inputNameField].SetValue(name)
+		m.inputs[inputTimeField].SetValue(date)
+		send(tea.KeyMsg{Type: tea.KeyCtrlS})
+		if m.state != showEvents {
+			t.Fatalf("Expected %s added, got %q", name, m.inputStatus)
+		}
+	}
+	add("Birthday", "2026-03-10")
+	add("Standup", "2026-03-10 09:00")
+	events, _ := readEventsFile()
+	if len(events) != 2 || !events[0].AllDay || events[1].AllDay {
+		t.Fatalf("Expected only the event without a time all-day, got %+v", events)
+	}
+
+	m.events.Select(0)
+	view := withColorProfile(termenv.Ascii, m.View)
+	for _, want := range []string{"tomorrow", "All day"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q the night before, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "12:00:00 AM") || strings.Contains(view, "0d 4h") {
+		t.Errorf("Expected no time of day for the all-day event, got:\n%s", view)
+	}
+
+	now = time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	view = withColorProfile(termenv.Ascii, m.View)
+	if !strings.Contains(view, "[TODAY] Birthday") || !strings.Contains(view, "[EARLIER] Standup") {
+		t.Errorf("Expected the all-day event to stay today while the timed one passed, got:\n%s", view)
+	}
+	if !strings.Contains(view, "│ today!") {
+		t.Errorf("Expected the all-day event counted as today rather than ago, got:\n%s", view)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if got := m.inputs[inputTimeField].Value(); got != "2026-03-10" {
+		t.Errorf("Expected the form to edit the date only, got %q", got)
+	}
+	m.inputs[inputTimeField].SetValue("2026-03-10 18:30")
+	send(tea.KeyMsg{Type: tea.KeyCtrlS}, tea.KeyMsg{Type: tea.KeyEnter})
+	events, _ = readEventsFile()
+	if len(events) != 2 || events[1].Name != "Birthday" || events[1].AllDay {
+		t.Errorf("Expected typing a time to make it a timed event, got %+v", events)
+	}
+}
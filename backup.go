@@ -0,0 +1,334 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// A backup bundle is a gzipped tar of countdown's files under fixed names,
+// config/ for the config directory and data/ for the events and what lives
+// next to them, so it restores onto a machine whose directories differ.
+const (
+	bundleConfigDir  = "config/"
+	bundleDataDir    = "data/"
+	bundleLocalesDir = bundleConfigDir + "locales/"
+	maxBundleEntry   = 64 << 20
+)
+
+// bundleEntry is one file read from a bundle.
+type bundleEntry struct {
+	Name    string
+	Data    []byte
+	ModTime time.Time
+}
+
+// bundleLayout is where the files of a bundle live on this machine.
+type bundleLayout struct {
+	Files   map[string]string // bundle name to path
+	Locales string            // directory of the user's translations
+}
+
+func currentBundleLayout() (bundleLayout, error) {
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		return bundleLayout{}, err
+	}
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		return bundleLayout{}, err
+	}
+	dataDir, err := appDataDir()
+	if err != nil {
+		return bundleLayout{}, err
+	}
+	return bundleLayout{
+		Files: map[string]string{
+			bundleConfigDir + configFileName: configFile,
+			bundleDataDir + eventsFileName:   eventsFile,
+			bundleDataDir + stateFileName:    filepath.Join(dataDir, stateFileName),
+			bundleDataDir + journalFileName:  filepath.Join(dataDir, journalFileName),
+		},
+		Locales: filepath.Join(filepath.Dir(configFile), "locales"),
+	}, nil
+}
+
+// path returns where the bundle file name is restored to, or false for a
+// name countdown does not know.
+func (l bundleLayout) path(name string) (string, bool) {
+	if p, ok := l.Files[name]; ok {
+		return p, true
+	}
+	if dir, base := path.Split(name); dir == bundleLocalesDir && path.Ext(base) == ".toml" && len(base) > len(".toml") {
+		return filepath.Join(l.Locales, base), true
+	}
+	return "", false
+}
+
+// names lists the bundle names of the files present here, in a fixed order.
+func (l bundleLayout) names() []string {
+	var names []string
+	for _, name := range []string{
+		bundleConfigDir + configFileName,
+		bundleDataDir + eventsFileName,
+		bundleDataDir + stateFileName,
+		bundleDataDir + journalFileName,
+	} {
+		if _, err := os.Stat(l.Files[name]); err == nil {
+			names = append(names, name)
+		}
+	}
+	locales, _ := filepath.Glob(filepath.Join(l.Locales, "*.toml"))
+	for _, f := range locales {
+		names = append(names, bundleLocalesDir+filepath.Base(f))
+	}
+	return names
+}
+
+// writeBundle writes the files present in l to w as a bundle and returns
+// their names.
+func writeBundle(w io.Writer, l bundleLayout) ([]string, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	names := l.names()
+	for _, name := range names {
+		p, _ := l.path(name)
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: info.ModTime(), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return names, gz.Close()
+}
+
+// readBundle reads and checks every file of a bundle before anything is
+// restored: names must be ones countdown writes, and each file must parse
+// as what it claims to be.
+func readBundle(r io.Reader, l bundleLayout) ([]bundleEntry, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup bundle: %w", err)
+	}
+	ar := tar.NewReader(gz)
+	var entries []bundleEntry
+	seen := map[string]bool{}
+	for {
+		hdr, err := ar.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a backup bundle: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("%s: not a regular file", hdr.Name)
+		}
+		if _, ok := l.path(hdr.Name); !ok || path.Clean(hdr.Name) != hdr.Name {
+			return nil, fmt.Errorf("%s: not a countdown file", hdr.Name)
+		}
+		if seen[hdr.Name] {
+			return nil, fmt.Errorf("%s: in the bundle twice", hdr.Name)
+		}
+		seen[hdr.Name] = true
+		if hdr.Size > maxBundleEntry {
+			return nil, fmt.Errorf("%s: too large", hdr.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(ar, maxBundleEntry))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+		e := bundleEntry{Name: hdr.Name, Data: data, ModTime: hdr.ModTime}
+		if err := checkBundleEntry(e); err != nil {
+			return nil, fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("the bundle is empty")
+	}
+	return entries, nil
+}
+
+func checkBundleEntry(e bundleEntry) error {
+	switch {
+	case e.Name == bundleConfigDir+configFileName:
+		cfg := defaultConfig()
+		if _, err := toml.Decode(string(e.Data), &cfg); err != nil {
+			return err
+		}
+		return cfg.validate()
+	case e.Name == bundleDataDir+eventsFileName:
+		_, err := decodeEvents(e.Name, e.Data)
+		return err
+	case e.Name == bundleDataDir+stateFileName:
+		var st appState
+		return json.Unmarshal(e.Data, &st)
+	case path.Dir(e.Name)+"/" == bundleLocalesDir:
+		var catalog map[string]interface{}
+		_, err := toml.Decode(string(e.Data), &catalog)
+		return err
+	}
+	// The journal tolerates a torn last line, so any content will do.
+	return nil
+}
+
+// restoreStep is what restoring one bundle file does.
+type restoreStep struct {
+	Entry     bundleEntry
+	Path      string
+	Unchanged bool // the file here already has this content
+	Newer     bool // the file here was modified after the one in the bundle
+}
+
+func planRestore(entries []bundleEntry, l bundleLayout) []restoreStep {
+	steps := make([]restoreStep, len(entries))
+	for i, e := range entries {
+		p, _ := l.path(e.Name)
+		steps[i] = restoreStep{Entry: e, Path: p}
+		if data, err := os.ReadFile(p); err == nil && bytes.Equal(data, e.Data) {
+			steps[i].Unchanged = true
+		} else if info, err := os.Stat(p); err == nil && info.ModTime().After(e.ModTime) {
+			steps[i].Newer = true
+		}
+	}
+	return steps
+}
+
+// restoreFile writes a bundle file into place, keeping its modification
+// time so a later restore can tell which copy is newer.
+func restoreFile(s restoreStep) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.Path, s.Entry.Data); err != nil {
+		return err
+	}
+	return os.Chtimes(s.Path, s.Entry.ModTime, s.Entry.ModTime)
+}
+
+func runBackup(args []string) int {
+	usage := func() int {
+		fmt.Fprintln(os.Stderr, "usage: countdown backup create <bundle.tar.gz>\n       countdown backup restore [--force] <bundle.tar.gz>")
+		return 2
+	}
+	if len(args) == 0 {
+		return usage()
+	}
+	switch args[0] {
+	case "create":
+		return runBackupCreate(args[1:], usage)
+	case "restore":
+		return runBackupRestore(args[1:], usage)
+	}
+	return usage()
+}
+
+func runBackupCreate(args []string, usage func() int) int {
+	if len(args) != 1 {
+		return usage()
+	}
+	l, err := currentBundleLayout()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		return 1
+	}
+	var b bytes.Buffer
+	names, err := writeBundle(&b, l)
+	if err == nil {
+		err = writeFileAtomic(args[0], b.Bytes())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		return 1
+	}
+	for _, name := range names {
+		p, _ := l.path(name)
+		fmt.Printf("%s ← %s\n", name, p)
+	}
+	fmt.Printf("Wrote %d files to %s\n", len(names), args[0])
+	return 0
+}
+
+func runBackupRestore(args []string, usage func() int) int {
+	fs := flag.NewFlagSet("backup restore", flag.ContinueOnError)
+	force := fs.Bool("force", false, "overwrite files modified after the backup was made")
+	fs.Usage = func() { usage() }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		return usage()
+	}
+	l, err := currentBundleLayout()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		return 1
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		return 1
+	}
+	defer f.Close()
+	entries, err := readBundle(f, l)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %s: %v, nothing restored\n", fs.Arg(0), err)
+		return 1
+	}
+
+	steps := planRestore(entries, l)
+	newer := 0
+	for _, s := range steps {
+		if s.Newer && !*force {
+			newer++
+			fmt.Fprintf(os.Stderr, "%s changed since the backup of %s\n", s.Path, s.Entry.ModTime.Format(inputTimeFormLong))
+		}
+	}
+	if newer > 0 {
+		fmt.Fprintf(os.Stderr, "backup: %d newer files, nothing restored; use --force to overwrite them\n", newer)
+		return 1
+	}
+	restored := 0
+	for _, s := range steps {
+		if s.Unchanged {
+			fmt.Printf("%s = %s (unchanged)\n", s.Entry.Name, s.Path)
+			continue
+		}
+		if err := restoreFile(s); err != nil {
+			fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+			return 1
+		}
+		restored++
+		fmt.Printf("%s → %s\n", s.Entry.Name, s.Path)
+	}
+	fmt.Printf("Restored %d files\n", restored)
+	return 0
+}
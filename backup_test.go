@@ -0,0 +1,117 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackupRoundTrip(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	writeConfigFile(t, "language = \"de\"\n")
+	if err := writeEventsFile([]Event{{Name: "Launch", Time: 1900000000}}); err != nil {
+		t.Fatal(err)
+	}
+	l, err := currentBundleLayout()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(l.Locales, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(l.Locales, "fr.toml"), []byte("[list]\nevents = \"Événements\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	stdout, stderr := os.Stdout, os.Stderr
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+	if code := runBackup([]string{"create", bundle}); code != 0 {
+		t.Fatalf("Expected create to succeed, got %d", code)
+	}
+
+	// A file changed after the backup is only overwritten with --force.
+	eventsFile := l.Files[bundleDataDir+eventsFileName]
+	later := time.Now().Add(time.Minute)
+	if err := writeEventsFile([]Event{{Name: "Changed", Time: 1900000000}}); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(eventsFile, later, later)
+	if code := runBackup([]string{"restore", bundle}); code != 1 {
+		t.Errorf("Expected restore to refuse a newer file, got %d", code)
+	}
+	if events, _ := readEventsFile(); storedNames(events) != "Changed" {
+		t.Errorf("Expected nothing restored, got %s", storedNames(events))
+	}
+	if code := runBackup([]string{"restore", "--force", bundle}); code != 0 {
+		t.Errorf("Expected --force to restore, got %d", code)
+	}
+	if events, _ := readEventsFile(); storedNames(events) != "Launch" {
+		t.Errorf("Expected the backed up events, got %s", storedNames(events))
+	}
+
+	// Everything restores onto a machine that has nothing yet.
+	for _, p := range []string{eventsFile, filepath.Join(l.Locales, "fr.toml"), l.Files[bundleConfigDir+configFileName]} {
+		os.Remove(p)
+	}
+	if code := runBackup([]string{"restore", bundle}); code != 0 {
+		t.Errorf("Expected restore onto empty directories, got %d", code)
+	}
+	for _, p := range []string{eventsFile, filepath.Join(l.Locales, "fr.toml"), l.Files[bundleConfigDir+configFileName]} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("Expected %s restored: %v", p, err)
+		}
+	}
+}
+
+func TestReadBundleRejects(t *testing.T) {
+	l := bundleLayout{Files: map[string]string{
+		bundleConfigDir + configFileName: "/c/config.toml",
+		bundleDataDir + eventsFileName:   "/d/events.json",
+	}, Locales: "/c/locales"}
+	bundle := func(name, content string) *bytes.Buffer {
+		var b bytes.Buffer
+		gz := gzip.NewWriter(&b)
+		tw := tar.NewWriter(gz)
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(content))
+		tw.Close()
+		gz.Close()
+		return &b
+	}
+
+	tests := []struct {
+		name, content, expected string
+	}{
+		{"data/events.json", `[{"name": "A",`, "events.json"},
+		{"config/config.toml", "week_start = \"tuesday\"\n", "week_start"},
+		{"data/../../etc/passwd", "root", "not a countdown file"},
+		{"config/locales/../../evil.toml", "", "not a countdown file"},
+		{"notes.txt", "hello", "not a countdown file"},
+	}
+	for _, tt := range tests {
+		_, err := readBundle(bundle(tt.name, tt.content), l)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected an error about %q, got %v", tt.name, tt.expected, err)
+		}
+	}
+	if _, err := readBundle(strings.NewReader("not gzip"), l); err == nil {
+		t.Error("Expected an error for a file that is no bundle")
+	}
+	entries, err := readBundle(bundle("config/locales/fr.toml", "[list]\n"), l)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected a translation to be accepted, got %v", err)
+	}
+	if p, _ := l.path("config/locales/fr.toml"); p != filepath.Join("/c/locales", "fr.toml") {
+		t.Errorf("Unexpected path %s", p)
+	}
+}
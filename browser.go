@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errNoBrowser means there is no browser to open a link in, as over SSH.
+var errNoBrowser = errors.New("no browser available")
+
+// wikiURL is the address of the English Wikipedia article titled title.
+func wikiURL(title string) string {
+	return "https://en.wikipedia.org/wiki/" + url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+}
+
+// openURL starts the desktop's browser on link without waiting for it. Its
+// output goes nowhere, so it cannot draw over the program. Tests replace it.
+var openURL = func(link string) error {
+	if os.Getenv("SSH_CONNECTION") != "" {
+		return errNoBrowser
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", link)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return errNoBrowser
+		}
+		cmd = exec.Command("xdg-open", link)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+type urlOpenedMsg struct {
+	url string
+	err error
+}
+
+// openBrowser opens link in the background and reports how it went.
+func openBrowser(link string) tea.Cmd {
+	return func() tea.Msg {
+		return urlOpenedMsg{url: link, err: openURL(link)}
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestWikiURL(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"Apollo_11", "https://en.wikipedia.org/wiki/Apollo_11"},
+		{"Neil Armstrong", "https://en.wikipedia.org/wiki/Neil_Armstrong"},
+		{"AC/DC", "https://en.wikipedia.org/wiki/AC%2FDC"},
+		{"Zürich", "https://en.wikipedia.org/wiki/Z%C3%BCrich"},
+	}
+	for _, tt := range tests {
+		if got := wikiURL(tt.title); got != tt.expected {
+			t.Errorf("wikiURL(%q): expected %s, got %s", tt.title, tt.expected, got)
+		}
+	}
+}
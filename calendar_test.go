@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountdownAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, berlin)
+	}
+
+	// Clocks go forward on March 29 and back on October 25, 2026.
+	tests := []struct {
+		name     string
+		event    time.Time
+		now      time.Time
+		expected string
+	}{
+		{"Day before spring forward", at(3, 29, 12, 0), at(3, 28, 12, 0), "1d 0h 0m 0s"},
+		{"Week before spring forward", at(3, 29, 12, 0), at(3, 26, 12, 0), "3d 0h 0m 0s"},
+		{"Hours across spring forward", at(3, 29, 12, 0), at(3, 29, 0, 0), "11h 0m 0s"},
+		{"Day before fall back", at(10, 25, 12, 0), at(10, 24, 12, 0), "1d 0h 0m 0s"},
+		{"Long fall back day", at(10, 25, 11, 30), at(10, 24, 12, 0), "24h 30m 0s"},
+		{"Since spring forward", at(3, 28, 12, 0), at(3, 29, 12, 0), "1d 0h 0m 0s ago"},
+		{"Since fall back", at(10, 24, 18, 0), at(10, 26, 18, 0), "2d 0h 0m 0s ago"},
+		{"Calendar year", at(3, 29, 12, 0), time.Date(2025, 3, 29, 12, 0, 0, 0, berlin), "1y 0d 0h 0m 0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCountdown(tt.event.Unix(), tt.now); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+
+	if got := formatDays(at(3, 28, 12, 0).Unix(), at(3, 29, 12, 0)); got != "1 day ago" {
+		t.Errorf("Expected a full day since across spring forward, got '%s'", got)
+	}
+}
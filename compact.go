@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+// compactWidth is the window width below which the main view turns into a
+// single column, as on a phone: the list alone, and Enter for the details of
+// the selected event in its place.
+const compactWidth = 60
+
+// compact reports whether the main view is a single column, because the
+// window is narrow or --compact asked for it.
+func (m MainModel) compact() bool {
+	return m.forceCompact || (m.windowWidth > 0 && m.windowWidth < compactWidth)
+}
+
+// short is the countdown to ts cut down to its two largest units, e.g.
+// "14d 8h" for "14d 8h 30m", for the narrow list of compact mode.
+func (f countdownFormat) short(ts int64, now time.Time) string {
+	if f.display == displayDays {
+		return formatDays(ts, now)
+	}
+	s := when.Breakdown(now, time.Unix(ts, 0))
+	units := strings.Fields(s.Units(f.showSeconds(ts, now)))
+	if len(units) > 2 {
+		units = units[:2]
+	}
+	if s.Past {
+		return trf("countdown.ago", strings.Join(units, " "))
+	}
+	return strings.Join(units, " ")
+}
+
+// compactDetailView shows the selected event full width in place of the
+// list, with each label above its value. Sections that do not fit the
+// window's height are left out, least important first.
+func (m MainModel) compactDetailView() string {
+	event := m.events.SelectedItem().(Event)
+	now := m.now()
+	remaining, final := finalCountdown(event.Time, now, m.config.completionHold())
+	if final && remaining == 0 {
+		now = time.Unix(event.Time, 0)
+	}
+	urgencyColor := event.urgencyColor(now)
+	width := m.detailWidth - 6
+	ts := time.Unix(event.Time, 0)
+
+	bar := func(s string, color string) string {
+		return lipgloss.NewStyle().
+			Width(width).
+			Foreground(paletteColor(cTextLightGray)).
+			Background(paletteColor(color)).
+			Padding(0, 1).
+			Align(lipgloss.Center).
+			Render(s) + "\n"
+	}
+	field := func(label, value string) string {
+		return HintStyle(label) + "\n  " + BrightTextStyle(value) + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(bar(fitTitle(event.Title(), width-2), urgencyColor) + "\n")
+	b.WriteString(field(tr("compact.date"), ts.Format(m.config.dateLayout())))
+	b.WriteString(field(tr("compact.time"), m.config.eventTime(event)))
+	if m.config.ShowWeekNumbers {
+		b.WriteString(field(tr("compact.week"), weekLabel(ts, m.config.weekStart())))
+	}
+	if m.config.ShowDayOfYear {
+		b.WriteString(field(tr("compact.year"), yearDayLine(now)))
+		if line, ok := addedDayLine(event, now); ok {
+			b.WriteString(field(tr("compact.added"), line))
+		}
+	}
+	if repeat := describeRecurrence(event); repeat != "" {
+		b.WriteString(field(tr("compact.repeats"), repeat))
+	}
+	if event.ReadOnly {
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(NormalTextStyle("🔒 ")+m.readOnlyLine(event)) + "\n")
+	}
+	if !m.whatIf.IsZero() {
+		b.WriteString(m.whatIfLine(event))
+	}
+
+	s := when.Breakdown(now, ts)
+	title := tr("detail.time_until")
+	if s.Past {
+		title = tr("detail.time_since")
+	}
+	countdownStr := m.config.countdownFormat().format(event.Time, now)
+	if final {
+		countdownStr = formatTenths(remaining)
+	} else if event.AllDay {
+		countdownStr = allDayCountdown(event.Time, now)
+	}
+	var countdown strings.Builder
+	countdown.WriteString("\n" + bar(title, urgencyColor) + "\n")
+	countdown.WriteString(renderTimeBlocks(s.Years, s.Days, s.Hours, s.Minutes, s.Seconds, urgencyColor, width-8) + "\n\n")
+	countdown.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Align(lipgloss.Center).
+		Foreground(paletteColor(urgencyColor)).
+		Bold(true).
+		Render(countdownStr) + "\n")
+	if final && remaining == 0 {
+		countdown.WriteString(lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(trf("detail.arrived", event.Name)) + "\n")
+	}
+
+	var reminders strings.Builder
+	if offsets := upcomingReminders(event, m.config.reminderOffsets(), now); len(offsets) > 0 {
+		reminders.WriteString("\n" + NormalTextStyle(tr("detail.reminders")) + "\n")
+		for _, offset := range offsets {
+			at := time.Unix(event.Time, 0).Add(-offset)
+			reminders.WriteString(field(trf("detail.reminder_before", formatLeadTime(offset)), formatCountdown(at.Unix(), now)))
+		}
+	}
+
+	diff := ts.Sub(now).Seconds()
+	if diff < 0 {
+		diff = -diff
+	}
+	var stats strings.Builder
+	stats.WriteString("\n" + bar(tr("detail.statistics"), cTitle) + "\n")
+	stats.WriteString(field(tr("detail.total_days"), formatLargeFloat(diff/float64(secondsPerDay), 2)))
+	stats.WriteString(field(tr("detail.total_hours"), formatLargeFloat(diff/float64(secondsPerHour), 2)))
+	stats.WriteString(field(tr("detail.total_seconds"), formatLargeNumber(int64(diff))))
+
+	// The help goes below a blank line.
+	room := m.windowHeight - 2
+	for _, section := range []string{countdown.String(), reminders.String(), stats.String()} {
+		if lipgloss.Height(strings.TrimSuffix(b.String()+section, "\n")) > room {
+			break
+		}
+		b.WriteString(section)
+	}
+	b.WriteString("\n" + HintStyle(tr("compact.help")))
+	return AppStyle.Render(lipgloss.NewStyle().Width(m.detailWidth-4).Padding(0, 1).Render(b.String()))
+}
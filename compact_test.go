@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestShortCountdown(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ts       time.Time
+		expected string
+	}{
+		{now.Add(14*24*time.Hour + 8*time.Hour + 30*time.Minute), "14d 8h"},
+		{now.Add(3*time.Hour + 12*time.Minute), "3h 12m"},
+		{now.Add(45 * time.Second), "45s"},
+		{now.Add(-50 * time.Hour), "2d 2h ago"},
+	}
+	for _, tt := range tests {
+		if got := (countdownFormat{}).short(tt.ts.Unix(), now); got != tt.expected {
+			t.Errorf("short(%s) = %q, want %q", tt.ts, got, tt.expected)
+		}
+	}
+}
+
+func TestCompactMode(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	withFixedLocal(t)
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Launch", Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix(), Reminders: []int64{86400}},
+		Event{Name: "Summer holiday", Time: time.Date(2026, 7, 20, 8, 0, 0, 0, time.UTC).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	view := func() string {
+		t.Helper()
+		v := withColorProfile(termenv.Ascii, m.View)
+		for _, line := range strings.Split(v, "\n") {
+			if w := lipgloss.Width(line); w > 45 {
+				t.Errorf("Expected every line within 45 columns, got %d:\n%s", w, v)
+				break
+			}
+		}
+		if h := lipgloss.Height(v); h > 20 {
+			t.Errorf("Expected the view within 20 rows, got %d:\n%s", h, v)
+		}
+		return v
+	}
+	update(tea.WindowSizeMsg{Width: 45, Height: 20})
+	if !m.compact() {
+		t.Fatal("Expected compact mode below the width threshold")
+	}
+	assertGolden(t, "compact_list.golden", view())
+
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	assertGolden(t, "compact_detail.golden", view())
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.compactDetail {
+		t.Error("Expected esc to return to the list")
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	assertGolden(t, "compact_form.golden", view())
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// A wide window is compact only when asked for.
+	update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	if m.compact() {
+		t.Error("Expected the columns back in a wide window")
+	}
+	m.forceCompact = true
+	update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	if v := stripANSI(m.View()); strings.Contains(v, "Time Until") || !strings.Contains(v, "14d 8h") {
+		t.Errorf("Expected --compact to show the list alone:\n%s", v)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sameEvent reports whether a and b are the same list entry. Events have no
+// ID, but name, time and order together tell them apart.
+func sameEvent(a, b Event) bool {
+	return a.Name == b.Name && a.Time == b.Time && a.Order == b.Order
+}
+
+// gapProgress returns how far now is through the gap between two events, as
+// a fraction from 0 (neither has happened) to 1 (both have). It reports
+// false when both are at the same time and there is no gap.
+func gapProgress(a, b int64, now time.Time) (float64, bool) {
+	if a > b {
+		a, b = b, a
+	}
+	if a == b {
+		return 0, false
+	}
+	start, end := time.Unix(a, 0), time.Unix(b, 0)
+	elapsed := float64(now.Sub(start)) / float64(end.Sub(start))
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if elapsed > 1 {
+		elapsed = 1
+	}
+	return elapsed, true
+}
+
+// formatGap shows whole days for gaps of a day or more and exact lead-time
+// units below that.
+func formatGap(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	if d >= 24*time.Hour {
+		return trn("compare.days", int(d/(24*time.Hour)))
+	}
+	return formatLeadTime(d)
+}
+
+// compareDelta says where b falls relative to a, e.g. "B is 17 days after A".
+func compareDelta(a, b Event) string {
+	gap := time.Unix(b.Time, 0).Sub(time.Unix(a.Time, 0))
+	switch {
+	case gap > 0:
+		return trf("compare.after", b.Name, formatGap(gap), a.Name)
+	case gap < 0:
+		return trf("compare.before", b.Name, formatGap(gap), a.Name)
+	}
+	return trf("compare.same", b.Name, a.Name)
+}
+
+// renderComparison replaces the detail column with compact countdowns for the
+// marked event and the selected one, and the gap between them.
+func (m MainModel) renderComparison(marked, selected Event) string {
+	var b strings.Builder
+	now := m.now()
+
+	for _, e := range []Event{marked, selected} {
+		color := getUrgencyColor(e.Time, now)
+		titleStyle := lipgloss.NewStyle().
+			Width(m.detailWidth-6).
+			Foreground(paletteColor(cTextLightGray)).
+			Background(paletteColor(color)).
+			Padding(0, 1).
+			Align(lipgloss.Center)
+		b.WriteString(titleStyle.Render(fitTitle(e.Title(), m.detailWidth-8)) + "\n\n")
+		b.WriteString(NormalTextStyle("📅 "))
+		b.WriteString(BrightTextStyle(time.Unix(e.Time, 0).Format(m.config.dateTimeLayout())) + "\n")
+		b.WriteString(lipgloss.NewStyle().
+			Width(m.detailWidth-6).
+			Align(lipgloss.Center).
+			Foreground(paletteColor(color)).
+			Bold(true).
+			Render(m.config.countdownFormat().format(e.Time, now)) + "\n\n")
+	}
+
+	compareTitleStyle := lipgloss.NewStyle().
+		Width(m.detailWidth-6).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center)
+	b.WriteString(compareTitleStyle.Render(tr("compare.title")) + "\n\n")
+	b.WriteString(BrightTextStyle(compareDelta(marked, selected)) + "\n")
+	if elapsed, ok := gapProgress(marked.Time, selected.Time, now); ok {
+		progressWidth := m.detailWidth - 30
+		if progressWidth < 10 {
+			progressWidth = 10
+		}
+		if progressWidth > 30 {
+			progressWidth = 30
+		}
+		b.WriteString(NormalTextStyle(tr("compare.elapsed")))
+		b.WriteString(renderProgressBar(elapsed, 1.0, progressWidth, cTimelineFuture))
+		b.WriteString(fmt.Sprintf(" %.1f%%\n", elapsed*100))
+	}
+
+	detailStyle := lipgloss.NewStyle().
+		Width(m.detailWidth).
+		Padding(1, 2).
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		BorderForeground(m.borderColor(detailPanel, lipgloss.AdaptiveColor{Light: cItemTitleLight, Dark: cItemTitleDark}))
+
+	return detailStyle.Render(b.String())
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGapProgress(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+	tests := []struct {
+		name     string
+		a, b     int64
+		expected float64
+		ok       bool
+	}{
+		{"Both upcoming", now.Unix() + day, now.Unix() + 10*day, 0, true},
+		{"One past, one upcoming", now.Unix() - day, now.Unix() + 3*day, 0.25, true},
+		{"Order does not matter", now.Unix() + 3*day, now.Unix() - day, 0.25, true},
+		{"Both past", now.Unix() - 10*day, now.Unix() - day, 1, true},
+		{"Same time", now.Unix() + day, now.Unix() + day, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := gapProgress(tt.a, tt.b, now)
+			if ok != tt.ok || math.Abs(got-tt.expected) > 0.0001 {
+				t.Errorf("Expected %.4f/%v, got %.4f/%v", tt.expected, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestCompareDelta(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Unix()
+	a := Event{Name: "A", Time: base}
+	tests := []struct {
+		name     string
+		b        Event
+		expected string
+	}{
+		{"After", Event{Name: "B", Time: base + 17*24*3600 + 3600}, "B is 17 days after A"},
+		{"Before", Event{Name: "B", Time: base - 24*3600}, "B is 1 day before A"},
+		{"Under a day", Event{Name: "B", Time: base + 90*60}, "B is 1h30m after A"},
+		{"Same time", Event{Name: "B", Time: base}, "B and A are at the same time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareDelta(a, tt.b); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompareMode(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Taxes", Time: now.AddDate(0, 0, 10).Unix()},
+		Event{Name: "Audit", Time: now.AddDate(0, 0, 27).Unix()},
+	)
+
+	press := func(msg tea.KeyMsg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.compareMark == nil || m.compareMark.Name != "Taxes" {
+		t.Fatalf("Expected Taxes to be marked, got %v", m.compareMark)
+	}
+	if out := stripANSI(m.detailsString()); strings.Contains(out, "Comparison") {
+		t.Errorf("Expected the single view while the marked event is selected, got:\n%s", out)
+	}
+
+	m.events.Select(1)
+	out := stripANSI(m.detailsString())
+	for _, want := range []string{"Taxes", "Audit", "Comparison", "Audit is 17 days after Taxes", "Gap elapsed:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the comparison, got:\n%s", want, out)
+		}
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.compareMark != nil {
+		t.Error("Expected esc to leave compare mode")
+	}
+	if out := stripANSI(m.detailsString()); strings.Contains(out, "Comparison") {
+		t.Errorf("Expected the single detail view again, got:\n%s", out)
+	}
+}
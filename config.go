@@ -0,0 +1,262 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const configFileName = "config.toml"
+
+type Config struct {
+	// FiscalYearStartMonth is the calendar month (1-12) the fiscal year starts in.
+	FiscalYearStartMonth int `toml:"fiscal_year_start_month"`
+	// PeriodEvents adds end-of-quarter and end-of-year virtual events to the list.
+	PeriodEvents bool `toml:"period_events"`
+	// SkyEvents adds the next solstice and equinox as virtual events, and
+	// the next sunrise and sunset when Latitude and Longitude are set.
+	SkyEvents bool `toml:"sky_events"`
+	// Latitude and Longitude are in degrees north and east.
+	Latitude  *float64 `toml:"latitude"`
+	Longitude *float64 `toml:"longitude"`
+	// ShowWeekNumbers shows week numbers in the detail pane.
+	ShowWeekNumbers bool `toml:"show_week_numbers"`
+	// ShowDayOfYear frames today as a day of the year in the detail pane,
+	// and as a day of the time since the event was added.
+	ShowDayOfYear bool `toml:"show_day_of_year"`
+	// ProgressSameTag measures the way to an event from the last past event
+	// sharing a tag with it, rather than from any past event.
+	ProgressSameTag bool `toml:"progress_same_tag"`
+	// WeekStart is the first day of the week: "monday" (ISO weeks),
+	// "sunday" or "saturday".
+	WeekStart string `toml:"week_start"`
+	// Reminders are lead times like "1d" or "2h" before each event at which
+	// the daemon sends a notification.
+	Reminders []string   `toml:"reminders"`
+	Push      PushConfig `toml:"push"`
+	// MQTT publishes the next event and every event to a broker from the
+	// daemon.
+	MQTT MQTTConfig `toml:"mqtt"`
+	// Mail is where `countdown digest` sends its summary.
+	Mail MailConfig `toml:"mail"`
+	// PauseWhenBlurred stops the per-second refresh while the terminal
+	// window is not focused.
+	PauseWhenBlurred bool `toml:"pause_when_blurred"`
+	// DisplayMode is the countdown style at startup: "full", "days" or
+	// "spacing".
+	DisplayMode string `toml:"display_mode"`
+	// HideSeconds leaves seconds out of the list and the compact countdown
+	// until an event's final hour.
+	HideSeconds bool `toml:"hide_seconds"`
+	// ListFormat is a Go template for the line under each event's name in
+	// the list, e.g. "{{.TotalDays}} days · {{.Date \"Jan 2\"}}". Empty
+	// uses the display mode.
+	ListFormat string `toml:"list_format"`
+	// SortOrder is how the list is ordered: "date", "newest" or
+	// "past_last".
+	SortOrder string `toml:"sort_order"`
+	// DuplicateWindow is how close in time events with the same name must
+	// be for dedupe to call them duplicates.
+	DuplicateWindow string `toml:"duplicate_window"`
+	// CompletionHold is how long the detail view stays frozen at 0.0 once an
+	// event is reached, before it starts counting up.
+	CompletionHold string `toml:"completion_hold"`
+	// DateFormat and TimeFormat are presets ("iso", "eu", "us", ...) or Go
+	// layout strings used wherever dates and times are shown.
+	DateFormat string `toml:"date_format"`
+	TimeFormat string `toml:"time_format"`
+	// Language selects the UI translation, e.g. "de". Empty means the
+	// language of the locale environment (LANG).
+	Language string `toml:"language"`
+	// MinYear and MaxYear bound the years accepted for new events.
+	MinYear int `toml:"min_year"`
+	MaxYear int `toml:"max_year"`
+	// ReviewEdits shows the changes an edit makes side by side before
+	// saving it.
+	ReviewEdits bool `toml:"review_edits"`
+	// QuitSummary prints the next few events to the terminal on quit.
+	QuitSummary bool `toml:"quit_summary"`
+	// ShareBaseURL is put in front of the payload of share links.
+	ShareBaseURL string `toml:"share_base_url"`
+	// SnapshotStripANSI writes ctrl+p snapshots of the view as plain text.
+	SnapshotStripANSI bool `toml:"snapshot_strip_ansi"`
+	// Groups divide the list into collapsible sections in this order, e.g.
+	// ["Releases", "Personal", "Admin"]. Events in none of them go in a
+	// section of their own at the end.
+	Groups []string `toml:"groups"`
+	// SidePanel is what the right-hand column shows at startup:
+	// "onthisday", "timeline" or "notes".
+	SidePanel string `toml:"side_panel"`
+	// EventsFile is where the events are kept instead of the data
+	// directory. COUNTDOWN_EVENTS_FILE takes precedence.
+	EventsFile string `toml:"events_file"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		FiscalYearStartMonth: 1,
+		WeekStart:            "monday",
+		Reminders:            []string{"1d", "1h"},
+		PauseWhenBlurred:     true,
+		DisplayMode:          "full",
+		SortOrder:            "date",
+		CompletionHold:       "3s",
+		DuplicateWindow:      "48h",
+		MinYear:              1,
+		MaxYear:              9999,
+		ReviewEdits:          true,
+		QuitSummary:          true,
+		SidePanel:            "onthisday",
+		DateFormat:           defaultDateFormat,
+		TimeFormat:           defaultTimeFormat,
+		MQTT: MQTTConfig{
+			TopicPrefix: "countdown",
+			Interval:    "1m",
+		},
+	}
+}
+
+func getConfigFilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+	return filepath.Join(configDir, appName, configFileName), nil
+}
+
+// configError marks errors fixed by editing the config file, as opposed to
+// runtime failures, so main can exit with a distinct status.
+type configError struct {
+	err error
+}
+
+func (e configError) Error() string { return e.err.Error() }
+func (e configError) Unwrap() error { return e.err }
+
+// loadConfig reads the config file, falling back to defaults for anything
+// not set. A missing file is not an error.
+func loadConfig() (Config, error) {
+	cfg := defaultConfig()
+
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		return cfg, err
+	}
+
+	if _, err := toml.DecodeFile(configFile, &cfg); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return defaultConfig(), fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return defaultConfig(), fmt.Errorf("%s: %w", configFile, err)
+	}
+
+	cfg.checkFormats()
+
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	if c.FiscalYearStartMonth < 1 || c.FiscalYearStartMonth > 12 {
+		return fmt.Errorf("fiscal_year_start_month must be between 1 and 12, got %d", c.FiscalYearStartMonth)
+	}
+
+	if (c.Latitude == nil) != (c.Longitude == nil) {
+		return errors.New("latitude and longitude must be set together")
+	}
+	if c.Latitude != nil && (*c.Latitude < -90 || *c.Latitude > 90) {
+		return fmt.Errorf("latitude must be between -90 and 90, got %g", *c.Latitude)
+	}
+	if c.Longitude != nil && (*c.Longitude < -180 || *c.Longitude > 180) {
+		return fmt.Errorf("longitude must be between -180 and 180, got %g", *c.Longitude)
+	}
+
+	if c.MinYear > c.MaxYear {
+		return fmt.Errorf("min_year %d is after max_year %d", c.MinYear, c.MaxYear)
+	}
+
+	if _, err := parseWeekStart(c.WeekStart); err != nil {
+		return err
+	}
+
+	if _, err := parseDisplayMode(c.DisplayMode); err != nil {
+		return fmt.Errorf("display_mode: %w", err)
+	}
+
+	if _, err := parseSortOrder(c.SortOrder); err != nil {
+		return fmt.Errorf("sort_order: %w", err)
+	}
+
+	if _, err := parseLeadTime(c.CompletionHold); err != nil {
+		return fmt.Errorf("invalid completion_hold %q: %w", c.CompletionHold, err)
+	}
+
+	if _, err := parseLeadTime(c.DuplicateWindow); err != nil {
+		return fmt.Errorf("invalid duplicate_window %q: %w", c.DuplicateWindow, err)
+	}
+
+	for _, r := range c.Reminders {
+		if _, err := parseLeadTime(r); err != nil {
+			return fmt.Errorf("invalid reminder %q: %w", r, err)
+		}
+	}
+
+	if err := c.MQTT.validate(); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, g := range c.Groups {
+		if strings.TrimSpace(g) == "" {
+			return errors.New("groups must not be empty")
+		}
+		if seen[g] {
+			return fmt.Errorf("group %q is listed twice", g)
+		}
+		seen[g] = true
+	}
+
+	if _, err := sideProviderIndex(c.SidePanel); err != nil {
+		return fmt.Errorf("side_panel: %w", err)
+	}
+
+	return nil
+}
+
+func (c Config) reminderOffsets() []time.Duration {
+	offsets := make([]time.Duration, 0, len(c.Reminders))
+	for _, r := range c.Reminders {
+		if d, err := parseLeadTime(r); err == nil {
+			offsets = append(offsets, d)
+		}
+	}
+	return offsets
+}
+
+func (c Config) completionHold() time.Duration {
+	d, _ := parseLeadTime(c.CompletionHold)
+	return d
+}
+
+func (c Config) duplicateWindow() time.Duration {
+	d, _ := parseLeadTime(c.DuplicateWindow)
+	return d
+}
+
+func (c Config) sortOrder() sortOrder {
+	o, _ := parseSortOrder(c.SortOrder)
+	return o
+}
+
+func (c Config) weekStart() time.Weekday {
+	start, _ := parseWeekStart(c.WeekStart)
+	return start
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) {
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		t.Fatalf("Failed to get config file path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("Missing file", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(cfg, defaultConfig()) {
+			t.Errorf("Expected default config, got %+v", cfg)
+		}
+	})
+
+	t.Run("Fiscal year start", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "fiscal_year_start_month = 2\nperiod_events = true\n")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.FiscalYearStartMonth != 2 {
+			t.Errorf("Expected fiscal_year_start_month 2, got %d", cfg.FiscalYearStartMonth)
+		}
+		if !cfg.PeriodEvents {
+			t.Error("Expected period_events to be enabled")
+		}
+	})
+
+	t.Run("Location", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "sky_events = true\nlatitude = 52.52\nlongitude = 13.405\n")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !cfg.SkyEvents || cfg.Latitude == nil || *cfg.Latitude != 52.52 || *cfg.Longitude != 13.405 {
+			t.Errorf("Expected the location to be read, got %+v", cfg)
+		}
+
+		for _, bad := range []string{"latitude = 52.52\n", "latitude = 91\nlongitude = 0\n", "latitude = 0\nlongitude = -181\n"} {
+			writeConfigFile(t, bad)
+			if _, err := loadConfig(); err == nil {
+				t.Errorf("Expected an error for %q", bad)
+			}
+		}
+	})
+
+	t.Run("Invalid month", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "fiscal_year_start_month = 13\n")
+
+		if _, err := loadConfig(); err == nil {
+			t.Error("Expected error for month 13, got nil")
+		}
+	})
+
+	t.Run("Reminders and push", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "reminders = [\"1w\", \"2h\"]\n\n[push]\nntfy_url = \"https://ntfy.sh/mytopic\"\n")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		offsets := cfg.reminderOffsets()
+		if len(offsets) != 2 || offsets[0] != 7*24*time.Hour || offsets[1] != 2*time.Hour {
+			t.Errorf("Unexpected reminder offsets: %v", offsets)
+		}
+		if cfg.Push.NtfyURL != "https://ntfy.sh/mytopic" {
+			t.Errorf("Expected ntfy URL, got '%s'", cfg.Push.NtfyURL)
+		}
+	})
+
+	t.Run("Display mode", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "display_mode = \"days\"\n")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if mode, _ := parseDisplayMode(cfg.DisplayMode); mode != displayDays {
+			t.Errorf("Expected days display mode, got %v", mode)
+		}
+
+		writeConfigFile(t, "display_mode = \"hours\"\n")
+		if _, err := loadConfig(); err == nil {
+			t.Error("Expected error for unknown display mode, got nil")
+		}
+	})
+
+	t.Run("Invalid reminder", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "reminders = [\"soon\"]\n")
+
+		if _, err := loadConfig(); err == nil {
+			t.Error("Expected error for invalid reminder, got nil")
+		}
+	})
+}
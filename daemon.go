@@ -0,0 +1,244 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// dueNotifications returns the reminders and expiry notices whose moment
+// falls in (last, now]. Events without reminders of their own use defaults.
+// Recurring events are taken at their first occurrence after last, as the
+// file may not have been rolled forward yet. Event times are shown with
+// layout.
+func dueNotifications(events []Event, defaults []time.Duration, layout string, last, now time.Time) []notification {
+	var due []notification
+	for _, e := range events {
+		if e.recurs() {
+			e.Time = nextOccurrence(e, last).Unix()
+		}
+		ts := time.Unix(e.Time, 0)
+		for _, offset := range e.reminderOffsets(defaults) {
+			at := ts.Add(-offset)
+			if at.After(last) && !at.After(now) {
+				due = append(due, notification{
+					Title:   e.Title(),
+					Message: trf("notify.reminder", e.Title(), formatCountdown(e.Time, now), ts.Format(layout)),
+					Urgency: urgencyBucket(e.Time, now),
+				})
+			}
+		}
+		if ts.After(last) && !ts.After(now) {
+			due = append(due, notification{
+				Title:   e.Title(),
+				Message: trf("notify.arrived", e.Title(), formatCountdown(e.Time, now)),
+				Urgency: urgencyBucket(e.Time, now),
+			})
+		}
+	}
+	return due
+}
+
+func deliver(notifiers []notifier, msg notification) {
+	fmt.Printf("%s  %s\n", time.Now().Format(inputTimeFormLong), msg.Message)
+	for _, n := range notifiers {
+		go func(n notifier) {
+			if err := notifyWithRetry(n, msg); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: giving up on %q: %v\n", n.Name(), msg.Title, err)
+			}
+		}(n)
+	}
+}
+
+func runDaemon(args []string) int {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	interval := fs.Duration("interval", 30*time.Second, "how often to check for due reminders")
+	mqttTest := fs.Bool("mqtt-test", false, "publish the MQTT topics once and exit")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+	if *mqttTest {
+		return runMQTTTest(cfg.MQTT)
+	}
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+		return 1
+	}
+	*interval = tickInterval(*interval, cfg)
+	d := newDaemon(eventsFile, cfg, *interval, time.Now())
+	notifiers := pushNotifiers(cfg.Push)
+	if cfg.MQTT.Broker != "" {
+		d.publisher = newMQTTPublisher(cfg.MQTT)
+		defer d.publisher.Close()
+		if events, err := d.store.Events(); err == nil {
+			publishMQTT(d.publisher, events, time.Now())
+		}
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		for _, msg := range d.tick(now) {
+			deliver(notifiers, msg)
+		}
+	}
+	return 0
+}
+
+// tickInterval is how often the daemon wakes up. With a broker it is at most
+// the MQTT interval: the keep-alive only leaves room for one missed publish,
+// and the broker drops a client it hears nothing from for longer.
+func tickInterval(interval time.Duration, cfg Config) time.Duration {
+	if cfg.MQTT.Broker != "" && cfg.MQTT.interval() < interval {
+		return cfg.MQTT.interval()
+	}
+	return interval
+}
+
+// daemon is what the daemon keeps between ticks. It only ever reads the
+// events file, through store, so it follows what the TUI and the other
+// commands save without writing over any of it. Which reminders were sent
+// stays in memory.
+type daemon struct {
+	store     *eventStore
+	clock     *reminderClock
+	interval  time.Duration
+	offsets   []time.Duration
+	layout    string
+	publisher *mqttPublisher
+	readErr   string // the last read error reported, so it is not repeated every tick
+}
+
+func newDaemon(eventsFile string, cfg Config, interval time.Duration, now time.Time) *daemon {
+	return &daemon{
+		store:    &eventStore{path: eventsFile},
+		clock:    newReminderClock(now),
+		interval: interval,
+		offsets:  cfg.reminderOffsets(),
+		layout:   cfg.dateTimeLayout(),
+	}
+}
+
+// tick returns the notifications due at now and publishes the events to
+// MQTT. While the events file cannot be read, as when it is saved half
+// edited by hand, the last events read are used.
+func (d *daemon) tick(now time.Time) []notification {
+	events, err := d.store.Events()
+	if err != nil && err.Error() != d.readErr {
+		fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+	}
+	d.readErr = ""
+	if err != nil {
+		d.readErr = err.Error()
+	}
+	from, jump := d.clock.advance(now, d.interval)
+	if jump != 0 {
+		fmt.Printf("%s  %s\n", now.Format(inputTimeFormLong), describeClockJump(jump))
+	}
+	if d.publisher != nil {
+		publishMQTT(d.publisher, events, now)
+	}
+	return dueNotifications(events, d.offsets, d.layout, from, now)
+}
+
+// reminderClock keeps track of which stretch of time the daemon has sent
+// reminders for, so a change of the system clock neither sends reminders
+// that only the jump ahead passed nor repeats those sent before a jump back.
+type reminderClock struct {
+	last time.Time // the previous tick
+	sent time.Time // reminders due up to here have been sent
+}
+
+func newReminderClock(now time.Time) *reminderClock {
+	return &reminderClock{last: now, sent: now}
+}
+
+// advance moves on to the tick at now, returning the time after which
+// reminders are due and how far the clock jumped, if it did.
+func (c *reminderClock) advance(now time.Time, interval time.Duration) (time.Time, time.Duration) {
+	jump := clockJump(c.last, now, interval)
+	c.last = now
+	if jump > 0 {
+		c.sent = now
+	}
+	from := c.sent
+	if now.After(c.sent) {
+		c.sent = now
+	}
+	return from, jump
+}
+
+func publishMQTT(p *mqttPublisher, events []Event, now time.Time) {
+	if _, err := p.Update(events, now); err != nil {
+		fmt.Fprintf(os.Stderr, "mqtt: %v, retrying in %s\n", err, p.retryAt.Sub(now))
+	}
+}
+
+// runMQTTTest publishes the current state once so the broker setup can be
+// checked without waiting for the daemon's interval.
+func runMQTTTest(cfg MQTTConfig) int {
+	if cfg.Broker == "" {
+		fmt.Fprintln(os.Stderr, "daemon: no MQTT broker configured, set mqtt.broker")
+		return 2
+	}
+	var events []Event
+	eventsFile, err := getEventsFilePath()
+	if err == nil {
+		events, err = (&eventStore{path: eventsFile}).Events()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+		return 1
+	}
+	p := newMQTTPublisher(cfg)
+	defer p.Close()
+	sent, err := p.Update(events, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mqtt: %v\n", err)
+		return 1
+	}
+	fmt.Printf("mqtt: published %d messages to %s under %s/\n", sent, cfg.Broker, strings.TrimRight(cfg.TopicPrefix, "/"))
+	return 0
+}
+
+func runNotifyTest(args []string) int {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "notify-test: %v\n", err)
+		return 2
+	}
+	notifiers := pushNotifiers(cfg.Push)
+	if len(notifiers) == 0 {
+		fmt.Fprintln(os.Stderr, "notify-test: no push targets configured, set push.ntfy_url or push.gotify_url")
+		return 2
+	}
+
+	msg := notification{
+		Title:   "countdown",
+		Message: "Test notification from countdown",
+		Urgency: 1,
+	}
+	failed := false
+	for _, n := range notifiers {
+		if err := n.Notify(msg); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", n.Name(), err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: sent\n", n.Name())
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDueNotifications(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	last := now.Add(-30 * time.Second)
+	offsets := []time.Duration{24 * time.Hour, time.Hour}
+
+	events := []Event{
+		{Name: "Reminder due", Time: now.Add(time.Hour - 10*time.Second).Unix()},
+		{Name: "Day ahead", Time: now.Add(24 * time.Hour).Unix()},
+		{Name: "Just expired", Time: now.Add(-5 * time.Second).Unix()},
+		{Name: "Not yet", Time: now.Add(2 * time.Hour).Unix()},
+		{Name: "Long gone", Time: now.Add(-time.Hour).Unix()},
+	}
+
+	due := dueNotifications(events, offsets, defaultConfig().dateTimeLayout(), last, now)
+	if len(due) != 3 {
+		t.Fatalf("Expected 3 notifications, got %d: %+v", len(due), due)
+	}
+	if !strings.HasPrefix(due[0].Message, "Reminder due in 59m 50s") {
+		t.Errorf("Unexpected reminder message: %q", due[0].Message)
+	}
+	if due[1].Title != "Day ahead" || due[1].Urgency != 5 {
+		t.Errorf("Unexpected day-ahead notification: %+v", due[1])
+	}
+	if !strings.Contains(due[2].Message, "is here (5s ago)") || due[2].Urgency != 0 {
+		t.Errorf("Unexpected expiry notification: %+v", due[2])
+	}
+
+	if again := dueNotifications(events, offsets, defaultConfig().dateTimeLayout(), now, now.Add(time.Second)); len(again) != 0 {
+		t.Errorf("Expected no repeated notifications, got %+v", again)
+	}
+}
+
+func TestDueNotificationsPerEventReminders(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	last := now.Add(-30 * time.Second)
+	defaults := []time.Duration{24 * time.Hour}
+
+	events := []Event{
+		{Name: "Own reminder", Time: now.Add(2 * time.Hour).Unix(), Reminders: []int64{2 * 60 * 60}},
+		{Name: "Overrides default", Time: now.Add(24 * time.Hour).Unix(), Reminders: []int64{60 * 60}},
+		{Name: "Uses default", Time: now.Add(24*time.Hour - 10*time.Second).Unix()},
+	}
+
+	due := dueNotifications(events, defaults, defaultConfig().dateTimeLayout(), last, now)
+	var titles []string
+	for _, n := range due {
+		titles = append(titles, n.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Own reminder,Uses default" {
+		t.Errorf("Expected Own reminder,Uses default, got %s", got)
+	}
+}
+
+func TestDaemonFollowsTUI(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now)
+	update := func(msg tea.KeyMsg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	path, err := getEventsFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDaemon(path, defaultConfig(), 30*time.Second, now)
+	tick := func() []notification {
+		now = now.Add(30 * time.Second)
+		return d.tick(now)
+	}
+	if due := tick(); len(due) != 0 {
+		t.Fatalf("Expected nothing due without events, got %+v", due)
+	}
+
+	// The 1h reminder falls 20s into the next tick.
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m.inputs[inputNameField].SetValue("Launch")
+	m.inputs[inputTimeField].SetValue(now.Add(time.Hour + 20*time.Second).Format(inputTimeFormLong))
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	saved, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(saved), "Launch") {
+		t.Fatalf("Expected the TUI to save the event, got %s (%v)", saved, err)
+	}
+	if due := tick(); len(due) != 1 || due[0].Title != "Launch" {
+		t.Fatalf("Expected the daemon to pick up the new event, got %+v", due)
+	}
+
+	// An edit is followed too.
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m.inputs[inputNameField].SetValue("Launch party")
+	m.inputs[inputTimeField].SetValue(now.Add(time.Hour + 10*time.Second).Format(inputTimeFormLong))
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	saved, _ = os.ReadFile(path)
+	if due := tick(); len(due) != 1 || due[0].Title != "Launch party" {
+		t.Fatalf("Expected the daemon to pick up the edit, got %+v", due)
+	}
+
+	after, _ := os.ReadFile(path)
+	if string(after) != string(saved) {
+		t.Errorf("Expected the daemon to leave the events file alone, got:\n%s", after)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".lock") || strings.HasPrefix(e.Name(), ".") {
+			t.Errorf("Expected no lock or temporary file left behind, got %s", e.Name())
+		}
+	}
+
+	missing := filepath.Join(t.TempDir(), "events.json")
+	newDaemon(missing, defaultConfig(), 30*time.Second, now).tick(now.Add(30 * time.Second))
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("Expected the daemon not to create a missing events file, got %v", err)
+	}
+}
+
+func TestDaemonKeepsLastGoodEvents(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "events.json")
+	if err := writeEventsTo(path, []Event{{Name: "Launch", Time: now.Add(time.Hour + 20*time.Second).Unix()}}); err != nil {
+		t.Fatal(err)
+	}
+	d := newDaemon(path, defaultConfig(), 30*time.Second, now)
+	d.tick(now)
+	if err := os.WriteFile(path, []byte(`[{"name": "Launch",`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if due := d.tick(now.Add(30 * time.Second)); len(due) != 1 || due[0].Title != "Launch" {
+		t.Errorf("Expected reminders from the last good events while the file is broken, got %+v", due)
+	}
+}
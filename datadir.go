@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// userDataDir is where the events and state live, apart from the config:
+// $XDG_DATA_HOME or ~/.local/share on Unix. macOS and Windows have no
+// separate place for data, so there it is the config directory as before.
+func userDataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// appDataDir is countdown's own data directory, created if need be. The
+// state file, the journal and their locks live there even when the events
+// file is elsewhere, so none of them end up in a shared or synced folder
+// holding it.
+func appDataDir() (string, error) {
+	dataDir, err := userDataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user data directory: %w", err)
+	}
+	dir := filepath.Join(dataDir, appName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", &setupError{Path: dir, Err: err}
+	}
+	return dir, nil
+}
+
+// dataFileNames are the files that used to live in the config directory.
+var dataFileNames = []string{eventsFileName, stateFileName}
+
+// movedNoteSuffix names the note left where events.json used to be.
+const movedNoteSuffix = ".moved"
+
+// migrateDataFiles moves the events and state files from the config
+// directory, where older versions kept them, to dataDir. A file is only
+// moved when dataDir has none yet, so this runs once. A note is left behind
+// for anyone looking for events.json in the old place.
+func migrateDataFiles(oldDir, dataDir string) error {
+	if filepath.Clean(oldDir) == filepath.Clean(dataDir) {
+		return nil
+	}
+	for _, name := range dataFileNames {
+		from, to := filepath.Join(oldDir, name), filepath.Join(dataDir, name)
+		if _, err := os.Stat(to); err == nil {
+			continue
+		}
+		if _, err := os.Stat(from); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := moveFile(from, to); err != nil {
+			return fmt.Errorf("failed to move %s to %s: %w", from, to, err)
+		}
+		if name == eventsFileName {
+			note := fmt.Sprintf("countdown now keeps its events in %s.\nThis file can be deleted.\n", to)
+			os.WriteFile(from+movedNoteSuffix, []byte(note), 0644)
+		}
+	}
+	return nil
+}
+
+// moveFile renames from to to, copying when they are on different file
+// systems.
+func moveFile(from, to string) error {
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(to, data, 0644); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUserDataDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+	if got, err := userDataDir(); err != nil || got != dir {
+		t.Errorf("Expected XDG_DATA_HOME %s, got %s (%v)", dir, got, err)
+	}
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "relative/path")
+	if got, _ := userDataDir(); got != filepath.Join(home, ".local", "share") {
+		t.Errorf("Expected a relative XDG_DATA_HOME to be ignored, got %s", got)
+	}
+}
+
+func TestEventsFileMovesOutOfConfigDir(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	oldDir := filepath.Join(th.testConfigDir, appName)
+	if err := os.MkdirAll(oldDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	events := `[{"name": "Launch", "ts": 1900000000}]`
+	os.WriteFile(filepath.Join(oldDir, eventsFileName), []byte(events), 0644)
+	os.WriteFile(filepath.Join(oldDir, stateFileName), []byte(`{"last_exit": 1}`), 0644)
+	writeConfigFile(t, `language = "en"`)
+
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		t.Fatalf("getEventsFilePath() failed: %v", err)
+	}
+	if want := filepath.Join(th.testDataDir, appName, eventsFileName); eventsFile != want {
+		t.Errorf("Expected %s, got %s", want, eventsFile)
+	}
+	if data, _ := os.ReadFile(eventsFile); string(data) != events {
+		t.Errorf("Expected the events to be moved, got %q", data)
+	}
+	if st := loadState(); st.LastExit != 1 {
+		t.Errorf("Expected the state to be moved, got %+v", st)
+	}
+	if _, err := os.Stat(filepath.Join(oldDir, eventsFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the old events file to be gone, got %v", err)
+	}
+	note, _ := os.ReadFile(filepath.Join(oldDir, eventsFileName+movedNoteSuffix))
+	if !strings.Contains(string(note), eventsFile) {
+		t.Errorf("Expected a note naming the new place, got %q", note)
+	}
+	if _, err := os.Stat(filepath.Join(oldDir, configFileName)); err != nil {
+		t.Errorf("Expected the config to stay, got %v", err)
+	}
+
+	// A file in the old place again, e.g. from a restored backup, does not
+	// replace the one in use.
+	os.WriteFile(filepath.Join(oldDir, eventsFileName), []byte(`[]`), 0644)
+	if _, err := getEventsFilePath(); err != nil {
+		t.Fatalf("getEventsFilePath() failed: %v", err)
+	}
+	if data, _ := os.ReadFile(eventsFile); string(data) != events {
+		t.Errorf("Expected the moved events to be kept, got %q", data)
+	}
+}
+
+func TestStateStaysOutOfSharedEventsDir(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	shared := t.TempDir()
+	t.Setenv(eventsFileEnv, filepath.Join(shared, "events.json"))
+	if err := updateState(func(st *appState) { st.LastExit = 1 }); err != nil {
+		t.Fatal(err)
+	}
+	journal, _ := getJournalFilePath()
+	if want := filepath.Join(th.testDataDir, appName, journalFileName); journal != want {
+		t.Errorf("Expected the journal at %s, got %s", want, journal)
+	}
+	if _, err := os.Stat(filepath.Join(th.testDataDir, appName, stateFileName)); err != nil {
+		t.Errorf("Expected the state in the data directory: %v", err)
+	}
+	if entries, _ := os.ReadDir(shared); len(entries) != 0 {
+		t.Errorf("Expected nothing written next to the shared events file, got %v", entries)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseDateInput is the date parser shared by the event form and prompts. It
+// accepts the form's absolute formats and relative forms: "today",
+// "tomorrow", "yesterday", offsets such as "+3d", "in 2w" or "-1w", and month
+// or weekday names meaning their next occurrence. Offsets in whole days give
+// midnight of that day; others such as "+90m" give an exact time. A run of
+// digits that is no date is tried as a Unix timestamp.
+func parseDateInput(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	layout := inputTimeFormLong
+	switch {
+	case len(s) <= len(inputTimeFormShort):
+		layout = inputTimeFormShort
+	case len(s) <= len(inputTimeFormMinutes):
+		layout = inputTimeFormMinutes
+	}
+	if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, ok := parseUnixTimestamp(s); ok {
+		return t, nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	word := strings.ToLower(s)
+	switch word {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	sign := 1
+	offset := ""
+	switch {
+	case strings.HasPrefix(word, "+"):
+		offset = word[1:]
+	case strings.HasPrefix(word, "-"):
+		sign, offset = -1, word[1:]
+	case strings.HasPrefix(word, "in "):
+		offset = word[3:]
+	}
+	if offset != "" {
+		d, err := parseLeadTime(strings.NewReplacer(" ", "", "days", "d", "day", "d", "weeks", "w", "week", "w").Replace(offset))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if d%(24*time.Hour) == 0 {
+			return today.AddDate(0, 0, sign*int(d/(24*time.Hour))), nil
+		}
+		return now.Add(time.Duration(sign) * d), nil
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		if name := strings.ToLower(m.String()); word == name || (len(word) >= 3 && strings.HasPrefix(name, word)) {
+			year := now.Year()
+			if m < now.Month() {
+				year++
+			}
+			return time.Date(year, m, 1, 0, 0, 0, 0, now.Location()), nil
+		}
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if name := strings.ToLower(d.String()); word == name || (len(word) >= 3 && strings.HasPrefix(name, word)) {
+			days := (int(d) - int(now.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+}
+
+// Unix timestamps between 1973 and 2286, in seconds or milliseconds. Shorter
+// digit runs such as 20261015 are not taken for timestamps.
+const (
+	minUnixSeconds = 1e8
+	maxUnixSeconds = 1e10
+)
+
+// parseUnixTimestamp reads s as a Unix timestamp if it is all digits and of a
+// plausible magnitude, telling seconds from milliseconds by size.
+func parseUnixTimestamp(s string) (time.Time, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	switch {
+	case n >= minUnixSeconds && n < maxUnixSeconds:
+		return time.Unix(n, 0), true
+	case n >= minUnixSeconds*1000 && n < maxUnixSeconds*1000:
+		return time.Unix(n/1000, 0), true
+	}
+	return time.Time{}, false
+}
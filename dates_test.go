@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateInput(t *testing.T) {
+	withFixedLocal(t)
+	// A Wednesday afternoon in June
+	now := time.Date(2026, 6, 10, 15, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2026-08-01", day(2026, 8, 1)},
+		{"2026-08-01 18:30:00", time.Date(2026, 8, 1, 18, 30, 0, 0, time.UTC)},
+		{"2026-08-01 18:30", time.Date(2026, 8, 1, 18, 30, 0, 0, time.UTC)},
+		{"today", day(2026, 6, 10)},
+		{"Tomorrow", day(2026, 6, 11)},
+		{"yesterday", day(2026, 6, 9)},
+		{"+3d", day(2026, 6, 13)},
+		{"in 2 weeks", day(2026, 6, 24)},
+		{"-1w", day(2026, 6, 3)},
+		{"+90m", time.Date(2026, 6, 10, 17, 0, 0, 0, time.UTC)},
+		{"august", day(2026, 8, 1)},
+		{"Aug", day(2026, 8, 1)},
+		{"june", day(2026, 6, 1)},
+		{"march", day(2027, 3, 1)},
+		{"friday", day(2026, 6, 12)},
+		{"wed", day(2026, 6, 17)},
+		{"1785600000", time.Date(2026, 8, 1, 16, 0, 0, 0, time.UTC)},
+		{"1785600000999", time.Date(2026, 8, 1, 16, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseDateInput(tt.input, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "invalid-time", "+3x", "ma", "2026-13-01", "20260801", "12345"} {
+		if _, err := parseDateInput(input, now); err == nil {
+			t.Errorf("Expected error for %q, got nil", input)
+		}
+	}
+}
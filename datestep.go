@@ -0,0 +1,135 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dateComponent is the part of a date field's text that arrow keys change.
+type dateComponent int
+
+const (
+	stepYear dateComponent = iota
+	stepMonth
+	stepDay
+	stepHour
+	stepMinute
+	stepSecond
+)
+
+// componentAt maps a cursor position in "2006-01-02 15:04:05" to the
+// component it is in or just after, so a cursor at the end of the short
+// format steps the day.
+func componentAt(pos int) dateComponent {
+	switch {
+	case pos <= 4:
+		return stepYear
+	case pos <= 7:
+		return stepMonth
+	case pos <= 10:
+		return stepDay
+	case pos <= 13:
+		return stepHour
+	case pos <= 16:
+		return stepMinute
+	}
+	return stepSecond
+}
+
+// bigSteps are the shift+arrow steps of each component.
+var bigSteps = map[dateComponent]int{
+	stepYear:   10,
+	stepMonth:  12,
+	stepDay:    7,
+	stepHour:   6,
+	stepMinute: 15,
+	stepSecond: 15,
+}
+
+// addMonths adds n months, clamping the day to the length of the month it
+// lands in: Jan 31 plus one month is Feb 28, not Mar 3.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// stepDate moves the component of t by n. Times step on the wall clock, so
+// an hour step across a DST change keeps the minutes.
+func stepDate(t time.Time, c dateComponent, n int) time.Time {
+	switch c {
+	case stepYear:
+		return addMonths(t, 12*n)
+	case stepMonth:
+		return addMonths(t, n)
+	case stepDay:
+		return t.AddDate(0, 0, n)
+	case stepHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+n, t.Minute(), t.Second(), 0, t.Location())
+	case stepMinute:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+n, t.Second(), 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second()+n, 0, t.Location())
+}
+
+// stepDateInput returns the date field's text with the component under the
+// cursor moved by delta, or by its big step when big is set. An empty field
+// becomes today. Other inputs the form accepts, such as "tomorrow", are
+// first written out as a date, then stepped at the end. It reports false
+// when the text is not a date or the result would leave years 1-9999.
+func stepDateInput(s string, pos, delta int, big bool, now time.Time) (string, int, bool) {
+	if s == "" {
+		today := now.Format(inputTimeFormShort)
+		return today, len(today), true
+	}
+
+	layout := ""
+	t, err := time.ParseInLocation(inputTimeFormLong, s, time.Local)
+	if err == nil {
+		layout = inputTimeFormLong
+	} else if t, err = time.ParseInLocation(inputTimeFormShort, s, time.Local); err == nil {
+		layout = inputTimeFormShort
+	} else if t, err = parseDateInput(s, now); err == nil {
+		layout = inputTimeFormLong
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+			layout = inputTimeFormShort
+		}
+		pos = len(layout)
+	} else {
+		return s, pos, false
+	}
+
+	c := componentAt(pos)
+	if layout == inputTimeFormShort && c > stepDay {
+		c = stepDay
+	}
+	if big {
+		delta *= bigSteps[c]
+	}
+	stepped := stepDate(t, c, delta)
+	if stepped.Year() < 1 || stepped.Year() > 9999 {
+		return s, pos, false
+	}
+	return stepped.Format(layout), pos, true
+}
+
+// stepDateField applies an arrow key to the date field. The caller lets the
+// key through to the inputs afterwards, which refreshes the preview.
+func (m *MainModel) stepDateField(msg tea.KeyMsg) {
+	delta := 1
+	if key.Matches(msg, Keymap.StepDown, Keymap.StepDownMore) {
+		delta = -1
+	}
+	big := key.Matches(msg, Keymap.StepUpMore, Keymap.StepDownMore)
+	in := &m.inputs[inputTimeField]
+	if s, pos, ok := stepDateInput(in.Value(), in.Position(), delta, big, m.now()); ok {
+		in.SetValue(s)
+		in.SetCursor(pos)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestComponentAt(t *testing.T) {
+	tests := []struct {
+		pos      int
+		expected dateComponent
+	}{
+		{0, stepYear},
+		{4, stepYear},
+		{5, stepMonth},
+		{7, stepMonth},
+		{8, stepDay},
+		{10, stepDay},
+		{11, stepHour},
+		{14, stepMinute},
+		{17, stepSecond},
+		{19, stepSecond},
+	}
+	for _, tt := range tests {
+		if got := componentAt(tt.pos); got != tt.expected {
+			t.Errorf("componentAt(%d): expected %d, got %d", tt.pos, tt.expected, got)
+		}
+	}
+}
+
+func TestStepDateInput(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		in       string
+		pos      int
+		delta    int
+		big      bool
+		expected string
+	}{
+		{"Year", "2026-03-15", 2, 1, false, "2027-03-15"},
+		{"Month", "2026-03-15", 6, -1, false, "2026-02-15"},
+		{"Month end clamps", "2026-01-31", 6, 1, false, "2026-02-28"},
+		{"Leap day clamps", "2024-02-29", 0, 1, false, "2025-02-28"},
+		{"Day rolls over", "2026-01-31", 9, 1, false, "2026-02-01"},
+		{"Day rolls back", "2026-03-01", 9, -1, false, "2026-02-28"},
+		{"Short format end steps day", "2026-03-15", 10, 1, false, "2026-03-16"},
+		{"Hour rolls over", "2026-12-31 23:15:00", 12, 1, false, "2027-01-01 00:15:00"},
+		{"Minute", "2026-03-15 18:59:00", 15, 1, false, "2026-03-15 19:00:00"},
+		{"Second", "2026-03-15 18:30:00", 19, -1, false, "2026-03-15 18:29:59"},
+		{"Big year", "2026-03-15", 1, 1, true, "2036-03-15"},
+		{"Big day", "2026-03-29", 9, 1, true, "2026-04-05"},
+		{"Big minute", "2026-03-15 18:50:00", 15, 1, true, "2026-03-15 19:05:00"},
+		{"Empty is today", "", 0, 1, false, "2026-03-01"},
+		{"Relative is written out", "tomorrow", 3, 1, false, "2026-03-03"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, ok := stepDateInput(tt.in, tt.pos, tt.delta, tt.big, now)
+			if !ok || got != tt.expected {
+				t.Errorf("Expected %q, got %q (ok %v)", tt.expected, got, ok)
+			}
+		})
+	}
+
+	if got, _, ok := stepDateInput("not a date", 3, 1, false, now); ok || got != "not a date" {
+		t.Errorf("Expected invalid input to be left alone, got %q (ok %v)", got, ok)
+	}
+	if _, _, ok := stepDateInput("9999-06-01", 0, 1, false, now); ok {
+		t.Error("Expected stepping past year 9999 to be refused")
+	}
+}
+
+func TestArrowKeysStepDateField(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	withFixedLocal(t)
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now)
+	m.state = showInput
+	m.focus = int(inputTimeField)
+	m.inputs[inputTimeField].SetValue("2026-01-31 18:00:00")
+	m.inputs[inputTimeField].SetCursor(6)
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = model.(MainModel)
+	if got := m.inputs[inputTimeField].Value(); got != "2026-02-28 18:00:00" {
+		t.Errorf("Expected the month to step with the day clamped, got %q", got)
+	}
+	if got := m.inputs[inputTimeField].Position(); got != 6 {
+		t.Errorf("Expected the cursor to stay at 6, got %d", got)
+	}
+	if !m.dateValid || !strings.Contains(m.datePreview, "Feb") {
+		t.Errorf("Expected the preview to follow the new date, got %q", m.datePreview)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	m = model.(MainModel)
+	if got := m.inputs[inputTimeField].Value(); got != "2025-02-28 18:00:00" {
+		t.Errorf("Expected shift+down to go back a year from the month, got %q", got)
+	}
+
+	m.focus = int(inputNameField)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = model.(MainModel)
+	if got := m.inputs[inputTimeField].Value(); got != "2025-02-28 18:00:00" {
+		t.Errorf("Expected arrows outside the date field to leave it alone, got %q", got)
+	}
+}
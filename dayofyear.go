@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// dayOfYear returns which day of its year t is and how many days the year
+// has, so December 31st is day 365 of 365, or 366 of 366 in a leap year.
+func dayOfYear(t time.Time) (day, total int) {
+	return t.YearDay(), time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// dayOfSpan numbers the calendar days from start to end, both included,
+// and returns which of them now falls on. ok is false unless start is
+// before end and now lies on one of those days.
+func dayOfSpan(start, end, now time.Time) (day, total int, ok bool) {
+	if !start.Before(end) {
+		return 0, 0, false
+	}
+	day = daysUntil(now.Unix(), start) + 1
+	total = daysUntil(end.Unix(), start) + 1
+	return day, total, day >= 1 && day <= total
+}
+
+// yearDayLine frames now as a day of its year.
+func yearDayLine(now time.Time) string {
+	day, total := dayOfYear(now)
+	return trf("dayofyear.day_of", day, total) + " · " + trf("dayofyear.left_in_year", trn("dayofyear.days", total-day), now.Year())
+}
+
+// addedDayLine frames now as a day of the stretch from when event was
+// added to the event, if it knows when that was.
+func addedDayLine(event Event, now time.Time) (string, bool) {
+	if event.Created == 0 {
+		return "", false
+	}
+	day, total, ok := dayOfSpan(time.Unix(event.Created, 0), time.Unix(event.Time, 0), now)
+	return trf("dayofyear.day_of", day, total), ok
+}
+
+func (m MainModel) dayOfYearLines(event Event, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(NormalTextStyle("📆 ") + BrightTextStyle(yearDayLine(now)) + "\n")
+	if line, ok := addedDayLine(event, now); ok {
+		b.WriteString(NormalTextStyle("🎯 ") + BrightTextStyle(line) + NormalTextStyle(" · "+tr("dayofyear.since_added")) + "\n")
+	}
+	return b.String()
+}
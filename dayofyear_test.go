@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDayOfYear(t *testing.T) {
+	tests := []struct {
+		date       time.Time
+		day, total int
+	}{
+		{time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), 1, 365},
+		{time.Date(2026, 9, 2, 12, 0, 0, 0, time.Local), 245, 365},
+		{time.Date(2026, 12, 31, 23, 59, 59, 0, time.Local), 365, 365},
+		{time.Date(2028, 2, 29, 12, 0, 0, 0, time.Local), 60, 366},
+		{time.Date(2028, 3, 1, 0, 0, 0, 0, time.Local), 61, 366},
+		{time.Date(2028, 12, 31, 0, 0, 0, 0, time.Local), 366, 366},
+		{time.Date(2100, 12, 31, 0, 0, 0, 0, time.Local), 365, 365},
+	}
+	for _, tt := range tests {
+		if day, total := dayOfYear(tt.date); day != tt.day || total != tt.total {
+			t.Errorf("dayOfYear(%s) = %d of %d, want %d of %d", tt.date.Format("2006-01-02"), day, total, tt.day, tt.total)
+		}
+	}
+}
+
+func TestDayOfSpan(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.Local)
+	deadline := time.Date(2026, 3, 31, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		name       string
+		start, end time.Time
+		now        time.Time
+		day, total int
+		ok         bool
+	}{
+		{"Day added", created, deadline, created.Add(time.Hour), 1, 90, true},
+		{"Day 12", created, deadline, time.Date(2026, 1, 12, 8, 0, 0, 0, time.Local), 12, 90, true},
+		{"Deadline day", created, deadline, deadline.Add(time.Hour), 90, 90, true},
+		{"Before added", created, deadline, created.Add(-24 * time.Hour), 0, 90, false},
+		{"After the deadline", created, deadline, deadline.AddDate(0, 0, 1), 91, 90, false},
+		{"Added after the event", deadline, created, deadline, 0, 0, false},
+		{"Over a leap day", time.Date(2028, 2, 28, 9, 0, 0, 0, time.Local), time.Date(2028, 3, 1, 9, 0, 0, 0, time.Local), time.Date(2028, 2, 29, 23, 0, 0, 0, time.Local), 2, 3, true},
+		{"Over New Year", time.Date(2026, 12, 31, 9, 0, 0, 0, time.Local), time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local), time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local), 2, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, total, ok := dayOfSpan(tt.start, tt.end, tt.now)
+			if ok != tt.ok || (ok && (day != tt.day || total != tt.total)) {
+				t.Errorf("Expected day %d of %d (%v), got %d of %d (%v)", tt.day, tt.total, tt.ok, day, total, ok)
+			}
+		})
+	}
+}
+
+func TestDayOfYearLines(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 9, 2, 12, 0, 0, 0, time.Local)
+	e := Event{
+		Name:    "Launch",
+		Time:    time.Date(2026, 11, 30, 9, 0, 0, 0, time.Local).Unix(),
+		Created: time.Date(2026, 8, 22, 9, 0, 0, 0, time.Local).Unix(),
+	}
+	m := newRefreshTestModel(t, &now, e)
+	m.detailWidth = 60
+
+	if view := stripANSI(m.renderDetails(e)); strings.Contains(view, "Day 245") {
+		t.Errorf("Expected no day of the year unless show_day_of_year is set, got:\n%s", view)
+	}
+	m.config.ShowDayOfYear = true
+	view := stripANSI(m.renderDetails(e))
+	for _, want := range []string{"Day 245 of 365 · 120 days left in 2026", "Day 12 of 101 · since it was added"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+
+	m.windowWidth, m.windowHeight = 50, 60
+	m.compactDetail = true
+	m.calculateWidths()
+	if view := stripANSI(m.compactDetailView()); !strings.Contains(view, "Since added") || !strings.Contains(view, "Day 12 of 101") {
+		t.Errorf("Expected the compact details to frame the days too, got:\n%s", view)
+	}
+
+	e.Created = 0
+	if view := stripANSI(m.renderDetails(e)); !strings.Contains(view, "Day 245 of 365") || strings.Contains(view, "since it was added") {
+		t.Errorf("Expected only the year line without a creation time, got:\n%s", view)
+	}
+}
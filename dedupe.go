@@ -0,0 +1,296 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// normalizedName folds case and runs of whitespace, so "Mom's  Birthday"
+// and "mom's birthday" are the same name.
+func normalizedName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// duplicateGroups finds saved events with the same normalized name, each
+// within window of the next, as groups in time order. Read-only events
+// belong to their source and are left alone.
+func duplicateGroups(events []Event, window time.Duration) [][]Event {
+	byName := map[string][]Event{}
+	var names []string
+	for _, e := range events {
+		if e.Virtual || e.ReadOnly {
+			continue
+		}
+		key := normalizedName(e.Name)
+		if _, ok := byName[key]; !ok {
+			names = append(names, key)
+		}
+		byName[key] = append(byName[key], e)
+	}
+
+	var groups [][]Event
+	for _, key := range names {
+		same := byName[key]
+		sortEventsByTime(same)
+		start := 0
+		for i := 1; i <= len(same); i++ {
+			if i < len(same) && time.Duration(same[i].Time-same[i-1].Time)*time.Second <= window {
+				continue
+			}
+			if i-start > 1 {
+				groups = append(groups, same[start:i])
+			}
+			start = i
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return eventBefore(groups[i][0], groups[j][0]) })
+	return groups
+}
+
+// keepPolicy is which event of a duplicate group survives the merge.
+type keepPolicy int
+
+const (
+	keepNewest keepPolicy = iota
+	keepOldest
+)
+
+func parseKeepPolicy(s string) (keepPolicy, error) {
+	switch s {
+	case "newest":
+		return keepNewest, nil
+	case "oldest":
+		return keepOldest, nil
+	}
+	return keepNewest, fmt.Errorf(`unknown policy %q, want "newest" or "oldest"`, s)
+}
+
+// prefers reports whether a should be kept over b. Newest means added last;
+// for events without a creation time it falls back to the later date.
+func (k keepPolicy) prefers(a, b Event) bool {
+	if a.Created != b.Created {
+		return (a.Created > b.Created) == (k == keepNewest)
+	}
+	if a.Time != b.Time {
+		return (a.Time > b.Time) == (k == keepNewest)
+	}
+	return false
+}
+
+// mergeGroup returns the event kept from group, carrying the tags and
+// notes of all of them.
+func mergeGroup(group []Event, keep keepPolicy) Event {
+	kept := group[0]
+	for _, e := range group[1:] {
+		if keep.prefers(e, kept) {
+			kept = e
+		}
+	}
+	merged := kept
+	merged.Tags = append([]string(nil), kept.Tags...)
+	notes := []string{}
+	if kept.Notes != "" {
+		notes = append(notes, kept.Notes)
+	}
+	for _, e := range group {
+		for _, tag := range e.Tags {
+			if !containsString(merged.Tags, tag) {
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+		if e.Notes != "" && !containsString(notes, e.Notes) {
+			notes = append(notes, e.Notes)
+		}
+	}
+	merged.Notes = strings.Join(notes, "\n\n")
+	return merged
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDedupe replaces each group in events by its merged event.
+func applyDedupe(events []Event, groups [][]Event, keep keepPolicy) []Event {
+	events = append([]Event(nil), events...)
+	for _, g := range groups {
+		for _, e := range g {
+			for i := range events {
+				if sameEvent(events[i], e) {
+					events = append(events[:i], events[i+1:]...)
+					break
+				}
+			}
+		}
+		events = append(events, mergeGroup(g, keep))
+	}
+	sortEventsByTime(events)
+	return events
+}
+
+func runDedupe(args []string) int {
+	fs := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	window := fs.String("window", "", "how close in time duplicates are, e.g. 48h or 3d (default duplicate_window)")
+	keepFlag := fs.String("keep", "", "merge, keeping the newest or oldest of each group")
+	dryRun := fs.Bool("dry-run", false, "show what --keep would do without saving")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: countdown dedupe [--window 48h] [--keep newest|oldest [--dry-run]]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dedupe: %v\n", err)
+		return 2
+	}
+	within := cfg.duplicateWindow()
+	if *window != "" {
+		if within, err = parseLeadTime(*window); err != nil {
+			fmt.Fprintf(os.Stderr, "dedupe: --window: %v\n", err)
+			return 2
+		}
+	}
+	var keep *keepPolicy
+	if *keepFlag != "" {
+		k, err := parseKeepPolicy(*keepFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dedupe: --keep: %v\n", err)
+			return 2
+		}
+		keep = &k
+	}
+
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dedupe: %v\n", err)
+		return 1
+	}
+	groups := duplicateGroups(events, within)
+	if len(groups) == 0 {
+		fmt.Printf("No duplicates within %s\n", formatLeadTime(within))
+		return 0
+	}
+
+	removed := 0
+	for _, g := range groups {
+		removed += len(g) - 1
+		fmt.Printf("%s\n", g[0].Name)
+		var kept Event
+		if keep != nil {
+			kept = mergeGroup(g, *keep)
+		}
+		for _, e := range g {
+			mark := "       "
+			if keep != nil && sameEvent(e, kept) {
+				mark = "  keep "
+			} else if keep != nil {
+				mark = "  merge"
+			}
+			line := fmt.Sprintf("%s  %s", mark, time.Unix(e.Time, 0).Format(inputTimeFormLong))
+			if e.Name != g[0].Name {
+				line += fmt.Sprintf("  %q", e.Name)
+			}
+			if len(e.Tags) > 0 {
+				line += "  #" + strings.Join(e.Tags, " #")
+			}
+			if e.Created != 0 {
+				line += "  added " + time.Unix(e.Created, 0).Format(inputTimeFormShort)
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Printf("\n%d groups of likely duplicates within %s; merging removes %d events\n", len(groups), formatLeadTime(within), removed)
+	if keep == nil {
+		fmt.Println("Run with --keep newest or --keep oldest to merge them")
+		return 0
+	}
+	if *dryRun {
+		return 0
+	}
+	if err := writeEventsFile(applyDedupe(events, groups, *keep)); err != nil {
+		fmt.Fprintf(os.Stderr, "dedupe: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Merged %d groups\n", len(groups))
+	return 0
+}
+
+// mergeDuplicates merges the duplicate groups in the list and saves them.
+func (m *MainModel) mergeDuplicates(keep keepPolicy) tea.Cmd {
+	now := m.now()
+	before := m.storedEvents()
+	groups := duplicateGroups(before, m.config.duplicateWindow())
+	if len(groups) == 0 {
+		return nil
+	}
+	events := append(applyDedupe(before, groups, keep), virtualEvents(now, m.config)...)
+	m.config.sortOrder().sort(events, now)
+	m.compareMark = nil
+	m.setEvents(events)
+	if cmd := m.saved(m.saveChange(before)); cmd != nil {
+		return cmd
+	}
+	return m.events.NewStatusMessage(SuccessStyle(trn("dedupe.merged", len(groups))))
+}
+
+func (m MainModel) dedupeView() string {
+	width := min(56, m.windowWidth-8)
+	groups := duplicateGroups(m.storedEvents(), m.config.duplicateWindow())
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("dedupe.title")) + "\n\n")
+
+	if len(groups) == 0 {
+		b.WriteString(NormalTextStyle(trf("dedupe.none", formatLeadTime(m.config.duplicateWindow()))) + "\n\n")
+		b.WriteString(HintStyle(tr("dedupe.close_hint")))
+	} else {
+		for _, g := range groups {
+			b.WriteString(BrightTextStyle(ansi.Truncate(g[0].Name, width, "…")) + "\n")
+			for _, e := range g {
+				line := "  " + time.Unix(e.Time, 0).Format(m.config.dateTimeLayout())
+				if len(e.Tags) > 0 {
+					line += "  #" + strings.Join(e.Tags, " #")
+				}
+				if e.Created != 0 {
+					line += "  " + trf("dedupe.added", time.Unix(e.Created, 0).Format(m.config.dateLayout()))
+				}
+				b.WriteString(NormalTextStyle(ansi.Truncate(line, width, "…")) + "\n")
+			}
+		}
+		b.WriteString("\n" + NormalTextStyle(trn("dedupe.summary", len(groups))) + "\n")
+		b.WriteString(HintStyle(tr("dedupe.hint")))
+	}
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
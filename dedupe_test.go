@@ -0,0 +1,136 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDuplicateGroups(t *testing.T) {
+	day := time.Date(2027, 5, 3, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return day.Add(d).Unix() }
+	events := []Event{
+		{Name: "Mom's birthday", Time: at(0)},
+		{Name: "mom's  Birthday ", Time: at(9 * time.Hour)},
+		{Name: "Mom's birthday", Time: at(24 * time.Hour)},
+		{Name: "Mom's birthday", Time: at(365 * 24 * time.Hour)},
+		{Name: "Launch", Time: at(0)},
+		{Name: "Launch", Time: at(72 * time.Hour)},
+		{Name: "Feed", Time: at(0), ReadOnly: true},
+		{Name: "Feed", Time: at(0)},
+	}
+	groups := duplicateGroups(events, 48*time.Hour)
+	if len(groups) != 1 || len(groups[0]) != 3 {
+		t.Fatalf("Expected one group of three, got %v", groups)
+	}
+	if groups := duplicateGroups(events, 3*24*time.Hour); len(groups) != 2 {
+		t.Errorf("Expected a wider window to catch the launches, got %d groups", len(groups))
+	}
+}
+
+func TestMergeGroup(t *testing.T) {
+	group := []Event{
+		{Name: "Party", Time: 100, Tags: []string{"family"}, Notes: "Bring cake", Created: 10},
+		{Name: "party", Time: 200, Tags: []string{"family", "home"}, Created: 30},
+		{Name: "Party", Time: 300, Notes: "Bring cake", Created: 20},
+	}
+	newest := mergeGroup(group, keepNewest)
+	if newest.Name != "party" || newest.Time != 200 {
+		t.Errorf("Expected the last added kept, got %+v", newest)
+	}
+	if strings.Join(newest.Tags, ",") != "family,home" || newest.Notes != "Bring cake" {
+		t.Errorf("Expected the tags and notes of all, got %v %q", newest.Tags, newest.Notes)
+	}
+	if oldest := mergeGroup(group, keepOldest); oldest.Time != 100 {
+		t.Errorf("Expected the first added kept, got %+v", oldest)
+	}
+
+	// Without creation times the date decides.
+	for i := range group {
+		group[i].Created = 0
+	}
+	if newest := mergeGroup(group, keepNewest); newest.Time != 300 {
+		t.Errorf("Expected the latest date kept, got %+v", newest)
+	}
+
+	events := applyDedupe(append(group, Event{Name: "Other", Time: 150}), [][]Event{group}, keepOldest)
+	if got := storedNames(events); got != "Party,Other" {
+		t.Errorf("Expected one party left, got %s", got)
+	}
+}
+
+func TestRunDedupe(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	day := time.Date(2030, 5, 3, 0, 0, 0, 0, time.Local)
+	if err := writeEventsFile([]Event{
+		{Name: "Party", Time: day.Unix(), Tags: []string{"a"}},
+		{Name: "Party", Time: day.Add(time.Hour).Unix(), Tags: []string{"b"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	for _, args := range [][]string{nil, {"--keep", "newest", "--dry-run"}} {
+		if code := runDedupe(args); code != 0 {
+			t.Errorf("%v: expected success, got %d", args, code)
+		}
+		if events, _ := readEventsFile(); len(events) != 2 {
+			t.Errorf("%v: expected nothing merged, got %d events", args, len(events))
+		}
+	}
+	if code := runDedupe([]string{"--keep", "latest"}); code != 2 {
+		t.Errorf("Expected an unknown policy to be a usage error, got %d", code)
+	}
+	if code := runDedupe([]string{"--keep", "oldest"}); code != 0 {
+		t.Errorf("Expected the merge to succeed, got %d", code)
+	}
+	events, _ := readEventsFile()
+	if len(events) != 1 || events[0].Time != day.Unix() || strings.Join(events[0].Tags, ",") != "a,b" {
+		t.Errorf("Expected the oldest kept with both tags, got %+v", events)
+	}
+}
+
+func TestDedupeScreen(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Party", Time: now.AddDate(0, 0, 3).Unix(), Created: now.Unix()},
+		Event{Name: "Party ", Time: now.AddDate(0, 0, 4).Unix()},
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 9).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if m.state != showDedupe {
+		t.Fatalf("Expected M to open the duplicates, got state %v", m.state)
+	}
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "Party") || strings.Contains(view, "Launch") || !strings.Contains(view, "1 group would be merged") {
+		t.Errorf("Unexpected duplicates screen:\n%s", view)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.state != showEvents {
+		t.Errorf("Expected the list back, got state %v", m.state)
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := storedNames(events); got != "Party,Launch" || events[0].Created == 0 {
+		t.Errorf("Expected the newest party kept and saved, got %s %+v", got, events)
+	}
+}
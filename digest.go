@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const digestMaxEvents = 8
+
+// passedSince returns the saved events that happened in (since, now], which
+// is what the user missed while the program was closed. A since of 0 means
+// there is no record of a previous run, so nothing was missed.
+func passedSince(events []Event, since int64, now time.Time) []Event {
+	if since == 0 {
+		return nil
+	}
+	var passed []Event
+	for _, e := range events {
+		if !e.Virtual && e.Time > since && e.Time <= now.Unix() {
+			passed = append(passed, e)
+		}
+	}
+	return passed
+}
+
+// formatPassed says how long ago e happened, in whole days.
+func formatPassed(e Event, now time.Time) string {
+	if n := int(now.Sub(time.Unix(e.Time, 0)).Hours() / 24); n > 0 {
+		return trf("digest.passed", e.Title(), trn("countdown.since", n))
+	}
+	return trf("digest.passed_today", e.Title())
+}
+
+// renderDigest draws the panel listing missed events. It takes the place of
+// the right-hand column until any key is pressed.
+func (m MainModel) renderDigest() string {
+	var b strings.Builder
+	now := m.now()
+
+	b.WriteString(TimelineTitleStyle.Width(m.timelineWidth-4).Render(tr("digest.title")) + "\n\n")
+	for i, e := range m.digest {
+		if i == digestMaxEvents {
+			b.WriteString(NormalTextStyle(trf("digest.more", len(m.digest)-i)) + "\n")
+			break
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(paletteColor(cPast)).Render("● "))
+		b.WriteString(BrightTextStyle(formatPassed(e, now)) + "\n")
+	}
+	b.WriteString("\n" + NormalTextStyle(tr("digest.dismiss")))
+
+	return m.timelineStyle().Render(b.String())
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPassedSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	lastExit := now.AddDate(0, 0, -5).Unix()
+	events := []Event{
+		{Name: "Long ago", Time: now.AddDate(0, 0, -30).Unix()},
+		{Name: "At exit", Time: lastExit},
+		{Name: "Visa appointment", Time: now.AddDate(0, 0, -2).Unix()},
+		{Name: "Virtual", Time: now.AddDate(0, 0, -1).Unix(), Virtual: true},
+		{Name: "Just now", Time: now.Unix()},
+		{Name: "Upcoming", Time: now.AddDate(0, 0, 1).Unix()},
+	}
+
+	var names []string
+	for _, e := range passedSince(events, lastExit, now) {
+		names = append(names, e.Name)
+	}
+	if got := strings.Join(names, ","); got != "Visa appointment,Just now" {
+		t.Errorf("Expected Visa appointment,Just now, got %s", got)
+	}
+	if got := passedSince(events, 0, now); got != nil {
+		t.Errorf("Expected no digest without a previous run, got %v", got)
+	}
+}
+
+func TestFormatPassed(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if got := formatPassed(Event{Name: "Visa appointment", Time: now.Add(-50 * time.Hour).Unix()}, now); got != "'Visa appointment' passed 2 days ago" {
+		t.Errorf("Unexpected digest line '%s'", got)
+	}
+	if got := formatPassed(Event{Name: "Standup", Time: now.Add(-3 * time.Hour).Unix()}, now); got != "'Standup' passed today" {
+		t.Errorf("Unexpected digest line '%s'", got)
+	}
+}
+
+func TestDigestShownOnce(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	if err := writeEventsFile([]Event{
+		{Name: "Visa appointment", Time: now.Add(-48 * time.Hour).Unix()},
+		{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()},
+	}); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+
+	m, err := NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	if len(m.digest) != 0 {
+		t.Errorf("Expected no digest on the first run, got %v", m.digest)
+	}
+
+	if err := saveState(appState{LastExit: now.Add(-72 * time.Hour).Unix()}); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+	m, err = NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	if len(m.digest) != 1 || m.digest[0].Name != "Visa appointment" {
+		t.Fatalf("Expected the missed event in the digest, got %v", m.digest)
+	}
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort)})
+	view := stripANSI(m.View())
+	for _, want := range []string{"Since you last checked", "'Visa appointment' passed 2 days ago", "Launch"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view, got:\n%s", want, view)
+		}
+	}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m = model.(MainModel)
+	if len(m.digest) != 0 || m.state != showEvents {
+		t.Errorf("Expected the key to dismiss the digest and nothing else, got state %v", m.state)
+	}
+
+	m, err = NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	if len(m.digest) != 0 {
+		t.Errorf("Expected the digest not to repeat, got %v", m.digest)
+	}
+}
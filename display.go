@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+type displayMode int
+
+const (
+	displayFull displayMode = iota // "1y 23d 4h 5m 6s"
+	displayDays                    // "388 days left"
+	displayGaps                    // full, with the gap to the event above: "+3d"
+)
+
+// countdownFormat is how list descriptions and the compact detail line show
+// the time to an event. With hideSeconds the seconds are left out until an
+// event's final hour, so that rows do not tick every second.
+type countdownFormat struct {
+	display     displayMode
+	hideSeconds bool
+}
+
+// countdownFormat is the format display_mode and hide_seconds ask for.
+func (c Config) countdownFormat() countdownFormat {
+	display, _ := parseDisplayMode(c.DisplayMode)
+	return countdownFormat{display: display, hideSeconds: c.HideSeconds}
+}
+
+// cycleDisplay switches display_mode to the next mode for this run.
+func (c *Config) cycleDisplay() {
+	display, _ := parseDisplayMode(c.DisplayMode)
+	c.DisplayMode = display.cycle().String()
+}
+
+// secondsWindow is how close an event must be for its seconds to show while
+// hideSeconds is on.
+const secondsWindow = time.Hour
+
+// showSeconds reports whether the countdown to ts shows seconds at now.
+func (f countdownFormat) showSeconds(ts int64, now time.Time) bool {
+	left := time.Unix(ts, 0).Sub(now)
+	return !f.hideSeconds || (left >= 0 && left < secondsWindow)
+}
+
+func parseDisplayMode(s string) (displayMode, error) {
+	switch s {
+	case "", "full":
+		return displayFull, nil
+	case "days":
+		return displayDays, nil
+	case "spacing":
+		return displayGaps, nil
+	}
+	return displayFull, fmt.Errorf(`unknown display mode %q, want "full", "days" or "spacing"`, s)
+}
+
+func (d displayMode) String() string {
+	switch d {
+	case displayDays:
+		return "days"
+	case displayGaps:
+		return "spacing"
+	}
+	return "full"
+}
+
+// cycle returns the display mode D switches to: full, days, spacing and
+// back to full.
+func (d displayMode) cycle() displayMode {
+	switch d {
+	case displayFull:
+		return displayDays
+	case displayDays:
+		return displayGaps
+	}
+	return displayFull
+}
+
+// format formats the countdown to ts in f's display mode.
+func (f countdownFormat) format(ts int64, now time.Time) string {
+	if f.display == displayDays {
+		return formatDays(ts, now)
+	}
+	return formatCountdownSeconds(ts, now, f.showSeconds(ts, now))
+}
+
+// daysUntil counts calendar days from now to the day of ts, so anything
+// tomorrow is 1 regardless of the hour. This is the remaining time rounded up
+// from the start of today.
+func daysUntil(ts int64, now time.Time) int {
+	t := time.Unix(ts, 0).In(now.Location())
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// formatDays shows only whole days. Upcoming events round up to calendar
+// days; past events round down, counting full days since on the clock.
+func formatDays(ts int64, now time.Time) string {
+	if ts >= now.Unix() {
+		if n := daysUntil(ts, now); n > 0 {
+			return trn("countdown.left", n)
+		}
+		return tr("countdown.today")
+	}
+	if n := when.WholeDays(time.Unix(ts, 0).In(now.Location()), now); n > 0 {
+		return trn("countdown.since", n)
+	}
+	return tr("countdown.today")
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatDays(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		target   time.Time
+		expected string
+	}{
+		{"Later today", now.Add(10 * time.Hour), "today!"},
+		{"Exactly now", now, "today!"},
+		{"Tomorrow morning", time.Date(2026, 3, 2, 0, 5, 0, 0, time.Local), "1 day left"},
+		{"Tomorrow night", time.Date(2026, 3, 2, 23, 0, 0, 0, time.Local), "1 day left"},
+		{"Day after tomorrow", now.Add(2*24*time.Hour + time.Hour), "2 days left"},
+		{"Next year", time.Date(2027, 3, 24, 12, 0, 0, 0, time.Local), "388 days left"},
+		{"Earlier today", now.Add(-5 * time.Hour), "today!"},
+		{"Rounds down past days", now.Add(-36 * time.Hour), "1 day ago"},
+		{"Three days ago", now.Add(-3*24*time.Hour - time.Minute), "3 days ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDays(tt.target.Unix(), now); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDisplayToggle(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.AddDate(0, 0, 14).Unix()})
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = model.(MainModel)
+	if got := m.config.countdownFormat().display; got != displayDays {
+		t.Fatalf("Expected days display mode after toggling, got %v", got)
+	}
+	if got := m.config.countdownFormat().format(now.AddDate(0, 0, 14).Unix(), now); got != "14 days left" {
+		t.Errorf("Expected '14 days left', got '%s'", got)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = model.(MainModel)
+	if got := m.config.countdownFormat().display; got != displayGaps {
+		t.Errorf("Expected spacing display mode after toggling twice, got %v", got)
+	}
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = model.(MainModel)
+	if got := m.config.countdownFormat().display; got != displayFull {
+		t.Errorf("Expected full display mode after toggling three times, got %v", got)
+	}
+}
+
+func TestHideSeconds(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	f := countdownFormat{hideSeconds: true}
+
+	tests := []struct {
+		name     string
+		ts       time.Time
+		expected string
+	}{
+		{"Days away", now.Add(49*time.Hour + 5*time.Minute + 7*time.Second), "2d 1h 5m"},
+		{"Final hour", now.Add(59*time.Minute + 7*time.Second), "59m 7s"},
+		{"Just over an hour", now.Add(time.Hour + 7*time.Second), "1h 0m"},
+		{"Past", now.Add(-3*time.Hour - 7*time.Second), "3h 0m ago"},
+		{"Just passed", now.Add(-7 * time.Second), "7s ago"},
+	}
+	for _, tt := range tests {
+		if got := f.format(tt.ts.Unix(), now); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+	if got := formatCountdown(now.Add(49*time.Hour+7*time.Second).Unix(), now); got != "2d 1h 0m 7s" {
+		t.Errorf("Expected formatCountdown to keep the seconds, got %q", got)
+	}
+}
+
+func TestListAndDetailAgree(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	start := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &start, Event{Name: "Launch", Time: start.AddDate(0, 0, 3).Unix()})
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = model.(MainModel)
+	// Every read of the clock is a second later, as if the render were slow
+	// enough to straddle a tick.
+	calls := 0
+	m.clock = func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls) * time.Second)
+	}
+
+	view := stripANSI(m.View())
+	// The list item comes first, then the detail column's compact string.
+	countdowns := regexp.MustCompile(`\d+d \d+h \d+m \d+s`).FindAllString(view, 2)
+	if len(countdowns) < 2 || countdowns[0] != "2d 23h 59m 59s" || countdowns[1] != countdowns[0] {
+		t.Errorf("Expected the list and the detail column to agree, got %q in:\n%s", countdowns, view)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+// checkLevel is how a doctor check came out.
+type checkLevel int
+
+const (
+	checkPass checkLevel = iota
+	checkWarn
+	checkFail
+)
+
+func (l checkLevel) String() string {
+	switch l {
+	case checkWarn:
+		return "warn"
+	case checkFail:
+		return "fail"
+	}
+	return "pass"
+}
+
+// checkResult is one line of `countdown doctor`. Remedy says what to do
+// about a warning or failure.
+type checkResult struct {
+	Name   string
+	Level  checkLevel
+	Detail string
+	Remedy string
+}
+
+func passCheck(name, detail string) checkResult {
+	return checkResult{Name: name, Level: checkPass, Detail: detail}
+}
+
+func warnCheck(name, detail, remedy string) checkResult {
+	return checkResult{Name: name, Level: checkWarn, Detail: detail, Remedy: remedy}
+}
+
+func failCheck(name, detail, remedy string) checkResult {
+	return checkResult{Name: name, Level: checkFail, Detail: detail, Remedy: remedy}
+}
+
+// clockSkew is how far in the future a file may have been written before
+// the system clock looks wrong.
+const clockSkew = 5 * time.Minute
+
+// checkOnThisDay fetches today's feed to see whether Wikipedia answers. It
+// is a variable so tests stay offline.
+var checkOnThisDay = func(now time.Time) error {
+	msg := fetchWikiFeed(now, feedSelected, 10*time.Second)
+	return msg.err
+}
+
+// checkWritable reports whether files can be created in dir.
+func checkWritable(name, dir string) checkResult {
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return passCheck(name, dir+" does not exist yet and will be created when needed")
+	}
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return failCheck(name, err.Error(), "make "+dir+" writable for your user")
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return passCheck(name, dir+" is writable")
+}
+
+func checkConfig() []checkResult {
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		return []checkResult{failCheck("Config", err.Error(), "set HOME or XDG_CONFIG_HOME")}
+	}
+	results := []checkResult{checkWritable("Config directory", filepath.Dir(configFile))}
+	cfg := defaultConfig()
+	if _, err := toml.DecodeFile(configFile, &cfg); errors.Is(err, os.ErrNotExist) {
+		return append(results, passCheck("Config", configFile+" not present, using defaults"))
+	} else if err != nil {
+		return append(results, failCheck("Config", err.Error(), "fix the TOML syntax in "+configFile))
+	}
+	if err := cfg.validate(); err != nil {
+		return append(results, failCheck("Config", err.Error(), "fix the value in "+configFile))
+	}
+	for _, f := range []struct{ key, value string }{{"date_format", cfg.DateFormat}, {"time_format", cfg.TimeFormat}} {
+		presets := datePresets
+		if f.key == "time_format" {
+			presets = timePresets
+		}
+		if _, ok := resolveLayout(f.value, presets); !ok {
+			return append(results, warnCheck("Config", fmt.Sprintf("%s %q is not a preset or Go layout", f.key, f.value),
+				"use a preset such as \"iso\" or a Go layout like \"2006-01-02\""))
+		}
+	}
+	if cfg.ListFormat != "" {
+		if _, err := compileListFormat(cfg.ListFormat); err != nil {
+			return append(results, warnCheck("Config", err.Error(), "fix list_format or remove it for the built-in format"))
+		}
+	}
+	return append(results, passCheck("Config", configFile+" loads"))
+}
+
+func checkEventsFile(eventsFile string) checkResult {
+	if info, err := os.Stat(eventsFile); err == nil && info.IsDir() {
+		return failCheck("Events file", eventsFile+" is a directory", "move it away; countdown starts a new events file")
+	}
+	data, err := os.ReadFile(eventsFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return warnCheck("Events file", eventsFile+" does not exist", "start countdown once to create it")
+	} else if err != nil {
+		return failCheck("Events file", err.Error(), "make "+eventsFile+" readable for your user")
+	}
+	events, err := decodeEvents(eventsFile, data)
+	if err != nil {
+		return failCheck("Events file", err.Error(), "fix the JSON, or start countdown to set the bad events aside")
+	}
+	return passCheck("Events file", fmt.Sprintf("%s has %d events", eventsFile, len(events)))
+}
+
+func checkStateFile(stateFile string, now time.Time) checkResult {
+	data, err := os.ReadFile(stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return passCheck("State file", "not present yet")
+	} else if err != nil {
+		return warnCheck("State file", err.Error(), "delete "+stateFile+"; it is written again on the next run")
+	}
+	var st appState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return warnCheck("State file", err.Error(), "delete "+stateFile+"; it is written again on the next run")
+	}
+	if time.Unix(st.LastExit, 0).After(now.Add(clockSkew)) {
+		return warnCheck("State file", "last run is recorded in the future", "check the system clock, then delete "+stateFile)
+	}
+	return passCheck("State file", stateFile+" is valid")
+}
+
+func checkJournal() checkResult {
+	entries, err := readJournal()
+	if err != nil {
+		return warnCheck("Journal", err.Error(), "delete the journal file if the changes in it are not needed")
+	}
+	if len(entries) > 0 {
+		return warnCheck("Journal", fmt.Sprintf("%d unsaved changes from a crash", len(entries)), "start countdown to recover them")
+	}
+	return passCheck("Journal", "no unsaved changes")
+}
+
+// checkClock compares the system time with when the files were last
+// written. A file from the future means the clock was or is wrong.
+func checkClock(now time.Time, files ...string) checkResult {
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(now.Add(clockSkew)) {
+			return failCheck("Clock", fmt.Sprintf("%s was written %s, after the current time %s",
+				filepath.Base(f), info.ModTime().Format(time.RFC3339), now.Format(time.RFC3339)),
+				"set the system clock, e.g. enable NTP")
+		}
+	}
+	return passCheck("Clock", now.Format(time.RFC3339)+", consistent with the files")
+}
+
+func checkNetwork(now time.Time) checkResult {
+	if err := checkOnThisDay(now); err != nil {
+		return warnCheck("On This Day", err.Error(), "check the network or proxy; the rest of countdown works offline")
+	}
+	return passCheck("On This Day", "api.wikimedia.org answers")
+}
+
+func checkTerminal(out *os.File) []checkResult {
+	if !term.IsTerminal(out.Fd()) {
+		return []checkResult{warnCheck("Terminal", "output is not a terminal", "run doctor in the terminal you use countdown in")}
+	}
+	var results []checkResult
+	profile := termenv.NewOutput(out).EnvColorProfile()
+	names := map[termenv.Profile]string{termenv.TrueColor: "true color", termenv.ANSI256: "256 colors", termenv.ANSI: "16 colors", termenv.Ascii: "no color"}
+	if profile == termenv.Ascii {
+		results = append(results, warnCheck("Colors", names[profile], "unset NO_COLOR, or set TERM or COLORTERM=truecolor"))
+	} else {
+		results = append(results, passCheck("Colors", names[profile]))
+	}
+	width, height, err := term.GetSize(out.Fd())
+	need := minListWidth + minDetailWidth + minTimelineWidth
+	switch {
+	case err != nil:
+		results = append(results, warnCheck("Size", err.Error(), "use a terminal that reports its size"))
+	case width < need || height < 24:
+		results = append(results, warnCheck("Size", fmt.Sprintf("%dx%d", width, height),
+			fmt.Sprintf("make the window at least %dx24 to see every column", need)))
+	default:
+		results = append(results, passCheck("Size", fmt.Sprintf("%dx%d", width, height)))
+	}
+	return results
+}
+
+// doctorChecks runs every check in the order they are printed.
+func doctorChecks(now time.Time, out *os.File) []checkResult {
+	results := checkConfig()
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		results = append(results, failCheck("Data directory", err.Error(), "set HOME or COUNTDOWN_EVENTS_FILE"))
+	} else {
+		stateFile, _ := getStateFilePath()
+		results = append(results,
+			checkWritable("Data directory", filepath.Dir(eventsFile)),
+			checkEventsFile(eventsFile),
+			checkStateFile(stateFile, now),
+			checkJournal(),
+			checkClock(now, eventsFile, stateFile))
+	}
+	results = append(results, checkNetwork(now))
+	return append(results, checkTerminal(out)...)
+}
+
+func printChecks(w io.Writer, results []checkResult) {
+	nameWidth := 0
+	for _, r := range results {
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+	for _, r := range results {
+		fmt.Fprintf(w, "%s  %-*s  %s\n", r.Level, nameWidth, r.Name, r.Detail)
+		if r.Remedy != "" {
+			fmt.Fprintf(w, "      %*s  → %s\n", nameWidth, "", r.Remedy)
+		}
+	}
+}
+
+// runDoctor prints the health of the files, network and terminal countdown
+// depends on, for bug reports. It fails if any check does.
+func runDoctor(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "usage: countdown doctor")
+		return 2
+	}
+	fmt.Printf("countdown %s\n\n", buildVersion())
+	results := doctorChecks(time.Now(), os.Stdout)
+	printChecks(os.Stdout, results)
+	for _, r := range results {
+		if r.Level == checkFail {
+			return 1
+		}
+	}
+	return 0
+}
+
+// runPath prints where the events file is, for scripts and editors.
+func runPath(args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "usage: countdown path")
+		return 2
+	}
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "path: %v\n", err)
+		return 1
+	}
+	fmt.Println(eventsFile)
+	return 0
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func findCheck(results []checkResult, name string) checkResult {
+	for _, r := range results {
+		if r.Name == name {
+			return r
+		}
+	}
+	return checkResult{}
+}
+
+func TestDoctorChecks(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	saved := checkOnThisDay
+	checkOnThisDay = func(time.Time) error { return errors.New("no route to host") }
+	defer func() { checkOnThisDay = saved }()
+
+	now := time.Now()
+	if err := writeEventsFile([]Event{{Name: "A", Time: now.Unix()}, {Name: "B", Time: now.Unix()}}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	results := doctorChecks(now, out)
+	for name, expected := range map[string]checkLevel{
+		"Config":         checkPass,
+		"Data directory": checkPass,
+		"Events file":    checkPass,
+		"Clock":          checkPass,
+		"On This Day":    checkWarn,
+		"Terminal":       checkWarn,
+	} {
+		if r := findCheck(results, name); r.Level != expected || r.Name == "" {
+			t.Errorf("%s: expected %s, got %+v", name, expected, r)
+		}
+	}
+	if r := findCheck(results, "Events file"); !strings.Contains(r.Detail, "2 events") {
+		t.Errorf("Expected the event count, got %q", r.Detail)
+	}
+
+	// A file from the future and a broken config both fail.
+	eventsFile, _ := getEventsFilePath()
+	future := now.Add(time.Hour)
+	if err := os.Chtimes(eventsFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	writeConfigFile(t, "week_start = \"tuesday\"\n")
+	results = doctorChecks(now, out)
+	for _, name := range []string{"Clock", "Config"} {
+		if r := findCheck(results, name); r.Level != checkFail || r.Remedy == "" {
+			t.Errorf("%s: expected a failure with a remedy, got %+v", name, r)
+		}
+	}
+
+	var b bytes.Buffer
+	printChecks(&b, results)
+	if !strings.Contains(b.String(), "fail  Clock") || !strings.Contains(b.String(), "→ set the system clock") {
+		t.Errorf("Unexpected report:\n%s", b.String())
+	}
+}
+
+func TestDoctorBrokenEventsFile(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(eventsFile, []byte(`[{"name": "A",`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkEventsFile(eventsFile); r.Level != checkFail {
+		t.Errorf("Expected a broken events file to fail, got %+v", r)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var leadTimeUnits = map[string]time.Duration{
+	"w": 7 * 24 * time.Hour,
+	"d": 24 * time.Hour,
+	"h": time.Hour,
+	"m": time.Minute,
+	"s": time.Second,
+}
+
+// parseLeadTime parses durations such as "1w", "2d", "90m" or "1d12h".
+// Unlike time.ParseDuration it understands days and weeks.
+func parseLeadTime(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && unicode.IsDigit(rune(s[i])) {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		s = s[i:]
+
+		j := 0
+		for j < len(s) && unicode.IsLetter(rune(s[j])) {
+			j++
+		}
+		unit, ok := leadTimeUnits[s[:j]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q, use w, d, h, m or s", s[:j])
+		}
+		s = s[j:]
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
+
+// formatLeadTime is the inverse of parseLeadTime, using the largest units first.
+func formatLeadTime(d time.Duration) string {
+	if d <= 0 {
+		return "0m"
+	}
+	var b strings.Builder
+	for _, u := range []string{"w", "d", "h", "m", "s"} {
+		unit := leadTimeUnits[u]
+		if d >= unit {
+			fmt.Fprintf(&b, "%d%s", d/unit, u)
+			d %= unit
+		}
+	}
+	return b.String()
+}
+
+// parseReminders parses a comma-separated list of lead times such as
+// "1w, 1d, 2h" into seconds before the event. An empty list is nil, which
+// means the configured defaults apply.
+func parseReminders(s string) ([]int64, error) {
+	var reminders []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := parseLeadTime(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", part, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("%q: must be at least 1s", part)
+		}
+		reminders = append(reminders, int64(d/time.Second))
+	}
+	return reminders, nil
+}
+
+// upcomingReminders returns the lead times of e's reminders that are still
+// to come, soonest first.
+func upcomingReminders(e Event, defaults []time.Duration, now time.Time) []time.Duration {
+	var upcoming []time.Duration
+	for _, offset := range e.reminderOffsets(defaults) {
+		if time.Unix(e.Time, 0).Add(-offset).After(now) {
+			upcoming = append(upcoming, offset)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool { return upcoming[i] > upcoming[j] })
+	return upcoming
+}
+
+// formatReminders is the inverse of parseReminders.
+func formatReminders(reminders []int64) string {
+	parts := make([]string, len(reminders))
+	for i, r := range reminders {
+		parts[i] = formatLeadTime(time.Duration(r) * time.Second)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLeadTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"1w", 7 * 24 * time.Hour, false},
+		{"2d", 48 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"1d12h", 36 * time.Hour, false},
+		{" 3H ", 3 * time.Hour, false},
+		{"", 0, true},
+		{"d", 0, true},
+		{"5", 0, true},
+		{"2y", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseLeadTime(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			if back, _ := parseLeadTime(formatLeadTime(got)); back != got {
+				t.Errorf("Round trip through '%s' gave %v", formatLeadTime(got), back)
+			}
+		})
+	}
+}
+
+func TestParseReminders(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"", "", false},
+		{" , ", "", false},
+		{"1w, 1d, 2h", "1w, 1d, 2h", false},
+		{"90m,1d12h", "1h30m, 1d12h", false},
+		{"1d, 2x", "", true},
+		{"0m", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseReminders(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if s := formatReminders(got); s != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestUpcomingReminders(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	defaults := []time.Duration{24 * time.Hour, time.Hour}
+	e := Event{Name: "Launch", Time: now.Add(3 * time.Hour).Unix()}
+
+	if got := upcomingReminders(e, defaults, now); len(got) != 1 || got[0] != time.Hour {
+		t.Errorf("Expected only the 1h default to be upcoming, got %v", got)
+	}
+	e.Reminders = []int64{60 * 60, 2 * 60 * 60, 7 * 24 * 60 * 60}
+	if got := upcomingReminders(e, defaults, now); len(got) != 2 || got[0] != 2*time.Hour || got[1] != time.Hour {
+		t.Errorf("Expected the event's 2h and 1h reminders, soonest first, got %v", got)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// editField is one field of the form as it was when editing began and as
+// it would be saved.
+type editField struct {
+	Label, Before, After string
+}
+
+func (f editField) changed() bool { return f.Before != f.After }
+
+// editFields lists the form's fields of before and after, in form order.
+func (m MainModel) editFields(before, after Event) []editField {
+	reminders := func(e Event) string {
+		if len(e.Reminders) == 0 {
+			return tr("review.default_reminders")
+		}
+		return formatReminders(e.Reminders)
+	}
+	repeat := func(e Event) string {
+		if s := formatRecurrence(e.Repeat, e.Yearly); s != "" {
+			return s
+		}
+		return tr("form.repeat_placeholder")
+	}
+	fields := []editField{
+		{tr("form.name"), before.Name, after.Name},
+		{tr("form.datetime"), m.config.eventDateTime(before), m.config.eventDateTime(after)},
+		{tr("form.reminders"), reminders(before), reminders(after)},
+		{tr("form.repeat"), repeat(before), repeat(after)},
+		{tr("form.kind"), kindLabel(before.Kind), kindLabel(after.Kind)},
+	}
+	if m.config.grouped() {
+		fields = append(fields, editField{tr("form.group"), groupLabel(before.Group), groupLabel(after.Group)})
+	}
+	return fields
+}
+
+// reviewEdit shows e against the event as it was before saving it, unless
+// the config turns the review off or nothing shown in the form changed.
+func (m *MainModel) reviewEdit(e Event) bool {
+	if !m.config.ReviewEdits {
+		return false
+	}
+	for _, f := range m.editFields(m.editOriginal, e) {
+		if f.changed() {
+			m.editPending = e
+			m.state = showEditReview
+			return true
+		}
+	}
+	return false
+}
+
+// updateEditReview saves the reviewed edit on enter or ctrl+s, or goes
+// back to the form as it was left on esc.
+func (m *MainModel) updateEditReview(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, Keymap.Enter, Keymap.Submit):
+		return m.saveForm(m.editPending, true)
+	case key.Matches(msg, Keymap.Back):
+		m.state = showEdit
+	}
+	return nil
+}
+
+func (m MainModel) editReviewView() string {
+	width := min(72, m.windowWidth-8)
+	column := (width - 3) / 2
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("review.title")) + "\n\n")
+
+	cell := lipgloss.NewStyle().Width(column)
+	b.WriteString(cell.Render(HintStyle(tr("review.before"))) + "   " + cell.Render(HintStyle(tr("review.after"))) + "\n")
+	for _, f := range m.editFields(m.editOriginal, m.editPending) {
+		before, after, arrow := HintStyle(f.Before), HintStyle(f.After), "   "
+		if f.changed() {
+			before, after, arrow = WarningStyle(f.Before), SuccessStyle(f.After), " → "
+		}
+		b.WriteString("\n" + BrightTextStyle(f.Label) + "\n")
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cell.Render(before), arrow, cell.Render(after)) + "\n")
+	}
+	b.WriteString("\n" + HintStyle(tr("review.help")))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEditReview(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 9).Unix(), Reminders: []int64{3600}},
+	)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m.inputs[inputNameField].SetValue("Product launch")
+	m.inputs[inputTimeField].SetValue("2026-03-20 09:00:00")
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state != showEditReview {
+		t.Fatalf("Expected the changes shown before saving, got state %v", m.state)
+	}
+	view := stripANSI(m.View())
+	for _, want := range []string{"Review Changes", "Before", "After", "Launch", "→ Product launch", "March 20, 2026", "1h"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != showEdit || m.inputs[inputNameField].Value() != "Product launch" {
+		t.Fatalf("Expected esc to return to the form as it was, got state %v with %q", m.state, m.inputs[inputNameField].Value())
+	}
+	if got := storedNames(m.storedEvents()); got != "Launch" {
+		t.Errorf("Expected nothing changed before confirming, got %s", got)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != showEvents {
+		t.Fatalf("Expected enter to save the edit, got state %v", m.state)
+	}
+	if events, _ := readEventsFile(); storedNames(events) != "Product launch" {
+		t.Errorf("Expected the edit saved, got %s", storedNames(events))
+	}
+
+	// With nothing changed, or the review turned off, the edit saves at once.
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state != showEvents {
+		t.Errorf("Expected an unchanged edit to save without review, got state %v", m.state)
+	}
+	m.config.ReviewEdits = false
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m.inputs[inputNameField].SetValue("Launch day")
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state != showEvents {
+		t.Errorf("Expected review_edits = false to skip the review, got state %v", m.state)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// eventID turns an event name into a stable identifier for topics and
+// metric labels: lowercase letters and digits, with runs of anything else
+// replaced by one dash. Renaming an event changes its ID.
+func eventID(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "event"
+	}
+	return b.String()
+}
+
+// eventIDs returns the ID of each event, numbering events whose names give
+// the same ID in list order: "launch", "launch-2", ...
+func eventIDs(events []Event) []string {
+	ids := make([]string, len(events))
+	seen := make(map[string]int)
+	for i, e := range events {
+		id := eventID(e.Name)
+		seen[id]++
+		if seen[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, seen[id])
+		}
+		ids[i] = id
+	}
+	return ids
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventID(t *testing.T) {
+	tests := map[string]string{
+		"Launch Day!":      "launch-day",
+		"  Über 2026  ":    "über-2026",
+		"a/b+c#d":          "a-b-c-d",
+		"!!!":              "event",
+		"Mom's birthday 🎂": "mom-s-birthday",
+	}
+	for name, expected := range tests {
+		if got := eventID(name); got != expected {
+			t.Errorf("%q: expected %q, got %q", name, expected, got)
+		}
+	}
+
+	ids := eventIDs([]Event{{Name: "Launch"}, {Name: "Standup"}, {Name: "launch!"}, {Name: "LAUNCH"}})
+	if got := strings.Join(ids, ","); got != "launch,standup,launch-2,launch-3" {
+		t.Errorf("Expected duplicates numbered in order, got %s", got)
+	}
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// eventProblem is one thing wrong with an entry of the events file.
+type eventProblem struct {
+	Index int // position in the array, from 0
+	Line  int // line the entry starts on
+	Field string
+	Issue string
+}
+
+func (p eventProblem) String() string {
+	if p.Field == "" {
+		return fmt.Sprintf("event %d (line %d): %s", p.Index, p.Line, p.Issue)
+	}
+	return fmt.Sprintf("event %d (line %d): %s: %s", p.Index, p.Line, p.Field, p.Issue)
+}
+
+// invalidEventsError reports every bad entry in the events file. Good holds
+// the entries that passed, so the caller can load them and quarantine Bad.
+type invalidEventsError struct {
+	Path     string
+	Problems []eventProblem
+	Good     []Event
+	Bad      []json.RawMessage
+}
+
+func (e *invalidEventsError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s has %d invalid ", e.Path, len(e.Bad))
+	if len(e.Bad) == 1 {
+		b.WriteString("event:")
+	} else {
+		b.WriteString("events:")
+	}
+	for _, p := range e.Problems {
+		b.WriteString("\n  " + p.String())
+	}
+	return b.String()
+}
+
+// eventFields lists the JSON kind each known field must have. Unknown fields
+// are left alone.
+var eventFields = []struct {
+	name     string
+	kind     string
+	required bool
+}{
+	{"name", "string", true},
+	{"ts", "integer", true},
+	{"tags", "string array", false},
+	{"yearly", "boolean", false},
+	{"leap_day", "boolean", false},
+	{"repeat", "object", false},
+	{"since", "integer", false},
+	{"source", "string", false},
+	{"order", "integer", false},
+	{"created", "integer", false},
+	{"reminders", "integer array", false},
+	{"readonly", "boolean", false},
+	{"notes", "string", false},
+	{"kind", "string", false},
+	{"all_day", "boolean", false},
+	{"group", "string", false},
+}
+
+// utf8BOM starts files saved by some Windows editors. encoding/json
+// rejects it.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// decodeEvents parses the events file, checking each entry on its own. A
+// document that is not a JSON array fails outright with the line of the
+// error; bad entries are collected into an *invalidEventsError. Files left
+// empty, blank, null or "" by sync conflicts hold no events, and the next
+// save writes them as [].
+func decodeEvents(path string, data []byte) ([]Event, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	switch string(bytes.TrimSpace(data)) {
+	case "", "null", `""`:
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, syntaxError(path, data, err)
+	} else if tok != json.Delim('[') {
+		return nil, syntaxError(path, data, fmt.Errorf("line %d: expected a list of events", lineAt(data, 0)))
+	}
+
+	var invalid invalidEventsError
+	var events []Event
+	for i := 0; dec.More(); i++ {
+		start := int(dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, syntaxError(path, data, err)
+		}
+		line := lineAt(data, start)
+
+		problems := checkEvent(raw)
+		if len(problems) > 0 {
+			for _, p := range problems {
+				p.Index, p.Line = i, line
+				invalid.Problems = append(invalid.Problems, p)
+			}
+			invalid.Bad = append(invalid.Bad, raw)
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(raw, &e); err != nil {
+			invalid.Problems = append(invalid.Problems, eventProblem{Index: i, Line: line, Issue: err.Error()})
+			invalid.Bad = append(invalid.Bad, raw)
+			continue
+		}
+		events = append(events, e)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, syntaxError(path, data, err)
+	}
+
+	if len(invalid.Bad) > 0 {
+		invalid.Path, invalid.Good = path, events
+		return events, &invalid
+	}
+	return events, nil
+}
+
+func checkEvent(raw json.RawMessage) []eventProblem {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return []eventProblem{{Issue: "expected an object, got " + withArticle(jsonKind(raw))}}
+	}
+
+	var problems []eventProblem
+	for _, field := range eventFields {
+		value, ok := fields[field.name]
+		if !ok || jsonKind(value) == "null" {
+			if field.required {
+				problems = append(problems, eventProblem{Field: field.name, Issue: "missing"})
+			}
+			continue
+		}
+		if got := jsonKind(value); got != field.kind && !(strings.HasSuffix(field.kind, " array") && got == "empty array") {
+			problems = append(problems, eventProblem{Field: field.name, Issue: fmt.Sprintf("expected %s, got %s", withArticle(field.kind), withArticle(got))})
+			continue
+		}
+		switch field.name {
+		case "name":
+			var name string
+			_ = json.Unmarshal(value, &name)
+			if strings.TrimSpace(name) == "" {
+				problems = append(problems, eventProblem{Field: field.name, Issue: "empty"})
+			}
+		case "repeat":
+			problems = append(problems, checkRepeat(value)...)
+		}
+	}
+	return problems
+}
+
+// checkRepeat checks an event's recurrence, which would otherwise never move
+// past the event's time. Fields of the wrong type are left to decoding.
+func checkRepeat(raw json.RawMessage) []eventProblem {
+	var r Recurrence
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil
+	}
+	var problems []eventProblem
+	switch r.Unit {
+	case repeatDaily, repeatWeekly, repeatMonthly:
+	default:
+		problems = append(problems, eventProblem{Field: "repeat.unit", Issue: fmt.Sprintf("expected day, week or month, got %q", r.Unit)})
+	}
+	if r.Every < 0 {
+		problems = append(problems, eventProblem{Field: "repeat.every", Issue: "negative"})
+	}
+	return problems
+}
+
+// jsonKind names the type of a JSON value, telling integers from other
+// numbers and arrays of one kind, such as "string array", from mixed ones.
+func jsonKind(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return "nothing"
+	}
+	switch raw[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return "array"
+		}
+		if len(items) == 0 {
+			return "empty array"
+		}
+		kind := jsonKind(items[0])
+		for _, item := range items[1:] {
+			if jsonKind(item) != kind {
+				return "array"
+			}
+		}
+		if strings.HasSuffix(kind, "array") {
+			return "array"
+		}
+		return kind + " array"
+	}
+	if _, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+		return "integer"
+	}
+	return "number"
+}
+
+func withArticle(kind string) string {
+	switch kind {
+	case "null", "nothing":
+		return kind
+	case "integer", "object", "array", "empty array", "integer array", "object array":
+		return "an " + kind
+	}
+	return "a " + kind
+}
+
+// lineAt returns the line of the first value at or after offset, skipping
+// the whitespace and comma the decoder leaves before it.
+func lineAt(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n,", data[offset]) >= 0 {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func syntaxError(path string, data []byte, err error) error {
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		err = fmt.Errorf("line %d: %w", lineAt(data, int(syntax.Offset)-1), err)
+	} else if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		err = fmt.Errorf("line %d: unexpected end of file", lineAt(data, len(data)))
+	}
+	return fmt.Errorf("%s is not a valid events file, fix it or move it away to start over: %w", path, err)
+}
+
+// eventsDirError is the error for a directory where the events file belongs,
+// e.g. after a restore that went wrong, which reading would only call "is a
+// directory".
+func eventsDirError(eventsFile string) error {
+	return fmt.Errorf("%s is a directory, not an events file, move it away to start over", eventsFile)
+}
+
+// quarantinedPath is where quarantineEvents moves bad entries.
+func quarantinedPath(eventsFile string) string {
+	return strings.TrimSuffix(eventsFile, ".json") + ".rejected.json"
+}
+
+// quarantineEvents appends the bad entries to the side file next to the
+// events file, then rewrites the events file with only the good ones.
+func quarantineEvents(invalid *invalidEventsError) error {
+	sideFile := quarantinedPath(invalid.Path)
+	var rejected []json.RawMessage
+	if data, err := os.ReadFile(sideFile); err == nil {
+		if err := json.Unmarshal(data, &rejected); err != nil {
+			return fmt.Errorf("%s is not a JSON list, move it away first: %w", sideFile, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", sideFile, err)
+	}
+	rejected = append(rejected, invalid.Bad...)
+	good := invalid.Good
+	if good == nil {
+		good = []Event{}
+	}
+
+	data, err := json.MarshalIndent(rejected, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(sideFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sideFile, err)
+	}
+	return writeEventsFile(good)
+}
+
+// confirmQuarantine asks whether to load the good events and move the bad
+// ones aside, defaulting to no.
+func confirmQuarantine(invalid *invalidEventsError, in io.Reader, out io.Writer) bool {
+	fmt.Fprintf(out, "Load the %d valid events and move the invalid ones to %s? [y/N] ", len(invalid.Good), quarantinedPath(invalid.Path))
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// eventStore keeps the events file in memory, reading it again only when
+// it changed: it was replaced, as every save does, or its modification time
+// or size differ. It never creates or writes the file, so the daemon and
+// serve can run alongside the TUI without getting in its way.
+type eventStore struct {
+	path string
+
+	mu     sync.Mutex
+	info   os.FileInfo
+	events []Event
+}
+
+// Events returns the current events. When the file cannot be read or is
+// invalid, the last good list is returned along with the error.
+func (s *eventStore) Events() ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.events, s.info = nil, nil
+		return nil, nil
+	} else if err != nil {
+		return s.events, err
+	}
+	if s.info != nil && os.SameFile(info, s.info) && info.ModTime().Equal(s.info.ModTime()) && info.Size() == s.info.Size() {
+		return s.events, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return s.events, err
+	}
+	events, err := decodeEvents(s.path, data)
+	if err != nil {
+		return s.events, err
+	}
+	s.events, s.info = events, info
+	return s.events, nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeEventsReportsEveryProblem(t *testing.T) {
+	data := []byte(`[
+  {"name": "Launch", "ts": 1773597600},
+  {"name": "Party", "ts": "tomorrow"},
+  {"name": "", "ts": 1.5, "tags": ["a", 2]},
+  {"ts": 1773597600, "yearly": "yes", "extra": true},
+  "not an event",
+  {"name": "Trip", "ts": 1780000000, "tags": [], "source": null, "reminders": [86400, 3600]},
+  {"name": "Call", "ts": 1780000000, "reminders": ["1d"]}
+]`)
+
+	events, err := decodeEvents("events.json", data)
+	var invalid *invalidEventsError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected an invalidEventsError, got %v", err)
+	}
+	if len(events) != 2 || events[0].Name != "Launch" || events[1].Name != "Trip" {
+		t.Errorf("Expected the two good events, got %+v", events)
+	}
+	if len(invalid.Good) != 2 || len(invalid.Bad) != 5 {
+		t.Errorf("Expected 2 good and 5 bad entries, got %d and %d", len(invalid.Good), len(invalid.Bad))
+	}
+
+	expected := []string{
+		"event 1 (line 3): ts: expected an integer, got a string",
+		"event 2 (line 4): name: empty",
+		"event 2 (line 4): ts: expected an integer, got a number",
+		"event 2 (line 4): tags: expected a string array, got an array",
+		"event 3 (line 5): name: missing",
+		"event 3 (line 5): yearly: expected a boolean, got a string",
+		"event 4 (line 6): expected an object, got a string",
+		"event 6 (line 8): reminders: expected an integer array, got a string array",
+	}
+	if len(invalid.Problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got:\n%v", len(expected), err)
+	}
+	for i, want := range expected {
+		if got := invalid.Problems[i].String(); got != want {
+			t.Errorf("Problem %d: expected '%s', got '%s'", i, want, got)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "events.json has 5 invalid events:\n  event 1") {
+		t.Errorf("Unexpected error message:\n%v", err)
+	}
+}
+
+func TestDecodeEventsChecksRepeat(t *testing.T) {
+	data := []byte(`[
+  {"name": "Standup", "ts": 1773597600, "repeat": {"unit": "week", "every": 2}},
+  {"name": "Payday", "ts": 1773597600, "repeat": {"unit": "fortnight"}},
+  {"name": "Gym", "ts": 1773597600, "repeat": {"unit": "day", "every": -1}}
+]`)
+
+	events, err := decodeEvents("events.json", data)
+	var invalid *invalidEventsError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Expected an invalidEventsError, got %v", err)
+	}
+	if len(events) != 1 || events[0].Name != "Standup" {
+		t.Errorf("Expected only Standup, got %+v", events)
+	}
+	expected := []string{
+		`event 1 (line 3): repeat.unit: expected day, week or month, got "fortnight"`,
+		"event 2 (line 4): repeat.every: negative",
+	}
+	if len(invalid.Problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got:\n%v", len(expected), err)
+	}
+	for i, want := range expected {
+		if got := invalid.Problems[i].String(); got != want {
+			t.Errorf("Problem %d: expected '%s', got '%s'", i, want, got)
+		}
+	}
+}
+
+func TestEventFieldsCoverEvent(t *testing.T) {
+	checked := map[string]bool{}
+	for _, field := range eventFields {
+		checked[field.name] = true
+	}
+	typ := reflect.TypeOf(Event{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if name != "-" && !checked[name] {
+			t.Errorf("Expected %s in eventFields, so a bad value is caught on load", name)
+		}
+	}
+
+	_, err := decodeEvents("events.json", []byte(`[{"name": "Trip", "ts": 1780000000, "repeat": "weekly", "all_day": 1, "kind": 2, "group": [], "leap_day": "no"}]`))
+	var invalid *invalidEventsError
+	if !errors.As(err, &invalid) || len(invalid.Problems) != 5 {
+		t.Errorf("Expected a problem with each of the five fields, got %v", err)
+	}
+}
+
+func TestDecodeEventsSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"Not a list", `{"name": "Launch"}`, "line 1: expected a list of events"},
+		{"Missing comma", "[\n  {\"name\": \"a\", \"ts\": 1}\n  {\"name\": \"b\", \"ts\": 2}\n]", "line 3: invalid character '{'"},
+		{"Truncated", "[\n  {\"name\": \"a\", \"ts\": 1},\n", "line 3: unexpected end"},
+		{"Null and more", "null\n[]", "line 1: expected a list of events"},
+		{"After a byte order mark", "\ufeff[\n  {\"name\": \"a\", \"ts\": 1}\n  {", "line 3: invalid character '{'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeEvents("events.json", []byte(tt.data))
+			var invalid *invalidEventsError
+			if err == nil || errors.As(err, &invalid) {
+				t.Fatalf("Expected a syntax error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected '%s' in '%v'", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestDecodeEventsBlankFiles(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range []string{"", " \n\t\r\n", "null", " null\n", `""`, "[]", "\ufeff", "\ufeff null\n"} {
+		if err := os.WriteFile(eventsFile, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		events, err := readEventsFile()
+		if err != nil || len(events) != 0 {
+			t.Errorf("%q: expected no events and no error, got %v, %v", data, events, err)
+		}
+		if !holdsNoEvents(eventsFile) {
+			t.Errorf("%q: expected the first-run wizard to count it as empty", data)
+		}
+		if err := writeEventsFile(events); err != nil {
+			t.Fatal(err)
+		}
+		if saved, _ := os.ReadFile(eventsFile); string(saved) != "[]" {
+			t.Errorf("%q: expected the next save to write [], got %q", data, saved)
+		}
+	}
+
+	events, err := decodeEvents("events.json", []byte("\ufeff[{\"name\": \"Launch\", \"ts\": 1773597600}]\n"))
+	if err != nil || len(events) != 1 || events[0].Name != "Launch" {
+		t.Errorf("Expected a byte order mark to be skipped, got %+v, %v", events, err)
+	}
+}
+
+func TestQuarantineEvents(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		t.Fatalf("Failed to get events file path: %v", err)
+	}
+	for _, bad := range []string{`{"name": "Party", "ts": "tomorrow"}`, `{"name": 42, "ts": 1}`} {
+		doc := `[{"name": "Launch", "ts": 1773597600}, ` + bad + `]`
+		if err := os.WriteFile(eventsFile, []byte(doc), 0644); err != nil {
+			t.Fatalf("Failed to write events: %v", err)
+		}
+		_, err := readEventsFile()
+		var invalid *invalidEventsError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("Expected an invalidEventsError, got %v", err)
+		}
+		if !confirmQuarantine(invalid, strings.NewReader("y\n"), &strings.Builder{}) {
+			t.Fatal("Expected 'y' to confirm")
+		}
+		if err := quarantineEvents(invalid); err != nil {
+			t.Fatalf("Failed to quarantine: %v", err)
+		}
+	}
+
+	events, err := readEventsFile()
+	if err != nil || len(events) != 1 || events[0].Name != "Launch" {
+		t.Errorf("Expected only the good event to remain, got %+v, %v", events, err)
+	}
+	data, err := os.ReadFile(quarantinedPath(eventsFile))
+	if err != nil {
+		t.Fatalf("Failed to read the quarantine file: %v", err)
+	}
+	var rejected []json.RawMessage
+	if err := json.Unmarshal(data, &rejected); err != nil || len(rejected) != 2 {
+		t.Errorf("Expected both bad entries in the quarantine file, got %s", data)
+	}
+}
+
+func TestConfirmQuarantineDefaultsToNo(t *testing.T) {
+	invalid := &invalidEventsError{Path: "events.json"}
+	for _, answer := range []string{"\n", "n\n", "nope\n", ""} {
+		var out strings.Builder
+		if confirmQuarantine(invalid, strings.NewReader(answer), &out) {
+			t.Errorf("Expected %q not to confirm", answer)
+		}
+		if !strings.Contains(out.String(), "events.rejected.json") {
+			t.Errorf("Expected the prompt to name the side file, got '%s'", out.String())
+		}
+	}
+}
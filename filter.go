@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// noMatches reports whether a filter is on and lets no event through.
+func (m MainModel) noMatches() bool {
+	return m.events.FilterState() != list.Unfiltered && len(m.events.Items()) > 0 && len(m.events.VisibleItems()) == 0
+}
+
+// renderNoMatches takes the place of the details while the filter matches
+// nothing, so the last selected event does not linger there.
+func (m MainModel) renderNoMatches() string {
+	var b strings.Builder
+	b.WriteString(HintStyle(trf("detail.no_matches", m.events.FilterValue())) + "\n\n")
+	b.WriteString(NormalTextStyle(tr("detail.clear_filter")))
+
+	detailStyle := lipgloss.NewStyle().
+		Width(m.detailWidth).
+		Padding(1, 2).
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		BorderForeground(m.borderColor(detailPanel, lipgloss.AdaptiveColor{Light: cItemTitleLight, Dark: cItemTitleDark}))
+
+	return detailStyle.Render(b.String())
+}
+
+// trackFilter keeps the event selected before a filter starts and selects
+// it again once the filter is cleared.
+func (m *MainModel) trackFilter(before list.FilterState, selected Event, ok bool) {
+	after := m.events.FilterState()
+	switch {
+	case before == list.Unfiltered && after != list.Unfiltered && ok:
+		m.filterReturn = &selected
+	case before != list.Unfiltered && after == list.Unfiltered:
+		if m.filterReturn != nil {
+			m.selectEvent(*m.filterReturn)
+		}
+		m.filterReturn = nil
+	}
+}
+
+// selectedIndex is the index of the selected event among all items. The
+// list's own Index counts only the items the filter lets through.
+func (m MainModel) selectedIndex() int {
+	selected, ok := m.events.SelectedItem().(Event)
+	if !ok {
+		return -1
+	}
+	for i, item := range m.events.Items() {
+		if e, ok := item.(Event); ok && sameEvent(e, selected) {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeSelected removes the selected event. The list's RemoveItem takes
+// the index among all items but drops the filter match at the same index,
+// so with a filter on the items are set again and filtered at once.
+func (m *MainModel) removeSelected() {
+	i := m.selectedIndex()
+	if i < 0 {
+		return
+	}
+	items := append([]list.Item(nil), m.events.Items()[:i]...)
+	items = append(items, m.events.Items()[i+1:]...)
+	if cmd := m.events.SetItems(items); cmd != nil {
+		m.events, _ = m.events.Update(cmd())
+	}
+	if m.noMatches() {
+		m.events.ResetFilter()
+	}
+	if visible := len(m.events.VisibleItems()); visible > 0 && m.events.Index() >= visible {
+		m.events.Select(visible - 1)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/timer"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterTestModel drives the model with keys, feeding the filter results
+// the list computes in commands back to it as the program would.
+type filterTestModel struct {
+	t *testing.T
+	m MainModel
+}
+
+func (f *filterTestModel) send(msgs ...tea.Msg) {
+	f.t.Helper()
+	for _, msg := range msgs {
+		model, cmd := f.m.Update(msg)
+		f.m = model.(MainModel)
+		f.deliverFilterMatches(cmd)
+	}
+}
+
+func (f *filterTestModel) deliverFilterMatches(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			f.deliverFilterMatches(c)
+		}
+	case list.FilterMatchesMsg:
+		model, _ := f.m.Update(msg)
+		f.m = model.(MainModel)
+	}
+}
+
+func (f *filterTestModel) typeText(s string) {
+	f.t.Helper()
+	for _, r := range s {
+		f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func (f *filterTestModel) selected() string {
+	if e, ok := f.m.events.SelectedItem().(Event); ok {
+		return e.Name
+	}
+	return ""
+}
+
+func newFilterTestModel(t *testing.T, names ...string) *filterTestModel {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var events []Event
+	for i, name := range names {
+		events = append(events, Event{Name: name, Time: now.AddDate(0, 0, i+1).Unix()})
+	}
+	f := &filterTestModel{t: t, m: newRefreshTestModel(t, &now, events...)}
+	// A blinking cursor would make every command wait for the next blink.
+	f.m.events.FilterInput.Cursor.SetMode(cursor.CursorStatic)
+	f.send(tea.WindowSizeMsg{Width: 160, Height: 40})
+	return f
+}
+
+func TestFilterWithoutMatches(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+
+	f := newFilterTestModel(t, "Alpha", "Beta", "Gamma")
+	f.send(tea.KeyMsg{Type: tea.KeyDown})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("zzz")
+
+	view := stripANSI(f.m.View())
+	if !strings.Contains(view, "No matches for 'zzz'") {
+		t.Errorf("Expected a placeholder in the detail column, got:\n%s", view)
+	}
+	if strings.Contains(view, "Time Until") {
+		t.Errorf("Expected the last selected event to be gone, got:\n%s", view)
+	}
+	if !strings.Contains(view, "On This Day") {
+		t.Errorf("Expected the side panel to stay, got:\n%s", view)
+	}
+
+	f.send(tea.KeyMsg{Type: tea.KeyEsc})
+	if f.m.events.FilterState() != list.Unfiltered || f.selected() != "Beta" {
+		t.Errorf("Expected clearing the filter to select Beta again, got %q", f.selected())
+	}
+}
+
+func TestClearingFilterRestoresSelection(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	f := newFilterTestModel(t, "Alpha", "Beta", "Gamma")
+	f.send(tea.KeyMsg{Type: tea.KeyDown})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("gam")
+	f.send(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.m.events.FilterState() != list.FilterApplied || f.selected() != "Gamma" {
+		t.Fatalf("Expected Gamma under the applied filter, got %q", f.selected())
+	}
+
+	f.send(tea.KeyMsg{Type: tea.KeyEsc})
+	if f.m.events.FilterState() != list.Unfiltered || f.selected() != "Beta" {
+		t.Errorf("Expected Beta selected again, got %q", f.selected())
+	}
+}
+
+func TestRemoveUnderFilter(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	f := newFilterTestModel(t, "Alpha", "Beta", "Gamma")
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("gam")
+	f.send(tea.KeyMsg{Type: tea.KeyEnter})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+
+	if got := eventNames(f.m.events.Items()); got != "Alpha,Beta" {
+		t.Errorf("Expected Gamma to be removed, got %s", got)
+	}
+	if f.m.state != showEvents || f.m.events.FilterState() != list.Unfiltered {
+		t.Errorf("Expected the list without the filter that matches nothing now, got state %d", f.m.state)
+	}
+	if f.selected() == "" {
+		t.Error("Expected an event to stay selected")
+	}
+}
+
+func TestRemoveLastEventUnderFilter(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	f := newFilterTestModel(t, "Solo")
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("so")
+	f.send(tea.KeyMsg{Type: tea.KeyEnter})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+
+	if f.m.state != noEvents || f.m.events.FilterState() != list.Unfiltered || f.m.filterReturn != nil {
+		t.Fatalf("Expected the empty screen without a filter, got state %d", f.m.state)
+	}
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if f.m.state != showInput {
+		t.Errorf("Expected + to open the form from the empty screen, got state %d", f.m.state)
+	}
+}
+
+func TestTickWhileFiltering(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	f := &filterTestModel{t: t, m: newRefreshTestModel(t, &now,
+		Event{Name: "Ada's birthday", Time: now.Add(time.Hour).Unix(), Yearly: true},
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 5).Unix()},
+	)}
+	f.m.events.FilterInput.Cursor.SetMode(cursor.CursorStatic)
+	f.send(tea.WindowSizeMsg{Width: 160, Height: 40})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("bir")
+	// Ticks are delivered without running their commands, which would wait
+	// for the next one.
+	tick := func() {
+		model, _ := f.m.Update(timer.TickMsg{ID: f.m.timer.ID()})
+		f.m = model.(MainModel)
+	}
+	tick()
+
+	// The birthday passes while the computer sleeps; the next tick rolls it
+	// on to next year, with the filter prompt still open.
+	now = now.AddDate(0, 0, 2)
+	tick()
+	if f.m.events.FilterState() != list.Filtering {
+		t.Fatalf("Expected the filter prompt to stay open, got %v", f.m.events.FilterState())
+	}
+	items := f.m.events.VisibleItems()
+	if len(items) != 1 {
+		t.Fatalf("Expected the birthday to stay visible, got %d items", len(items))
+	}
+	if e := items[0].(Event); e.Time != time.Date(2027, 3, 1, 10, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("Expected the match to show next year's birthday, got %s", time.Unix(e.Time, 0).UTC())
+	}
+	if view := stripANSI(f.m.View()); !strings.Contains(view, "363d 1h") {
+		t.Errorf("Expected the countdown to next year's birthday, got:\n%s", view)
+	}
+}
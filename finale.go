@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// finalCountdownWindow is how close an event must be before the detail
+	// view switches to tenths of a second.
+	finalCountdownWindow = time.Minute
+	fastTickInterval     = 100 * time.Millisecond
+)
+
+type fastTickMsg time.Time
+
+// finalCountdown reports whether ts is within the last minute before it, or
+// within hold after it, along with the time remaining. Remaining is never
+// negative: during the hold the countdown stays at zero.
+func finalCountdown(ts int64, now time.Time, hold time.Duration) (time.Duration, bool) {
+	remaining := time.Unix(ts, 0).Sub(now)
+	switch {
+	case remaining > finalCountdownWindow:
+		return remaining, false
+	case remaining > 0:
+		return remaining, true
+	case -remaining <= hold:
+		return 0, true
+	}
+	return remaining, false
+}
+
+// formatTenths formats d as seconds with one truncated decimal, e.g. "07.4".
+func formatTenths(d time.Duration) string {
+	d = d.Truncate(fastTickInterval)
+	return fmt.Sprintf("%04.1f", d.Seconds())
+}
+
+// scheduleFastTick starts 10Hz refreshes while the selected event is in its
+// final countdown. Each tick schedules the next, so they stop on their own
+// once the window has passed.
+func (m *MainModel) scheduleFastTick() tea.Cmd {
+	if m.fastTicking || m.state != showEvents {
+		return nil
+	}
+	event, ok := m.events.SelectedItem().(Event)
+	if !ok {
+		return nil
+	}
+	if _, active := finalCountdown(event.Time, m.now(), m.config.completionHold()); !active {
+		return nil
+	}
+	m.fastTicking = true
+	return tea.Tick(fastTickInterval, func(t time.Time) tea.Msg { return fastTickMsg(t) })
+}
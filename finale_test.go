@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/timer"
+)
+
+func TestFinalCountdown(t *testing.T) {
+	event := time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC)
+	hold := 3 * time.Second
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		active    bool
+		remaining time.Duration
+	}{
+		{"Outside window", event.Add(-61 * time.Second), false, 61 * time.Second},
+		{"Start of window", event.Add(-time.Minute), true, time.Minute},
+		{"Last tenth", event.Add(-50 * time.Millisecond), true, 50 * time.Millisecond},
+		{"Held at zero", event.Add(2 * time.Second), true, 0},
+		{"End of hold", event.Add(3 * time.Second), true, 0},
+		{"After hold", event.Add(3*time.Second + time.Millisecond), false, -3*time.Second - time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, active := finalCountdown(event.Unix(), tt.now, hold)
+			if active != tt.active || remaining != tt.remaining {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.remaining, tt.active, remaining, active)
+			}
+		})
+	}
+}
+
+func TestFormatTenths(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Minute:                           "60.0",
+		42*time.Second + 380*time.Millisecond: "42.3",
+		7*time.Second + 99*time.Millisecond:   "07.0",
+		50 * time.Millisecond:                 "00.0",
+		0:                                     "00.0",
+	}
+	for d, expected := range tests {
+		if got := formatTenths(d); got != expected {
+			t.Errorf("formatTenths(%v): expected '%s', got '%s'", d, expected, got)
+		}
+	}
+}
+
+func TestFinalCountdownRender(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	event := time.Now().Add(time.Hour).Truncate(time.Second)
+	now := event.Add(-12*time.Second - 340*time.Millisecond)
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: event.Unix()})
+
+	if out := stripANSI(m.detailsString()); !strings.Contains(out, "12.3") {
+		t.Errorf("Expected tenths in the final minute, got:\n%s", out)
+	}
+
+	now = event.Add(time.Second)
+	out := stripANSI(m.detailsString())
+	if !strings.Contains(out, "00.0") || !strings.Contains(out, "Launch is here!") {
+		t.Errorf("Expected the countdown to hold at zero, got:\n%s", out)
+	}
+	if strings.Contains(out, "ago") {
+		t.Errorf("Expected no 'ago' during the hold, got:\n%s", out)
+	}
+
+	now = event.Add(time.Minute)
+	if out := stripANSI(m.detailsString()); !strings.Contains(out, "1m 0s ago") {
+		t.Errorf("Expected a normal countdown after the hold, got:\n%s", out)
+	}
+}
+
+func TestFastTickOnlyInFinalMinute(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	event := time.Now().Add(time.Hour).Truncate(time.Second)
+	now := event.Add(-2 * time.Minute)
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: event.Unix()})
+
+	model, cmd := m.Update(timer.TickMsg{ID: m.timer.ID()})
+	m = model.(MainModel)
+	if m.fastTicking {
+		t.Error("Expected no fast ticks two minutes out")
+	}
+
+	now = event.Add(-30 * time.Second)
+	model, cmd = m.Update(timer.TickMsg{ID: m.timer.ID()})
+	m = model.(MainModel)
+	if !m.fastTicking || cmd == nil {
+		t.Fatal("Expected fast ticks to start in the final minute")
+	}
+
+	now = event.Add(10 * time.Second)
+	model, _ = m.Update(fastTickMsg(now))
+	m = model.(MainModel)
+	if m.fastTicking {
+		t.Error("Expected fast ticks to stop after the hold")
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type fiscalQuarter struct {
+	Year    int // fiscal year, named after the calendar year it ends in
+	Quarter int // 1-4
+	Start   time.Time
+	End     time.Time // exclusive; the first instant of the next quarter
+}
+
+// fiscalYearStart returns the first day of the fiscal year containing t.
+func fiscalYearStart(t time.Time, startMonth int) time.Time {
+	year := t.Year()
+	if int(t.Month()) < startMonth {
+		year--
+	}
+	return time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, t.Location())
+}
+
+func fiscalQuarterOf(t time.Time, startMonth int) fiscalQuarter {
+	fyStart := fiscalYearStart(t, startMonth)
+	offset := (int(t.Month()) - startMonth + 12) % 12
+	quarter := offset/3 + 1
+
+	year := fyStart.Year()
+	if startMonth != 1 {
+		year++
+	}
+
+	start := fyStart.AddDate(0, (quarter-1)*3, 0)
+	return fiscalQuarter{
+		Year:    year,
+		Quarter: quarter,
+		Start:   start,
+		End:     start.AddDate(0, 3, 0),
+	}
+}
+
+// fiscalYearEnd returns the exclusive end of the fiscal year containing t.
+func fiscalYearEnd(t time.Time, startMonth int) time.Time {
+	return fiscalYearStart(t, startMonth).AddDate(1, 0, 0)
+}
+
+func (q fiscalQuarter) Label() string {
+	return fmt.Sprintf("Q%d FY%02d", q.Quarter, q.Year%100)
+}
+
+func (q fiscalQuarter) Elapsed(now time.Time) float64 {
+	total := q.End.Sub(q.Start).Seconds()
+	elapsed := now.Sub(q.Start).Seconds()
+	if elapsed < 0 {
+		return 0
+	}
+	if elapsed > total {
+		return 1
+	}
+	return elapsed / total
+}
+
+func (q fiscalQuarter) DaysRemaining(now time.Time) int {
+	if !now.Before(q.End) {
+		return 0
+	}
+	return int(math.Ceil(q.End.Sub(now).Hours() / 24))
+}
+
+func (q fiscalQuarter) ProgressString(now time.Time) string {
+	return trf("fiscal.progress", q.Label(), int(q.Elapsed(now)*100), trn("fiscal.days", q.DaysRemaining(now)))
+}
+
+// periodEvents returns the virtual end-of-quarter and end-of-year events
+// for the fiscal period containing now.
+func periodEvents(now time.Time, startMonth int) []Event {
+	q := fiscalQuarterOf(now, startMonth)
+	events := []Event{
+		{Name: trf("period.quarter_end", q.Label()), Time: q.End.Unix(), Virtual: true},
+	}
+	if q.Quarter != 4 {
+		events = append(events, Event{
+			Name:    trf("period.year_end", q.Year%100),
+			Time:    fiscalYearEnd(now, startMonth).Unix(),
+			Virtual: true,
+		})
+	}
+	return events
+}
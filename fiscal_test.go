@@ -0,0 +1,156 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiscalQuarterOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		startMonth int
+		year       int
+		quarter    int
+		start      time.Time
+		end        time.Time
+	}{
+		{
+			name:       "Calendar year Q1",
+			date:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			startMonth: 1,
+			year:       2026,
+			quarter:    1,
+			start:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Calendar year Q4 last second",
+			date:       time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC),
+			startMonth: 1,
+			year:       2026,
+			quarter:    4,
+			start:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "February start, first day",
+			date:       time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			startMonth: 2,
+			year:       2026,
+			quarter:    1,
+			start:      time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "February start, January is Q4 of previous fiscal year",
+			date:       time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+			startMonth: 2,
+			year:       2026,
+			quarter:    4,
+			start:      time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "February start, Q3 boundary",
+			date:       time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			startMonth: 2,
+			year:       2026,
+			quarter:    3,
+			start:      time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "February start, last day of Q2",
+			date:       time.Date(2025, 7, 31, 23, 0, 0, 0, time.UTC),
+			startMonth: 2,
+			year:       2026,
+			quarter:    2,
+			start:      time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "October start (US federal)",
+			date:       time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC),
+			startMonth: 10,
+			year:       2026,
+			quarter:    1,
+			start:      time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "December start, November is Q4",
+			date:       time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+			startMonth: 12,
+			year:       2026,
+			quarter:    4,
+			start:      time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := fiscalQuarterOf(tt.date, tt.startMonth)
+			if q.Year != tt.year || q.Quarter != tt.quarter {
+				t.Errorf("Expected Q%d FY%d, got Q%d FY%d", tt.quarter, tt.year, q.Quarter, q.Year)
+			}
+			if !q.Start.Equal(tt.start) {
+				t.Errorf("Expected start %s, got %s", tt.start, q.Start)
+			}
+			if !q.End.Equal(tt.end) {
+				t.Errorf("Expected end %s, got %s", tt.end, q.End)
+			}
+		})
+	}
+}
+
+func TestFiscalQuarterProgress(t *testing.T) {
+	now := time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+	q := fiscalQuarterOf(now, 2)
+
+	expected := "Q3 FY26 — 36% elapsed, 58 days remaining"
+	if got := q.ProgressString(now); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+
+	if got := q.Elapsed(q.Start.Add(-time.Hour)); got != 0 {
+		t.Errorf("Expected 0 elapsed before start, got %f", got)
+	}
+	if got := q.DaysRemaining(q.End); got != 0 {
+		t.Errorf("Expected 0 days remaining at end, got %d", got)
+	}
+}
+
+func TestPeriodEvents(t *testing.T) {
+	t.Run("Mid year", func(t *testing.T) {
+		events := periodEvents(time.Date(2025, 9, 4, 0, 0, 0, 0, time.Local), 2)
+		if len(events) != 2 {
+			t.Fatalf("Expected 2 events, got %d", len(events))
+		}
+		if events[0].Name != "End of Q3 FY26" {
+			t.Errorf("Expected 'End of Q3 FY26', got '%s'", events[0].Name)
+		}
+		if events[1].Name != "End of FY26" {
+			t.Errorf("Expected 'End of FY26', got '%s'", events[1].Name)
+		}
+		if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local).Unix(); events[1].Time != want {
+			t.Errorf("Expected year end %d, got %d", want, events[1].Time)
+		}
+		for _, e := range events {
+			if !e.Virtual {
+				t.Errorf("Expected '%s' to be virtual", e.Name)
+			}
+		}
+	})
+
+	t.Run("Last quarter has a single event", func(t *testing.T) {
+		events := periodEvents(time.Date(2026, 1, 10, 0, 0, 0, 0, time.Local), 2)
+		if len(events) != 1 {
+			t.Fatalf("Expected 1 event, got %d", len(events))
+		}
+		if events[0].Name != "End of Q4 FY26" {
+			t.Errorf("Expected 'End of Q4 FY26', got '%s'", events[0].Name)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// panel is one of the columns of the main view that can take keyboard focus.
+type panel int
+
+const (
+	listPanel panel = iota
+	detailPanel
+	sidePanel
+	panelCount
+)
+
+// BlurredTitleStyle replaces TitleStyle on the list while another panel has
+// focus.
+var BlurredTitleStyle = TitleStyle.Background(lipgloss.Color("240"))
+
+// focusPanel moves keyboard focus by delta panels, wrapping around. Only the
+// list can have focus while nothing is selected, or in compact mode, since
+// the other panels are not shown.
+func (m *MainModel) focusPanel(delta int) {
+	p := listPanel
+	if m.hasSelectedEvent() && !m.compact() {
+		p = (m.panelFocus + panel(delta) + panelCount) % panelCount
+	}
+	m.panelFocus = p
+	if p == listPanel {
+		m.events.Styles.Title = TitleStyle
+	} else {
+		m.events.Styles.Title = BlurredTitleStyle
+	}
+}
+
+// borderColor is the border color of panel p: normal, or the focus color
+// while p has focus. The list shows focus through its title instead.
+func (m MainModel) borderColor(p panel, normal lipgloss.TerminalColor) lipgloss.TerminalColor {
+	if m.panelFocus == p && p != listPanel {
+		return paletteColor(cPromptBorder)
+	}
+	return normal
+}
+
+// updatePanel handles a key while the detail or side panel has focus. Keys
+// for the list, such as remove, are ignored here; other keys go to what the
+// side panel shows.
+func (m *MainModel) updatePanel(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, Keymap.Quit):
+		return m.quit()
+	case key.Matches(msg, Keymap.Back):
+		m.focusPanel(int(listPanel - m.panelFocus))
+	case key.Matches(msg, Keymap.Display):
+		m.config.cycleDisplay()
+	case key.Matches(msg, Keymap.Seconds):
+		m.config.HideSeconds = !m.config.HideSeconds
+	case key.Matches(msg, Keymap.Clock):
+		m.config.toggleClock()
+	case m.panelFocus == sidePanel:
+		return m.updateSides(msg)
+	}
+	return nil
+}
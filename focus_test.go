@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPanelFocus(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()},
+		Event{Name: "Standup", Time: now.Add(72 * time.Hour).Unix()},
+	)
+	press := func(k tea.KeyMsg) {
+		t.Helper()
+		model, _ := m.Update(k)
+		m = model.(MainModel)
+	}
+	ctrlL := tea.KeyMsg{Type: tea.KeyCtrlL}
+	ctrlH := tea.KeyMsg{Type: tea.KeyCtrlH}
+
+	for _, want := range []panel{detailPanel, sidePanel, listPanel, detailPanel} {
+		press(ctrlL)
+		if m.panelFocus != want {
+			t.Fatalf("Expected focus on panel %d, got %d", want, m.panelFocus)
+		}
+	}
+	if m.events.Styles.Title.GetBackground() != BlurredTitleStyle.GetBackground() {
+		t.Error("Expected the list title dimmed while the detail panel has focus")
+	}
+
+	// List keys do nothing while another panel has focus.
+	for _, k := range []string{"-", "e", "+", "j"} {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	if m.state != showEvents || len(m.events.Items()) != 2 || m.events.Index() != 0 {
+		t.Fatalf("Expected list keys to be ignored, got state %v, %d events, index %d", m.state, len(m.events.Items()), m.events.Index())
+	}
+
+	press(ctrlH)
+	press(ctrlH)
+	if m.panelFocus != sidePanel {
+		t.Errorf("Expected ctrl+h to wrap around to the side panel, got %d", m.panelFocus)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.panelFocus != listPanel || m.events.Styles.Title.GetBackground() != TitleStyle.GetBackground() {
+		t.Errorf("Expected esc to return focus to the list, got %d", m.panelFocus)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	if len(m.events.Items()) != 1 {
+		t.Errorf("Expected remove to work with the list focused, got %d events", len(m.events.Items()))
+	}
+}
+
+func TestPanelFocusNeedsSelection(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()})
+	m.events.SetItems(nil)
+	m.focusPanel(1)
+	if m.panelFocus != listPanel {
+		t.Errorf("Expected focus to stay on the list without a selection, got %d", m.panelFocus)
+	}
+}
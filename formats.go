@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDateFormat = "long"
+	defaultTimeFormat = "12h"
+)
+
+var datePresets = map[string]string{
+	"long": "Monday, January 2, 2006",
+	"iso":  "2006-01-02",
+	"eu":   "Monday, 02.01.2006",
+	"us":   "Monday, 01/02/2006",
+}
+
+var timePresets = map[string]string{
+	"12h": "3:04:05 PM MST",
+	"24h": "15:04:05 MST",
+	"iso": "15:04:05",
+	"eu":  "15:04:05 MST",
+	"us":  "3:04:05 PM MST",
+}
+
+// layoutProbe shares no field with Go's reference time, so formatting it
+// with a layout that contains no layout elements returns the layout as is.
+var layoutProbe = time.Date(2001, 3, 4, 5, 6, 7, 0, time.UTC)
+
+// resolveLayout turns a preset name or a Go layout string into a layout.
+func resolveLayout(value string, presets map[string]string) (string, bool) {
+	if layout, ok := presets[strings.ToLower(value)]; ok {
+		return layout, true
+	}
+	if value == "" || layoutProbe.Format(value) == value {
+		return "", false
+	}
+	return value, true
+}
+
+// checkFormats resets invalid date and time formats to their defaults,
+// printing a warning rather than refusing to start.
+func (c *Config) checkFormats() {
+	if _, ok := resolveLayout(c.DateFormat, datePresets); !ok {
+		fmt.Fprintf(os.Stderr, "warning: date_format %q is not a preset or Go layout, using %q\n", c.DateFormat, defaultDateFormat)
+		c.DateFormat = defaultDateFormat
+	}
+	if _, ok := resolveLayout(c.TimeFormat, timePresets); !ok {
+		fmt.Fprintf(os.Stderr, "warning: time_format %q is not a preset or Go layout, using %q\n", c.TimeFormat, defaultTimeFormat)
+		c.TimeFormat = defaultTimeFormat
+	}
+}
+
+func (c Config) dateLayout() string {
+	if layout, ok := resolveLayout(c.DateFormat, datePresets); ok {
+		return layout
+	}
+	return datePresets[defaultDateFormat]
+}
+
+func (c Config) timeLayout() string {
+	if layout, ok := resolveLayout(c.TimeFormat, timePresets); ok {
+		return layout
+	}
+	return timePresets[defaultTimeFormat]
+}
+
+// dateTimeLayout is used where date and time share a line, such as the form
+// preview and notifications.
+func (c Config) dateTimeLayout() string {
+	return c.dateLayout() + " at " + c.timeLayout()
+}
+
+// toggleClock flips the time format between the 12 and 24-hour presets.
+func (c *Config) toggleClock() {
+	if strings.Contains(c.timeLayout(), "15") {
+		c.TimeFormat = "12h"
+	} else {
+		c.TimeFormat = "24h"
+	}
+}
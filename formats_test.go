@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+func TestResolveLayout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"iso", "2006-01-02", true},
+		{"EU", "Monday, 02.01.2006", true},
+		{"02/01/06", "02/01/06", true},
+		{"Jan 2", "Jan 2", true},
+		{"", "", false},
+		{"yyyy-mm-dd", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			layout, ok := resolveLayout(tt.value, datePresets)
+			if layout != tt.expected || ok != tt.ok {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, layout, ok)
+			}
+		})
+	}
+}
+
+func TestFormatConfig(t *testing.T) {
+	t.Run("Presets", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "date_format = \"eu\"\ntime_format = \"24h\"\n")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cfg.dateLayout() != "Monday, 02.01.2006" || cfg.timeLayout() != "15:04:05 MST" {
+			t.Errorf("Unexpected layouts %q and %q", cfg.dateLayout(), cfg.timeLayout())
+		}
+	})
+
+	t.Run("Invalid layout falls back", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "date_format = \"DD.MM.YYYY\"\ntime_format = \"15:04\"\n")
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatalf("Expected a warning rather than an error, got %v", err)
+		}
+		if cfg.DateFormat != defaultDateFormat {
+			t.Errorf("Expected date_format to fall back to %q, got %q", defaultDateFormat, cfg.DateFormat)
+		}
+		if cfg.timeLayout() != "15:04" {
+			t.Errorf("Expected custom time layout to be kept, got %q", cfg.timeLayout())
+		}
+	})
+}
+
+func TestToggleClock(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.toggleClock()
+	if cfg.timeLayout() != timePresets["24h"] {
+		t.Errorf("Expected 24-hour layout, got %q", cfg.timeLayout())
+	}
+	cfg.toggleClock()
+	if cfg.timeLayout() != timePresets["12h"] {
+		t.Errorf("Expected 12-hour layout, got %q", cfg.timeLayout())
+	}
+}
+
+func TestDetailUsesConfiguredFormats(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	event := Event{Name: "Launch", Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix()}
+
+	cfg := defaultConfig()
+	cfg.DateFormat = "eu"
+	cfg.TimeFormat = "24h"
+	m := MainModel{detailWidth: 50, config: cfg, clock: func() time.Time { return now }}
+	out := stripANSI(renderSnapshotAt(m, event, termenv.Ascii))
+	if !strings.Contains(out, "Sunday, 15.03.2026") || !strings.Contains(out, "18:00:00 UTC") {
+		t.Errorf("Expected EU date and 24-hour time, got:\n%s", out)
+	}
+}
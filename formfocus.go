@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// formKey is a key that moves through the form.
+type formKey int
+
+const (
+	formNext   formKey = iota // tab
+	formPrev                  // shift+tab
+	formEnter                 // enter
+	formSubmit                // ctrl+s
+)
+
+// formAction is what a formKey does besides moving the focus.
+type formAction int
+
+const (
+	formMove formAction = iota
+	formSave
+	formCancel
+)
+
+// formStep is the form's focus flow. The usual way through it is name,
+// date, Enter: Enter on the date saves once everything is valid, and
+// ctrl+s saves from anywhere. Tab and shift+tab wrap around, so going back
+// from the name lands on Submit rather than Cancel.
+func formStep(focus inputFields, k formKey, valid bool) (inputFields, formAction) {
+	switch k {
+	case formNext:
+		if focus == inputSubmitButton {
+			return inputNameField, formMove
+		}
+		return focus + 1, formMove
+	case formPrev:
+		if focus == inputNameField {
+			return inputSubmitButton, formMove
+		}
+		return focus - 1, formMove
+	case formSubmit:
+		return focus, formSave
+	}
+	switch focus {
+	case inputNameField:
+		return inputTimeField, formMove
+	case inputTimeField:
+		if valid {
+			return focus, formSave
+		}
+		return inputRemindersField, formMove
+	case inputRemindersField, inputRepeatField, inputKindField, inputGroupField:
+		return inputSubmitButton, formMove
+	case inputCancelButton:
+		return focus, formCancel
+	}
+	return focus, formSave
+}
+
+// formKeyOf maps msg to the form key it is, if any.
+func formKeyOf(msg tea.KeyMsg) (formKey, bool) {
+	switch {
+	case key.Matches(msg, Keymap.Next):
+		return formNext, true
+	case key.Matches(msg, Keymap.Prev):
+		return formPrev, true
+	case key.Matches(msg, Keymap.Enter):
+		return formEnter, true
+	case key.Matches(msg, Keymap.Submit):
+		return formSubmit, true
+	}
+	return 0, false
+}
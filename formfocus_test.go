@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormStep(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   inputFields
+		key    formKey
+		valid  bool
+		focus  inputFields
+		action formAction
+	}{
+		{"Tab from name", inputNameField, formNext, false, inputTimeField, formMove},
+		{"Tab wraps", inputSubmitButton, formNext, false, inputNameField, formMove},
+		{"Shift+Tab from name skips Cancel", inputNameField, formPrev, false, inputSubmitButton, formMove},
+		{"Shift+Tab from Submit", inputSubmitButton, formPrev, false, inputCancelButton, formMove},
+		{"Enter on name goes to date", inputNameField, formEnter, false, inputTimeField, formMove},
+		{"Enter on empty name still goes to date", inputNameField, formEnter, true, inputTimeField, formMove},
+		{"Enter on valid date saves", inputTimeField, formEnter, true, inputTimeField, formSave},
+		{"Enter on invalid date moves on", inputTimeField, formEnter, false, inputRemindersField, formMove},
+		{"Enter on reminders goes to Submit", inputRemindersField, formEnter, false, inputSubmitButton, formMove},
+		{"Enter on kind goes to Submit", inputKindField, formEnter, false, inputSubmitButton, formMove},
+		{"Enter on group goes to Submit", inputGroupField, formEnter, false, inputSubmitButton, formMove},
+		{"Enter on Cancel", inputCancelButton, formEnter, true, inputCancelButton, formCancel},
+		{"Enter on Submit", inputSubmitButton, formEnter, false, inputSubmitButton, formSave},
+		{"Ctrl+S from name", inputNameField, formSubmit, false, inputNameField, formSave},
+		{"Ctrl+S from Cancel", inputCancelButton, formSubmit, true, inputCancelButton, formSave},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			focus, action := formStep(tt.from, tt.key, tt.valid)
+			if focus != tt.focus || action != tt.action {
+				t.Errorf("Expected %d/%d, got %d/%d", tt.focus, tt.action, focus, action)
+			}
+		})
+	}
+}
+
+func TestFormNameDateEnter(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now)
+	m.openForm(showInput)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+	typeText := func(s string) {
+		t.Helper()
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+
+	typeText("Launch")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.focus != int(inputTimeField) {
+		t.Fatalf("Expected Enter to move to the date, got focus %d", m.focus)
+	}
+	typeText("2026-05-01")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != showEvents || eventNames(m.events.Items()) != "Launch" {
+		t.Fatalf("Expected Enter on a valid date to save, got state %d with %s", m.state, eventNames(m.events.Items()))
+	}
+
+	m.openForm(showInput)
+	typeText("Review")
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	typeText("2026-06-01")
+	send(tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state != showEvents || eventNames(m.events.Items()) != "Launch,Review" {
+		t.Errorf("Expected ctrl+s to save from the name field, got state %d with %s", m.state, eventNames(m.events.Items()))
+	}
+}
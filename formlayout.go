@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The add/edit form is half the window wide within these bounds, and only
+// narrower when the window itself is. Below minFormWidth the buttons no
+// longer fit side by side and the form gives way to a note.
+const (
+	minFormWidth     = 46
+	defaultFormWidth = 50
+	maxFormWidth     = 80
+	formFrame        = 4 // margin and border around the form, on each axis
+)
+
+// Names may be up to nameCharLimit characters. The counter by the name label
+// turns to a warning within nameWarnChars of the limit.
+const (
+	nameCharLimit = 120
+	nameWarnChars = 20
+)
+
+// nameCounter is the "n/limit" counter shown by the name label, empty while
+// there is no name.
+func nameCounter(name string, limit int) string {
+	n := utf8.RuneCountInString(name)
+	if n == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("%d/%d", n, limit)
+	if n > limit-nameWarnChars {
+		return WarningStyle(s)
+	}
+	return HintStyle(s)
+}
+
+// formWidth returns the width of the form in a window windowWidth wide, and
+// false when the window is too narrow for it.
+func formWidth(windowWidth int) (int, bool) {
+	w := windowWidth / 2
+	if w < defaultFormWidth {
+		w = defaultFormWidth
+	}
+	if w > maxFormWidth {
+		w = maxFormWidth
+	}
+	if w > windowWidth-formFrame {
+		w = windowWidth - formFrame
+	}
+	return w, w >= minFormWidth
+}
+
+// formFieldWidth is the width of the bordered fields inside a form w wide.
+func formFieldWidth(w int) int {
+	return w - 10
+}
+
+// In compact mode the form fills the window, without the margin and with
+// less padding, down to minNarrowFormWidth.
+const (
+	minNarrowFormWidth = 36
+	narrowFormFrame    = 2 // just the border
+)
+
+// formSize is how wide the add/edit form and its parts are drawn.
+type formSize struct {
+	width   int // the form inside its border, as formWidth gives it
+	content int // inside the form's padding
+	field   int // the fields taking a whole row
+	half    int // the fields sharing a row
+	fits    bool
+}
+
+func (m MainModel) formSize() formSize {
+	if m.compact() {
+		w := m.windowWidth - narrowFormFrame
+		field := w - 4
+		return formSize{width: w, content: w - 2, field: field, half: (field - 4) / 2, fits: w >= minNarrowFormWidth}
+	}
+	w, fits := formWidth(m.windowWidth)
+	return formSize{width: w, content: w - 4, field: formFieldWidth(w), half: (formFieldWidth(w) - 2) / 2, fits: fits}
+}
+
+// layoutForm makes the text inputs as wide as the fields they are drawn in,
+// so that long values scroll inside the border instead of pushing it out.
+// It runs when the form opens and whenever the window is resized.
+func (m *MainModel) layoutForm() {
+	size := m.formSize()
+	for i := range m.inputs {
+		text := size.field - 2 // the field's padding
+		if inputFields(i) == inputRemindersField || inputFields(i) == inputRepeatField {
+			text = size.half - 2
+		}
+		// One more column for the cursor at the end of the value.
+		m.inputs[i].Width = max(1, text-lipgloss.Width(m.inputs[i].Prompt)-1)
+	}
+}
+
+// formHint renders a hint line wrapped to width, keeping the message's
+// leading indent on every wrapped line.
+func formHint(s string, width int) string {
+	text := strings.TrimLeft(s, " ")
+	indent := len(s) - len(text)
+	return lipgloss.NewStyle().
+		Foreground(paletteColor(cHint)).
+		PaddingLeft(indent).
+		Width(width).
+		Render(text)
+}
+
+// formTooSmall takes the place of a form that does not fit the window. What
+// was typed is kept; the form comes back when the window grows.
+func (m MainModel) formTooSmall() string {
+	least := minFormWidth + formFrame
+	if m.compact() {
+		least = minNarrowFormWidth + narrowFormFrame
+	}
+	note := lipgloss.NewStyle().
+		Width(max(1, m.windowWidth)).
+		Align(lipgloss.Center).
+		Render(WarningStyle(trf("form.too_small", least)))
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, note)
+}
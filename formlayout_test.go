@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFormWidth(t *testing.T) {
+	tests := []struct {
+		window   int
+		expected int
+		fits     bool
+	}{
+		{200, maxFormWidth, true},
+		{120, 60, true},
+		{80, defaultFormWidth, true},
+		{52, 48, true},
+		{50, minFormWidth, true},
+		{40, 36, false},
+	}
+	for _, tt := range tests {
+		if got, fits := formWidth(tt.window); got != tt.expected || fits != tt.fits {
+			t.Errorf("formWidth(%d): expected %d (%v), got %d (%v)", tt.window, tt.expected, tt.fits, got, fits)
+		}
+	}
+}
+
+func TestFormFollowsResize(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now)
+	m.openForm(showInput)
+	m.inputs[inputNameField].SetValue(strings.Repeat("A very long event name ", 3))
+
+	resize := func(w, h int) string {
+		t.Helper()
+		model, _ := m.Update(tea.WindowSizeMsg{Width: w, Height: h})
+		m = model.(MainModel)
+		return m.View()
+	}
+
+	view := resize(54, 45)
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 54 {
+			t.Fatalf("Expected the form to fit 54 columns, got a line of %d:\n%s", w, stripANSI(view))
+		}
+	}
+	if w := m.inputs[inputNameField].Width; w != 54-narrowFormFrame-4-2-lipgloss.Width(m.inputs[inputNameField].Prompt)-1 {
+		t.Errorf("Expected the name input to follow the field width, got %d", w)
+	}
+	if !strings.Contains(stripANSI(view), "Cancel") || !strings.Contains(stripANSI(view), "Create") {
+		t.Errorf("Expected both buttons in the narrow form:\n%s", stripANSI(view))
+	}
+
+	if view := stripANSI(resize(36, 45)); !strings.Contains(view, "too small") {
+		t.Errorf("Expected the too-small note in a narrow window, got:\n%s", view)
+	}
+	view = stripANSI(resize(80, 24))
+	if strings.Contains(view, "too small") || strings.Contains(view, "Format:") || !strings.Contains(view, "Create") {
+		t.Errorf("Expected the compact form without hints in 80x24, got:\n%s", view)
+	}
+	if view := stripANSI(resize(120, 12)); !strings.Contains(view, "too small") {
+		t.Errorf("Expected the too-small note in a short window, got:\n%s", view)
+	}
+
+	view = stripANSI(resize(120, 45))
+	if strings.Contains(view, "too small") || !strings.Contains(view, "Event Name") {
+		t.Errorf("Expected the form back after growing, got:\n%s", view)
+	}
+	if !strings.HasPrefix(m.inputs[inputNameField].Value(), "A very long") {
+		t.Error("Expected the typed name to survive the resizes")
+	}
+}
+
+func TestNameCounter(t *testing.T) {
+	if got := nameCounter("", nameCharLimit); got != "" {
+		t.Errorf("Expected no counter without a name, got %q", got)
+	}
+	if got := stripANSI(nameCounter("Café", nameCharLimit)); got != "4/120" {
+		t.Errorf("Expected characters counted, got %q", got)
+	}
+	near := strings.Repeat("x", nameCharLimit-5)
+	if nameCounter(near, nameCharLimit) != WarningStyle("115/120") {
+		t.Error("Expected a warning near the limit")
+	}
+
+	th := newTestHelper(t)
+	defer th.cleanup()
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	long := strings.Repeat("Hand-edited name ", 10)
+	m := newRefreshTestModel(t, &now, Event{Name: long, Time: now.AddDate(0, 0, 3).Unix()})
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 45})
+	m = model.(MainModel)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = model.(MainModel)
+	if m.inputs[inputNameField].Value() != long {
+		t.Errorf("Expected editing to keep a name over the limit, got %q", m.inputs[inputNameField].Value())
+	}
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "170/170") {
+		t.Errorf("Expected the counter by the name label:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 120 {
+			t.Fatalf("Expected the form to fit, got a line of %d", w)
+		}
+	}
+
+	m.resetInputs()
+	m.returnToList()
+	m.openForm(showInput)
+	m.inputs[inputNameField].SetValue(long)
+	if got := len(m.inputs[inputNameField].Value()); got != nameCharLimit {
+		t.Errorf("Expected new names cut at %d characters, got %d", nameCharLimit, got)
+	}
+}
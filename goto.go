@@ -0,0 +1,65 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// firstFrom returns the index of the earliest of items at or after ts, or
+// -1 if there is none. Items may be in any order; section headers are
+// skipped.
+func firstFrom(items []list.Item, ts int64) int {
+	best := -1
+	for i, item := range items {
+		e, ok := item.(Event)
+		if ok && e.Time >= ts && (best < 0 || e.Time < items[best].(Event).Time) {
+			best = i
+		}
+	}
+	return best
+}
+
+// lastBefore returns the index of the latest of items before ts, or -1 if
+// there is none.
+func lastBefore(items []list.Item, ts int64) int {
+	best := -1
+	for i, item := range items {
+		e, ok := item.(Event)
+		if ok && e.Time < ts && (best < 0 || e.Time > items[best].(Event).Time) {
+			best = i
+		}
+	}
+	return best
+}
+
+// goToDate selects the earliest visible event on or after the date typed
+// into the go-to prompt, or reports why it could not.
+func (m *MainModel) goToDate(input string) tea.Cmd {
+	date, err := parseDateInput(input, m.now())
+	if err != nil {
+		return m.events.NewStatusMessage(ErrStyle(err.Error()))
+	}
+	if best := firstFrom(m.events.VisibleItems(), date.Unix()); best >= 0 {
+		m.events.Select(best)
+		return nil
+	}
+	return m.events.NewStatusMessage(trf("goto.none", date.Format(m.config.dateLayout())))
+}
+
+// jumpToNow selects the next upcoming visible event, or with upcoming false
+// the one that passed most recently.
+func (m *MainModel) jumpToNow(upcoming bool) tea.Cmd {
+	items, now := m.events.VisibleItems(), m.now().Unix()
+	if upcoming {
+		if i := firstFrom(items, now); i >= 0 {
+			m.events.Select(i)
+			return nil
+		}
+		return m.events.NewStatusMessage(tr("goto.no_upcoming"))
+	}
+	if i := lastBefore(items, now); i >= 0 {
+		m.events.Select(i)
+		return nil
+	}
+	return m.events.NewStatusMessage(tr("goto.no_passed"))
+}
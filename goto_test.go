@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGoToDate(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 6, 10, 15, 30, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Dentist", Time: time.Date(2026, 6, 20, 9, 0, 0, 0, time.Local).Unix()},
+		Event{Name: "Vacation", Time: time.Date(2026, 8, 3, 8, 0, 0, 0, time.Local).Unix()},
+		Event{Name: "Wedding", Time: time.Date(2026, 8, 22, 14, 0, 0, 0, time.Local).Unix()},
+	)
+
+	typeGoTo := func(m MainModel, input string) (MainModel, tea.Cmd) {
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+		m = model.(MainModel)
+		if m.state != showGoTo {
+			t.Fatalf("Expected the go-to prompt, got state %v", m.state)
+		}
+		model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(input)})
+		model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return model.(MainModel), cmd
+	}
+
+	m, _ = typeGoTo(m, "aug")
+	if m.state != showEvents {
+		t.Errorf("Expected to return to the list, got state %v", m.state)
+	}
+	if got := m.events.SelectedItem().(Event).Name; got != "Vacation" {
+		t.Errorf("Expected Vacation selected, got %s", got)
+	}
+
+	m, _ = typeGoTo(m, "2026-08-04")
+	if got := m.events.SelectedItem().(Event).Name; got != "Wedding" {
+		t.Errorf("Expected Wedding selected, got %s", got)
+	}
+
+	m, cmd := typeGoTo(m, "2027-01-01")
+	if got := m.events.SelectedItem().(Event).Name; got != "Wedding" {
+		t.Errorf("Expected the selection to stay on Wedding, got %s", got)
+	}
+	if cmd == nil {
+		t.Error("Expected a status message when no event follows the date")
+	}
+}
+
+func TestJumpToNow(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(days int) int64 { return now.AddDate(0, 0, days).Unix() }
+	// Out of time order, as another sort would leave them.
+	f := &filterTestModel{t: t, m: newRefreshTestModel(t, &now,
+		Event{Name: "Team offsite", Time: at(10)},
+		Event{Name: "Team retro", Time: at(-2)},
+		Event{Name: "Trip", Time: at(5)},
+		Event{Name: "Team lunch", Time: at(1)},
+		Event{Name: "Dinner", Time: at(-1)},
+	)}
+	f.m.events.FilterInput.Cursor.SetMode(cursor.CursorStatic)
+	f.send(tea.WindowSizeMsg{Width: 160, Height: 40})
+
+	for _, tt := range []struct{ key, want string }{
+		{"n", "Team lunch"},
+		{"N", "Dinner"},
+		{"n", "Team lunch"},
+		{"P", "Dinner"},
+	} {
+		f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+		if got := f.selected(); got != tt.want {
+			t.Errorf("%s: expected %s selected, got %s", tt.key, tt.want, got)
+		}
+	}
+
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("team")
+	f.send(tea.KeyMsg{Type: tea.KeyEnter})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if got := f.selected(); got != "Team retro" {
+		t.Errorf("Expected the last passed match selected, got %s", got)
+	}
+
+	f.send(tea.KeyMsg{Type: tea.KeyEscape})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	f.typeText("trip")
+	f.send(tea.KeyMsg{Type: tea.KeyEnter})
+	f.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	if got := f.selected(); got != "Trip" {
+		t.Errorf("Expected the selection to stay on Trip, got %s", got)
+	}
+	if view := stripANSI(f.m.events.View()); !strings.Contains(view, "no past") {
+		t.Errorf("Expected a status message, got:\n%s", view)
+	}
+}
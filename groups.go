@@ -0,0 +1,358 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultGroup is the group of events not assigned to one of the
+// configured groups. Their section comes after the configured ones.
+const defaultGroup = ""
+
+// sectionHeader heads the events of one group in the list while groups
+// are configured. Collapsing it sets its events aside in hiddenEvents.
+type sectionHeader struct {
+	Group     string
+	Count     int
+	Collapsed bool
+	Next      *Event // nearest upcoming event, nil if all have passed
+}
+
+func (h sectionHeader) Title() string {
+	arrow := "▾"
+	if h.Collapsed {
+		arrow = "▸"
+	}
+	return fmt.Sprintf("%s %s (%s)", arrow, groupLabel(h.Group), trn("tags.group", h.Count))
+}
+
+// nextLine is the description of a section or tag: the nearest upcoming
+// event in it and the countdown to it.
+func (d eventDelegate) nextLine(next *Event) string {
+	if next == nil {
+		return tr("tags.no_upcoming")
+	}
+	return next.Name + " · " + d.countdown(next.Time)
+}
+
+// FilterValue is empty, so filtering matches events and never a header.
+func (h sectionHeader) FilterValue() string { return "" }
+
+// groupLabel names a group in the list and the form.
+func groupLabel(group string) string {
+	if group == defaultGroup {
+		return tr("groups.default")
+	}
+	return group
+}
+
+// grouped reports whether the list is divided into sections.
+func (c Config) grouped() bool {
+	return len(c.Groups) > 0
+}
+
+// sectionOf is the group whose section lists e. An event of a group no
+// longer in the config goes under the default section, keeping its group.
+func (c Config) sectionOf(e Event) string {
+	for _, g := range c.Groups {
+		if g == e.Group {
+			return g
+		}
+	}
+	return defaultGroup
+}
+
+// stepGroup returns the group step places after group, with the default
+// group before the configured ones, wrapping around.
+func (c Config) stepGroup(group string, step int) string {
+	groups := append([]string{defaultGroup}, c.Groups...)
+	i := 0
+	for j, g := range groups {
+		if g == group {
+			i = j
+		}
+	}
+	return groups[(i+step+len(groups))%len(groups)]
+}
+
+// eventsOf returns the events among items, leaving out section headers.
+func eventsOf(items []list.Item) []Event {
+	events := make([]Event, 0, len(items))
+	for _, item := range items {
+		if e, ok := item.(Event); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// selectedEvent returns the event under the cursor, and false on a section
+// header or when there is none.
+func (m MainModel) selectedEvent() (Event, bool) {
+	e, ok := m.events.SelectedItem().(Event)
+	return e, ok
+}
+
+func (m MainModel) hasSelectedEvent() bool {
+	_, ok := m.selectedEvent()
+	return ok
+}
+
+func (m MainModel) isCollapsed(group string) bool {
+	for _, g := range m.collapsed {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionItems lays events out under a header per group, in the
+// configured order and skipping groups without events. The events of
+// collapsed sections are returned apart. Without groups the items are the
+// events as they are.
+func (m MainModel) sectionItems(events []Event, now time.Time) (items []list.Item, folded []Event) {
+	if !m.config.grouped() {
+		items = make([]list.Item, len(events))
+		for i := range events {
+			items[i] = events[i]
+		}
+		return items, nil
+	}
+	bySection := map[string][]Event{}
+	for _, e := range events {
+		g := m.config.sectionOf(e)
+		bySection[g] = append(bySection[g], e)
+	}
+	for _, g := range append(append([]string(nil), m.config.Groups...), defaultGroup) {
+		section := bySection[g]
+		if len(section) == 0 {
+			continue
+		}
+		h := sectionHeader{Group: g, Count: len(section), Collapsed: m.isCollapsed(g)}
+		if next, ok := nextEvent(section, now, nextOptions{Virtual: true}); ok {
+			h.Next = &next
+		}
+		items = append(items, h)
+		if h.Collapsed {
+			folded = append(folded, section...)
+			continue
+		}
+		for _, e := range section {
+			items = append(items, e)
+		}
+	}
+	return items, folded
+}
+
+// regroup lays the sections out again after the events in them changed.
+func (m *MainModel) regroup() {
+	if m.config.grouped() {
+		m.relist()
+	}
+}
+
+// relist puts every event back in the list in sort order, in sections and
+// within the tag scope.
+func (m *MainModel) relist() {
+	events := m.allEvents()
+	m.config.sortOrder().sort(events, m.now())
+	m.setEvents(events)
+}
+
+// setCollapsed collapses or expands the section of group and remembers it
+// in the state file for the next start.
+func (m *MainModel) setCollapsed(group string, collapsed bool) tea.Cmd {
+	if m.isCollapsed(group) == collapsed {
+		return nil
+	}
+	// Copied rather than changed in place, since earlier copies of the
+	// model share the slice.
+	var groups []string
+	for _, g := range m.collapsed {
+		if g != group {
+			groups = append(groups, g)
+		}
+	}
+	if collapsed {
+		groups = append(groups, group)
+	}
+	m.collapsed = groups
+	m.relist()
+	err := updateState(func(st *appState) { st.CollapsedGroups = groups })
+	if err != nil {
+		return m.events.NewStatusMessage(ErrStyle(trf("groups.state_failed", err)))
+	}
+	return nil
+}
+
+// updateSection handles the keys that act on a selected section header:
+// left collapses it, right expands it and enter toggles it. It reports
+// whether msg was one of them.
+func (m *MainModel) updateSection(msg tea.KeyMsg) (tea.Cmd, bool) {
+	h, ok := m.events.SelectedItem().(sectionHeader)
+	if !ok || m.events.FilterState() != list.Unfiltered {
+		return nil, false
+	}
+	switch {
+	case key.Matches(msg, Keymap.OptionPrev):
+		return m.setCollapsed(h.Group, true), true
+	case key.Matches(msg, Keymap.OptionNext), key.Matches(msg, Keymap.Enter):
+		collapsed := key.Matches(msg, Keymap.Enter) && !h.Collapsed
+		return m.setCollapsed(h.Group, collapsed), true
+	}
+	return nil, false
+}
+
+// isMarked reports whether e is marked for assigning to a group.
+func isMarked(marked []Event, e Event) bool {
+	for _, mk := range marked {
+		if sameEvent(mk, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleMark marks the selected event for assigning to a group, or unmarks
+// it. Virtual and read-only events cannot be assigned.
+func (m *MainModel) toggleMark() {
+	e, ok := m.selectedEvent()
+	if !ok || e.Virtual || e.ReadOnly {
+		return
+	}
+	var marked []Event
+	for _, mk := range m.marked {
+		if !sameEvent(mk, e) {
+			marked = append(marked, mk)
+		}
+	}
+	if len(marked) == len(m.marked) {
+		marked = append(marked, e)
+	}
+	m.marked = marked
+}
+
+// assignGroup moves the marked events, or the selected one if none is
+// marked, to the group after the first one's. Pressing it again steps on
+// through the groups. The marks stay, so they move together.
+func (m *MainModel) assignGroup() tea.Cmd {
+	targets := m.marked
+	if len(targets) == 0 {
+		e, ok := m.selectedEvent()
+		if !ok || e.Virtual || e.ReadOnly {
+			return nil
+		}
+		targets = []Event{e}
+	}
+	group := m.config.stepGroup(m.config.sectionOf(targets[0]), 1)
+
+	before := m.storedEvents()
+	events := m.allEvents()
+	n := 0
+	for i, e := range events {
+		if isMarked(targets, e) {
+			events[i].Group = group
+			n++
+		}
+	}
+	var marked []Event
+	for _, e := range m.marked {
+		e.Group = group
+		marked = append(marked, e)
+	}
+	m.marked = marked
+	if n == 0 {
+		return nil
+	}
+	m.config.sortOrder().sort(events, m.now())
+	m.setEvents(events)
+	if cmd := m.saved(m.saveChange(before)); cmd != nil {
+		return cmd
+	}
+	return m.events.NewStatusMessage(SuccessStyle(trf("groups.assigned", trn("tags.group", n), groupLabel(group))))
+}
+
+// renderHeader draws a section header like an event, its title in bold.
+func (d eventDelegate) renderHeader(w io.Writer, m list.Model, index int, h sectionHeader) {
+	s := &d.Styles
+	s.NormalTitle = s.NormalTitle.Bold(true)
+	s.SelectedTitle = s.SelectedTitle.Bold(true)
+	d.DefaultDelegate.Render(w, m, index, listRow{h, h.Title(), d.nextLine(h.Next)})
+}
+
+// sectionStatus is the list's status bar while it is in sections: the
+// events, without their headers, and the next event of each collapsed
+// section.
+func (m MainModel) sectionStatus(l list.Model) string {
+	visible := eventsOf(l.VisibleItems())
+	name := tr("list.items")
+	if len(visible) == 1 {
+		name = tr("list.item")
+	}
+	if n := countToday(l.VisibleItems(), m.now()); n > 0 {
+		name = trf("today.count", name, n)
+	}
+	status := fmt.Sprintf("%d %s", len(visible), name)
+	if l.FilterState() == list.FilterApplied {
+		status = fmt.Sprintf("“%s” ", strings.TrimSpace(l.FilterValue())) + status
+	}
+	for _, item := range l.Items() {
+		if h, ok := item.(sectionHeader); ok && h.Collapsed && h.Next != nil {
+			status += l.Styles.DividerDot.String() +
+				trf("groups.next", groupLabel(h.Group), h.Next.Name, formatRelative(time.Unix(h.Next.Time, 0), m.now()))
+		}
+	}
+	return l.Styles.StatusBar.Render(status)
+}
+
+// listView renders events. In sections the list's own status bar would
+// count the headers as events, so sectionStatus takes its place below the
+// title.
+func (m MainModel) listView(events list.Model) string {
+	events.SetDelegate(m.delegate(m.eventsDelegate))
+	if !m.config.grouped() || !events.ShowStatusBar() {
+		return events.View()
+	}
+	status := m.sectionStatus(events)
+	events.SetShowStatusBar(false)
+	events.SetHeight(events.Height() - lipgloss.Height(status))
+	lines := strings.Split(events.View(), "\n")
+	head := listHeadHeight(events)
+	if head > len(lines) {
+		head = len(lines)
+	}
+	out := append(append(append([]string(nil), lines[:head]...), strings.Split(status, "\n")...), lines[head:]...)
+	return strings.Join(out, "\n")
+}
+
+// listHeadHeight is how many lines the list draws above its status bar:
+// the title, or the filter being typed, with the title bar's padding.
+func listHeadHeight(l list.Model) int {
+	switch {
+	case l.ShowTitle() || l.FilterState() == list.Filtering:
+		return 1 + l.Styles.TitleBar.GetVerticalFrameSize()
+	case l.ShowFilter() && l.FilteringEnabled():
+		return 1
+	}
+	return 0
+}
+
+// selectedGroup is the group of the section the cursor is in, which new
+// events start in.
+func (m MainModel) selectedGroup() string {
+	switch item := m.events.SelectedItem().(type) {
+	case sectionHeader:
+		return item.Group
+	case Event:
+		return m.config.sectionOf(item)
+	}
+	return defaultGroup
+}
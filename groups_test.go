@@ -0,0 +1,232 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// newGroupsTestModel starts the program on events with the groups
+// Releases and Personal configured.
+func newGroupsTestModel(t *testing.T, now *time.Time, events ...Event) MainModel {
+	t.Helper()
+	writeConfigFile(t, "groups = [\"Releases\", \"Personal\"]\n")
+	if err := writeEventsFile(events); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	m.clock = func() time.Time { return *now }
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort)})
+	m.relist()
+	// Narrow enough for the list to take the whole width.
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 58, Height: 40})
+	return model.(MainModel)
+}
+
+// listTitles names the list items in order, headers by their group in
+// brackets.
+func listTitles(m MainModel) []string {
+	var titles []string
+	for _, item := range m.events.Items() {
+		switch item := item.(type) {
+		case sectionHeader:
+			titles = append(titles, "["+groupLabel(item.Group)+"]")
+		case Event:
+			titles = append(titles, item.Name)
+		}
+	}
+	return titles
+}
+
+func TestStepGroup(t *testing.T) {
+	c := Config{Groups: []string{"Releases", "Personal"}}
+	tests := []struct {
+		group string
+		step  int
+		want  string
+	}{
+		{"", 1, "Releases"},
+		{"Releases", 1, "Personal"},
+		{"Personal", 1, ""},
+		{"", -1, "Personal"},
+		{"Gone", 1, "Releases"},
+	}
+	for _, tt := range tests {
+		if got := c.stepGroup(tt.group, tt.step); got != tt.want {
+			t.Errorf("stepGroup(%q, %d) = %q, want %q", tt.group, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestGroupSections(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.Local)
+	day := func(d int) int64 { return now.AddDate(0, 0, d).Unix() }
+	m := newGroupsTestModel(t, &now,
+		Event{Name: "Dentist", Time: day(1), Group: "Personal"},
+		Event{Name: "Launch", Time: day(3), Group: "Releases"},
+		Event{Name: "Archive", Time: day(5), Group: "Gone"},
+		Event{Name: "Taxes", Time: day(10)},
+	)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+
+	want := []string{"[Releases]", "Launch", "[Personal]", "Dentist", "[Other]", "Archive", "Taxes"}
+	if got := listTitles(m); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected sections in the configured order, got %v", got)
+	}
+	view := withColorProfile(termenv.Ascii, m.View)
+	if !strings.Contains(view, "▾ Releases (1 event)") || !strings.Contains(view, "4 events") {
+		t.Errorf("Expected the headers and the events counted without them, got:\n%s", view)
+	}
+
+	m.events.Select(0)
+	send(tea.KeyMsg{Type: tea.KeyLeft})
+	want = []string{"[Releases]", "[Personal]", "Dentist", "[Other]", "Archive", "Taxes"}
+	if got := listTitles(m); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected left to collapse the section, got %v", got)
+	}
+	if _, ok := m.events.SelectedItem().(sectionHeader); !ok || m.events.Index() != 0 {
+		t.Errorf("Expected the collapsed header still selected, got %v", m.events.SelectedItem())
+	}
+	view = stripANSI(m.View())
+	if !strings.Contains(view, "▸ Releases") || !strings.Contains(view, "Releases: Launch in 3 days") {
+		t.Errorf("Expected the collapsed section's next event in the status bar, got:\n%s", view)
+	}
+	if got := storedNames(m.storedEvents()); got != "Dentist,Launch,Archive,Taxes" {
+		t.Errorf("Expected the collapsed events still saved, got %v", got)
+	}
+	if st := loadState(); !reflect.DeepEqual(st.CollapsedGroups, []string{"Releases"}) {
+		t.Errorf("Expected the collapsed section in the state file, got %v", st.CollapsedGroups)
+	}
+
+	restarted := newGroupsTestModel(t, &now, m.storedEvents()...)
+	if got := listTitles(restarted); got[1] != "[Personal]" {
+		t.Errorf("Expected the section to stay collapsed after a restart, got %v", got)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := listTitles(m); len(got) != 7 || got[1] != "Launch" {
+		t.Errorf("Expected enter to expand the section again, got %v", got)
+	}
+	if st := loadState(); len(st.CollapsedGroups) != 0 {
+		t.Errorf("Expected no collapsed sections left, got %v", st.CollapsedGroups)
+	}
+}
+
+func TestAssignGroupToMarked(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.Local)
+	m := newGroupsTestModel(t, &now,
+		Event{Name: "Dentist", Time: now.AddDate(0, 0, 1).Unix()},
+		Event{Name: "Taxes", Time: now.AddDate(0, 0, 10).Unix()},
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 3).Unix(), Group: "Releases"},
+	)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+	mark := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")}
+	assign := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")}
+
+	// [Releases] Launch [Other] Dentist Taxes
+	m.events.Select(3)
+	send(mark)
+	m.events.Select(4)
+	send(mark)
+	if view := stripANSI(m.View()); !strings.Contains(view, "✓ Dentist") || !strings.Contains(view, "✓ Taxes") {
+		t.Errorf("Expected the marked events ticked, got:\n%s", view)
+	}
+	send(assign)
+	events, _ := readEventsFile()
+	for _, e := range events {
+		if e.Group != "Releases" {
+			t.Errorf("Expected every event in Releases, got %s in %q", e.Name, e.Group)
+		}
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "Moved 2 events to Releases") {
+		t.Errorf("Expected the move confirmed, got:\n%s", view)
+	}
+
+	send(assign)
+	events, _ = readEventsFile()
+	groups := map[string]string{}
+	for _, e := range events {
+		groups[e.Name] = e.Group
+	}
+	if groups["Dentist"] != "Personal" || groups["Taxes"] != "Personal" || groups["Launch"] != "Releases" {
+		t.Errorf("Expected pressing again to move only the marked events on, got %v", groups)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.marked) != 0 {
+		t.Errorf("Expected esc to clear the marks, got %v", m.marked)
+	}
+}
+
+func TestFormGroup(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.Local)
+	m := newGroupsTestModel(t, &now, Event{Name: "Dentist", Time: now.AddDate(0, 0, 1).Unix(), Group: "Personal"})
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if m.formGroup != "Personal" {
+		t.Errorf("Expected a new event to start in the selected section, got %q", m.formGroup)
+	}
+	for m.focus != int(inputGroupField) {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	send(tea.KeyMsg{Type: tea.KeyRight})
+	if view := stripANSI(m.View()); !strings.Contains(view, "‹ Other ›") {
+		t.Errorf("Expected right to step to the next group, got:\n%s", view)
+	}
+	send(tea.KeyMsg{Type: tea.KeyLeft})
+	m.inputs[inputNameField].SetValue("Gym")
+	m.inputs[inputTimeField].SetValue("2026-07-05 18:00")
+	send(tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	events, _ := readEventsFile()
+	if len(events) != 2 || events[1].Name != "Gym" || events[1].Group != "Personal" {
+		t.Errorf("Expected the event saved in Personal, got %+v", events)
+	}
+	if got := listTitles(m); !reflect.DeepEqual(got, []string{"[Personal]", "Dentist", "Gym"}) {
+		t.Errorf("Expected the new event in its section, got %v", got)
+	}
+
+	m.config.Groups = nil
+	m.openForm(showInput)
+	for i := 0; i < 6; i++ {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+		if m.focus == int(inputGroupField) {
+			t.Fatal("Expected the group field skipped without groups")
+		}
+	}
+}
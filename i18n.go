@@ -0,0 +1,665 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+//go:embed locales/*.toml
+var bundledLocales embed.FS
+
+// englishMessages is the message catalog every translation falls back to.
+// IDs ending in .one and .other are the singular and plural forms used by trn.
+var englishMessages = map[string]string{
+	"empty.message": "No events, add one with '+'\n\nPress 'q' to quit",
+
+	"list.events":            "Events",
+	"list.events_in_tag":     "Events · %s",
+	"list.tags":              "Tags",
+	"list.item":              "event",
+	"list.items":             "events",
+	"today.badge":            "TODAY",
+	"today.earlier":          "EARLIER",
+	"today.count":            "%s · %d today",
+	"allday.all_day":         "All day",
+	"allday.tomorrow":        "tomorrow",
+	"allday.yesterday":       "yesterday",
+	"allday.in_days.one":     "in %d day",
+	"allday.in_days.other":   "in %d days",
+	"groups.default":         "Other",
+	"groups.next":            "%s: %s %s",
+	"groups.assigned":        "Moved %s to %s",
+	"groups.state_failed":    "Could not remember the sections: %v",
+	"tags.untagged":          "(untagged)",
+	"tags.no_upcoming":       "no upcoming events",
+	"tags.group.one":         "%d event",
+	"tags.group.other":       "%d events",
+	"tags.changed.one":       "%d event changed",
+	"tags.changed.other":     "%d events changed",
+	"tags.added":             "Added #%s: %s",
+	"tags.removed":           "Removed #%s: %s",
+	"tags.renamed":           "Renamed #%s to #%s: %s",
+	"tags.add_prompt":        "Add tag to these events: ",
+	"tags.rename_prompt":     "Rename #%s to: ",
+	"period.quarter_end":     "End of %s",
+	"period.year_end":        "End of FY%02d",
+	"sky.march_equinox":      "March equinox",
+	"sky.june_solstice":      "June solstice",
+	"sky.september_equinox":  "September equinox",
+	"sky.december_solstice":  "December solstice",
+	"sky.sunrise":            "Sunrise",
+	"sky.sunset":             "Sunset",
+	"fiscal.progress":        "%s — %d%% elapsed, %s",
+	"fiscal.days.one":        "%d day remaining",
+	"fiscal.days.other":      "%d days remaining",
+	"weeks.label":            "week %d of %d",
+	"weeks.left.one":         "%d whole week left",
+	"weeks.left.other":       "%d whole weeks left",
+	"weeks.ago.one":          "%d whole week ago",
+	"weeks.ago.other":        "%d whole weeks ago",
+	"dayofyear.day_of":       "Day %d of %d",
+	"dayofyear.left_in_year": "%s left in %d",
+	"dayofyear.days.one":     "%d day",
+	"dayofyear.days.other":   "%d days",
+	"dayofyear.since_added":  "since it was added",
+	"countdown.ago":          "%s ago",
+	"countdown.today":        "today!",
+	"countdown.left.one":     "%d day left",
+	"countdown.left.other":   "%d days left",
+	"countdown.since.one":    "%d day ago",
+	"countdown.since.other":  "%d days ago",
+	"relative.in":            "in %s",
+	"relative.ago":           "%s ago",
+	"relative.now":           "now",
+	"relative.years.one":     "%d year",
+	"relative.years.other":   "%d years",
+	"relative.weeks.one":     "%d week",
+	"relative.weeks.other":   "%d weeks",
+	"relative.days.one":      "%d day",
+	"relative.days.other":    "%d days",
+	"relative.hours.one":     "%d hour",
+	"relative.hours.other":   "%d hours",
+	"relative.minutes.one":   "%d minute",
+	"relative.minutes.other": "%d minutes",
+
+	"detail.time_until":      "⏳ Time Until",
+	"detail.no_matches":      "No matches for '%s'",
+	"detail.clear_filter":    "Esc clears the filter.",
+	"detail.time_since":      "⏪ Time Since",
+	"detail.arrived":         "🎉 %s is here! 🎉",
+	"detail.years":           "Years",
+	"detail.days":            "Days",
+	"detail.hours":           "Hours",
+	"detail.minutes":         "Minutes",
+	"detail.seconds":         "Seconds",
+	"detail.day_progress":    "Day progress: ",
+	"detail.quarter":         "Quarter: ",
+	"detail.statistics":      "📊 Statistics",
+	"detail.total_seconds":   "Total seconds:",
+	"detail.total_minutes":   "Total minutes:",
+	"detail.total_hours":     "Total hours:",
+	"detail.total_days":      "Total days:",
+	"detail.total_years":     "Total years:",
+	"detail.reminders":       "🔔 Reminders",
+	"detail.reminder_before": "%s before:",
+	"detail.pace_daily":      "Each day is %.1f%% of the time left",
+	"detail.pace_halfway":    "Halfway point: %s",
+	"detail.pace_burn":       "%.1f%% of the wait passes each week",
+
+	"form.new":                "✨ New Event",
+	"form.edit":               "✏️  Edit Event",
+	"form.name":               "📝 Event Name",
+	"form.name_placeholder":   "e.g., Birthday Party",
+	"form.datetime":           "📅 Date & Time",
+	"form.format_hint":        "   Format: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS",
+	"form.example_hint":       "   Example: 2025-12-31 18:30:00, tomorrow, +2w, aug",
+	"form.step_hint":          "   ↑/↓ change the part under the cursor, Shift for bigger steps",
+	"form.past_event":         "%s (past event)",
+	"form.unix_timestamp":     "(interpreted as Unix timestamp)",
+	"form.invalid_date":       "Invalid date format",
+	"form.cancel":             "✗ Cancel",
+	"form.create":             "✓ Create",
+	"form.update":             "✓ Update",
+	"form.create_past":        "⚠ Create past event?",
+	"form.update_past":        "⚠ Save past date?",
+	"form.past_confirm":       "This date has already passed. Press Enter again to save it anyway.",
+	"form.help":               "Tab: next field • Shift+Tab: previous • Enter: select • Ctrl+S: save • Esc: cancel",
+	"form.name_required":      "event name is required",
+	"form.date_required":      "date/time is required",
+	"form.date_invalid":       "invalid date format",
+	"form.error":              "Error: %v",
+	"form.year_range":         "year %d is outside %d-%d",
+	"form.conflict_same_day":  "same day as '%s'",
+	"form.conflict_near":      "within a day of '%s'",
+	"form.reminders":          "🔔 Reminders",
+	"form.reminders_hint":     "   e.g. 1w, 1d, 2h; empty uses the defaults",
+	"form.reminders_invalid":  "invalid reminder %v",
+	"form.repeat":             "🔁 Repeat",
+	"form.repeat_placeholder": "once",
+	"form.repeat_hint":        "   e.g. yearly, every 14th, every 2 weeks, daily; empty for once",
+	"form.repeat_invalid":     "invalid repeat %v",
+	"form.kind":               "🧭 Kind",
+	"form.group":              "🗂 Group",
+	"form.too_small":          "The window is too small for the form. Make it larger (at least %d columns); Esc cancels.",
+
+	"review.title":             "✏️  Review Changes",
+	"review.before":            "Before",
+	"review.after":             "After",
+	"review.default_reminders": "defaults",
+	"review.help":              "Enter: save • Esc: back to the form",
+
+	"onthisday.title":       "📜 On This Day - %s",
+	"onthisday.loading":     "Loading historical events...",
+	"onthisday.failed":      "  Failed to load events",
+	"onthisday.retry":       "  Press R to retry",
+	"onthisday.pages":       "  Open with 1-%d:",
+	"onthisday.opened":      "  Opened in your browser",
+	"onthisday.open_it":     "  Open in a browser:",
+	"onthisday.era_all":     "all",
+	"onthisday.era_100":     "last 100 years",
+	"onthisday.era_50":      "last 50 years",
+	"onthisday.era_century": "this century",
+	"onthisday.newest":      "newest first",
+	"onthisday.oldest":      "oldest first",
+	"onthisday.count":       "  %d of %d, %s",
+	"onthisday.none_in_era": "  Nothing from the %s",
+	"onthisday.births":      "👶 Born on %s",
+	"onthisday.deaths":      "🕯 Died on %s",
+	"onthisday.none":        "  No historical events found",
+	"onthisday.skipped":     "  Not fetched, add --wiki to include it",
+	"onthisday.more":        "  ... and %d more events",
+	"onthisday.year":        "%d (%d yrs ago)",
+	"onthisday.source":      "  Source: Wikipedia",
+
+	"timeline.title":      "🗺 Timeline",
+	"timeline.now":        "NOW",
+	"timeline.future":     "future",
+	"timeline.none":       "  No upcoming events",
+	"timeline.more.one":   "... and %d more event",
+	"timeline.more.other": "... and %d more events",
+
+	"notes.title": "📝 Notes",
+	"notes.none":  "  No notes. Add a \"notes\" field to the event in events.json to see them here.",
+
+	"summary.title": "Coming up:",
+
+	"goto.prompt":      "Go to date: ",
+	"goto.none":        "no events after %s",
+	"goto.no_upcoming": "no upcoming events",
+	"goto.no_passed":   "no past events",
+
+	"whatif.prompt":        "What if it were: ",
+	"whatif.banner":        "🧭 What if: %s · esc for now",
+	"whatif.after":         "%s after",
+	"whatif.before":        "%s before",
+	"whatif.same":          "same time",
+	"whatif.detail_after":  "%s after %s",
+	"whatif.detail_before": "%s before %s",
+	"whatif.detail_same":   "at the same time as %s",
+
+	"share.title":       "🔗 Share",
+	"share.copied":      "Link copied to the clipboard",
+	"share.copy_failed": "Could not copy the link: %v",
+	"share.qr_too_long": "The link is too long for a QR code",
+	"share.close":       "Press any key to close",
+	"snapshot.saved":    "View saved to %s",
+	"snapshot.failed":   "Could not save the view: %v",
+
+	"readonly.detail":  "Read-only, from %s",
+	"readonly.refused": "%q comes from %s and is read-only, change it there",
+	"readonly.unknown": "another program",
+
+	"info.title":              "ℹ About",
+	"info.version":            "Version",
+	"info.events_file":        "Events file",
+	"info.file_missing":       "not created yet",
+	"info.file_stat":          "%s, modified %s",
+	"info.events":             "Events",
+	"info.events_count.one":   "%d saved",
+	"info.events_count.other": "%d saved",
+	"info.state_file":         "State file",
+	"info.profile":            "Profile",
+	"info.no_profiles":        "none, one events file per config directory",
+	"info.config_file":        "Config file",
+	"info.config_ok":          "loads cleanly",
+	"info.config_missing":     "not found, using defaults",
+	"info.config_error":       "does not load now: %v",
+	"info.config_fallback":    "loads, but %s is invalid and uses the default",
+	"info.wiki":               "On this day",
+	"info.wiki_loading":       "loading",
+	"info.wiki_not_loaded":    "not loaded, the panel has not been shown yet",
+	"info.wiki_failed":        "failed: %v",
+	"info.wiki_loaded.one":    "%d event in memory, fetched again when the day changes",
+	"info.wiki_loaded.other":  "%d events in memory, fetched again when the day changes",
+
+	"stats.title":                 "📈 Statistics",
+	"stats.events":                "Events",
+	"stats.events_split":          "%d, %d upcoming, %d past",
+	"stats.average":               "Average",
+	"stats.days":                  "%.1f days out",
+	"stats.farthest":              "Farthest",
+	"stats.lingering":             "Past",
+	"stats.lingering_count.one":   "%d past event is still in the file",
+	"stats.lingering_count.other": "%d past events are still in the file",
+	"stats.horizon":               "Horizon",
+	"stats.horizon_span":          "%s, %s",
+	"stats.quietest":              "Quietest stretch",
+	"stats.quietest_gap":          "%s after “%s”",
+	"stats.span_days.one":         "%d day",
+	"stats.span_days.other":       "%d days",
+	"stats.span_weeks.one":        "%d week",
+	"stats.span_weeks.other":      "%d weeks",
+	"stats.span_months.one":       "%d month",
+	"stats.span_months.other":     "%d months",
+	"stats.span_years.one":        "%d year",
+	"stats.span_years.other":      "%d years",
+	"stats.by_month":              "Next 12 months",
+	"stats.by_urgency":            "By urgency",
+	"stats.by_tag":                "By tag",
+	"stats.bucket_0":              "Past",
+	"stats.bucket_1":              "30+ days",
+	"stats.bucket_2":              "14-30 days",
+	"stats.bucket_3":              "7-14 days",
+	"stats.bucket_4":              "3-7 days",
+	"stats.bucket_5":              "1-3 days",
+	"stats.bucket_6":              "Within a day",
+	"stats.by_time":               "By weekday and hour",
+	"stats.weekdays":              "Sun Mon Tue Wed Thu Fri Sat",
+	"stats.no_times":              "No events to show",
+	"stats.past_hint":             "a to include past events, any other key to close",
+	"stats.upcoming_hint":         "a for upcoming events only, any other key to close",
+
+	"imminent.title":      "⏰ Next 24 Hours",
+	"imminent.none":       "Nothing in the next 24 hours",
+	"imminent.next":       "Next up: '%s' %s",
+	"imminent.hint":       "⏰ T: 24h",
+	"imminent.close_hint": "Press any key to close",
+
+	"midnight.today": "📅 Today: %s",
+
+	"clock.ahead": "Clock changed: moved ahead %s",
+	"clock.back":  "Clock changed: moved back %s",
+
+	"compact.date":    "Date",
+	"compact.time":    "Time",
+	"compact.week":    "Week",
+	"compact.year":    "Year",
+	"compact.added":   "Since added",
+	"compact.repeats": "Repeats",
+	"compact.help":    "esc back • e edit • q quit",
+
+	"wizard.title":              "👋 Welcome to countdown",
+	"wizard.intro":              "A few choices before you start. You can change them later in config.toml.",
+	"wizard.path":               "Where to keep your events",
+	"wizard.path_default":       "default: %s",
+	"wizard.wikipedia":          "Wikipedia's On This Day beside the events",
+	"wizard.show":               "Show",
+	"wizard.hide":               "Hide",
+	"wizard.clock":              "Clock",
+	"wizard.clock_12h":          "12-hour",
+	"wizard.clock_24h":          "24-hour",
+	"wizard.first":              "First event",
+	"wizard.first_own":          "Add my own",
+	"wizard.first_none":         "Nothing for now",
+	"wizard.start":              "Start",
+	"wizard.help":               "Tab/↑↓: move • ←→: change • Enter: next • Ctrl+S: start • Esc: skip",
+	"wizard.template.new_year":  "New Year's Day",
+	"wizard.template.valentine": "Valentine's Day",
+	"wizard.template.halloween": "Halloween",
+	"wizard.template.christmas": "Christmas",
+
+	"kind.generic":            "Event",
+	"kind.deadline":           "Deadline",
+	"kind.birthday":           "Birthday",
+	"kind.trip":               "Trip",
+	"kind.working_days_left":  "Working days left",
+	"kind.working_days_since": "Working days over",
+	"kind.month_before":       "Month to go",
+	"kind.week_before":        "Week to go",
+	"kind.day_before":         "Last day",
+	"kind.turns":              "Turns",
+	"kind.next_anniversary":   "Next birthday",
+	"kind.days_to_go":         "Days to go",
+	"kind.away":               "Away for",
+	"kind.days.one":           "%d day",
+	"kind.days.other":         "%d days",
+	"kind.packing_list":       "Packing list",
+	"kind.packing_empty":      "Add items to the notes, one per line; start a line with \"x \" once packed",
+
+	"watch.remaining": "%s — %s remaining",
+	"watch.ago":       "%s — %s ago",
+	"watch.now":       "%s — now",
+
+	"dedupe.title":         "🧹 Likely Duplicates",
+	"dedupe.none":          "No events share a name within %s",
+	"dedupe.added":         "added %s",
+	"dedupe.summary.one":   "%d group would be merged into one event",
+	"dedupe.summary.other": "%d groups would each be merged into one event",
+	"dedupe.hint":          "n keeps the newest of each, o the oldest, any other key to close",
+	"dedupe.close_hint":    "Press any key to close",
+	"dedupe.merged.one":    "Merged %d group",
+	"dedupe.merged.other":  "Merged %d groups",
+
+	"unsaved.failed":    "Not saved: %v",
+	"unsaved.title":     "⚠ Events Not Saved",
+	"unsaved.retry":     "try saving again and quit",
+	"unsaved.elsewhere": "save them to this file instead and quit",
+	"unsaved.discard":   "quit anyway and discard the changes",
+	"unsaved.back":      "esc to go back",
+
+	"repeat.yearly":  "every year",
+	"repeat.monthly": "monthly on day %d",
+	"repeat.months":  "every %d months on day %d",
+	"repeat.weekly":  "every week",
+	"repeat.weeks":   "every %d weeks",
+	"repeat.daily":   "every day",
+	"repeat.days":    "every %d days",
+
+	"digest.title":        "🔔 Since you last checked",
+	"digest.passed":       "'%s' passed %s",
+	"digest.passed_today": "'%s' passed today",
+	"digest.more":         "… and %d more",
+	"digest.dismiss":      "Press any key to continue",
+
+	"rank.upcoming":              "%s soonest of %s",
+	"rank.upcoming_events.one":   "%d upcoming event",
+	"rank.upcoming_events.other": "%d upcoming events",
+	"rank.past":                  "%s most recent of %s",
+	"rank.past_events.one":       "%d past event",
+	"rank.past_events.other":     "%d past events",
+	"rank.only_upcoming":         "the only upcoming event",
+	"rank.only_past":             "the only past event",
+	"rank.after":                 "%s after “%s”",
+	"rank.before":                "%s before “%s”",
+	"rank.same":                  "same time as “%s”",
+	"rank.ordinal":               "%d.",
+	"stretch.between":            "%.0f%% of the way from “%s” to “%s”",
+	"stretch.since_added":        "%.0f%% of the way to “%s” since it was added",
+	"compare.title":              "⚖️  Comparison",
+	"compare.after":              "%s is %s after %s",
+	"compare.before":             "%s is %s before %s",
+	"compare.same":               "%s and %s are at the same time",
+	"compare.elapsed":            "Gap elapsed: ",
+	"compare.days.one":           "%d day",
+	"compare.days.other":         "%d days",
+
+	"notify.reminder": "%s in %s (%s)",
+	"notify.arrived":  "%s is here (%s)",
+
+	"mail.subject":      "countdown: %s in the next %d days",
+	"mail.events.one":   "%d event",
+	"mail.events.other": "%d events",
+	"mail.today":        "Today",
+	"mail.week":         "This week",
+	"mail.later":        "Later",
+
+	"help.add":           "add",
+	"help.remove":        "remove",
+	"help.edit":          "edit",
+	"help.back":          "back",
+	"help.tags":          "tags",
+	"help.days":          "days / gaps",
+	"help.clock":         "12/24h",
+	"help.seconds":       "seconds",
+	"help.goto":          "go to date",
+	"help.what_if":       "what if",
+	"help.next_upcoming": "next upcoming",
+	"help.last_passed":   "last passed",
+	"help.move_up":       "move up",
+	"help.move_down":     "move down",
+	"help.compare":       "compare",
+	"help.share":         "share",
+	"help.snapshot":      "save view",
+	"help.mark":          "mark",
+	"help.assign_group":  "next group",
+	"help.info":          "info",
+	"help.stats":         "stats",
+	"help.imminent":      "next 24h",
+	"help.dedupe":        "duplicates",
+	"help.rename":        "rename",
+	"help.next_panel":    "next panel",
+	"help.prev_panel":    "prev panel",
+	"help.side_panel":    "side panel",
+	"help.reload":        "reload",
+	"help.retry":         "retry",
+	"help.open":          "open",
+	"help.era":           "era",
+	"help.order":         "order",
+	"help.feed":          "births/deaths",
+	"help.quit":          "quit",
+	"help.up":            "up",
+	"help.down":          "down",
+	"help.prev_page":     "prev page",
+	"help.next_page":     "next page",
+	"help.go_to_start":   "go to start",
+	"help.go_to_end":     "go to end",
+	"help.filter":        "filter",
+	"help.clear_filter":  "clear filter",
+	"help.cancel":        "cancel",
+	"help.apply_filter":  "apply filter",
+	"help.more":          "more",
+	"help.close_help":    "close help",
+}
+
+// pluralOne reports whether n takes the singular form. Languages not listed
+// use the English rule.
+var pluralOne = map[string]func(n int) bool{
+	"fr": func(n int) bool { return n == 0 || n == 1 },
+}
+
+type catalog struct {
+	lang     string
+	messages map[string]string
+}
+
+var activeCatalog = catalog{lang: "en", messages: englishMessages}
+
+func tr(id string) string {
+	if s, ok := activeCatalog.messages[id]; ok {
+		return s
+	}
+	if s, ok := englishMessages[id]; ok {
+		return s
+	}
+	return id
+}
+
+func trf(id string, args ...interface{}) string {
+	return fmt.Sprintf(tr(id), args...)
+}
+
+// trn formats n with the singular or plural form of id.
+func trn(id string, n int) string {
+	one, ok := pluralOne[activeCatalog.lang]
+	if !ok {
+		one = func(n int) bool { return n == 1 }
+	}
+	if one(n) {
+		return trf(id+".one", n)
+	}
+	return trf(id+".other", n)
+}
+
+// ordinal writes n as an ordinal number, "3rd" in English and "3." in
+// most other languages.
+func ordinal(n int) string {
+	if activeCatalog.lang == "en" {
+		return fmt.Sprintf("%d%s", n, ordinalSuffix(n))
+	}
+	return trf("rank.ordinal", n)
+}
+
+// detectLanguage picks the configured language, or else the one from the
+// locale environment, reduced to its language code ("de_DE.UTF-8" is "de").
+func detectLanguage(configured string) string {
+	lang := configured
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang != "" {
+			break
+		}
+		lang = os.Getenv(env)
+	}
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.ToLower(lang)
+	if lang == "" || lang == "c" || lang == "posix" {
+		return "en"
+	}
+	return lang
+}
+
+// loadCatalog reads the translation for lang from the user's locales
+// directory, falling back to the translations built into the binary. A
+// language with no translation is not an error; its strings stay English.
+func loadCatalog(lang string) (catalog, error) {
+	cat := catalog{lang: lang, messages: englishMessages}
+	if lang == "en" {
+		return cat, nil
+	}
+
+	if configFile, err := getConfigFilePath(); err == nil {
+		dir := filepath.Join(filepath.Dir(configFile), "locales")
+		for _, ext := range []string{".toml", ".json"} {
+			data, err := os.ReadFile(filepath.Join(dir, lang+ext))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return cat, err
+			}
+			messages, err := parseCatalog(data, ext)
+			if err != nil {
+				return cat, fmt.Errorf("failed to parse %s%s translation: %w", lang, ext, err)
+			}
+			cat.messages = messages
+			return cat, nil
+		}
+	}
+
+	data, err := fs.ReadFile(bundledLocales, "locales/"+lang+".toml")
+	if err != nil {
+		return cat, nil
+	}
+	messages, err := parseCatalog(data, ".toml")
+	if err != nil {
+		return cat, fmt.Errorf("failed to parse built-in %s translation: %w", lang, err)
+	}
+	cat.messages = messages
+	return cat, nil
+}
+
+// parseCatalog reads a translation file. Nested tables or objects are
+// flattened into dotted IDs, so [detail] time_until = "…" is detail.time_until.
+func parseCatalog(data []byte, ext string) (map[string]string, error) {
+	var raw map[string]interface{}
+	var err error
+	if ext == ".json" {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		err = toml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	messages := map[string]string{}
+	var flatten func(prefix string, m map[string]interface{}) error
+	flatten = func(prefix string, m map[string]interface{}) error {
+		for k, v := range m {
+			switch v := v.(type) {
+			case string:
+				messages[prefix+k] = v
+			case map[string]interface{}:
+				if err := flatten(prefix+k+".", v); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("%s%s: expected a string", prefix, k)
+			}
+		}
+		return nil
+	}
+	if err := flatten("", raw); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// setLanguage activates the catalog for the configured or environment
+// language. On error it warns and keeps English.
+func setLanguage(configured string) {
+	cat, err := loadCatalog(detectLanguage(configured))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, using English\n", err)
+	}
+	activeCatalog = cat
+	localizeKeymap()
+}
+
+func localizeKeymap() {
+	Keymap.Add.SetHelp("+", tr("help.add"))
+	Keymap.Remove.SetHelp("-", tr("help.remove"))
+	Keymap.Edit.SetHelp("e", tr("help.edit"))
+	Keymap.Back.SetHelp("esc", tr("help.back"))
+	Keymap.Tags.SetHelp("t", tr("help.tags"))
+	Keymap.Display.SetHelp("D", tr("help.days"))
+	Keymap.Clock.SetHelp("H", tr("help.clock"))
+	Keymap.Seconds.SetHelp(".", tr("help.seconds"))
+	Keymap.GoTo.SetHelp("G", tr("help.goto"))
+	Keymap.WhatIf.SetHelp("W", tr("help.what_if"))
+	Keymap.NextUpcoming.SetHelp("n", tr("help.next_upcoming"))
+	Keymap.LastPassed.SetHelp("N/P", tr("help.last_passed"))
+	Keymap.MoveUp.SetHelp("ctrl+↑", tr("help.move_up"))
+	Keymap.MoveDown.SetHelp("ctrl+↓", tr("help.move_down"))
+	Keymap.Compare.SetHelp("v", tr("help.compare"))
+	Keymap.Share.SetHelp("s", tr("help.share"))
+	Keymap.Snapshot.SetHelp("ctrl+p", tr("help.snapshot"))
+	Keymap.Mark.SetHelp("m", tr("help.mark"))
+	Keymap.AssignGroup.SetHelp("A", tr("help.assign_group"))
+	Keymap.Info.SetHelp("i", tr("help.info"))
+	Keymap.Stats.SetHelp("S", tr("help.stats"))
+	Keymap.Imminent.SetHelp("T", tr("help.imminent"))
+	Keymap.Dedupe.SetHelp("M", tr("help.dedupe"))
+	Keymap.RenameTag.SetHelp("r", tr("help.rename"))
+	Keymap.NextPanel.SetHelp("ctrl+l", tr("help.next_panel"))
+	Keymap.PrevPanel.SetHelp("ctrl+h", tr("help.prev_panel"))
+	Keymap.SidePanel.SetHelp("p", tr("help.side_panel"))
+	Keymap.Reload.SetHelp("r", tr("help.reload"))
+	Keymap.Retry.SetHelp("R", tr("help.retry"))
+	Keymap.ScrollUp.SetHelp("↑/k", tr("help.up"))
+	Keymap.ScrollDown.SetHelp("↓/j", tr("help.down"))
+	Keymap.Open.SetHelp("o", tr("help.open"))
+	Keymap.Era.SetHelp("f", tr("help.era"))
+	Keymap.Order.SetHelp("O", tr("help.order"))
+	Keymap.Feed.SetHelp("b", tr("help.feed"))
+	Keymap.Quit.SetHelp("q", tr("help.quit"))
+}
+
+// localizeList translates the built-in help of a bubbles list.
+func localizeList(l *list.Model) {
+	k := &l.KeyMap
+	k.CursorUp.SetHelp("↑/k", tr("help.up"))
+	k.CursorDown.SetHelp("↓/j", tr("help.down"))
+	k.PrevPage.SetHelp("←/h/pgup", tr("help.prev_page"))
+	k.NextPage.SetHelp("→/l/pgdn", tr("help.next_page"))
+	k.GoToStart.SetHelp("g/home", tr("help.go_to_start"))
+	k.GoToEnd.SetHelp("G/end", tr("help.go_to_end"))
+	k.Filter.SetHelp("/", tr("help.filter"))
+	k.ClearFilter.SetHelp("esc", tr("help.clear_filter"))
+	k.CancelWhileFiltering.SetHelp("esc", tr("help.cancel"))
+	k.AcceptWhileFiltering.SetHelp("enter", tr("help.apply_filter"))
+	k.ShowFullHelp.SetHelp("?", tr("help.more"))
+	k.CloseFullHelp.SetHelp("?", tr("help.close_help"))
+	k.Quit.SetHelp("q", tr("help.quit"))
+	l.SetStatusBarItemName(tr("list.item"), tr("list.items"))
+}
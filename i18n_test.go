@@ -0,0 +1,144 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func useLanguage(t *testing.T, lang string) {
+	cat, err := loadCatalog(lang)
+	if err != nil {
+		t.Fatalf("Failed to load %s catalog: %v", lang, err)
+	}
+	saved := activeCatalog
+	activeCatalog = cat
+	t.Cleanup(func() { activeCatalog = saved })
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		env        map[string]string
+		expected   string
+	}{
+		{"Config wins", "de", map[string]string{"LANG": "fr_FR.UTF-8"}, "de"},
+		{"LANG", "", map[string]string{"LANG": "de_DE.UTF-8"}, "de"},
+		{"LC_ALL over LANG", "", map[string]string{"LC_ALL": "fr_CA", "LANG": "de_DE"}, "fr"},
+		{"C locale", "", map[string]string{"LANG": "C.UTF-8"}, "en"},
+		{"Nothing set", "", nil, "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(env, tt.env[env])
+			}
+			if got := detectLanguage(tt.configured); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlurals(t *testing.T) {
+	if got := trn("countdown.left", 1); got != "1 day left" {
+		t.Errorf("Expected '1 day left', got '%s'", got)
+	}
+	if got := trn("countdown.left", 2); got != "2 days left" {
+		t.Errorf("Expected '2 days left', got '%s'", got)
+	}
+
+	useLanguage(t, "de")
+	if got := trn("countdown.since", 1); got != "vor 1 Tag" {
+		t.Errorf("Expected 'vor 1 Tag', got '%s'", got)
+	}
+	if got := trn("countdown.since", 3); got != "vor 3 Tagen" {
+		t.Errorf("Expected 'vor 3 Tagen', got '%s'", got)
+	}
+}
+
+var formatVerb = regexp.MustCompile(`%[0-9]*[a-z%]`)
+
+// TestBundledTranslations keeps shipped catalogs in step with English: every
+// ID must exist there and take the same format arguments.
+func TestBundledTranslations(t *testing.T) {
+	files, err := fs.Glob(bundledLocales, "locales/*.toml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Expected bundled translations, got %v (%v)", files, err)
+	}
+	for _, file := range files {
+		data, _ := fs.ReadFile(bundledLocales, file)
+		messages, err := parseCatalog(data, ".toml")
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for id, msg := range messages {
+			english, ok := englishMessages[id]
+			if !ok {
+				t.Errorf("%s: unknown message ID %q", file, id)
+				continue
+			}
+			want := strings.Join(formatVerb.FindAllString(english, -1), " ")
+			if got := strings.Join(formatVerb.FindAllString(msg, -1), " "); got != want {
+				t.Errorf("%s: %q has verbs %q, English has %q", file, id, got, want)
+			}
+		}
+		for id := range englishMessages {
+			if _, ok := messages[id]; !ok {
+				t.Errorf("%s: missing translation for %q", file, id)
+			}
+		}
+	}
+}
+
+func TestUserTranslationOverridesBundled(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		t.Fatalf("Failed to get config file path: %v", err)
+	}
+	dir := filepath.Join(filepath.Dir(configFile), "locales")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create locales directory: %v", err)
+	}
+	json := `{"detail": {"statistics": "📊 Statistiques"}, "countdown": {"today": "aujourd'hui !"}}`
+	if err := os.WriteFile(filepath.Join(dir, "fr.json"), []byte(json), 0644); err != nil {
+		t.Fatalf("Failed to write translation: %v", err)
+	}
+
+	useLanguage(t, "fr")
+	if got := tr("detail.statistics"); got != "📊 Statistiques" {
+		t.Errorf("Expected translated string, got '%s'", got)
+	}
+	if got := tr("detail.time_until"); got != "⏳ Time Until" {
+		t.Errorf("Expected English fallback, got '%s'", got)
+	}
+	if got := trn("countdown.left", 0); got != "0 day left" {
+		t.Errorf("Expected French singular for 0, got '%s'", got)
+	}
+}
+
+func TestGermanDetailView(t *testing.T) {
+	withFixedLocal(t)
+	t.Cleanup(localizeKeymap)
+	useLanguage(t, "de")
+	localizeKeymap()
+
+	m := MainModel{detailWidth: 50, config: defaultConfig()}
+	out := stripANSI(m.renderDetails(Event{Name: "Launch", Time: 4102444800}))
+	for _, want := range []string{"⏳ Zeit bis", "Tagesfortschritt", "📊 Statistik", "Sekunden:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in German detail view, got:\n%s", want, out)
+		}
+	}
+	if Keymap.Add.Help().Desc != "neu" {
+		t.Errorf("Expected German key help, got '%s'", Keymap.Add.Help().Desc)
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unfoldLines reads the content lines of a vCard or iCalendar file, joining
+// folded lines, which continue on the next line after a space or tab.
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitContentLine splits "item1.NAME;PARAM=x:value" into the upper-case
+// property name without its group, the parameters and the value.
+func splitContentLine(line string) (name string, params map[string]string, value string, ok bool) {
+	sep := strings.Index(line, ":")
+	if sep < 0 {
+		return "", nil, "", false
+	}
+	parts := strings.Split(line[:sep], ";")
+	name = strings.ToUpper(parts[0])
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:] // drop group prefixes like "item1."
+	}
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return name, params, strings.TrimSpace(line[sep+1:]), true
+}
+
+// icsEvent is what countdown keeps of a VEVENT.
+type icsEvent struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	AllDay  bool
+	RRule   string
+}
+
+// icsCalendar is one iCalendar file: its name, if it gives one, and its
+// events.
+type icsCalendar struct {
+	Name   string
+	Events []icsEvent
+}
+
+// parseICS reads the events of an iCalendar file. Cancelled events and the
+// changed instances of recurring ones, which repeat the series' UID, are
+// left out. An event whose start cannot be read fails the whole file, so
+// that nothing is imported from a file read wrong.
+func parseICS(r io.Reader) (icsCalendar, error) {
+	var cal icsCalendar
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return cal, err
+	}
+
+	found := false
+	var event *icsEvent
+	skip := false
+	for n, line := range lines {
+		name, params, value, ok := splitContentLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			found = true
+		case name == "X-WR-CALNAME" && event == nil:
+			cal.Name = unescapeICSText(value)
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event, skip = &icsEvent{}, false
+		case event == nil:
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if !skip && !event.Start.IsZero() {
+				cal.Events = append(cal.Events, *event)
+			}
+			event = nil
+		case name == "UID":
+			event.UID = value
+		case name == "SUMMARY":
+			event.Summary = unescapeICSText(value)
+		case name == "RRULE":
+			event.RRule = value
+		case name == "RECURRENCE-ID":
+			skip = true
+		case name == "STATUS" && strings.EqualFold(value, "CANCELLED"):
+			skip = true
+		case name == "DTSTART":
+			event.Start, event.AllDay, err = parseICSTime(value, params)
+			if err != nil {
+				return cal, fmt.Errorf("line %d: %w", n+1, err)
+			}
+		}
+	}
+	if !found {
+		return cal, fmt.Errorf("not an iCalendar file")
+	}
+	return cal, nil
+}
+
+// parseICSTime reads a DATE-TIME in UTC ("20260315T180000Z"), in the zone
+// its TZID names, or floating in local time, or a DATE, which starts at
+// local midnight.
+func parseICSTime(value string, params map[string]string) (t time.Time, allDay bool, err error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, time.Local)
+		if err != nil {
+			return t, false, fmt.Errorf("bad date %q", value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+	} else {
+		loc := time.Local
+		if tzid := params["TZID"]; tzid != "" {
+			if l, lerr := time.LoadLocation(tzid); lerr == nil {
+				loc = l
+			}
+		}
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return t, false, fmt.Errorf("bad date-time %q", value)
+	}
+	return t, false, nil
+}
+
+func unescapeICSText(s string) string {
+	r := strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`)
+	return strings.TrimSpace(r.Replace(s))
+}
+
+// icsRule is a simple RRULE: a frequency and interval, bounded by UNTIL or
+// COUNT or not at all.
+type icsRule struct {
+	Event Event // the frequency and interval as countdown repeats events
+	Until time.Time
+	Count int
+}
+
+// parseRRule reads rules countdown can follow. Rules that pick days with
+// BYDAY, BYMONTHDAY and the like, beyond the start's own, are not simple
+// and give false.
+func parseRRule(rule string, start time.Time) (icsRule, bool) {
+	r := icsRule{Event: Event{Time: start.Unix()}}
+	every := 1
+	for _, part := range strings.Split(rule, ";") {
+		k, v, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			switch strings.ToUpper(v) {
+			case "YEARLY":
+				r.Event.Yearly = true
+			case "MONTHLY":
+				r.Event.Repeat = &Recurrence{Unit: repeatMonthly, Day: start.Day()}
+			case "WEEKLY":
+				r.Event.Repeat = &Recurrence{Unit: repeatWeekly}
+			case "DAILY":
+				r.Event.Repeat = &Recurrence{Unit: repeatDaily}
+			default:
+				return r, false
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return r, false
+			}
+			every = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return r, false
+			}
+			r.Count = n
+		case "UNTIL":
+			until, _, err := parseICSTime(v, nil)
+			if err != nil {
+				return r, false
+			}
+			r.Until = until
+		case "WKST":
+		default:
+			return r, false
+		}
+	}
+	switch {
+	case r.Event.Repeat != nil:
+		r.Event.Repeat.Every = every
+	case r.Event.Yearly && every != 1:
+		// countdown repeats yearly events every year only.
+		return r, false
+	case !r.Event.Yearly:
+		return r, false
+	}
+	return r, true
+}
+
+// next returns the first occurrence after now, and false once the rule has
+// run out.
+func (r icsRule) next(now time.Time) (time.Time, bool) {
+	t := time.Unix(r.Event.Time, 0).In(now.Location())
+	if r.Count > 0 {
+		// Step through the occurrences to count them.
+		e := r.Event
+		for i := 1; !t.After(now); i++ {
+			if i >= r.Count {
+				return t, false
+			}
+			t = nextOccurrence(e, t)
+			e.Time = t.Unix()
+		}
+	} else if !t.After(now) {
+		t = nextOccurrence(r.Event, now)
+	}
+	if !r.Until.IsZero() && t.After(r.Until) {
+		return t, false
+	}
+	return t, true
+}
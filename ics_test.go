@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"X-WR-CALNAME:Personal\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Europe/Berlin\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:19701025T030000\r\n" +
+	"END:STANDARD\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20260315T180000Z\r\n" +
+	"UID:launch@google.com\r\n" +
+	"SUMMARY:Product\\, launch\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20260401T090000\r\n" +
+	"UID:standup@google.com\r\n" +
+	"RRULE:FREQ=WEEKLY;INTERVAL=2\r\n" +
+	"SUMMARY:Plan\r\n" +
+	" ning\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"TRIGGER:-PT10M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260720\r\n" +
+	"UID:holiday@google.com\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20260415T090000\r\n" +
+	"UID:standup@google.com\r\n" +
+	"RECURRENCE-ID;TZID=Europe/Berlin:20260415T090000\r\n" +
+	"SUMMARY:Planning (moved)\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20260501T100000Z\r\n" +
+	"UID:cancelled@google.com\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"SUMMARY:Called off\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	cal, err := parseICS(strings.NewReader(testICS))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cal.Name != "Personal" {
+		t.Errorf("Expected the calendar name, got %q", cal.Name)
+	}
+	if len(cal.Events) != 3 {
+		t.Fatalf("Expected 3 events without the moved instance and the cancelled one, got %+v", cal.Events)
+	}
+
+	launch := cal.Events[0]
+	if launch.Summary != "Product, launch" || !launch.Start.Equal(time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)) || launch.UID != "launch@google.com" {
+		t.Errorf("Unexpected first event: %+v", launch)
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	planning := cal.Events[1]
+	if planning.Summary != "Planning" || !planning.Start.Equal(time.Date(2026, 4, 1, 9, 0, 0, 0, berlin)) || planning.RRule != "FREQ=WEEKLY;INTERVAL=2" {
+		t.Errorf("Expected the folded summary, zone and rule read, got %+v", planning)
+	}
+	holiday := cal.Events[2]
+	if !holiday.AllDay || !holiday.Start.Equal(time.Date(2026, 7, 20, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected an all-day event at local midnight, got %+v", holiday)
+	}
+
+	if _, err := parseICS(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:2026-03-15\r\nEND:VEVENT\r\n")); err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("Expected a bad start to fail with its line, got %v", err)
+	}
+	if _, err := parseICS(strings.NewReader("BEGIN:VCARD\r\nFN:Ada\r\nEND:VCARD\r\n")); err == nil {
+		t.Error("Expected a vCard to be refused")
+	}
+}
+
+func TestRRuleNext(t *testing.T) {
+	start := time.Date(2020, 3, 10, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule     string
+		expected time.Time // zero when the rule has run out or is not simple
+	}{
+		{"FREQ=YEARLY", time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{"FREQ=MONTHLY;INTERVAL=2", time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{"FREQ=DAILY;WKST=MO", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"FREQ=YEARLY;COUNT=10", time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{"FREQ=YEARLY;COUNT=3", time.Time{}},
+		{"FREQ=WEEKLY;UNTIL=20251231T000000Z", time.Time{}},
+		{"FREQ=WEEKLY;BYDAY=MO,WE", time.Time{}},
+		{"FREQ=YEARLY;INTERVAL=4", time.Time{}},
+		{"FREQ=HOURLY", time.Time{}},
+	}
+	for _, tt := range tests {
+		var got time.Time
+		if rule, ok := parseRRule(tt.rule, start); ok {
+			if next, ok := rule.next(now); ok {
+				got = next
+			}
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("%s: expected %s, got %s", tt.rule, tt.expected, got)
+		}
+	}
+}
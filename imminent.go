@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The imminent view lists what happens within imminentAhead, and what
+// started within imminentBehind so it does not vanish the moment it begins.
+// Its bars fill up over imminentAhead.
+const (
+	imminentAhead        = 24 * time.Hour
+	imminentBehind       = 2 * time.Hour
+	imminentHintLifetime = 10 * time.Second
+)
+
+// imminentEvents returns the events from imminentBehind ago to imminentAhead
+// from now, soonest first.
+func imminentEvents(events []Event, now time.Time) []Event {
+	from, to := now.Add(-imminentBehind).Unix(), now.Add(imminentAhead).Unix()
+	var near []Event
+	for _, e := range events {
+		if e.Time >= from && e.Time <= to {
+			near = append(near, e)
+		}
+	}
+	sortEventsByTime(near)
+	return near
+}
+
+// suggestImminent points to the imminent view in the list's status bar when
+// a saved event first comes within a day. Computed events such as sunsets
+// are always that close, so they do not count.
+func (m *MainModel) suggestImminent(now time.Time) tea.Cmd {
+	if !hasUpcomingImminent(m.storedEvents(), now) {
+		m.imminentSuggested = false
+		return nil
+	}
+	if m.imminentSuggested || m.state != showEvents {
+		return nil
+	}
+	m.imminentSuggested = true
+	return m.longStatus(HintStyle(tr("imminent.hint")), imminentHintLifetime)
+}
+
+// hasUpcomingImminent reports whether any of events is due within a day
+// and still to come.
+func hasUpcomingImminent(events []Event, now time.Time) bool {
+	_, ok := nextEvent(events, now, nextOptions{Horizon: imminentAhead})
+	return ok
+}
+
+// longStatus shows s in the list's status bar for lifetime rather than the
+// list's usual moment.
+func (m *MainModel) longStatus(s string, lifetime time.Duration) tea.Cmd {
+	usual := m.events.StatusMessageLifetime
+	m.events.StatusMessageLifetime = lifetime
+	defer func() { m.events.StatusMessageLifetime = usual }()
+	return m.events.NewStatusMessage(s)
+}
+
+func (m MainModel) imminentView() string {
+	now := m.now()
+	width := min(56, m.windowWidth-8)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("imminent.title")) + "\n\n")
+
+	all := m.allEvents()
+	events := imminentEvents(all, now)
+	if len(events) == 0 {
+		b.WriteString(NormalTextStyle(tr("imminent.none")) + "\n")
+		if e, ok := nextEvent(all, now, nextOptions{Virtual: true}); ok {
+			b.WriteString(HintStyle(trf("imminent.next", e.Name, formatRelative(time.Unix(e.Time, 0), now))) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	// Bars share a width, whatever the length of the time after them.
+	atWidth := 0
+	for _, e := range events {
+		atWidth = max(atWidth, ansi.StringWidth(time.Unix(e.Time, 0).Format(m.config.timeLayout())))
+	}
+	for _, e := range events {
+		color := getUrgencyColor(e.Time, now)
+		countdown := formatCountdownSeconds(e.Time, now, m.config.countdownFormat().showSeconds(e.Time, now))
+		name := ansi.Truncate(e.Title(), width-ansi.StringWidth(countdown)-2, "…")
+		gap := width - ansi.StringWidth(name) - ansi.StringWidth(countdown)
+		b.WriteString(BrightTextStyle(name) + strings.Repeat(" ", max(gap, 1)) +
+			lipgloss.NewStyle().Foreground(paletteColor(color)).Bold(true).Render(countdown) + "\n")
+
+		at := time.Unix(e.Time, 0).Format(m.config.timeLayout())
+		left := time.Unix(e.Time, 0).Sub(now)
+		bar := renderProgressBar(float64(imminentAhead-left), float64(imminentAhead), width-atWidth-1, color)
+		b.WriteString(bar + " " + NormalTextStyle(fmt.Sprintf("%*s", atWidth, at)) + "\n\n")
+	}
+
+	b.WriteString(HintStyle(tr("imminent.close_hint")))
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestImminentEvents(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return now.Add(d).Unix() }
+	events := []Event{
+		{Name: "Tomorrow evening", Time: at(30 * time.Hour)},
+		{Name: "Flight", Time: at(24 * time.Hour)},
+		{Name: "Dentist", Time: at(3 * time.Hour)},
+		{Name: "Lunch", Time: at(-2 * time.Hour)},
+		{Name: "Breakfast", Time: at(-3 * time.Hour)},
+	}
+	if got := storedNames(imminentEvents(events, now)); got != "Lunch,Dentist,Flight" {
+		t.Errorf("Expected the window from 2h ago to 24h ahead, got %s", got)
+	}
+}
+
+func TestImminentView(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Dentist", Time: now.Add(3*time.Hour + 20*time.Minute).Unix()},
+		Event{Name: "Later", Time: now.AddDate(0, 0, 3).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if m.state != showImminent {
+		t.Fatalf("Expected T to open the imminent view, got state %v", m.state)
+	}
+	view := stripANSI(m.View())
+	for _, want := range []string{"Next 24 Hours", "Dentist", "3h 20m 0s", "3:20:00 PM"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Later") {
+		t.Errorf("Expected only the next 24 hours:\n%s", view)
+	}
+
+	now = now.Add(6 * time.Hour)
+	view = stripANSI(m.View())
+	if !strings.Contains(view, "Nothing in the next 24 hours") || !strings.Contains(view, "Next up: 'Later' in 2 days") {
+		t.Errorf("Expected the empty state to name the next event:\n%s", view)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.state != showEvents {
+		t.Errorf("Expected any key to close the view, got state %v", m.state)
+	}
+}
+
+func TestSuggestImminent(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now, Event{Name: "Flight", Time: now.Add(26 * time.Hour).Unix()})
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = model.(MainModel)
+	if m.suggestImminent(now) != nil {
+		t.Error("Expected no hint more than a day ahead")
+	}
+	now = now.Add(3 * time.Hour)
+	if m.suggestImminent(now) == nil {
+		t.Fatal("Expected a hint once the event is within a day")
+	}
+	if got := stripANSI(m.events.View()); !strings.Contains(got, "⏰ T: 24h") {
+		t.Errorf("Expected the hint in the status bar:\n%s", got)
+	}
+	if m.events.StatusMessageLifetime == imminentHintLifetime {
+		t.Error("Expected the list's message lifetime restored")
+	}
+	if m.suggestImminent(now) != nil {
+		t.Error("Expected the hint only once")
+	}
+}
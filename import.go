@@ -0,0 +1,230 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type importStats struct {
+	Added      int
+	Updated    int
+	Duplicates int
+	Skipped    int
+}
+
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	vcfPath := fs.String("vcf", "", "import birthdays from a .vcf file or a directory of them")
+	todoPath := fs.String("todo-txt", "", "import tasks with a due: tag from a todo.txt file")
+	taskwarrior := fs.Bool("taskwarrior", false, "import pending tasks with a due date from `task export`")
+	share := fs.String("share", "", "add the event from a share link or payload")
+	takeout := fs.String("takeout", "", "import events from a Google Takeout calendar export (.zip)")
+	calendar := fs.String("calendar", "", "the calendar in the --takeout export to import; omit to list them")
+	years := fs.Int("years", 2, "import --takeout events up to this many years ahead")
+	readOnly := fs.Bool("read-only", false, "protect imported tasks from edit and remove, since the next import would undo changes")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *vcfPath == "" && *todoPath == "" && !*taskwarrior && *share == "" && *takeout == "" {
+		fmt.Fprintln(os.Stderr, "import: nothing to import")
+		fs.Usage()
+		return 2
+	}
+	if *years < 1 {
+		fmt.Fprintln(os.Stderr, "import: --years must be at least 1")
+		return 2
+	}
+
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+
+	changed := false
+	if *takeout != "" {
+		cals, err := readTakeout(*takeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		if *calendar == "" {
+			fmt.Printf("Calendars in %s:\n", *takeout)
+			for _, c := range cals {
+				fmt.Printf("  %s (%d events)\n", c.Name(), len(c.Cal.Events))
+			}
+			fmt.Println("Pick one with --calendar")
+			return 0
+		}
+		c, ok := findTakeoutCalendar(cals, *calendar)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "import: no calendar named %q in %s\n", *calendar, *takeout)
+			return 1
+		}
+		if c.Err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", c.Err)
+			return 1
+		}
+		fmt.Printf("Reading %d events from %s\n", len(c.Cal.Events), c.Name())
+		incoming, skipped := takeoutEvents(c.Cal, time.Now(), *years)
+		setReadOnly(incoming, *readOnly)
+		var stats importStats
+		events, stats = mergeBySource(events, incoming)
+		stats.Skipped = skipped
+		changed = changed || stats.Added > 0 || stats.Updated > 0
+		fmt.Printf("%s: imported %d events, updated %d, %d unchanged, skipped %d past, beyond %d years or repeating irregularly\n",
+			c.Name(), stats.Added, stats.Updated, stats.Duplicates, stats.Skipped, *years)
+	}
+
+	if *vcfPath != "" {
+		cards, err := readVCardPath(*vcfPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		var stats importStats
+		events, stats = mergeBirthdays(events, cards, time.Now())
+		changed = changed || stats.Added > 0
+		fmt.Printf("Imported %d birthdays, skipped %d duplicates and %d cards without a birthday\n",
+			stats.Added, stats.Duplicates, stats.Skipped)
+	}
+
+	if *todoPath != "" {
+		f, err := os.Open(*todoPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		tasks, skipped, err := parseTodoTxt(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		setReadOnly(tasks, *readOnly)
+		var stats importStats
+		events, stats = mergeBySource(events, tasks)
+		stats.Skipped = skipped
+		changed = changed || stats.Added > 0 || stats.Updated > 0
+		printTaskImportStats("todo.txt", stats)
+	}
+
+	if *taskwarrior {
+		data, err := runTaskwarriorExport()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		tasks, skipped, err := parseTaskwarriorExport(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		setReadOnly(tasks, *readOnly)
+		var stats importStats
+		events, stats = mergeBySource(events, tasks)
+		stats.Skipped = skipped
+		changed = changed || stats.Added > 0 || stats.Updated > 0
+		printTaskImportStats("taskwarrior", stats)
+	}
+
+	if *share != "" {
+		e, err := decodeShare(*share)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+		if hasSharedEvent(events, e) {
+			fmt.Printf("Already have %q\n", e.Name)
+		} else {
+			e.Created = time.Now().Unix()
+			events = append(events, e)
+			sortEventsByTime(events)
+			changed = true
+			fmt.Printf("Imported %q at %s\n", e.Name, time.Unix(e.Time, 0).Format(inputTimeFormLong))
+		}
+	}
+
+	if changed {
+		if err := writeEventsFile(events); err != nil {
+			fmt.Fprintf(os.Stderr, "import: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+func printTaskImportStats(source string, stats importStats) {
+	fmt.Printf("%s: imported %d tasks, updated %d, %d unchanged, skipped %d completed or undated\n",
+		source, stats.Added, stats.Updated, stats.Duplicates, stats.Skipped)
+}
+
+func readVCardPath(path string) ([]vCard, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".vcf") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	var cards []vCard
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseVCards(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		cards = append(cards, parsed...)
+	}
+	return cards, nil
+}
+
+// mergeBirthdays adds a birthday event for every card that has a name and a
+// birthday, skipping names already present in events.
+func mergeBirthdays(events []Event, cards []vCard, now time.Time) ([]Event, importStats) {
+	var stats importStats
+
+	seen := make(map[string]bool, len(events))
+	for _, e := range events {
+		seen[strings.ToLower(e.Name)] = true
+	}
+
+	for _, card := range cards {
+		if card.Name == "" || !card.HasBDay {
+			stats.Skipped++
+			continue
+		}
+		event := card.birthdayEvent(now)
+		key := strings.ToLower(event.Name)
+		if seen[key] {
+			stats.Duplicates++
+			continue
+		}
+		seen[key] = true
+		events = append(events, event)
+		stats.Added++
+	}
+
+	sortEventsByTime(events)
+	return events, stats
+}
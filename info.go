@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// version is set at build time with -ldflags "-X main.version=v1.2.3".
+var version = "dev"
+
+// buildVersion returns the version with the commit it was built from, when
+// the Go toolchain recorded one.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return version
+	}
+	v := version
+	if v == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		v = info.Main.Version
+	}
+	var commit []string
+	for _, s := range info.Settings {
+		switch {
+		case s.Key == "vcs.revision" && len(s.Value) > 12:
+			commit = append([]string{s.Value[:12]}, commit...)
+		case s.Key == "vcs.revision":
+			commit = append([]string{s.Value}, commit...)
+		case s.Key == "vcs.modified" && s.Value == "true":
+			commit = append(commit, "modified")
+		}
+	}
+	if len(commit) > 0 {
+		v += " (" + strings.Join(commit, ", ") + ")"
+	}
+	return v
+}
+
+// infoRow is one line of the info screen.
+type infoRow struct {
+	Label string
+	Value string
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// configStatus reads the config file again to say whether it loads cleanly
+// now. Unlike loadConfig it prints nothing, since the screen is in use.
+func configStatus(configFile string) string {
+	cfg := defaultConfig()
+	if _, err := toml.DecodeFile(configFile, &cfg); errors.Is(err, os.ErrNotExist) {
+		return tr("info.config_missing")
+	} else if err != nil {
+		return trf("info.config_error", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return trf("info.config_error", err)
+	}
+	if _, ok := resolveLayout(cfg.DateFormat, datePresets); !ok {
+		return trf("info.config_fallback", "date_format")
+	}
+	if _, ok := resolveLayout(cfg.TimeFormat, timePresets); !ok {
+		return trf("info.config_fallback", "time_format")
+	}
+	return tr("info.config_ok")
+}
+
+// gatherInfo collects what the info screen shows. It touches the disk, so
+// it runs when the screen opens rather than at startup.
+func (m MainModel) gatherInfo() []infoRow {
+	rows := []infoRow{{tr("info.version"), buildVersion()}}
+
+	if eventsFile, err := getEventsFilePath(); err != nil {
+		rows = append(rows, infoRow{tr("info.events_file"), err.Error()})
+	} else {
+		rows = append(rows, infoRow{tr("info.events_file"), eventsFile})
+		if st, err := os.Stat(eventsFile); err != nil {
+			rows = append(rows, infoRow{"", tr("info.file_missing")})
+		} else {
+			rows = append(rows, infoRow{"", trf("info.file_stat", formatSize(st.Size()), st.ModTime().Format(m.config.dateTimeLayout()))})
+		}
+	}
+	if stateFile, err := getStateFilePath(); err == nil {
+		rows = append(rows, infoRow{tr("info.state_file"), stateFile})
+	}
+	saved := 0
+	for _, e := range m.allEvents() {
+		if !e.Virtual {
+			saved++
+		}
+	}
+	rows = append(rows, infoRow{tr("info.events"), trn("info.events_count", saved)})
+	rows = append(rows, infoRow{tr("info.profile"), tr("info.no_profiles")})
+
+	if configFile, err := getConfigFilePath(); err != nil {
+		rows = append(rows, infoRow{tr("info.config_file"), err.Error()})
+	} else {
+		rows = append(rows, infoRow{tr("info.config_file"), configFile})
+		rows = append(rows, infoRow{"", configStatus(configFile)})
+	}
+
+	w := m.wiki()
+	wiki := trn("info.wiki_loaded", len(w.events))
+	switch {
+	case w.loading:
+		wiki = tr("info.wiki_loading")
+	case w.date == "":
+		wiki = tr("info.wiki_not_loaded")
+	case w.err != nil:
+		wiki = trf("info.wiki_failed", w.err)
+	}
+	rows = append(rows, infoRow{tr("info.wiki"), wiki})
+	return rows
+}
+
+func (m MainModel) infoView() string {
+	labelWidth := 0
+	for _, row := range m.info {
+		labelWidth = max(labelWidth, lipgloss.Width(row.Label))
+	}
+	valueWidth := max(30, m.windowWidth-labelWidth-12)
+	if valueWidth > 70 {
+		valueWidth = 70
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(labelWidth+2+valueWidth).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("info.title")) + "\n\n")
+	labelStyle := lipgloss.NewStyle().Width(labelWidth + 2)
+	valueStyle := lipgloss.NewStyle().Width(valueWidth)
+	for _, row := range m.info {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			labelStyle.Render(NormalTextStyle(row.Label)),
+			valueStyle.Render(BrightTextStyle(row.Value))) + "\n")
+	}
+	b.WriteString("\n" + HintStyle(tr("share.close")))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInfoScreen(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()},
+		Event{Name: "Q4 end", Time: now.Add(72 * time.Hour).Unix(), Virtual: true},
+	)
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort), events: []WikiEvent{{Year: 1969, Text: "Moon"}}})
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = model.(MainModel)
+	if m.state != showInfo {
+		t.Fatalf("Expected the info screen, got state %v", m.state)
+	}
+	eventsFile, _ := getEventsFilePath()
+	got := map[string]string{}
+	for _, row := range m.info {
+		if row.Label != "" {
+			got[row.Label] = row.Value
+		}
+	}
+	for label, want := range map[string]string{
+		"Events file": eventsFile,
+		"Events":      "1 saved",
+		"Config file": "config.toml",
+		"On this day": "1 event in memory",
+		"Version":     "dev",
+	} {
+		if !strings.Contains(got[label], want) {
+			t.Errorf("Expected %s to contain '%s', got '%s'", label, want, got[label])
+		}
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "not found, using defaults") {
+		t.Errorf("Expected the config status in the view, got:\n%s", view)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(MainModel).state != showEvents {
+		t.Errorf("Expected any key to close the info screen")
+	}
+}
+
+func TestConfigStatus(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	configFile, _ := getConfigFilePath()
+
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"", "loads cleanly"},
+		{"fiscal_year_start_month = 13\n", "does not load now: fiscal_year_start_month must be between 1 and 12"},
+		{"week_start = [\n", "does not load now"},
+		{"date_format = \"nope\"\n", "date_format is invalid"},
+	}
+	for _, tt := range tests {
+		writeConfigFile(t, tt.content)
+		if got := configStatus(configFile); !strings.Contains(got, tt.expected) {
+			t.Errorf("%q: expected '%s', got '%s'", tt.content, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 3 << 20: "3.0 MiB"}
+	for n, expected := range tests {
+		if got := formatSize(n); got != expected {
+			t.Errorf("%d: expected %s, got %s", n, expected, got)
+		}
+	}
+}
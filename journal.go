@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const journalFileName = "journal.jsonl"
+
+// journalEntry is one change to the events, written to the journal before
+// the events file is saved. An edit removes the old event and adds the new
+// one; moving an event within its time renumbers its neighbours the same way.
+type journalEntry struct {
+	File   string  `json:"file,omitempty"` // the events file changed
+	Remove []Event `json:"remove,omitempty"`
+	Add    []Event `json:"add,omitempty"`
+}
+
+// belongsTo reports whether the change was made to the events file at path.
+// Entries written before the journal named the file are taken to be its.
+func (e journalEntry) belongsTo(path string) bool {
+	return e.File == "" || e.File == path
+}
+
+// splitJournal separates the entries for the events file at path from those
+// a run with another events file left behind.
+func splitJournal(entries []journalEntry, path string) (own, others []journalEntry) {
+	for _, e := range entries {
+		if e.belongsTo(path) {
+			own = append(own, e)
+		} else {
+			others = append(others, e)
+		}
+	}
+	return own, others
+}
+
+func getJournalFilePath() (string, error) {
+	dir, err := appDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, journalFileName), nil
+}
+
+// journalChanges returns the change that turns before into after, or false
+// when they hold the same events. Any difference counts, so an edit that
+// only touches the reminders is recorded too.
+func journalChanges(before, after []Event) (journalEntry, bool) {
+	var e journalEntry
+	for _, b := range before {
+		if !containsEqual(after, b) {
+			e.Remove = append(e.Remove, b)
+		}
+	}
+	for _, a := range after {
+		if !containsEqual(before, a) {
+			e.Add = append(e.Add, a)
+		}
+	}
+	return e, len(e.Remove)+len(e.Add) > 0
+}
+
+func containsEqual(events []Event, e Event) bool {
+	for _, other := range events {
+		if reflect.DeepEqual(other, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEvent(events []Event, e Event) bool {
+	for _, other := range events {
+		if sameEvent(other, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendJournal adds an entry as one line and syncs it, so that it is on
+// disk before the events file is touched.
+func appendJournal(entry journalEntry) error {
+	path, err := getJournalFilePath()
+	if err != nil {
+		return err
+	}
+	if entry.File, err = getEventsFilePath(); err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// readJournal returns the entries left by a run that did not get to save
+// them. A torn last line, from a crash while appending, is ignored.
+func readJournal() ([]journalEntry, error) {
+	path, err := getJournalFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var entries []journalEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var e journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// clearJournal empties the journal once its changes are in the events file.
+// Changes to another events file are kept for a run that uses it.
+func clearJournal() error {
+	path, err := getJournalFilePath()
+	if err != nil {
+		return err
+	}
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		return err
+	}
+	entries, err := readJournal()
+	if err != nil {
+		return err
+	}
+	if _, others := splitJournal(entries, eventsFile); len(others) > 0 {
+		var data []byte
+		for _, e := range others {
+			line, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			data = append(append(data, line...), '\n')
+		}
+		if err := writeFileAtomic(path, data); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear %s: %w", path, err)
+	}
+	return nil
+}
+
+// replayJournal applies the entries to events in order. Changes that are
+// already in events, because the save went through before the journal was
+// cleared, are skipped, so replaying twice does no harm.
+func replayJournal(events []Event, entries []journalEntry) []Event {
+	events = append([]Event(nil), events...)
+	for _, entry := range entries {
+		for _, r := range entry.Remove {
+			for i, e := range events {
+				if sameEvent(e, r) {
+					events = append(events[:i], events[i+1:]...)
+					break
+				}
+			}
+		}
+		for _, a := range entry.Add {
+			if !containsEvent(events, a) {
+				events = append(events, a)
+			}
+		}
+	}
+	sortEventsByTime(events)
+	return events
+}
+
+// describeJournal lists the changes for the recovery prompt, one per line.
+func describeJournal(entries []journalEntry) []string {
+	var lines []string
+	for _, entry := range entries {
+		for _, a := range entry.Add {
+			verb := "add"
+			for _, r := range entry.Remove {
+				if r.Name == a.Name {
+					verb = "change"
+					break
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%s %q (%s)", verb, a.Name, time.Unix(a.Time, 0).Format(inputTimeFormLong)))
+		}
+		for _, r := range entry.Remove {
+			if !containsName(entry.Add, r.Name) {
+				lines = append(lines, fmt.Sprintf("remove %q", r.Name))
+			}
+		}
+	}
+	return lines
+}
+
+func containsName(events []Event, name string) bool {
+	for _, e := range events {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recoverJournal looks for changes a crashed run left in the journal and,
+// when asked, applies them to the events file. Without a terminal to ask on,
+// or when the events file cannot be read, the journal is kept for a later
+// start; loading the events reports what is wrong with the file. Changes to
+// another events file are only pointed out, never applied to this one.
+func recoverJournal(in io.Reader, out io.Writer, interactive bool) error {
+	entries, err := readJournal()
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	path, _ := getJournalFilePath()
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		return nil
+	}
+	entries, others := splitJournal(entries, eventsFile)
+	noted := map[string]bool{}
+	for _, e := range others {
+		if !noted[e.File] {
+			noted[e.File] = true
+			fmt.Fprintf(out, "countdown: %s has unsaved changes to %s; start countdown with that events file to recover them\n", path, e.File)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		return nil
+	}
+	if !interactive {
+		fmt.Fprintf(out, "countdown: %s has unsaved changes from an earlier run; start countdown in a terminal to recover them\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(out, "countdown did not save these changes the last time it ran:\n")
+	for _, line := range describeJournal(entries) {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	fmt.Fprintf(out, "Recover them? [Y/n] ")
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "" && answer != "y" && answer != "yes" {
+		return clearJournal()
+	}
+
+	if err := writeEventsFile(replayJournal(events, entries)); err != nil {
+		return err
+	}
+	return clearJournal()
+}
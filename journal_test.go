@@ -0,0 +1,217 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// crashAfter writes events as the last save and leaves the changes to them
+// in the journal, as if the program died before saving again.
+func crashAfter(t *testing.T, saved []Event, changes ...[]Event) {
+	t.Helper()
+	if err := writeEventsFile(saved); err != nil {
+		t.Fatal(err)
+	}
+	before := saved
+	for _, after := range changes {
+		entry, ok := journalChanges(before, after)
+		if !ok {
+			t.Fatal("Expected a change")
+		}
+		if err := appendJournal(entry); err != nil {
+			t.Fatal(err)
+		}
+		before = after
+	}
+}
+
+func TestJournalRecoversCrashedChanges(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	base := time.Date(2030, 5, 1, 12, 0, 0, 0, time.Local).Unix()
+	a := Event{Name: "Alpha", Time: base}
+	b := Event{Name: "Beta", Time: base + 3600}
+	moved := Event{Name: "Beta", Time: base + 7200}
+	c := Event{Name: "Gamma", Time: base + 60}
+	crashAfter(t, []Event{a, b},
+		[]Event{a, moved},
+		[]Event{a, c, moved},
+		[]Event{c, moved},
+	)
+
+	var out strings.Builder
+	if err := recoverJournal(strings.NewReader("y\n"), &out, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`change "Beta"`, `add "Gamma"`, `remove "Alpha"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the prompt, got:\n%s", want, out.String())
+		}
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := storedNames(events); got != "Gamma,Beta" || events[1].Time != moved.Time {
+		t.Errorf("Expected the recovered events Gamma,Beta with Beta moved, got %s", got)
+	}
+	if entries, _ := readJournal(); len(entries) != 0 {
+		t.Errorf("Expected the journal to be cleared, got %d entries", len(entries))
+	}
+}
+
+func TestJournalDeclinedOrNotInteractive(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	a := Event{Name: "Alpha", Time: time.Date(2030, 5, 1, 0, 0, 0, 0, time.Local).Unix()}
+	b := Event{Name: "Beta", Time: a.Time + 60}
+	crashAfter(t, []Event{a}, []Event{a, b})
+
+	var out strings.Builder
+	if err := recoverJournal(strings.NewReader(""), &out, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), journalFileName) {
+		t.Errorf("Expected a note naming the journal, got %q", out.String())
+	}
+	if entries, _ := readJournal(); len(entries) != 1 {
+		t.Fatalf("Expected the journal to be kept without a terminal, got %d entries", len(entries))
+	}
+
+	if err := recoverJournal(strings.NewReader("n\n"), &out, true); err != nil {
+		t.Fatal(err)
+	}
+	events, _ := readEventsFile()
+	if got := storedNames(events); got != "Alpha" {
+		t.Errorf("Expected the file to be left alone, got %s", got)
+	}
+	if entries, _ := readJournal(); len(entries) != 0 {
+		t.Errorf("Expected the declined journal to be cleared, got %d entries", len(entries))
+	}
+}
+
+func TestJournalKeepsOtherEventsFile(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	a := Event{Name: "Alpha", Time: time.Date(2030, 5, 1, 0, 0, 0, 0, time.Local).Unix()}
+	b := Event{Name: "Beta", Time: a.Time + 60}
+	first := filepath.Join(th.testConfigDir, "first.json")
+	t.Setenv(eventsFileEnv, first)
+	crashAfter(t, []Event{a}, []Event{a, b})
+
+	// A run with another events file neither applies nor drops the changes.
+	t.Setenv(eventsFileEnv, filepath.Join(th.testConfigDir, "second.json"))
+	if err := writeEventsFile(nil); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	if err := recoverJournal(strings.NewReader("y\n"), &out, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), first) || strings.Contains(out.String(), "Recover them?") {
+		t.Errorf("Expected only a note naming %s, got %q", first, out.String())
+	}
+	if events, _ := readEventsFile(); len(events) != 0 {
+		t.Errorf("Expected the other events file left alone, got %s", storedNames(events))
+	}
+	if err := clearJournal(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := readJournal(); len(entries) != 1 {
+		t.Fatalf("Expected the changes to %s kept, got %d entries", first, len(entries))
+	}
+
+	t.Setenv(eventsFileEnv, first)
+	out.Reset()
+	if err := recoverJournal(strings.NewReader("y\n"), &out, true); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := readEventsFile(); storedNames(events) != "Alpha,Beta" {
+		t.Errorf("Expected the changes recovered into %s, got %s", first, storedNames(events))
+	}
+}
+
+func TestJournalIgnoresTornLine(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	a := Event{Name: "Alpha", Time: time.Date(2030, 5, 1, 0, 0, 0, 0, time.Local).Unix()}
+	crashAfter(t, nil, []Event{a})
+	path, _ := getJournalFilePath()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"add":[{"name":"Hal`)
+	f.Close()
+
+	entries, err := readJournal()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected the one whole entry, got %d (%v)", len(entries), err)
+	}
+	once := replayJournal(nil, entries)
+	twice := replayJournal(once, entries)
+	if storedNames(twice) != "Alpha" {
+		t.Errorf("Expected replaying twice to change nothing, got %s", storedNames(twice))
+	}
+}
+
+func TestJournalReplaysMove(t *testing.T) {
+	ts := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC).Unix()
+	before := []Event{{Name: "One", Time: ts}, {Name: "Two", Time: ts, Order: 1}}
+	after := []Event{{Name: "Two", Time: ts}, {Name: "One", Time: ts, Order: 1}}
+
+	entry, ok := journalChanges(before, after)
+	if !ok || len(entry.Remove) != 2 || len(entry.Add) != 2 {
+		t.Fatalf("Expected both events renumbered, got %+v", entry)
+	}
+	if got := storedNames(replayJournal(before, []journalEntry{entry})); got != "Two,One" {
+		t.Errorf("Expected Two,One, got %s", got)
+	}
+	if _, ok := journalChanges(after, after); ok {
+		t.Error("Expected no change between equal lists")
+	}
+
+	reminded := after[0]
+	reminded.Reminders = []int64{3600}
+	entry, ok = journalChanges(after, []Event{reminded, after[1]})
+	if !ok {
+		t.Fatal("Expected a reminders edit to be a change")
+	}
+	replayed := replayJournal(after, []journalEntry{entry})
+	if len(replayed) != 2 || len(replayed[0].Reminders) != 1 {
+		t.Errorf("Expected the reminders edit replayed, got %+v", replayed)
+	}
+}
+
+func TestSaveClearsJournal(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	a := Event{Name: "Alpha", Time: now.Add(time.Hour).Unix()}
+	m := newRefreshTestModel(t, &now, a)
+	if err := appendJournal(journalEntry{Add: []Event{a}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.saveChange(nil); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := readJournal(); len(entries) != 0 {
+		t.Errorf("Expected the save to clear the journal, got %d entries", len(entries))
+	}
+}
+
+func storedNames(events []Event) string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Name
+	}
+	return strings.Join(names, ",")
+}
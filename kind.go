@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+// An event's kind picks what the details show below the countdown. Events
+// without one, and kinds this version does not know, are generic.
+const (
+	kindGeneric  = ""
+	kindDeadline = "deadline"
+	kindBirthday = "birthday"
+	kindTrip     = "trip"
+)
+
+// eventKinds are the kinds in the order the form offers them.
+var eventKinds = []string{kindGeneric, kindDeadline, kindBirthday, kindTrip}
+
+// kindSections render each kind's part of the details. Adding a kind takes
+// its section here and its place in eventKinds.
+var kindSections = map[string]func(MainModel, Event, time.Time) string{
+	kindGeneric:  MainModel.genericSection,
+	kindDeadline: MainModel.deadlineSection,
+	kindBirthday: MainModel.birthdaySection,
+	kindTrip:     MainModel.tripSection,
+}
+
+func (m MainModel) kindSection(e Event, now time.Time) string {
+	section, ok := kindSections[e.Kind]
+	if !ok {
+		section = kindSections[kindGeneric]
+	}
+	return section(m, e, now)
+}
+
+// kindLabel names a kind in the form. A kind from a newer version is shown
+// as it is stored.
+func kindLabel(kind string) string {
+	if _, ok := kindSections[kind]; !ok {
+		return kind
+	}
+	if kind == kindGeneric {
+		return tr("kind.generic")
+	}
+	return tr("kind." + kind)
+}
+
+// stepKind returns the kind step places after kind in eventKinds, wrapping
+// around. An unknown kind steps as if it were generic.
+func stepKind(kind string, step int) string {
+	i := 0
+	for j, k := range eventKinds {
+		if k == kind {
+			i = j
+		}
+	}
+	return eventKinds[(i+step+len(eventKinds))%len(eventKinds)]
+}
+
+// kindRow is a label and value of a kind section.
+func kindRow(label, value string) string {
+	labelStyle := lipgloss.NewStyle().Width(18).Foreground(lipgloss.AdaptiveColor{Light: cDimmedDescLight, Dark: cDimmedDescDark})
+	return labelStyle.Render(label) + BrightTextStyle(value) + "\n"
+}
+
+// genericSection shows how far through the day and the fiscal quarter now
+// is.
+func (m MainModel) genericSection(event Event, now time.Time) string {
+	var b strings.Builder
+	s := when.Breakdown(now, time.Unix(event.Time, 0))
+	progressWidth := m.detailWidth - 30
+	if progressWidth < 10 {
+		progressWidth = 10
+	}
+	if progressWidth > 30 {
+		progressWidth = 30
+	}
+	b.WriteString(NormalTextStyle(tr("detail.day_progress")))
+	dayProgress := float64(s.Hours*3600+s.Minutes*60+s.Seconds) / float64(secondsPerDay)
+	if dayProgress > 1 {
+		dayProgress = 1
+	}
+	b.WriteString(renderProgressBar(dayProgress, 1.0, progressWidth, getUrgencyColor(event.Time, now)))
+	b.WriteString(fmt.Sprintf(" %.1f%%\n", dayProgress*100))
+	quarter := fiscalQuarterOf(now, m.config.FiscalYearStartMonth)
+	b.WriteString(NormalTextStyle(tr("detail.quarter")))
+	b.WriteString(BrightTextStyle(quarter.ProgressString(now)) + "\n\n")
+	return b.String()
+}
+
+// deadlineMilestones are the points before a deadline worth watching for.
+var deadlineMilestones = []struct {
+	ID                  string
+	Years, Months, Days int
+}{
+	{"kind.month_before", 0, -1, 0},
+	{"kind.week_before", 0, 0, -7},
+	{"kind.day_before", 0, 0, -1},
+}
+
+// deadlineSection counts the working days left and when the last month,
+// week and day begin.
+func (m MainModel) deadlineSection(event Event, now time.Time) string {
+	var b strings.Builder
+	ts := time.Unix(event.Time, 0)
+	if ts.Before(now) {
+		b.WriteString(kindRow(tr("kind.working_days_since"), fmt.Sprint(workingDaysBetween(ts, now))))
+	} else {
+		b.WriteString(kindRow(tr("kind.working_days_left"), fmt.Sprint(workingDaysBetween(now, ts))))
+	}
+	for _, ms := range deadlineMilestones {
+		at := ts.AddDate(ms.Years, ms.Months, ms.Days)
+		value := "✓ " + at.Format(m.config.dateLayout())
+		if at.After(now) {
+			value = at.Format(m.config.dateLayout()) + " · " + formatRelative(at, now)
+		}
+		b.WriteString(kindRow(tr(ms.ID), value))
+	}
+	return b.String() + "\n"
+}
+
+// workingDaysBetween counts the Mondays to Fridays after from's day up to
+// and including to's.
+func workingDaysBetween(from, to time.Time) int {
+	y, mo, d := from.Date()
+	day := time.Date(y, mo, d+1, 0, 0, 0, 0, from.Location())
+	n := 0
+	for !day.After(to) {
+		if wd := day.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			n++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return n
+}
+
+// birthdaySection shows the age turned next and when.
+func (m MainModel) birthdaySection(event Event, now time.Time) string {
+	var b strings.Builder
+	ts := time.Unix(event.Time, 0)
+	next := ts
+	if !ts.After(now) {
+		next = nextAnniversary(ts.Month(), ts.Day(), now)
+	}
+	if event.Since > 0 {
+		b.WriteString(kindRow(tr("kind.turns"), fmt.Sprint(next.Year()-event.Since)))
+	}
+	b.WriteString(kindRow(tr("kind.next_anniversary"), next.Format(m.config.dateLayout())))
+	b.WriteString(kindRow(tr("kind.days_to_go"), trn("kind.days", daysUntil(next.Unix(), now))))
+	return b.String() + "\n"
+}
+
+// tripSection counts the days to departure and shows the event's notes as
+// a packing list, one item per line. Items starting with "x " are packed.
+func (m MainModel) tripSection(event Event, now time.Time) string {
+	var b strings.Builder
+	ts := time.Unix(event.Time, 0)
+	if ts.After(now) {
+		b.WriteString(kindRow(tr("kind.days_to_go"), trn("kind.days", daysUntil(event.Time, now))))
+	} else {
+		b.WriteString(kindRow(tr("kind.away"), trn("kind.days", daysUntil(now.Unix(), ts))))
+	}
+
+	var list strings.Builder
+	for _, line := range strings.Split(event.Notes, "\n") {
+		item := strings.TrimSpace(line)
+		switch {
+		case item == "":
+		case strings.HasPrefix(item, "x "):
+			list.WriteString(HintStyle("☑ "+strings.TrimSpace(item[2:])) + "\n")
+		default:
+			list.WriteString(BrightTextStyle("☐ "+item) + "\n")
+		}
+	}
+	content := strings.TrimSuffix(list.String(), "\n")
+	if content == "" {
+		content = HintStyle(tr("kind.packing_empty"))
+	}
+	b.WriteString("\n" + NormalTextStyle(tr("kind.packing_list")) + "\n")
+	b.WriteString(lipgloss.NewStyle().
+		Width(m.detailWidth-10).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Render(content) + "\n\n")
+	return b.String()
+}
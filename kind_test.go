@@ -0,0 +1,185 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newKindTestModel(t *testing.T, now time.Time) MainModel {
+	m := newRefreshTestModel(t, &now)
+	m.detailWidth = 60
+	m.config.DateFormat = "iso"
+	return m
+}
+
+func TestKindSectionFallsBackToGeneric(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newKindTestModel(t, now)
+	e := Event{Name: "Later", Time: now.Add(5 * time.Hour).Unix()}
+	generic := m.kindSection(e, now)
+	if !strings.Contains(stripANSI(generic), "Quarter") {
+		t.Errorf("Expected the day and quarter progress, got:\n%s", stripANSI(generic))
+	}
+	e.Kind = "wedding"
+	if got := m.kindSection(e, now); got != generic {
+		t.Errorf("Expected an unknown kind shown as generic, got:\n%s", stripANSI(got))
+	}
+	if got := kindLabel("wedding"); got != "wedding" {
+		t.Errorf("Expected an unknown kind labelled as stored, got %q", got)
+	}
+	if got := kindLabel(kindGeneric); got != "Event" {
+		t.Errorf("Expected the generic kind labelled Event, got %q", got)
+	}
+}
+
+func TestFormKind(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now)
+	m.openForm(showInput)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Lisbon")}, tea.KeyMsg{Type: tea.KeyTab})
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2026-05-01")})
+	for m.focus != int(inputKindField) {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	send(tea.KeyMsg{Type: tea.KeyLeft})
+	if view := stripANSI(m.View()); !strings.Contains(view, "‹ Trip ›") {
+		t.Errorf("Expected the trip kind picked, got:\n%s", view)
+	}
+	send(tea.KeyMsg{Type: tea.KeyCtrlS})
+	events, err := readEventsFile()
+	if err != nil || len(events) != 1 || events[0].Kind != kindTrip {
+		t.Fatalf("Expected the trip saved with its kind, got %v (%v)", events, err)
+	}
+
+	m.openForm(showInput)
+	if m.formKind != kindGeneric {
+		t.Errorf("Expected a new form to start generic, got %q", m.formKind)
+	}
+}
+
+func TestStepKind(t *testing.T) {
+	tests := []struct {
+		kind string
+		step int
+		want string
+	}{
+		{kindGeneric, 1, kindDeadline},
+		{kindTrip, 1, kindGeneric},
+		{kindGeneric, -1, kindTrip},
+		{kindBirthday, -1, kindDeadline},
+		{"wedding", 1, kindDeadline},
+	}
+	for _, tt := range tests {
+		if got := stepKind(tt.kind, tt.step); got != tt.want {
+			t.Errorf("stepKind(%q, %d) = %q, want %q", tt.kind, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	// Tuesday.
+	from := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	tests := []struct {
+		to   time.Time
+		want int
+	}{
+		{from, 0},
+		{from.Add(2 * time.Hour), 0},
+		{time.Date(2026, 3, 13, 9, 0, 0, 0, time.Local), 3},
+		{time.Date(2026, 3, 15, 9, 0, 0, 0, time.Local), 3},
+		{time.Date(2026, 3, 17, 9, 0, 0, 0, time.Local), 5},
+	}
+	for _, tt := range tests {
+		if got := workingDaysBetween(from, tt.to); got != tt.want {
+			t.Errorf("workingDaysBetween to %s = %d, want %d", tt.to.Format("Mon Jan 2"), got, tt.want)
+		}
+	}
+}
+
+func TestDeadlineSection(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newKindTestModel(t, now)
+	e := Event{Name: "Taxes", Time: time.Date(2026, 3, 24, 17, 0, 0, 0, time.Local).Unix(), Kind: kindDeadline}
+
+	got := stripANSI(m.deadlineSection(e, now))
+	for _, want := range []string{"Working days left 10", "✓ 2026-02-24", "2026-03-17 ·", "2026-03-23 ·"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+
+	got = stripANSI(m.deadlineSection(e, now.AddDate(0, 0, 21)))
+	if !strings.Contains(got, "Working days over 5") || strings.Contains(got, " · ") {
+		t.Errorf("Expected a passed deadline with every milestone ticked, got:\n%s", got)
+	}
+}
+
+func TestBirthdaySection(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newKindTestModel(t, now)
+	e := Event{Name: "Ada", Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local).Unix(), Since: 1990, Kind: kindBirthday}
+
+	got := stripANSI(m.birthdaySection(e, now))
+	for _, want := range []string{"Turns             37", "Next birthday     2027-03-01", "Days to go        356 days"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+
+	e.Since = 0
+	e.Time = time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local).Unix()
+	got = stripANSI(m.birthdaySection(e, now))
+	if strings.Contains(got, "Turns") || !strings.Contains(got, "1 day") {
+		t.Errorf("Expected no age and a day to go, got:\n%s", got)
+	}
+}
+
+func TestTripSection(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newKindTestModel(t, now)
+	e := Event{
+		Name:  "Lisbon",
+		Time:  time.Date(2026, 3, 14, 7, 0, 0, 0, time.Local).Unix(),
+		Notes: "passport\n\n  x charger \nsunscreen",
+		Kind:  kindTrip,
+	}
+
+	got := stripANSI(m.tripSection(e, now))
+	for _, want := range []string{"Days to go        4 days", "Packing list", "☐ passport", "☑ charger", "☐ sunscreen"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+
+	e.Notes = ""
+	got = stripANSI(m.tripSection(e, now.AddDate(0, 0, 6)))
+	if !strings.Contains(got, "Away for          2 days") || !strings.Contains(got, "Add items to the notes") {
+		t.Errorf("Expected the days away and the empty list hint, got:\n%s", got)
+	}
+}
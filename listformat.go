@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+// listFormat is the list_format template list descriptions are rendered
+// with, or nil for the built-in countdown. It is set once from the config, as
+// the template is compiled and tried on the way in.
+var listFormat *template.Template
+
+// listFields is what a list_format template sees for each event.
+type listFields struct {
+	Name string
+	// The countdown broken down as in "1y 23d 4h 5m 6s".
+	Years, Days, Hours, Minutes, Seconds int
+	// TotalDays is the number of days as the "days" display counts them.
+	TotalDays int
+	Past      bool
+	// Countdown is the built-in description, without color.
+	Countdown string
+	// Relative is the largest whole unit, e.g. "in 6 weeks".
+	Relative string
+	// Clock is the whole days and a clock of the rest, e.g. "3d 04:12:55".
+	Clock string
+	// Urgency names the color the description gets.
+	Urgency string
+	Tags    []string
+
+	time time.Time
+}
+
+// Date formats the event's time with a Go layout, e.g. {{.Date "Jan 2"}}.
+func (f listFields) Date(layout string) string {
+	return f.time.Format(layout)
+}
+
+// urgencyNames are the Urgency values, by urgencyBucket.
+var urgencyNames = [...]string{"past", "far", "month", "fortnight", "week", "soon", "imminent"}
+
+func newListFields(e Event, now time.Time, countdown countdownFormat) listFields {
+	t := time.Unix(e.Time, 0).In(now.Location())
+	s := when.Breakdown(now, t)
+	f := listFields{
+		Name:      e.Name,
+		Years:     s.Years,
+		Days:      s.Days,
+		Hours:     s.Hours,
+		Minutes:   s.Minutes,
+		Seconds:   s.Seconds,
+		Past:      s.Past,
+		Countdown: countdown.format(e.Time, now),
+		Relative:  formatRelative(t, now),
+		Clock:     formatClock(e.Time, now),
+		Urgency:   urgencyNames[urgencyBucket(e.Time, now)],
+		Tags:      e.Tags,
+		time:      t,
+	}
+	if e.Time >= now.Unix() {
+		f.TotalDays = daysUntil(e.Time, now)
+	} else {
+		f.TotalDays = when.WholeDays(t, now)
+	}
+	return f
+}
+
+// compileListFormat parses a list_format template and tries it on a sample
+// event, so a misspelt field shows up at startup rather than in every row.
+func compileListFormat(s string) (*template.Template, error) {
+	return compileFieldsTemplate("list_format", s)
+}
+
+// compileFieldsTemplate parses a template over listFields, naming it name
+// in errors, and tries it on a sample event.
+func compileFieldsTemplate(name, s string) (*template.Template, error) {
+	t, err := template.New(name).Parse(s)
+	if err != nil {
+		return nil, cleanTemplateError(name, err)
+	}
+	now := time.Now()
+	sample := Event{Name: "Sample", Time: now.AddDate(0, 1, 0).Unix(), Tags: []string{"sample"}}
+	if err := t.Execute(&bytes.Buffer{}, newListFields(sample, now, countdownFormat{})); err != nil {
+		return nil, cleanTemplateError(name, err)
+	}
+	return t, nil
+}
+
+// cleanTemplateError trims the template package's wording down to
+// "list_format:line:column: at <.Field>: what is wrong there".
+func cleanTemplateError(name string, err error) error {
+	msg := strings.TrimPrefix(err.Error(), "template: ")
+	return errors.New(strings.Replace(msg, `executing "`+name+`" `, "", 1))
+}
+
+// setListFormat installs the config's list_format, warning and keeping the
+// built-in description if it does not work.
+func setListFormat(s string) {
+	listFormat = nil
+	if s == "" {
+		return
+	}
+	t, err := compileListFormat(s)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, using the built-in format\n", err)
+		return
+	}
+	listFormat = t
+}
+
+// formatListItem renders e's list description with the list_format
+// template, colored by urgency like the built-in one.
+func (d eventDelegate) formatListItem(e Event) string {
+	var b strings.Builder
+	if err := listFormat.Execute(&b, newListFields(e, d.now, d.format)); err != nil {
+		return d.countdown(e.Time)
+	}
+	color := getUrgencyColor(e.Time, d.now)
+	return lipgloss.NewStyle().Foreground(paletteColor(color)).Render(b.String())
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListFormat(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	defer func() { listFormat = nil }()
+	d := eventDelegate{now: now}
+
+	e := Event{
+		Name: "Launch",
+		Time: time.Date(2026, 6, 11, 15, 30, 0, 0, time.UTC).Unix(),
+		Tags: []string{"work", "space"},
+	}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{`{{.Days}}d {{.Hours}}h · {{.Date "Jan 2"}}`, "10d 3h · Jun 11"},
+		{`{{.TotalDays}} days, {{.Relative}}`, "10 days, in 10 days"},
+		{`{{.Urgency}}{{range .Tags}} #{{.}}{{end}}`, "fortnight #work #space"},
+		{`{{if .Past}}done{{else}}{{.Countdown}}{{end}}`, "10d 3h 30m 0s"},
+	}
+	for _, tt := range tests {
+		tmpl, err := compileListFormat(tt.format)
+		if err != nil {
+			t.Errorf("%s: %v", tt.format, err)
+			continue
+		}
+		listFormat = tmpl
+		if got := stripANSI(d.description(e)); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.format, tt.expected, got)
+		}
+	}
+}
+
+func TestListFormatErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"{{.Days}} {{.Dayz}}", "list_format:1:12: at <.Dayz>: can't evaluate field Dayz"},
+		{"{{.Days", "list_format:1: unclosed action"},
+		{"{{.Date}}", "list_format:1:2: at <.Date>: wrong number of args"},
+	}
+	for _, tt := range tests {
+		_, err := compileListFormat(tt.format)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.format, tt.want, err)
+		}
+	}
+
+	defer func() { listFormat = nil }()
+	setListFormat("{{.Nope}}")
+	if listFormat != nil {
+		t.Error("Expected a broken list_format to fall back to the built-in one")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Windows shorter than shortListHeight leave the help out from under the
+// lists, where it would crowd out the events; ? shows it over the whole
+// window instead. Pages are then numbered "1/4" rather than drawn as dots.
+const (
+	shortListHeight = 15
+	listHelpHeight  = 5
+)
+
+// layoutLists fits the events and tags lists to the window. At the very
+// least the selected event stays in view: when the title, status bar and
+// page number leave no room for it, the status bar goes too.
+func (m *MainModel) layoutLists() {
+	_, v := AppStyle.GetFrameSize()
+	height := max(1, m.windowHeight-v)
+	short := m.windowHeight < shortListHeight
+	for _, l := range []*list.Model{&m.events, &m.tags} {
+		l.Styles.HelpStyle = lipgloss.NewStyle().Width(m.listWidth).Height(listHelpHeight)
+		l.Help.Width = m.listWidth
+		l.Paginator.Type = paginator.Dots
+		if short {
+			l.Paginator.Type = paginator.Arabic
+		}
+		l.SetShowHelp(!short)
+		l.SetShowStatusBar(true)
+		l.SetSize(m.listWidth, height)
+		if lipgloss.Height(l.View()) > height {
+			l.SetShowStatusBar(false)
+		}
+	}
+}
+
+// shortListHelp is what ? shows in a window too short for the help under
+// l: every key l takes, wrapped across the whole window. ok is false unless
+// ? was pressed there.
+func (m MainModel) shortListHelp(l list.Model) (view string, ok bool) {
+	if l.ShowHelp() || !l.Help.ShowAll {
+		return "", false
+	}
+	var keys []key.Binding
+	for _, group := range l.FullHelp() {
+		for _, k := range group {
+			if k.Enabled() {
+				keys = append(keys, k)
+			}
+		}
+	}
+	help := l.Help
+	help.Width = 0
+	width := max(1, m.windowWidth-2)
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		MaxHeight(m.windowHeight).
+		Render(l.Styles.Title.Render(l.Title) + "\n\n" + lipgloss.NewStyle().Width(width).Render(help.ShortHelpView(keys))), true
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestListFitsShortWindows(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	var events []Event
+	for i := 0; i < 12; i++ {
+		events = append(events, Event{Name: fmt.Sprintf("Event %02d", i), Time: now.AddDate(0, 0, i+1).Unix()})
+	}
+	m := newRefreshTestModel(t, &now, events...)
+	pageNumber := regexp.MustCompile(`\b\d+/\d+\b`)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+
+	for height := 5; height <= 24; height++ {
+		send(tea.WindowSizeMsg{Width: 120, Height: height})
+		m.events.Select(7)
+		view := m.View()
+		if h := lipgloss.Height(view); h > height {
+			t.Errorf("%d rows: expected the view to fit, got %d lines", height, h)
+		}
+		list := stripANSI(m.events.View())
+		if !strings.Contains(list, "Event 07") {
+			t.Errorf("%d rows: expected the selected event in view, got:\n%s", height, list)
+		}
+		short := height < shortListHeight
+		if help := strings.Contains(list, "↑/k up"); help == short {
+			t.Errorf("%d rows: expected help shown %v, got:\n%s", height, !short, list)
+		}
+		if short && !pageNumber.MatchString(list) {
+			t.Errorf("%d rows: expected pages numbered, got:\n%s", height, list)
+		}
+	}
+
+	send(tea.WindowSizeMsg{Width: 120, Height: 10})
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	view := m.View()
+	if !strings.Contains(stripANSI(view), "go to date") || lipgloss.Height(view) > 10 {
+		t.Errorf("Expected ? to show the help in a short window, got:\n%s", stripANSI(view))
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if view := stripANSI(m.View()); !strings.Contains(view, "Event 07") {
+		t.Errorf("Expected ? again to bring the list back, got:\n%s", view)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// MailConfig is where `countdown digest` sends its summary.
+type MailConfig struct {
+	// SMTP is the server as host:port, e.g. "smtp.example.com:587". Empty
+	// hands the message to the local sendmail instead.
+	SMTP     string   `toml:"smtp"`
+	Username string   `toml:"username"`
+	Password string   `toml:"password"`
+	From     string   `toml:"from"`
+	To       []string `toml:"to"`
+}
+
+// dueWithin returns the saved events from now up to horizon ahead, in
+// order.
+func dueWithin(events []Event, now time.Time, horizon time.Duration) []Event {
+	var due []Event
+	end := now.Add(horizon).Unix()
+	for _, e := range events {
+		if !e.Virtual && e.Time >= now.Unix() && e.Time <= end {
+			due = append(due, e)
+		}
+	}
+	sortEventsByTime(due)
+	return due
+}
+
+// composeDigest writes the plain-text summary of the events due within
+// horizon of now, grouped into today, the next seven days and later. Dates
+// are shown with layout and countdowns in display mode. It returns "" when
+// nothing is due.
+func composeDigest(events []Event, now time.Time, horizon time.Duration, layout string, display displayMode) string {
+	due := dueWithin(events, now, horizon)
+	if len(due) == 0 {
+		return ""
+	}
+
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	weekEnd := tomorrow.AddDate(0, 0, 6)
+	groups := []struct {
+		title  string
+		events []Event
+	}{{title: tr("mail.today")}, {title: tr("mail.week")}, {title: tr("mail.later")}}
+	nameWidth, dateWidth := 0, 0
+	for _, e := range due {
+		ts := time.Unix(e.Time, 0).In(now.Location())
+		g := 2
+		if ts.Before(tomorrow) {
+			g = 0
+		} else if ts.Before(weekEnd) {
+			g = 1
+		}
+		groups[g].events = append(groups[g].events, e)
+		nameWidth = max(nameWidth, runewidth.StringWidth(e.Title()))
+		dateWidth = max(dateWidth, runewidth.StringWidth(ts.Format(layout)))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", digestSubject(len(due), horizon))
+	for _, g := range groups {
+		if len(g.events) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s\n", g.title)
+		for _, e := range g.events {
+			date := time.Unix(e.Time, 0).In(now.Location()).Format(layout)
+			fmt.Fprintf(&b, "  %s  %s  %s\n",
+				runewidth.FillRight(e.Title(), nameWidth),
+				runewidth.FillRight(date, dateWidth),
+				countdownFormat{display: display}.format(e.Time, now))
+		}
+	}
+	return b.String()
+}
+
+func digestSubject(n int, horizon time.Duration) string {
+	return trf("mail.subject", trn("mail.events", n), int(horizon/(24*time.Hour)))
+}
+
+// buildMail makes a plain-text UTF-8 message.
+func buildMail(from string, to []string, subject, body string, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// sendMail delivers msg over SMTP, or through sendmail when no server is
+// configured. It is a variable so tests can catch the message.
+var sendMail = func(cfg MailConfig, msg []byte) error {
+	if cfg.SMTP == "" {
+		cmd := exec.Command("sendmail", "-t", "-i")
+		cmd.Stdin = bytes.NewReader(msg)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("sendmail: %v: %s", err, bytes.TrimSpace(out))
+		}
+		return nil
+	}
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		host, _, err := net.SplitHostPort(cfg.SMTP)
+		if err != nil {
+			return fmt.Errorf("mail.smtp: %w", err)
+		}
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return smtp.SendMail(cfg.SMTP, auth, cfg.From, cfg.To, msg)
+}
+
+func runDigest(args []string) int {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	days := fs.Int("days", 7, "include events due within this many days")
+	server := fs.String("smtp", "", "SMTP server as host:port, overriding mail.smtp")
+	from := fs.String("from", "", "sender address, overriding mail.from")
+	to := fs.String("to", "", "comma-separated recipients, overriding mail.to")
+	stdout := fs.Bool("stdout", false, "print the summary instead of sending it")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *days < 1 {
+		fmt.Fprintln(os.Stderr, "digest: --days must be at least 1")
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "digest: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+	mail := cfg.Mail
+	if *server != "" {
+		mail.SMTP = *server
+	}
+	if *from != "" {
+		mail.From = *from
+	}
+	if *to != "" {
+		mail.To = strings.Split(*to, ",")
+		for i := range mail.To {
+			mail.To[i] = strings.TrimSpace(mail.To[i])
+		}
+	}
+	if !*stdout && (mail.From == "" || len(mail.To) == 0) {
+		fmt.Fprintln(os.Stderr, "digest: set mail.from and mail.to (or --from and --to), or use --stdout")
+		return 2
+	}
+
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "digest: %v\n", err)
+		return 1
+	}
+	now := time.Now()
+	rollForwardRecurring(events, now, cfg.completionHold())
+	horizon := time.Duration(*days) * 24 * time.Hour
+	body := composeDigest(events, now, horizon, cfg.dateTimeLayout(), cfg.countdownFormat().display)
+	if *stdout {
+		if body == "" {
+			body = digestSubject(0, horizon) + "\n"
+		}
+		fmt.Print(body)
+		return 0
+	}
+	// Nothing due sends nothing, so a daily cron job stays quiet.
+	if body == "" {
+		return 0
+	}
+	subject := digestSubject(len(dueWithin(events, now, horizon)), horizon)
+	if err := sendMail(mail, buildMail(mail.From, mail.To, subject, body, now)); err != nil {
+		fmt.Fprintf(os.Stderr, "digest: %v\n", err)
+		return 1
+	}
+	return 0
+}
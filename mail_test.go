@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComposeDigest(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC) // a Monday morning
+	at := func(day, hour int) int64 { return time.Date(2026, 6, day, hour, 0, 0, 0, time.UTC).Unix() }
+	events := []Event{
+		{Name: "Standup", Time: at(1, 9)},
+		{Name: "Dentist", Time: at(3, 14)},
+		{Name: "Release", Time: at(7, 23)},
+		{Name: "Holiday", Time: at(8, 0)},
+		{Name: "Too far", Time: at(20, 0)},
+		{Name: "Yesterday", Time: at(-1, 0)},
+		{Name: "End of Q2", Time: at(2, 0), Virtual: true},
+	}
+
+	got := composeDigest(events, now, 14*24*time.Hour, "2006-01-02 15:04", displayFull)
+	expected := strings.Join([]string{
+		"countdown: 4 events in the next 14 days",
+		"",
+		"Today",
+		"  Standup  2026-06-01 09:00  2h 0m 0s",
+		"",
+		"This week",
+		"  Dentist  2026-06-03 14:00  2d 7h 0m 0s",
+		"  Release  2026-06-07 23:00  6d 16h 0m 0s",
+		"",
+		"Later",
+		"  Holiday  2026-06-08 00:00  6d 17h 0m 0s",
+		"",
+	}, "\n")
+	if got != expected {
+		t.Errorf("Unexpected digest:\n%s\nExpected:\n%s", got, expected)
+	}
+
+	if got := composeDigest(events, now, time.Hour, "2006-01-02", displayFull); got != "" {
+		t.Errorf("Expected nothing due within the hour, got:\n%s", got)
+	}
+}
+
+func TestBuildMail(t *testing.T) {
+	now := time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC)
+	msg := string(buildMail("me@example.com", []string{"a@example.com", "b@example.com"}, "countdown: 2 Ereignisse in den nächsten 7 Tagen", "Heute\n  X\n", now))
+	for _, want := range []string{
+		"From: me@example.com\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"\r\n\r\nHeute\r\n  X\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected %q in:\n%s", want, msg)
+		}
+	}
+}
+
+func TestRunDigest(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	if err := writeEventsFile([]Event{{Name: "Soon", Time: time.Now().Add(time.Hour).Unix()}}); err != nil {
+		t.Fatal(err)
+	}
+	var sent []byte
+	saved := sendMail
+	sendMail = func(cfg MailConfig, msg []byte) error {
+		sent = msg
+		return nil
+	}
+	defer func() { sendMail = saved }()
+
+	if code := runDigest(nil); code != 2 || sent != nil {
+		t.Errorf("Expected a usage error without recipients, got %d", code)
+	}
+
+	writeConfigFile(t, "[mail]\nsmtp = \"smtp.example.com:587\"\nfrom = \"me@example.com\"\nto = [\"me@example.com\"]\n")
+	if code := runDigest([]string{"--days", "2"}); code != 0 || !bytes.Contains(sent, []byte("Soon")) {
+		t.Errorf("Expected the digest to be sent, got %d:\n%s", code, sent)
+	}
+
+	r, w, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = w
+	sent = nil
+	code := runDigest([]string{"--stdout"})
+	os.Stdout = stdout
+	w.Close()
+	var out bytes.Buffer
+	io.Copy(&out, r)
+	if code != 0 || sent != nil || !strings.Contains(out.String(), "Soon") {
+		t.Errorf("Expected --stdout to print and not send, got %d:\n%s", code, out.String())
+	}
+}
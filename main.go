@@ -3,13 +3,16 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -17,6 +20,9 @@ import (
 	"github.com/charmbracelet/bubbles/timer"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-isatty"
+	"github.com/rom41572/countdown/pkg/when"
 )
 
 const (
@@ -32,89 +38,116 @@ const (
 	eventsFileName     = "events.json"
 	inputTimeFormShort = "2006-01-02"
 	inputTimeFormLong  = "2006-01-02 15:04:05"
-	cError             = "#CF002E"
-	cItemTitleDark     = "#F5EB6D"
-	cItemTitleLight    = "#F3B512"
-	cItemDescDark      = "#9E9742"
-	cItemDescLight     = "#FFD975"
-	cTitle             = "#2389D3"
-	cDetailTitle       = "#D32389"
-	cPromptBorder      = "#D32389"
-	cDimmedTitleDark   = "#DDDDDD"
-	cDimmedTitleLight  = "#222222"
-	cDimmedDescDark    = "#999999"
-	cDimmedDescLight   = "#555555"
-	cTextLightGray     = "#000000ff"
-	cSuccess           = "#146034ff"
-	cWarning           = "#F39C12"
-	cHint              = "#7F8C8D"
-	cUrgency1          = "#347a51ff" // > 30 days (green)
-	cUrgency2          = "#58D68D"   // 14-30 days (light green)
-	cUrgency3          = "#F4D03F"   // 7-14 days (yellow)
-	cUrgency4          = "#F39C12"   // 3-7 days (orange)
-	cUrgency5          = "#E74C3C"   // 1-3 days (red)
-	cUrgency6          = "#C0392B"   // < 1 day (dark red)
-	cPast              = "#9B59B6"   // past events (purple)
-	cBarEmpty          = "#2C3E50"
-	cTimelineTrack     = "#34495E"
-	cTimelineNow       = "#E74C3C"
-	cTimelineFuture    = "#3498DB"
-	cTimelineSelected  = "#F39C12"
+	// inputTimeFormMinutes is also accepted, for typing less.
+	inputTimeFormMinutes = "2006-01-02 15:04"
+	cError               = "#CF002E"
+	cItemTitleDark       = "#F5EB6D"
+	cItemTitleLight      = "#F3B512"
+	cItemDescDark        = "#9E9742"
+	cItemDescLight       = "#FFD975"
+	cTitle               = "#2389D3"
+	cDetailTitle         = "#D32389"
+	cPromptBorder        = "#D32389"
+	cDimmedTitleDark     = "#DDDDDD"
+	cDimmedTitleLight    = "#222222"
+	cDimmedDescDark      = "#999999"
+	cDimmedDescLight     = "#555555"
+	cTextLightGray       = "#EEEEEE"
+	cSuccess             = "#146034"
+	cWarning             = "#F39C12"
+	cHint                = "#7F8C8D"
+	cUrgency1            = "#347A51" // > 30 days (green)
+	cUrgency2            = "#58D68D" // 14-30 days (light green)
+	cUrgency3            = "#F4D03F" // 7-14 days (yellow)
+	cUrgency4            = "#F39C12" // 3-7 days (orange)
+	cUrgency5            = "#E74C3C" // 1-3 days (red)
+	cUrgency6            = "#C0392B" // < 1 day (dark red)
+	cPast                = "#9B59B6" // past events (purple)
+	cWhatIf              = "#1ABC9C" // what-if distances (teal)
+	cTodayBadge          = "#F39C12"
+	cTodayDark           = "#2B2F3A" // rows of today's events
+	cTodayLight          = "#FDF2DC"
+	cBarEmpty            = "#2C3E50"
+	cTimelineTrack       = "#34495E"
+	cTimelineNow         = "#E74C3C"
+	cTimelineFuture      = "#3498DB"
+	cTimelineSelected    = "#F39C12"
 )
 
+// eventsFilePath is the events file of the running session, resolved once
+// by runTUI so that editing events_file meanwhile cannot move it partway
+// through. Empty until then, as for the one-shot commands.
+var eventsFilePath string
+
 func getEventsFilePath() (string, error) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	if eventsFilePath != "" {
+		return eventsFilePath, nil
 	}
+	return resolveEventsFilePath()
+}
 
-	appConfigDir := filepath.Join(configDir, appName)
-	if err := os.MkdirAll(appConfigDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create config directory: %w", err)
+// resolveEventsFilePath finds the events file from the environment and the
+// config, moving the data files of older versions into place.
+func resolveEventsFilePath() (string, error) {
+	if file := os.Getenv(eventsFileEnv); file != "" {
+		return filepath.Abs(file)
+	}
+	if file := configuredEventsFile(); file != "" {
+		return filepath.Abs(file)
+	}
+	dataDir, err := appDataDir()
+	if err != nil {
+		return "", err
+	}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		if err := migrateDataFiles(filepath.Join(configDir, appName), dataDir); err != nil {
+			return "", err
+		}
 	}
 
-	return filepath.Join(appConfigDir, eventsFileName), nil
+	return filepath.Join(dataDir, eventsFileName), nil
 }
 
 var AppStyle = lipgloss.NewStyle().Margin(0, 1)
 var TitleStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color(cTextLightGray)).
-	Background(lipgloss.Color(cTitle)).
+	Foreground(paletteColor(cTextLightGray)).
+	Background(paletteColor(cTitle)).
 	Padding(0, 1)
 var SelectedTitle = lipgloss.NewStyle().
 	Border(lipgloss.NormalBorder(), false, false, false, true).
 	BorderForeground(lipgloss.AdaptiveColor{Light: cItemTitleLight, Dark: cItemTitleDark}).
 	Foreground(lipgloss.AdaptiveColor{Light: cItemTitleLight, Dark: cItemTitleDark}).
 	Padding(0, 0, 0, 1)
-var SelectedDesc = SelectedTitle.Copy().
+var SelectedDesc = SelectedTitle.
 	Foreground(lipgloss.AdaptiveColor{Light: cItemDescLight, Dark: cItemDescDark})
 var DimmedTitle = lipgloss.NewStyle().
 	Foreground(lipgloss.AdaptiveColor{Light: cDimmedTitleLight, Dark: cDimmedTitleDark}).
 	Padding(0, 0, 0, 2)
-var DimmedDesc = DimmedTitle.Copy().
+var DimmedDesc = DimmedTitle.
 	Foreground(lipgloss.AdaptiveColor{Light: cDimmedDescDark, Dark: cDimmedDescLight})
-var ErrStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(cError)).Render
-var SuccessStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(cSuccess)).Render
-var WarningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(cWarning)).Render
-var HintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(cHint)).Render
+var ErrStyle = lipgloss.NewStyle().Foreground(paletteColor(cError)).Render
+var SuccessStyle = lipgloss.NewStyle().Foreground(paletteColor(cSuccess)).Render
+var WarningStyle = lipgloss.NewStyle().Foreground(paletteColor(cWarning)).Render
+var HintStyle = lipgloss.NewStyle().Foreground(paletteColor(cHint)).Render
+var WhatIfStyle = lipgloss.NewStyle().Foreground(paletteColor(cWhatIf)).Italic(true).Render
 var NoStyle = lipgloss.NewStyle()
-var FocusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(cPromptBorder))
+var FocusedStyle = lipgloss.NewStyle().Foreground(paletteColor(cPromptBorder))
 var BlurredStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 var InputLabelStyle = lipgloss.NewStyle().
 	Foreground(lipgloss.AdaptiveColor{Light: cDimmedTitleLight, Dark: cDimmedTitleDark}).
 	Bold(true).
 	MarginTop(1)
 var DatePreviewStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color(cHint)).
+	Foreground(paletteColor(cHint)).
 	Italic(true).
 	MarginLeft(2)
 var ButtonStyle = lipgloss.NewStyle().
 	Padding(0, 2).
 	Border(lipgloss.RoundedBorder(), true).
 	BorderForeground(lipgloss.Color("240"))
-var ButtonFocusedStyle = ButtonStyle.Copy().
-	BorderForeground(lipgloss.Color(cPromptBorder)).
-	Foreground(lipgloss.Color(cPromptBorder)).
+var ButtonFocusedStyle = ButtonStyle.
+	BorderForeground(paletteColor(cPromptBorder)).
+	Foreground(paletteColor(cPromptBorder)).
 	Bold(true)
 
 var BrightTextStyle = lipgloss.NewStyle().
@@ -123,28 +156,89 @@ var NormalTextStyle = lipgloss.NewStyle().
 	Foreground(lipgloss.AdaptiveColor{Light: cDimmedDescLight, Dark: cDimmedDescDark}).Render
 
 var TimelineTitleStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color(cTextLightGray)).
-	Background(lipgloss.Color(cTitle)).
+	Foreground(paletteColor(cTextLightGray)).
+	Background(paletteColor(cTitle)).
 	Padding(0, 1).
 	MarginBottom(1)
 var TimelineTrackStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color(cTimelineTrack))
+	Foreground(paletteColor(cTimelineTrack))
 var TimelineNowStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color(cTimelineNow)).
+	Foreground(paletteColor(cTimelineNow)).
 	Bold(true)
 var TimelineSelectedStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color(cTimelineSelected)).
+	Foreground(paletteColor(cTimelineSelected)).
 	Bold(true)
 
 type keymap struct {
-	Add    key.Binding
-	Remove key.Binding
-	Edit   key.Binding
-	Next   key.Binding
-	Prev   key.Binding
-	Enter  key.Binding
-	Back   key.Binding
-	Quit   key.Binding
+	Add     key.Binding
+	Remove  key.Binding
+	Edit    key.Binding
+	Next    key.Binding
+	Prev    key.Binding
+	Enter   key.Binding
+	Submit  key.Binding // saves the form from any field
+	Back    key.Binding
+	Tags    key.Binding
+	Display key.Binding
+	Clock   key.Binding
+	Seconds key.Binding // hides seconds until the final hour
+	GoTo    key.Binding
+	// WhatIf measures the list from a typed date instead of now.
+	WhatIf key.Binding
+	// NextUpcoming and LastPassed jump to either side of now, whatever the
+	// sort order.
+	NextUpcoming key.Binding
+	LastPassed   key.Binding
+	MoveUp       key.Binding
+	MoveDown     key.Binding
+	// StepUp and StepDown change the date component under the cursor in
+	// the form, the More variants by a bigger step.
+	StepUp       key.Binding
+	StepDown     key.Binding
+	StepUpMore   key.Binding
+	StepDownMore key.Binding
+	Compare      key.Binding
+	Share        key.Binding
+	Snapshot     key.Binding // writes the frame on screen to a file
+	Mark         key.Binding // marks events to move to a group together; only with groups
+	AssignGroup  key.Binding // moves the marked events to the next group
+	Info         key.Binding
+	Stats        key.Binding
+	StatsPast    key.Binding // counts past events in the stats heatmap
+	Imminent     key.Binding
+	Dedupe       key.Binding
+	RenameTag    key.Binding // renames the selected tag in the tags view
+	KeepNewest   key.Binding // merges duplicates keeping the newest
+	KeepOldest   key.Binding
+	// SaveRetry, SaveElsewhere and SaveDiscard answer the prompt shown on
+	// quit while the events could not be saved.
+	SaveRetry     key.Binding
+	SaveElsewhere key.Binding
+	SaveDiscard   key.Binding
+	// NextPanel and PrevPanel move keyboard focus between the columns.
+	NextPanel key.Binding
+	PrevPanel key.Binding
+	// SidePanel switches what the right-hand column shows; Reload is for
+	// that column while it has focus. Retry reloads On This Day from
+	// anywhere.
+	SidePanel key.Binding
+	Reload    key.Binding
+	Retry     key.Binding
+	// ScrollUp and ScrollDown move the highlight in the side panel, Open
+	// opens what it is on. Era and Order filter and sort On This Day, Feed
+	// switches it to births and deaths.
+	ScrollUp   key.Binding
+	ScrollDown key.Binding
+	Open       key.Binding
+	Era        key.Binding
+	Order      key.Binding
+	Feed       key.Binding
+	// OptionPrev and OptionNext change the answer under the cursor in the
+	// first-run wizard and the form, and collapse and expand the section
+	// header under the cursor in the list.
+	OptionPrev key.Binding
+	OptionNext key.Binding
+	Quit       key.Binding
 }
 
 var Keymap = keymap{
@@ -169,12 +263,175 @@ var Keymap = keymap{
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 	),
+	Submit: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+	),
 	Back: key.NewBinding(
 		key.WithKeys("esc"),
 		key.WithHelp("esc", "back"),
 	),
+	Tags: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "tags"),
+	),
+	Display: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "days only"),
+	),
+	Clock: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "12/24h"),
+	),
+	Seconds: key.NewBinding(
+		key.WithKeys("."),
+		key.WithHelp(".", "seconds"),
+	),
+	GoTo: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("G", "go to date"),
+	),
+	WhatIf: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "what if"),
+	),
+	NextUpcoming: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next upcoming"),
+	),
+	LastPassed: key.NewBinding(
+		key.WithKeys("N", "P"),
+		key.WithHelp("N/P", "last passed"),
+	),
+	MoveUp: key.NewBinding(
+		key.WithKeys("ctrl+up"),
+		key.WithHelp("ctrl+↑", "move up"),
+	),
+	MoveDown: key.NewBinding(
+		key.WithKeys("ctrl+down"),
+		key.WithHelp("ctrl+↓", "move down"),
+	),
+	StepUp: key.NewBinding(
+		key.WithKeys("up"),
+	),
+	StepDown: key.NewBinding(
+		key.WithKeys("down"),
+	),
+	StepUpMore: key.NewBinding(
+		key.WithKeys("shift+up"),
+	),
+	StepDownMore: key.NewBinding(
+		key.WithKeys("shift+down"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "compare"),
+	),
+	Snapshot: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "save view"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "mark"),
+	),
+	AssignGroup: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "next group"),
+	),
+	Share: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "share"),
+	),
+	Info: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "info"),
+	),
+	Stats: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "stats"),
+	),
+	StatsPast: key.NewBinding(
+		key.WithKeys("a"),
+	),
+	Imminent: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "next 24h"),
+	),
+	Dedupe: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "duplicates"),
+	),
+	RenameTag: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "rename"),
+	),
+	KeepNewest: key.NewBinding(
+		key.WithKeys("n"),
+	),
+	KeepOldest: key.NewBinding(
+		key.WithKeys("o"),
+	),
+	SaveRetry: key.NewBinding(
+		key.WithKeys("r"),
+	),
+	SaveElsewhere: key.NewBinding(
+		key.WithKeys("a"),
+	),
+	SaveDiscard: key.NewBinding(
+		key.WithKeys("d"),
+	),
+	NextPanel: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("ctrl+l", "next panel"),
+	),
+	PrevPanel: key.NewBinding(
+		key.WithKeys("ctrl+h"),
+		key.WithHelp("ctrl+h", "prev panel"),
+	),
+	SidePanel: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "side panel"),
+	),
+	Reload: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reload"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "retry"),
+	),
+	ScrollUp: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	ScrollDown: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter", "o"),
+		key.WithHelp("o", "open"),
+	),
+	Era: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "era"),
+	),
+	Order: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "order"),
+	),
+	Feed: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "births/deaths"),
+	),
+	OptionPrev: key.NewBinding(
+		key.WithKeys("left"),
+	),
+	OptionNext: key.NewBinding(
+		key.WithKeys("right", " "),
+	),
 	Quit: key.NewBinding(
-		key.WithKeys("ctlr+c", "q"),
+		key.WithKeys("ctrl+c", "q"),
 		key.WithHelp("q", "quit"),
 	),
 }
@@ -186,6 +443,17 @@ const (
 	showInput
 	showEdit
 	noEvents
+	showTags
+	showGoTo
+	showShare
+	showInfo
+	showStats
+	showImminent
+	showDedupe
+	showUnsaved
+	showWhatIf
+	showEditReview
+	showWizard
 )
 
 type inputFields int
@@ -193,47 +461,171 @@ type inputFields int
 const (
 	inputNameField inputFields = iota
 	inputTimeField
+	inputRemindersField
+	inputRepeatField
+	inputKindField
+	inputGroupField
 	inputCancelButton
 	inputSubmitButton
 )
 
 type Event struct {
-	Name string `json:"name"`
-	Time int64  `json:"ts"`
+	Name      string      `json:"name"`
+	Time      int64       `json:"ts"`
+	Tags      []string    `json:"tags,omitempty"`
+	Yearly    bool        `json:"yearly,omitempty"`
+	LeapDay   bool        `json:"leap_day,omitempty"` // yearly on February 29th, on the 28th in common years
+	Repeat    *Recurrence `json:"repeat,omitempty"`
+	Since     int         `json:"since,omitempty"`  // year of the first occurrence, for ages
+	Source    string      `json:"source,omitempty"` // ID in the system the event was imported from
+	Order     int         `json:"order,omitempty"`  // tiebreaker among events at the same time
+	Created   int64       `json:"created,omitempty"`
+	Reminders []int64     `json:"reminders,omitempty"` // seconds before the event; none means the configured defaults
+	ReadOnly  bool        `json:"readonly,omitempty"`  // owned by Source; edit and remove refuse to touch it
+	Notes     string      `json:"notes,omitempty"`
+	Kind      string      `json:"kind,omitempty"`    // deadline, birthday or trip; picks the details shown
+	AllDay    bool        `json:"all_day,omitempty"` // at midnight and lasting the day, entered without a time
+	Group     string      `json:"group,omitempty"`   // one of the configured groups; none is the default section
+	Virtual   bool        `json:"-"`
+}
+
+// reminderOffsets returns the event's own reminder lead times, or defaults
+// when it has none.
+func (e Event) reminderOffsets(defaults []time.Duration) []time.Duration {
+	if len(e.Reminders) == 0 {
+		return defaults
+	}
+	offsets := make([]time.Duration, len(e.Reminders))
+	for i, r := range e.Reminders {
+		offsets[i] = time.Duration(r) * time.Second
+	}
+	return offsets
 }
 
 func (e Event) ToBasicString() string {
 	return time.Unix(e.Time, 0).String()
 }
 
-func (e Event) Title() string       { return e.Name }
-func (e Event) Description() string { return countdownParser(e.Time) }
+// Age returns how many years the event's next occurrence marks, or 0 when
+// the first occurrence is unknown.
+func (e Event) Age() int {
+	if e.Since == 0 {
+		return 0
+	}
+	return time.Unix(e.Time, 0).Year() - e.Since
+}
+
+func (e Event) Title() string {
+	if age := e.Age(); age > 0 {
+		return fmt.Sprintf("%s (%d)", e.Name, age)
+	}
+	return e.Name
+}
+
 func (e Event) FilterValue() string { return e.Name }
 
+// description is e's line in the list below its title.
+func (d eventDelegate) description(e Event) string {
+	if !d.whatIf.IsZero() {
+		return d.whatIfDescription(e)
+	}
+	if listFormat != nil {
+		return d.formatListItem(e)
+	}
+	if e.AllDay {
+		return d.allDayCountdown(e)
+	}
+	return d.countdown(e.Time)
+}
+
+func sortEventsByTime(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool { return eventBefore(events[i], events[j]) })
+}
+
 type MainModel struct {
-	state            sessionState
-	focus            int
-	events           list.Model
-	inputs           []textinput.Model
-	timer            timer.Model
-	inputStatus      string
-	datePreview      string
-	dateValid        bool
-	editIndex        int
-	windowWidth      int
-	windowHeight     int
-	listWidth        int
-	detailWidth      int
-	timelineWidth    int
-	onThisDay        []WikiEvent
-	onThisDayErr     error
-	onThisDayLoading bool
+	state             sessionState
+	focus             int
+	panelFocus        panel // column that gets key presses in showEvents
+	events            list.Model
+	inputs            []textinput.Model
+	timer             timer.Model
+	inputStatus       string
+	datePreview       string
+	dateValid         bool
+	editIndex         int
+	editOriginal      Event // the event being edited, as it was when editing began
+	editPending       Event // the edit shown for review before it is saved
+	wizard            wizardModel
+	formKind          string // the kind picked in the form
+	formGroup         string // the group picked in the form
+	tagPrompt         tagOp  // the tag view's bulk edit being typed, if any
+	tagInput          textinput.Model
+	filterReturn      *Event // selected before the filter, to select again after
+	windowWidth       int
+	windowHeight      int
+	forceCompact      bool // --compact: one column whatever the window's width
+	compactDetail     bool // compact mode shows the selected event in place of the list
+	listWidth         int
+	detailWidth       int
+	timelineWidth     int
+	sides             []sideProvider // contents the side panel can show
+	side              int            // index of the one shown
+	config            Config
+	clock             func() time.Time
+	tags              list.Model
+	tagScope          string
+	eventsDelegate    list.DefaultDelegate // styles and help of the events list, for eventDelegate
+	tagsDelegate      list.DefaultDelegate // the same for the tag list
+	hiddenEvents      []Event              // outside the tag scope or in a collapsed section
+	collapsed         []string             // groups whose sections are collapsed
+	marked            []Event              // marked with m to assign to a group together
+	lastTick          time.Time
+	fastTicking       bool
+	gotoInput         textinput.Model
+	compareMark       *Event    // event marked with v to compare against the selection
+	whatIf            time.Time // date the list counts from in what-if mode, zero for now
+	lastMidnight      time.Time // the midnight the list last moved on to a new day at
+	refreshPending    bool      // a refresh held back while the form was open
+	statsPast         bool      // the stats heatmap counts past events too
+	imminentSuggested bool      // the status bar has pointed to the imminent view
+	digest            []Event   // events missed since the last run, shown until a key is pressed
+	remindersError    string
+	repeatError       string
+	formEvents        []Event // sorted saved events, for conflict hints in the form
+	previewColor      string
+	dateConflict      string
+	pastConfirmed     string // date field text whose past date was confirmed
+	shareLink         string
+	shareQR           string
+	shareStatus       string
+	info              []infoRow // gathered when the info screen opens
+	readOnlyRefused   bool      // an edit or remove of a read-only event was just refused
+	saveErr           error     // why the last save failed; nil once the events are saved
+	alternatePath     string    // where the unsaved prompt offers to save the events instead
+	alternateErr      error
+	savedTo           string // alternate file the events went to on quit
+	err               error  // why the program quit, if it failed
+}
+
+// now returns the model's notion of the current time, which tests and
+// headless renders can pin with clock.
+func (m MainModel) now() time.Time {
+	if m.clock != nil {
+		return m.clock()
+	}
+	return time.Now()
 }
 
 func (m *MainModel) calculateWidths() {
 	availableWidth := m.windowWidth - 6
 
-	if availableWidth < minListWidth+minDetailWidth+minTimelineWidth {
+	if m.compact() {
+		// One column: the list or the details take the whole window.
+		h, _ := AppStyle.GetFrameSize()
+		m.listWidth = max(1, m.windowWidth-h)
+		m.detailWidth = m.windowWidth
+		m.timelineWidth = minTimelineWidth
+	} else if availableWidth < minListWidth+minDetailWidth+minTimelineWidth {
 		m.listWidth = minListWidth
 		m.detailWidth = minDetailWidth
 		m.timelineWidth = minTimelineWidth
@@ -244,46 +636,86 @@ func (m *MainModel) calculateWidths() {
 		m.timelineWidth = max(minTimelineWidth, availableWidth*60/100)
 	}
 
-	if len(m.events.Items()) >= 0 {
-		_, v := AppStyle.GetFrameSize()
-		m.events.SetSize(m.listWidth, m.windowHeight-v)
-	}
+	m.layoutLists()
 }
 
-func NewMainModel() MainModel {
-	m := MainModel{
-		state:            showEvents,
-		timer:            timer.NewWithInterval(timeout, time.Second),
-		editIndex:        -1,
-		windowWidth:      120,
-		windowHeight:     40,
-		listWidth:        minListWidth,
-		detailWidth:      minDetailWidth,
-		timelineWidth:    minTimelineWidth,
-		onThisDayLoading: true,
+func NewMainModel() (MainModel, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return MainModel{}, configError{err}
 	}
+	setLanguage(config.Language)
+	setListFormat(config.ListFormat)
 	events, err := readEventsFile()
 	if err != nil {
-		panic(err)
+		return MainModel{}, err
+	}
+	// Look for missed events before yearly ones move on to next year. The
+	// start counts as seen, so a crash cannot show the same digest again.
+	now := time.Now()
+	var digest []Event
+	var collapsed []string
+	err = updateState(func(st *appState) {
+		digest = passedSince(events, st.LastExit, now)
+		st.LastExit = now.Unix()
+		collapsed = st.CollapsedGroups
+	})
+	if err != nil {
+		return MainModel{}, err
 	}
-	items := make([]list.Item, len(events))
-	for i := range events {
-		items[i] = events[i]
+	if rollForwardRecurring(events, now, config.completionHold()) {
+		sortEventsByTime(events)
+		if err := writeEventsFile(events); err != nil {
+			return MainModel{}, err
+		}
 	}
-	m.inputs = make([]textinput.Model, 2)
+	m := newMainModel(config, events, now)
+	m.digest = digest
+	m.collapsed = collapsed
+	m.regroup()
+	return m, nil
+}
+
+// newMainModel builds the model for events without touching any files, so
+// render can use it as well.
+func newMainModel(config Config, events []Event, now time.Time) MainModel {
+	m := MainModel{
+		state:         showEvents,
+		timer:         timer.NewWithInterval(timeout, time.Second),
+		editIndex:     -1,
+		windowWidth:   120,
+		windowHeight:  40,
+		listWidth:     minListWidth,
+		detailWidth:   minDetailWidth,
+		timelineWidth: minTimelineWidth,
+		sides:         newSideProviders(),
+		config:        config,
+	}
+	m.side, _ = sideProviderIndex(config.SidePanel)
+	Keymap.Mark.SetEnabled(config.grouped())
+	Keymap.AssignGroup.SetEnabled(config.grouped())
+	events = append(events, virtualEvents(now, m.config)...)
+	m.config.sortOrder().sort(events, now)
+	var items []list.Item
+	items, m.hiddenEvents = m.sectionItems(events, now)
+	m.inputs = make([]textinput.Model, 4)
 	var t textinput.Model
 	for i := range m.inputs {
 		t = textinput.New()
-		t.CharLimit = 50
+		t.CharLimit = nameCharLimit
 		switch i {
 		case 0:
-			t.Placeholder = "e.g., Birthday Party"
+			t.Placeholder = tr("form.name_placeholder")
 			t.Focus()
 			t.PromptStyle = FocusedStyle
 			t.TextStyle = FocusedStyle
 		case 1:
 			t.Placeholder = "2025-12-31 or 2025-12-31 18:00:00"
 			t.CharLimit = 19
+		case 2:
+			t.Placeholder = strings.Join(config.Reminders, ", ")
+		case 3:
+			t.Placeholder = tr("form.repeat_placeholder")
 		}
 		m.inputs[i] = t
 	}
@@ -292,13 +724,39 @@ func NewMainModel() MainModel {
 	delegate.Styles.SelectedDesc = SelectedDesc
 	delegate.Styles.DimmedTitle = DimmedTitle
 	delegate.Styles.DimmedDesc = DimmedDesc
-	delegate.ShortHelpFunc = func() []key.Binding { return []key.Binding{Keymap.Add, Keymap.Remove, Keymap.Edit} }
-	delegate.FullHelpFunc = func() [][]key.Binding { return [][]key.Binding{{Keymap.Add, Keymap.Remove, Keymap.Edit}} }
-	m.events = list.New(items, delegate, m.listWidth, 40)
-	m.events.Title = "Events"
+	tagDelegate := delegate
+	delegate.ShortHelpFunc = func() []key.Binding {
+		return []key.Binding{Keymap.Add, Keymap.Remove, Keymap.Edit, Keymap.Tags, Keymap.Display, Keymap.Clock, Keymap.GoTo}
+	}
+	delegate.FullHelpFunc = func() [][]key.Binding {
+		return [][]key.Binding{
+			{Keymap.Add, Keymap.Remove, Keymap.Edit, Keymap.Tags, Keymap.Display, Keymap.Clock, Keymap.GoTo},
+			{Keymap.Seconds, Keymap.WhatIf, Keymap.NextUpcoming, Keymap.LastPassed, Keymap.MoveUp, Keymap.MoveDown, Keymap.Compare, Keymap.Mark, Keymap.AssignGroup, Keymap.Share, Keymap.Snapshot, Keymap.Info, Keymap.Stats, Keymap.Imminent, Keymap.Dedupe, Keymap.NextPanel, Keymap.PrevPanel, Keymap.SidePanel},
+		}
+	}
+	m.eventsDelegate = delegate
+	m.events = list.New(items, m.delegate(delegate), m.listWidth, 40)
+	m.events.Title = tr("list.events")
 	m.events.Styles.Title = TitleStyle
-	m.events.Styles.HelpStyle = lipgloss.NewStyle().Width(m.listWidth).Height(5)
+	m.events.Styles.HelpStyle = lipgloss.NewStyle().Width(m.listWidth).Height(listHelpHeight)
 	m.events.SetShowPagination(true)
+	localizeList(&m.events)
+	// G is go to date here, so going to the end is left to the End key.
+	m.events.KeyMap.GoToEnd.SetKeys("end")
+	m.events.KeyMap.GoToEnd.SetHelp("end", tr("help.go_to_end"))
+	m.gotoInput = textinput.New()
+	m.gotoInput.Prompt = tr("goto.prompt")
+	m.gotoInput.Placeholder = "2026-08-01, aug, +2w"
+	m.gotoInput.CharLimit = 19
+	tagDelegate.ShortHelpFunc = func() []key.Binding {
+		return []key.Binding{Keymap.Add, Keymap.Remove, Keymap.RenameTag, Keymap.Back}
+	}
+	m.tagsDelegate = tagDelegate
+	m.tags = list.New(nil, m.delegate(tagDelegate), m.listWidth, 40)
+	m.tags.Title = tr("list.tags")
+	m.tags.Styles.Title = TitleStyle
+	localizeList(&m.tags)
+	m.tags.Styles.HelpStyle = lipgloss.NewStyle().Width(m.listWidth).Height(listHelpHeight)
 	if len(m.events.Items()) == 0 {
 		m.state = noEvents
 	}
@@ -306,21 +764,59 @@ func NewMainModel() MainModel {
 }
 
 func (m MainModel) Init() tea.Cmd {
-	return tea.Batch(m.timer.Init(), fetchOnThisDay)
+	cmds := []tea.Cmd{m.timer.Init(), midnightTick(m.now())}
+	for _, p := range m.sides {
+		cmds = append(cmds, p.Init())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	m = model.(MainModel)
+	if m.refreshPending && !m.editing() {
+		m.refreshPending = false
+		cmd = tea.Batch(cmd, m.refreshEvents())
+	}
+	return m, tea.Batch(cmd, m.showSide())
+}
+
+func (m MainModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if _, ok := msg.(tea.KeyMsg); !ok {
+		cmds = append(cmds, m.updateSides(msg))
+	}
 	switch msg := msg.(type) {
-	case OnThisDayMsg:
-		m.onThisDayLoading = false
+	case tea.FocusMsg, tea.BlurMsg:
+		cmds = append(cmds, m.handleFocus(msg))
+	case timer.TickMsg:
+		cmds = append(cmds, m.handleFocus(msg), m.scheduleFastTick(), m.suggestImminent(m.now()))
+	case midnightMsg:
+		cmds = append(cmds, m.handleMidnight(msg))
+	case fastTickMsg:
+		m.fastTicking = false
+		cmds = append(cmds, m.scheduleFastTick())
+	case shareCopiedMsg:
 		if msg.err != nil {
-			m.onThisDayErr = msg.err
-		} else {
-			m.onThisDay = msg.events
+			m.shareStatus = ErrStyle(trf("share.copy_failed", msg.err))
+		} else if m.shareStatus == "" {
+			m.shareStatus = SuccessStyle(tr("share.copied"))
 		}
+	case viewSnapshotMsg:
+		cmds = append(cmds, m.viewSnapshotStatus(msg))
+	case shutdownMsg:
+		return m, m.shutdown()
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, Keymap.Snapshot) {
+		return m, tea.Batch(append(cmds, m.snapshotView())...)
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && len(m.digest) > 0 && !key.Matches(msg, Keymap.Quit) {
+		m.digest = nil
+		return m, tea.Batch(cmds...)
 	}
 
 	switch m.state {
@@ -333,9 +829,10 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyMsg:
 			switch {
 			case key.Matches(msg, Keymap.Add):
-				m.state = showInput
+				m.openForm(showInput)
+				m.formGroup = m.selectedGroup()
 			case key.Matches(msg, Keymap.Quit):
-				return m, tea.Quit
+				return m, m.quit()
 			}
 		}
 	case showEvents:
@@ -344,45 +841,215 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.windowWidth = msg.Width
 			m.windowHeight = msg.Height
 			m.calculateWidths()
-			_, v := AppStyle.GetFrameSize()
-			m.events.SetSize(m.listWidth, msg.Height-v)
-			m.events.Styles.HelpStyle = lipgloss.NewStyle().Width(m.listWidth).Height(5)
 		case tea.KeyMsg:
+			m.readOnlyRefused = false
 			// Don't process custom keybindings when filtering
 			if m.events.FilterState() == list.Filtering {
 				break
 			}
 			switch {
+			case key.Matches(msg, Keymap.NextPanel):
+				m.focusPanel(1)
+				return m, nil
+			case key.Matches(msg, Keymap.PrevPanel):
+				m.focusPanel(-1)
+				return m, nil
+			case key.Matches(msg, Keymap.SidePanel):
+				m.side = (m.side + 1) % len(m.sides)
+				return m, nil
+			case key.Matches(msg, Keymap.Retry) && m.sideVisible():
+				p, cmd := m.wiki().reload(m.sideContext())
+				m.setSide(p)
+				return m, cmd
+			case m.panelFocus != listPanel:
+				return m, m.updatePanel(msg)
+			}
+			if cmd, ok := m.updateSection(msg); ok {
+				return m, cmd
+			}
+			switch {
+			case m.compact() && key.Matches(msg, Keymap.Enter) && m.hasSelectedEvent():
+				m.compactDetail = !m.compactDetail
+				return m, nil
+			case m.compactDetail && key.Matches(msg, Keymap.Back):
+				m.compactDetail = false
+				return m, nil
+			case key.Matches(msg, Keymap.Back) && !m.whatIf.IsZero() && m.events.FilterState() == list.Unfiltered:
+				m.whatIf = time.Time{}
+				return m, nil
+			case key.Matches(msg, Keymap.Back) && m.compareMark != nil && m.events.FilterState() == list.Unfiltered:
+				m.compareMark = nil
+				return m, nil
+			case key.Matches(msg, Keymap.Back) && len(m.marked) > 0 && m.events.FilterState() == list.Unfiltered:
+				m.marked = nil
+				return m, nil
+			case key.Matches(msg, Keymap.Back) && m.tagScope != "" && m.events.FilterState() == list.Unfiltered:
+				m.openTags()
+				return m, nil
 			case key.Matches(msg, Keymap.Quit):
-				return m, tea.Quit
+				return m, m.quit()
 			case key.Matches(msg, Keymap.Add):
-				m.state = showInput
+				m.openForm(showInput)
+				m.formGroup = m.selectedGroup()
+			case key.Matches(msg, Keymap.Tags):
+				m.openTags()
+				return m, nil
+			case key.Matches(msg, Keymap.Display):
+				m.config.cycleDisplay()
+				return m, nil
+			case key.Matches(msg, Keymap.Seconds):
+				m.config.HideSeconds = !m.config.HideSeconds
+				return m, nil
+			case key.Matches(msg, Keymap.Clock):
+				m.config.toggleClock()
+				return m, nil
+			case key.Matches(msg, Keymap.MoveUp, Keymap.MoveDown):
+				delta := 1
+				if key.Matches(msg, Keymap.MoveUp) {
+					delta = -1
+				}
+				before := m.storedEvents()
+				if m.moveEvent(delta) {
+					return m, m.saved(m.saveChange(before))
+				}
+				return m, nil
+			case key.Matches(msg, Keymap.Compare):
+				if m.compareMark != nil {
+					m.compareMark = nil
+				} else if e, ok := m.events.SelectedItem().(Event); ok {
+					m.compareMark = &e
+				}
+				return m, nil
+			case key.Matches(msg, Keymap.Mark):
+				m.toggleMark()
+				return m, nil
+			case key.Matches(msg, Keymap.AssignGroup):
+				return m, m.assignGroup()
+			case key.Matches(msg, Keymap.Share):
+				if e, ok := m.events.SelectedItem().(Event); ok {
+					return m, m.openShare(e)
+				}
+			case key.Matches(msg, Keymap.Info):
+				m.info = m.gatherInfo()
+				m.state = showInfo
+				return m, nil
+			case key.Matches(msg, Keymap.Stats):
+				m.state = showStats
+				return m, nil
+			case key.Matches(msg, Keymap.Imminent):
+				m.state = showImminent
+				return m, nil
+			case key.Matches(msg, Keymap.Dedupe):
+				m.state = showDedupe
+				return m, nil
+			case key.Matches(msg, Keymap.GoTo):
+				m.gotoInput.Reset()
+				m.gotoInput.Prompt = tr("goto.prompt")
+				m.state = showGoTo
+				return m, m.gotoInput.Focus()
+			case key.Matches(msg, Keymap.WhatIf):
+				m.gotoInput.Reset()
+				m.gotoInput.Prompt = tr("whatif.prompt")
+				m.state = showWhatIf
+				return m, m.gotoInput.Focus()
+			case key.Matches(msg, Keymap.NextUpcoming):
+				return m, m.jumpToNow(true)
+			case key.Matches(msg, Keymap.LastPassed):
+				return m, m.jumpToNow(false)
+			case key.Matches(msg, Keymap.Edit, Keymap.Remove) && m.selectedReadOnly():
+				m.readOnlyRefused = true
+				return m, nil
 			case key.Matches(msg, Keymap.Edit):
-				if len(m.events.Items()) > 0 {
+				if event, ok := m.selectedEvent(); ok && !event.Virtual {
 					m.editIndex = m.events.Index()
-					event := m.events.SelectedItem().(Event)
+					m.editOriginal = event
+					// A longer name from a hand-edited file is not cut short.
+					m.inputs[0].CharLimit = max(nameCharLimit, utf8.RuneCountInString(event.Name))
 					m.inputs[0].SetValue(event.Name)
-					ts := time.Unix(event.Time, 0)
-					m.inputs[1].SetValue(ts.Format(inputTimeFormLong))
+					m.inputs[1].SetValue(formDateValue(event))
+					m.inputs[inputRemindersField].SetValue(formatReminders(event.Reminders))
+					m.inputs[inputRepeatField].SetValue(formatRecurrence(event.Repeat, event.Yearly))
+					m.formKind = event.Kind
+					m.formGroup = event.Group
+					m.openForm(showEdit)
 					m.updateDatePreview()
-					m.state = showEdit
+					m.updateRemindersStatus()
 				}
 			case key.Matches(msg, Keymap.Remove):
-				if len(m.events.Items()) > 0 {
-					m.events.RemoveItem(m.events.Index())
-					if err := m.saveEventsToFile(); err != nil {
-						panic(err)
-					}
-					if len(m.events.Items()) == 0 {
-						m.state = noEvents
+				if event, ok := m.selectedEvent(); ok && !event.Virtual {
+					if m.compareMark != nil && sameEvent(*m.compareMark, event) {
+						m.compareMark = nil
 					}
+					before := m.storedEvents()
+					m.removeSelected()
+					m.regroup()
+					cmds = append(cmds, m.saved(m.saveChange(before)))
+					m.returnToList()
 				}
 			}
 		}
+		filterBefore := m.events.FilterState()
+		selected, hadSelection := m.events.SelectedItem().(Event)
 		newEvents, newCmd := m.events.Update(msg)
 		m.events = newEvents
 		cmd = newCmd
-	case showInput, showEdit:
+		m.trackFilter(filterBefore, selected, hadSelection)
+	case showUnsaved:
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.windowWidth = msg.Width
+			m.windowHeight = msg.Height
+			m.calculateWidths()
+		case tea.KeyMsg:
+			return m, m.updateUnsaved(msg)
+		}
+	case showEditReview:
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.windowWidth = msg.Width
+			m.windowHeight = msg.Height
+			m.calculateWidths()
+			m.layoutForm()
+		case tea.KeyMsg:
+			cmds = append(cmds, m.updateEditReview(msg))
+		}
+	case showWizard:
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.windowWidth = msg.Width
+			m.windowHeight = msg.Height
+			m.calculateWidths()
+			m.layoutForm()
+		case tea.KeyMsg:
+			cmds = append(cmds, m.updateWizard(msg))
+		default:
+			var cmd tea.Cmd
+			m.wizard.path, cmd = m.wizard.path.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+	case showShare, showInfo, showStats, showImminent, showDedupe:
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.windowWidth = msg.Width
+			m.windowHeight = msg.Height
+			m.calculateWidths()
+		case tea.KeyMsg:
+			if m.state == showStats && key.Matches(msg, Keymap.StatsPast) {
+				m.statsPast = !m.statsPast
+				return m, nil
+			}
+			if m.state == showDedupe && key.Matches(msg, Keymap.KeepNewest, Keymap.KeepOldest) {
+				keep := keepNewest
+				if key.Matches(msg, Keymap.KeepOldest) {
+					keep = keepOldest
+				}
+				m.state = showEvents
+				return m, m.mergeDuplicates(keep)
+			}
+			m.state = showEvents
+			return m, nil
+		}
+	case showGoTo, showWhatIf:
 		switch msg := msg.(type) {
 		case tea.WindowSizeMsg:
 			m.windowWidth = msg.Width
@@ -391,68 +1058,121 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyMsg:
 			switch {
 			case key.Matches(msg, Keymap.Back):
-				m.resetInputs()
+				m.gotoInput.Blur()
+				m.state = showEvents
+				return m, nil
+			case key.Matches(msg, Keymap.Enter):
+				m.gotoInput.Blur()
+				whatIf := m.state == showWhatIf
 				m.state = showEvents
-				if len(m.events.Items()) == 0 {
-					m.state = noEvents
+				if whatIf {
+					return m, m.startWhatIf(m.gotoInput.Value())
 				}
-			case key.Matches(msg, Keymap.Next):
-				m.focus++
-				if m.focus > int(inputSubmitButton) {
-					m.focus = int(inputNameField)
+				return m, m.goToDate(m.gotoInput.Value())
+			}
+		}
+		m.gotoInput, cmd = m.gotoInput.Update(msg)
+	case showTags:
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.windowWidth = msg.Width
+			m.windowHeight = msg.Height
+			m.calculateWidths()
+		case tea.KeyMsg:
+			if m.tagPrompt != tagNone {
+				return m, m.updateTagPrompt(msg)
+			}
+			if m.tags.FilterState() == list.Filtering {
+				break
+			}
+			switch {
+			case key.Matches(msg, Keymap.Quit):
+				return m, m.quit()
+			case key.Matches(msg, Keymap.Add):
+				return m, m.openTagPrompt(tagAdd)
+			case key.Matches(msg, Keymap.RenameTag):
+				return m, m.openTagPrompt(tagRename)
+			case key.Matches(msg, Keymap.Remove):
+				return m, m.removeSelectedTag()
+			case key.Matches(msg, Keymap.Enter):
+				if group, ok := m.tags.SelectedItem().(tagGroup); ok {
+					m.enterTagScope(group.Tag)
+					m.state = showEvents
 				}
-			case key.Matches(msg, Keymap.Prev):
-				m.focus--
-				if m.focus < int(inputNameField) {
-					m.focus = int(inputSubmitButton)
+				return m, nil
+			case key.Matches(msg, Keymap.Back, Keymap.Tags) && m.tags.FilterState() == list.Unfiltered:
+				m.returnToList()
+				return m, nil
+			}
+		}
+		newTags, newCmd := m.tags.Update(msg)
+		m.tags = newTags
+		cmd = newCmd
+	case showInput, showEdit:
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
+			m.windowWidth = msg.Width
+			m.windowHeight = msg.Height
+			m.calculateWidths()
+			m.layoutForm()
+		case tea.KeyMsg:
+			switch {
+			case key.Matches(msg, Keymap.Back):
+				m.resetInputs()
+				m.returnToList()
+			case m.focus == int(inputTimeField) && key.Matches(msg, Keymap.StepUp, Keymap.StepDown, Keymap.StepUpMore, Keymap.StepDownMore):
+				m.stepDateField(msg)
+			case m.focus == int(inputKindField) && key.Matches(msg, Keymap.OptionPrev):
+				m.formKind = stepKind(m.formKind, -1)
+			case m.focus == int(inputKindField) && key.Matches(msg, Keymap.OptionNext):
+				m.formKind = stepKind(m.formKind, 1)
+			case m.focus == int(inputGroupField) && key.Matches(msg, Keymap.OptionPrev):
+				m.formGroup = m.config.stepGroup(m.formGroup, -1)
+			case m.focus == int(inputGroupField) && key.Matches(msg, Keymap.OptionNext):
+				m.formGroup = m.config.stepGroup(m.formGroup, 1)
+			default:
+				k, ok := formKeyOf(msg)
+				if !ok {
+					break
 				}
-			case key.Matches(msg, Keymap.Enter):
-				switch inputFields(m.focus) {
-				case inputNameField, inputTimeField:
-					m.focus++
-				case inputCancelButton:
+				_, invalid := m.validateInputs()
+				focus, action := formStep(inputFields(m.focus), k, invalid == nil)
+				if focus == inputGroupField && !m.config.grouped() {
+					// Without groups the field is not shown.
+					focus, action = formStep(focus, k, invalid == nil)
+				}
+				m.focus = int(focus)
+				switch action {
+				case formCancel:
 					m.resetInputs()
-					m.state = showEvents
-					if len(m.events.Items()) == 0 {
-						m.state = noEvents
-					}
-				case inputSubmitButton:
+					m.returnToList()
+				case formSave:
 					e, err := m.validateInputs()
 					if err != nil {
 						m.inputs[inputNameField].Reset()
 						m.inputs[inputTimeField].Reset()
+						m.inputs[inputRemindersField].Reset()
+						m.inputs[inputRepeatField].Reset()
+						m.remindersError = ""
+						m.repeatError = ""
 						m.focus = 0
-						m.inputStatus = fmt.Sprintf("Error: %v", err)
+						m.inputStatus = trf("form.error", err)
 						m.datePreview = ""
 						m.dateValid = false
 						break
 					}
-
-					if m.state == showEdit {
-						m.events.RemoveItem(m.editIndex)
-					}
-
-					if len(m.events.Items()) == 0 {
-						m.events.InsertItem(0, e)
-					} else {
-						index := 0
-						for _, item := range m.events.Items() {
-							if e.Time >= item.(Event).Time {
-								index++
-							}
-						}
-						m.events.InsertItem(index, e)
+					if m.unconfirmedPast(e) {
+						m.pastConfirmed = m.inputs[inputTimeField].Value()
+						break
 					}
-
-					if err := m.saveEventsToFile(); err != nil {
-						panic(err)
+					if m.state == showEdit && m.reviewEdit(e) {
+						break
 					}
 
+					cmds = append(cmds, m.saveForm(e, m.state == showEdit))
 					newEvents, newCmd := m.events.Update(msg)
 					m.events = newEvents
 					cmd = newCmd
-					m.resetInputs()
-					m.state = showEvents
 				}
 			}
 		}
@@ -463,6 +1183,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 		m.updateDatePreview()
+		m.updateRemindersStatus()
 	}
 	timerModel, timerCmd := m.timer.Update(msg)
 	m.timer = timerModel
@@ -471,62 +1192,241 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// View renders the model as of a single instant, so the list items and the
+// detail column never disagree about a second that ticked over mid-render.
 func (m MainModel) View() string {
+	now := m.now()
+	m.clock = func() time.Time { return now }
+	return m.view()
+}
+
+func (m MainModel) view() string {
 	switch m.state {
 	case noEvents:
 		content := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(cPromptBorder)).
+			BorderForeground(paletteColor(cPromptBorder)).
 			Padding(2, 4).
-			Render("No events, add one with '+'\n\nPress 'q' to quit")
+			Render(tr("empty.message"))
 		return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, content)
 	case showInput:
-		return m.inputView("✨ New Event")
+		return m.inputView(tr("form.new"))
 	case showEdit:
-		return m.inputView("✏️  Edit Event")
+		return m.inputView(tr("form.edit"))
+	case showTags:
+		if help, ok := m.shortListHelp(m.tags); ok {
+			return help
+		}
+		m.tags.SetDelegate(m.delegate(m.tagsDelegate))
+		tags := m.tags.View()
+		if m.tagPrompt != tagNone {
+			tags = lipgloss.JoinVertical(lipgloss.Left, tags, m.tagInput.View())
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, AppStyle.Render(tags), m.renderSide())
+	case showShare:
+		return m.shareView()
+	case showInfo:
+		return m.infoView()
+	case showStats:
+		return m.statsView()
+	case showImminent:
+		return m.imminentView()
+	case showDedupe:
+		return m.dedupeView()
+	case showUnsaved:
+		return m.unsavedView()
+	case showEditReview:
+		return m.editReviewView()
+	case showWizard:
+		return m.wizardView()
 	default:
-		listStr := AppStyle.Render(m.events.View())
-		if m.events.SelectedItem() == nil {
+		if n := countToday(m.events.Items(), m.now()); n > 0 {
+			m.events.SetStatusBarItemName(trf("today.count", tr("list.item"), n), trf("today.count", tr("list.items"), n))
+		}
+		if help, ok := m.shortListHelp(m.events); ok && m.state == showEvents {
+			return help
+		}
+		listStr := AppStyle.Render(m.listView(m.events))
+		if m.state == showGoTo || m.state == showWhatIf {
+			// The prompt takes the place of the list title.
+			events := m.events
+			events.SetShowTitle(false)
+			listStr = AppStyle.Render("  " + m.gotoInput.View() + "\n\n" + m.listView(events))
+		} else if !m.whatIf.IsZero() {
+			events := m.events
+			events.SetShowTitle(false)
+			listStr = AppStyle.Render("  " + m.whatIfBanner() + "\n\n" + m.listView(events))
+		}
+		if m.compact() {
+			if m.compactDetail && m.hasSelectedEvent() && m.events.FilterState() != list.Filtering {
+				return m.compactDetailView()
+			}
+			return listStr
+		}
+		if m.noMatches() {
+			return lipgloss.JoinHorizontal(lipgloss.Top, listStr, m.renderNoMatches(), m.renderSide())
+		}
+		if !m.hasSelectedEvent() {
 			return listStr
 		}
 		detailStr := m.detailsString()
-		onThisDayStr := m.renderOnThisDay()
-		return lipgloss.JoinHorizontal(lipgloss.Top, listStr, detailStr, onThisDayStr)
+		sideStr := m.renderSide()
+		if len(m.digest) > 0 {
+			sideStr = m.renderDigest()
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, listStr, detailStr, sideStr)
 	}
 }
 
 func main() {
-	p := tea.NewProgram(NewMainModel(), tea.WithAltScreen())
-	if err := p.Start(); err != nil {
-		fmt.Printf("There was an error: %v", err)
-		os.Exit(1)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "add":
+			os.Exit(runAdd(os.Args[2:]))
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "daemon":
+			os.Exit(runDaemon(os.Args[2:]))
+		case "notify-test":
+			os.Exit(runNotifyTest(os.Args[2:]))
+		case "snapshot":
+			os.Exit(runSnapshot(os.Args[2:]))
+		case "serve":
+			os.Exit(runServe(os.Args[2:]))
+		case "render":
+			os.Exit(runRender(os.Args[2:]))
+		case "digest":
+			os.Exit(runDigest(os.Args[2:]))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:]))
+		case "path":
+			os.Exit(runPath(os.Args[2:]))
+		case "watch":
+			os.Exit(runWatch(os.Args[2:]))
+		case "tmux-status":
+			os.Exit(runTmuxStatus(os.Args[2:]))
+		case "backup":
+			os.Exit(runBackup(os.Args[2:]))
+		case "dedupe":
+			os.Exit(runDedupe(os.Args[2:]))
+		case "tag":
+			os.Exit(runTag(os.Args[2:]))
+		}
 	}
+
+	os.Exit(runTUI(os.Args[1:]))
 }
 
-func getUrgencyColor(ts int64) string {
-	t := time.Unix(ts, 0)
-	diff := time.Until(t)
+// tuiInput is where the program reads keys from instead of the terminal,
+// which it opens even when stdin is redirected. Tests set it to run the
+// program in a child process that has no terminal.
+var tuiInput io.Reader
+
+// runTUI runs the interactive program and returns the exit status: 2 when
+// the config file needs fixing or the flags are wrong, 3 when the events
+// file cannot be set up, 1 for any other failure.
+func runTUI(args []string) int {
+	fs := flag.NewFlagSet("countdown", flag.ContinueOnError)
+	compact := fs.Bool("compact", false, "show one column at a time, as for a phone-sized terminal")
+	noWizard := fs.Bool("no-wizard", false, "skip the first-run setup questions")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "countdown: unknown command %q\n", fs.Arg(0))
+		return 2
+	}
+	// A failure here is reported by starting up below.
+	if path, err := resolveEventsFilePath(); err == nil {
+		eventsFilePath = path
+		defer func() { eventsFilePath = "" }()
+	}
+	if err := recoverJournal(os.Stdin, os.Stderr, isatty.IsTerminal(os.Stdin.Fd())); err != nil {
+		fmt.Fprintf(os.Stderr, "countdown: %v\n", err)
+		return 1
+	}
+	// Ask before starting up, which creates the events file.
+	firstRun := !*noWizard && isFirstRun()
+	m, err := NewMainModel()
+	var invalid *invalidEventsError
+	if errors.As(err, &invalid) && len(invalid.Good) > 0 && isatty.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprintf(os.Stderr, "countdown: %v\n", err)
+		if !confirmQuarantine(invalid, os.Stdin, os.Stderr) {
+			return 1
+		}
+		if err = quarantineEvents(invalid); err == nil {
+			m, err = NewMainModel()
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "countdown: %v\n", err)
+		var cfgErr configError
+		if errors.As(err, &cfgErr) {
+			return 2
+		}
+		var setupErr *setupError
+		if errors.As(err, &setupErr) {
+			return exitSetup
+		}
+		return 1
+	}
 
-	if diff < 0 {
-		return cPast
+	m.forceCompact = *compact
+	if firstRun {
+		m.openWizard()
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithReportFocus(), tea.WithoutSignalHandler()}
+	if tuiInput != nil {
+		opts = append(opts, tea.WithInput(tuiInput))
+	}
+	p := tea.NewProgram(m, opts...)
+	stopSignals := forwardSignals(p)
+	final, err := p.Run()
+	stopSignals()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "countdown: %v\n", err)
+		return 1
+	}
+	m = final.(MainModel)
+	if m.savedTo != "" {
+		fmt.Fprintf(os.Stderr, "countdown: events saved to %s\n", m.savedTo)
+	}
+	if m.err != nil {
+		fmt.Fprintf(os.Stderr, "countdown: %v\n", m.err)
+		return 1
+	}
+	if err := updateState(func(st *appState) { st.LastExit = time.Now().Unix() }); err != nil {
+		fmt.Fprintf(os.Stderr, "countdown: %v\n", err)
+		return 1
+	}
+	if m.config.QuitSummary {
+		summary := renderQuitSummary(m)
+		if !isatty.IsTerminal(os.Stdout.Fd()) {
+			summary = stripANSI(summary)
+		}
+		fmt.Print(summary)
 	}
+	return 0
+}
 
-	days := diff.Hours() / 24
+// urgencyBucket classifies how soon ts is relative to now: 0 for past
+// events, then 1 (more than 30 days away) through 6 (less than a day away).
+func urgencyBucket(ts int64, now time.Time) int {
+	return when.UrgencyBucket(now, time.Unix(ts, 0))
+}
 
-	switch {
-	case days < 1:
-		return cUrgency6 // < 1 day - dark red
-	case days < 3:
-		return cUrgency5 // 1-3 days - red
-	case days < 7:
-		return cUrgency4 // 3-7 days - orange
-	case days < 14:
-		return cUrgency3 // 7-14 days - yellow
-	case days < 30:
-		return cUrgency2 // 14-30 days - light green
-	default:
-		return cUrgency1 // > 30 days - green
-	}
+var urgencyColors = [...]string{
+	cPast,     // past events - purple
+	cUrgency1, // > 30 days - green
+	cUrgency2, // 14-30 days - light green
+	cUrgency3, // 7-14 days - yellow
+	cUrgency4, // 3-7 days - orange
+	cUrgency5, // 1-3 days - red
+	cUrgency6, // < 1 day - dark red
+}
+
+func getUrgencyColor(ts int64, now time.Time) string {
+	return urgencyColors[urgencyBucket(ts, now)]
 }
 
 func formatLargeNumber(n int64) string {
@@ -593,8 +1493,8 @@ func renderProgressBar(value, max float64, width int, color string) string {
 		filled = width
 	}
 
-	filledStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
-	emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(cBarEmpty))
+	filledStyle := lipgloss.NewStyle().Foreground(paletteColor(color))
+	emptyStyle := lipgloss.NewStyle().Foreground(paletteColor(cBarEmpty))
 
 	bar := filledStyle.Render(strings.Repeat("█", filled)) +
 		emptyStyle.Render(strings.Repeat("░", width-filled))
@@ -604,10 +1504,10 @@ func renderProgressBar(value, max float64, width int, color string) string {
 
 func renderTimeBlocks(years, days, hours, minutes, seconds int, color string, width int) string {
 	var b strings.Builder
-	blockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	blockStyle := lipgloss.NewStyle().Foreground(paletteColor(color))
 	emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#333333"))
-	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(cDimmedDescDark)).Width(10)
-	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(cDimmedTitleDark)).Width(4).Align(lipgloss.Right)
+	labelStyle := lipgloss.NewStyle().Foreground(paletteColor(cDimmedDescDark)).Width(10)
+	valueStyle := lipgloss.NewStyle().Foreground(paletteColor(cDimmedTitleDark)).Width(4).Align(lipgloss.Right)
 
 	// Calculate max bar width
 	barWidth := width - 20
@@ -625,25 +1525,31 @@ func renderTimeBlocks(years, days, hours, minutes, seconds int, color string, wi
 	}
 
 	units := []timeUnit{
-		{"Years", years, 10},
-		{"Days", days, 365},
-		{"Hours", hours, 24},
-		{"Minutes", minutes, 60},
-		{"Seconds", seconds, 60},
+		{tr("detail.years"), years, 10},
+		{tr("detail.days"), days, 365},
+		{tr("detail.hours"), hours, 24},
+		{tr("detail.minutes"), minutes, 60},
+		{tr("detail.seconds"), seconds, 60},
 	}
 
-	for _, unit := range units {
-		if unit.value == 0 && unit.label == "Years" {
-			continue
-		}
+	// Years has no natural maximum, so a long way out its bar fills up
+	// and the number carries the scale.
+	if years > units[0].maxValue {
+		units[0].maxValue = years
+	}
 
-		blocks := (unit.value * barWidth) / unit.maxValue
+	// Leading zero units are noise: a two-minute countdown shows only
+	// minutes and seconds. Seconds always show.
+	first := 0
+	for first < len(units)-1 && units[first].value == 0 {
+		first++
+	}
+
+	for _, unit := range units[first:] {
+		blocks := min(unit.value*barWidth/unit.maxValue, barWidth)
 		if unit.value > 0 && blocks == 0 {
 			blocks = 1
 		}
-		if blocks > barWidth {
-			blocks = barWidth
-		}
 
 		b.WriteString(labelStyle.Render(unit.label))
 		b.WriteString(valueStyle.Render(fmt.Sprintf("%d", unit.value)))
@@ -659,101 +1565,135 @@ func renderTimeBlocks(years, days, hours, minutes, seconds int, color string, wi
 func (m MainModel) timelineStyle() lipgloss.Style {
 	return lipgloss.NewStyle().
 		Width(m.timelineWidth).
-		Height(m.windowHeight-4).
+		Height(max(0, m.windowHeight-4)).
+		MaxHeight(m.windowHeight).
 		Padding(1, 2).
 		Border(lipgloss.ThickBorder(), false, false, false, true).
-		BorderForeground(lipgloss.Color(cTimelineFuture))
+		BorderForeground(m.borderColor(sidePanel, paletteColor(cTimelineFuture)))
 }
 
 func (m MainModel) detailsString() string {
+	selected := m.events.SelectedItem().(Event)
+	if m.compareMark != nil && !sameEvent(*m.compareMark, selected) {
+		return m.renderComparison(*m.compareMark, selected)
+	}
+	return m.renderDetails(selected)
+}
+
+func (m MainModel) renderDetails(event Event) string {
 	var b strings.Builder
-	event := m.events.SelectedItem().(Event)
-	urgencyColor := getUrgencyColor(event.Time)
+	now := m.now()
+	remaining, final := finalCountdown(event.Time, now, m.config.completionHold())
+	if final && remaining == 0 {
+		// Hold everything at the moment of the event.
+		now = time.Unix(event.Time, 0)
+	}
+	urgencyColor := event.urgencyColor(now)
 
 	titleStyle := lipgloss.NewStyle().
 		Width(m.detailWidth-6).
-		Foreground(lipgloss.Color(cTextLightGray)).
-		Background(lipgloss.Color(urgencyColor)).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(urgencyColor)).
 		Padding(0, 1).
 		Align(lipgloss.Center)
 
-	b.WriteString(titleStyle.Render(event.Name) + "\n\n")
+	b.WriteString(titleStyle.Render(fitTitle(event.Title(), m.detailWidth-8)) + "\n\n")
 
 	ts := time.Unix(event.Time, 0)
 
 	b.WriteString(NormalTextStyle("📅 "))
-	b.WriteString(BrightTextStyle(ts.Format("Monday, January 2, 2006")) + "\n")
+	b.WriteString(BrightTextStyle(ts.Format(m.config.dateLayout())) + "\n")
 	b.WriteString(NormalTextStyle("🕐 "))
-	b.WriteString(BrightTextStyle(ts.Format("3:04:05 PM MST")) + "\n\n")
+	b.WriteString(BrightTextStyle(m.config.eventTime(event)) + "\n")
+	if m.config.ShowWeekNumbers {
+		weeks := weeksBetween(now, ts, m.config.weekStart())
+		weeksStr := trn("weeks.left", weeks)
+		if ts.Before(now) {
+			weeksStr = trn("weeks.ago", weeks)
+		}
+		b.WriteString(NormalTextStyle("🗓  "))
+		b.WriteString(BrightTextStyle(weekLabel(ts, m.config.weekStart())))
+		b.WriteString(NormalTextStyle(" · "+weeksStr) + "\n")
+	}
+	if m.config.ShowDayOfYear {
+		b.WriteString(m.dayOfYearLines(event, now))
+	}
+	if repeat := describeRecurrence(event); repeat != "" {
+		b.WriteString(NormalTextStyle("🔁 "))
+		b.WriteString(BrightTextStyle(repeat) + "\n")
+	}
+	if event.ReadOnly {
+		b.WriteString(lipgloss.NewStyle().Width(m.detailWidth-6).Render(NormalTextStyle("🔒 ")+m.readOnlyLine(event)) + "\n")
+	}
+	if !m.whatIf.IsZero() {
+		b.WriteString(m.whatIfLine(event))
+	}
+	b.WriteString("\n")
 
 	countdownTitleStyle := lipgloss.NewStyle().
 		Width(m.detailWidth-6).
-		Foreground(lipgloss.Color(cTextLightGray)).
-		Background(lipgloss.Color(urgencyColor)).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(urgencyColor)).
 		Padding(0, 1).
 		Align(lipgloss.Center)
 
-	diff := time.Until(ts).Seconds()
-	isPast := diff < 0
-	if isPast {
-		b.WriteString(countdownTitleStyle.Render("⏪ Time Since") + "\n\n")
+	diff := ts.Sub(now).Seconds()
+	s := when.Breakdown(now, ts)
+	if s.Past {
+		b.WriteString(countdownTitleStyle.Render(tr("detail.time_since")) + "\n\n")
 		diff = -diff
 	} else {
-		b.WriteString(countdownTitleStyle.Render("⏳ Time Until") + "\n\n")
+		b.WriteString(countdownTitleStyle.Render(tr("detail.time_until")) + "\n\n")
 	}
 
-	totalSeconds := int(diff)
-	years := totalSeconds / secondsPerYear
-	days := (totalSeconds - years*secondsPerYear) / secondsPerDay
-	hours := (totalSeconds - years*secondsPerYear - days*secondsPerDay) / secondsPerHour
-	minutes := (totalSeconds - years*secondsPerYear - days*secondsPerDay - hours*secondsPerHour) / secondsPerMinute
-	seconds := totalSeconds - years*secondsPerYear - days*secondsPerDay - hours*secondsPerHour - minutes*secondsPerMinute
-
-	b.WriteString(renderTimeBlocks(years, days, hours, minutes, seconds, urgencyColor, m.detailWidth))
+	b.WriteString(renderTimeBlocks(s.Years, s.Days, s.Hours, s.Minutes, s.Seconds, urgencyColor, m.detailWidth))
 	b.WriteString("\n\n")
 
 	compactStyle := lipgloss.NewStyle().
 		Width(m.detailWidth - 6).
 		Align(lipgloss.Center).
-		Foreground(lipgloss.Color(urgencyColor)).
+		Foreground(paletteColor(urgencyColor)).
 		Bold(true)
 
-	var countdownStr string
-	if years > 0 {
-		countdownStr = fmt.Sprintf("%dy %dd %dh %dm %ds", years, days, hours, minutes, seconds)
-	} else if days > 0 {
-		countdownStr = fmt.Sprintf("%dd %dh %dm %ds", days, hours, minutes, seconds)
-	} else if hours > 0 {
-		countdownStr = fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
-	} else if minutes > 0 {
-		countdownStr = fmt.Sprintf("%dm %ds", minutes, seconds)
-	} else {
-		countdownStr = fmt.Sprintf("%ds", seconds)
+	countdownStr := m.config.countdownFormat().format(event.Time, now)
+	if final {
+		countdownStr = formatTenths(remaining)
+	} else if event.AllDay {
+		countdownStr = allDayCountdown(event.Time, now)
 	}
-	if isPast {
-		countdownStr += " ago"
+	b.WriteString(compactStyle.Render(countdownStr) + "\n")
+	if final && remaining == 0 {
+		b.WriteString(compactStyle.Render(trf("detail.arrived", event.Name)) + "\n")
 	}
-	b.WriteString(compactStyle.Render(countdownStr) + "\n\n")
+	b.WriteString("\n")
+
+	b.WriteString(m.kindSection(event, now))
 
-	progressWidth := m.detailWidth - 30
-	if progressWidth < 10 {
-		progressWidth = 10
+	if reminders := upcomingReminders(event, m.config.reminderOffsets(), now); len(reminders) > 0 {
+		reminderLabelStyle := lipgloss.NewStyle().Width(16).Foreground(lipgloss.AdaptiveColor{Light: cDimmedDescLight, Dark: cDimmedDescDark})
+		b.WriteString(NormalTextStyle(tr("detail.reminders")) + "\n")
+		for _, offset := range reminders {
+			at := time.Unix(event.Time, 0).Add(-offset)
+			b.WriteString(reminderLabelStyle.Render(trf("detail.reminder_before", formatLeadTime(offset))))
+			b.WriteString(BrightTextStyle(formatCountdown(at.Unix(), now)) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if rank := m.renderRank(event, now); rank != "" {
+		b.WriteString(rank + "\n")
 	}
-	if progressWidth > 30 {
-		progressWidth = 30
+	if s := m.renderStretch(event, now); s != "" {
+		b.WriteString(s + "\n")
 	}
-	b.WriteString(NormalTextStyle("Day progress: "))
-	dayProgress := float64(hours*3600+minutes*60+seconds) / float64(secondsPerDay)
-	b.WriteString(renderProgressBar(dayProgress, 1.0, progressWidth, urgencyColor))
-	b.WriteString(fmt.Sprintf(" %.1f%%\n\n", dayProgress*100))
 
 	statsTitleStyle := lipgloss.NewStyle().
 		Width(m.detailWidth-6).
-		Foreground(lipgloss.Color(cTextLightGray)).
-		Background(lipgloss.Color(cTitle)).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cTitle)).
 		Padding(0, 1).
 		Align(lipgloss.Center)
-	b.WriteString(statsTitleStyle.Render("📊 Statistics") + "\n\n")
+	b.WriteString(statsTitleStyle.Render(tr("detail.statistics")) + "\n\n")
 
 	totalSecondsFloat := diff
 	totalMinutes := totalSecondsFloat / float64(secondsPerMinute)
@@ -767,161 +1707,203 @@ func (m MainModel) detailsString() string {
 	statsValueStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.AdaptiveColor{Light: cDimmedTitleLight, Dark: cDimmedTitleDark})
 
-	b.WriteString(statsLabelStyle.Render("Total seconds:"))
+	b.WriteString(statsLabelStyle.Render(tr("detail.total_seconds")))
 	b.WriteString(statsValueStyle.Render(formatLargeNumber(int64(totalSecondsFloat))) + "\n")
-	b.WriteString(statsLabelStyle.Render("Total minutes:"))
+	b.WriteString(statsLabelStyle.Render(tr("detail.total_minutes")))
 	b.WriteString(statsValueStyle.Render(formatLargeFloat(totalMinutes, 2)) + "\n")
-	b.WriteString(statsLabelStyle.Render("Total hours:"))
+	b.WriteString(statsLabelStyle.Render(tr("detail.total_hours")))
 	b.WriteString(statsValueStyle.Render(formatLargeFloat(totalHours, 2)) + "\n")
-	b.WriteString(statsLabelStyle.Render("Total days:"))
+	b.WriteString(statsLabelStyle.Render(tr("detail.total_days")))
 	b.WriteString(statsValueStyle.Render(formatLargeFloat(totalDays, 2)) + "\n")
-	b.WriteString(statsLabelStyle.Render("Total years:"))
+	b.WriteString(statsLabelStyle.Render(tr("detail.total_years")))
 	b.WriteString(statsValueStyle.Render(formatLargeFloat(totalYears, 4)) + "\n")
 
+	if p, ok := eventPace(event.Created, event.Time, now); ok {
+		b.WriteString("\n")
+		b.WriteString(NormalTextStyle(trf("detail.pace_daily", p.DailyShare)) + "\n")
+		b.WriteString(NormalTextStyle(trf("detail.pace_halfway", p.Halfway.Format(m.config.dateLayout()))) + "\n")
+		if p.HasBurn {
+			b.WriteString(NormalTextStyle(trf("detail.pace_burn", p.WeeklyBurn)) + "\n")
+		}
+	}
+
 	detailStyle := lipgloss.NewStyle().
 		Width(m.detailWidth).
+		MaxHeight(m.windowHeight).
 		Padding(1, 2).
 		Border(lipgloss.ThickBorder(), false, false, false, true).
-		BorderForeground(lipgloss.AdaptiveColor{Light: cItemTitleLight, Dark: cItemTitleDark})
+		BorderForeground(m.borderColor(detailPanel, lipgloss.AdaptiveColor{Light: cItemTitleLight, Dark: cItemTitleDark}))
 
 	return detailStyle.Render(b.String())
 }
 
-func countdownParser(ts int64) string {
-	t := time.Unix(ts, 0)
-	diff := int(time.Until(t).Seconds())
-	isPast := diff < 0
-	if isPast {
-		diff = -diff
-	}
-	years := diff / secondsPerYear
-	days := (diff - years*secondsPerYear) / secondsPerDay
-	hours := (diff - years*secondsPerYear - days*secondsPerDay) / secondsPerHour
-	minutes := (diff - years*secondsPerYear - days*secondsPerDay - hours*secondsPerHour) / secondsPerMinute
-	seconds := diff - years*secondsPerYear - days*secondsPerDay - hours*secondsPerHour - minutes*secondsPerMinute
-	var result string
-	if years > 0 {
-		result = fmt.Sprintf("%dy %dd %dh %dm %ds", years, days, hours, minutes, seconds)
-	} else if days > 0 {
-		result = fmt.Sprintf("%dd %dh %dm %ds", days, hours, minutes, seconds)
-	} else if hours > 0 {
-		result = fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
-	} else if minutes > 0 {
-		result = fmt.Sprintf("%dm %ds", minutes, seconds)
-	} else {
-		result = fmt.Sprintf("%ds", seconds)
+// countdown is the countdown to ts in the list, colored by urgency.
+func (d eventDelegate) countdown(ts int64) string {
+	color := getUrgencyColor(ts, d.now)
+	coloredStyle := lipgloss.NewStyle().Foreground(paletteColor(color))
+	if d.compact {
+		return coloredStyle.Render(d.format.short(ts, d.now))
 	}
+	return coloredStyle.Render(d.format.format(ts, d.now))
+}
 
-	color := getUrgencyColor(ts)
-	coloredStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+// formatCountdown returns the uncolored countdown from now to ts, suffixed
+// with "ago" for past events.
+func formatCountdown(ts int64, now time.Time) string {
+	return formatCountdownSeconds(ts, now, true)
+}
 
-	if isPast {
-		result = coloredStyle.Render(result + " ago")
-	} else {
-		result = coloredStyle.Render(result)
+// formatCountdownSeconds is formatCountdown with the seconds left off
+// unless seconds is set. Under a minute they always show.
+func formatCountdownSeconds(ts int64, now time.Time, seconds bool) string {
+	s := when.Breakdown(now, time.Unix(ts, 0))
+	if s.Past {
+		return trf("countdown.ago", s.Units(seconds))
 	}
-	return result
+	return s.Units(seconds)
 }
 
 func readEventsFile() ([]Event, error) {
 	eventsFile, err := getEventsFilePath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get events file path: %w", err)
+		return nil, err
 	}
 
-	var events []Event
-	if _, err := os.Stat(eventsFile); errors.Is(err, os.ErrNotExist) {
-		_, err := os.Create(eventsFile)
-		if err != nil {
-			return events, err
-		}
-		event := nextGolangAnniversary()
-		events = append(events, event)
-		bytes, err := json.MarshalIndent(events, "", "  ")
-		if err != nil {
-			return events, err
-		}
-		err = os.WriteFile(eventsFile, bytes, 0644)
-		return events, err
+	info, err := os.Stat(eventsFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return seedEventsFile(eventsFile)
+	} else if err == nil && info.IsDir() {
+		return nil, eventsDirError(eventsFile)
 	}
 	bytes, err := os.ReadFile(eventsFile)
 	if err != nil {
-		return events, err
+		return nil, fmt.Errorf("failed to read %s: %w", eventsFile, err)
 	}
-	err = json.Unmarshal(bytes, &events)
-	if err != nil {
-		return events, err
+	return decodeEvents(eventsFile, bytes)
+}
+
+// storedEvents returns the events that belong in the events file.
+func (m MainModel) storedEvents() []Event {
+	all := m.allEvents()
+	events := make([]Event, 0, len(all))
+	for _, e := range all {
+		if !e.Virtual {
+			events = append(events, e)
+		}
 	}
-	return events, nil
+	return events
 }
 
+// saveEventsToFile writes the events file and, once it is written, clears
+// the journal of the changes it now holds.
 func (m MainModel) saveEventsToFile() error {
-	eventsFile, err := getEventsFilePath()
-	if err != nil {
-		return fmt.Errorf("failed to get events file path: %w", err)
+	if err := writeEventsFile(m.storedEvents()); err != nil {
+		return err
 	}
+	return clearJournal()
+}
 
-	items := m.events.Items()
-	events := make([]Event, len(items))
-	for i := range items {
-		events[i] = items[i].(Event)
+// saveChange records the change from before in the journal, then saves.
+func (m MainModel) saveChange(before []Event) error {
+	if entry, ok := journalChanges(before, m.storedEvents()); ok {
+		if err := appendJournal(entry); err != nil {
+			return err
+		}
 	}
-	bytes, err := json.MarshalIndent(events, "", "  ")
+	return m.saveEventsToFile()
+}
+
+func writeEventsFile(events []Event) error {
+	eventsFile, err := getEventsFilePath()
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(eventsFile, bytes, 0644)
-	return err
+
+	if err := writeEventsTo(eventsFile, events); err != nil {
+		return fmt.Errorf("failed to save events to %s: %w", eventsFile, err)
+	}
+	return nil
 }
 
 func (m MainModel) inputView(title string) string {
-	var b strings.Builder
-
-	inputWidth := m.windowWidth / 2
-	if inputWidth < 50 {
-		inputWidth = 50
+	if !m.formSize().fits {
+		return m.formTooSmall()
 	}
-	if inputWidth > 80 {
-		inputWidth = 80
+	// A short window gets the form without its hints before giving up.
+	form := m.renderForm(title, false)
+	if lipgloss.Height(form) > m.windowHeight {
+		form = m.renderForm(title, true)
 	}
+	if lipgloss.Height(form) > m.windowHeight {
+		return m.formTooSmall()
+	}
+	// Center the input form
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, form)
+}
+
+// renderForm draws the add/edit form. The short form leaves out the hints
+// and the space around the fields, keeping only what is needed to fill it in.
+func (m MainModel) renderForm(title string, short bool) string {
+	var b strings.Builder
+
+	size := m.formSize()
+	contentWidth := size.content
 
 	titleStyle := lipgloss.NewStyle().
-		Width(inputWidth-6).
-		Foreground(lipgloss.Color(cTextLightGray)).
-		Background(lipgloss.Color(cDetailTitle)).
+		Width(contentWidth-2).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
 		Padding(0, 1).
 		Align(lipgloss.Center)
 
-	b.WriteString(titleStyle.Render(title) + "\n\n")
+	b.WriteString(titleStyle.Render(title) + "\n")
+	if !short {
+		b.WriteString("\n")
+	}
 
 	fieldStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder(), true).
 		BorderForeground(lipgloss.Color("240")).
 		Padding(0, 1).
-		Width(inputWidth - 10)
-	fieldFocusedStyle := fieldStyle.Copy().
-		BorderForeground(lipgloss.Color(cPromptBorder))
+		Width(size.field)
+	fieldFocusedStyle := fieldStyle.
+		BorderForeground(paletteColor(cPromptBorder))
+	labelStyle := InputLabelStyle
+	if m.compact() {
+		labelStyle = labelStyle.MarginTop(0)
+	}
 
-	b.WriteString(InputLabelStyle.Render("📝 Event Name") + "\n")
+	label := labelStyle.Render(tr("form.name"))
+	if counter := nameCounter(m.inputs[inputNameField].Value(), m.inputs[inputNameField].CharLimit); counter != "" {
+		gap := size.field + 2 - lipgloss.Width(label) - lipgloss.Width(counter)
+		label = lipgloss.JoinHorizontal(lipgloss.Bottom, label, strings.Repeat(" ", max(gap, 1)), counter)
+	}
+	b.WriteString(label + "\n")
 	nameFieldStyle := fieldStyle
 	if m.focus == int(inputNameField) {
 		nameFieldStyle = fieldFocusedStyle
 	}
 	b.WriteString(nameFieldStyle.Render(m.inputs[0].View()) + "\n")
 
-	b.WriteString(InputLabelStyle.Render("📅 Date & Time") + "\n")
+	b.WriteString(labelStyle.Render(tr("form.datetime")) + "\n")
 	timeFieldStyle := fieldStyle
 	if m.focus == int(inputTimeField) {
 		timeFieldStyle = fieldFocusedStyle
 	}
 	b.WriteString(timeFieldStyle.Render(m.inputs[1].View()) + "\n")
 
-	b.WriteString(HintStyle("   Format: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS") + "\n")
-	b.WriteString(HintStyle("   Example: 2025-12-31 or 2025-12-31 18:30:00") + "\n")
+	if !short {
+		b.WriteString(formHint(tr("form.format_hint"), contentWidth) + "\n")
+		b.WriteString(formHint(tr("form.example_hint"), contentWidth) + "\n")
+		b.WriteString(formHint(tr("form.step_hint"), contentWidth) + "\n")
+	}
 
 	if m.datePreview != "" {
 		if m.dateValid {
-			b.WriteString(DatePreviewStyle.Render("→ "+m.datePreview) + "\n")
+			b.WriteString(DatePreviewStyle.Foreground(paletteColor(m.previewColor)).Render("→ "+m.datePreview) + "\n")
+			if m.dateConflict != "" {
+				b.WriteString(WarningStyle("   ⚠ "+m.dateConflict) + "\n")
+			}
 		} else {
 			b.WriteString(ErrStyle("   ✗ "+m.datePreview) + "\n")
 		}
@@ -929,6 +1911,54 @@ func (m MainModel) inputView(title string) string {
 		b.WriteString("\n")
 	}
 
+	// Reminders and repeat share a row, each in half a field.
+	halfField := func(label string, field inputFields, invalid bool) string {
+		style := fieldStyle.Width(size.half)
+		if m.focus == int(field) {
+			style = fieldFocusedStyle.Width(size.half)
+		}
+		if invalid {
+			style = style.BorderForeground(paletteColor(cError))
+		}
+		return labelStyle.Render(label) + "\n" + style.Render(m.inputs[field].View())
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+		halfField(tr("form.reminders"), inputRemindersField, m.remindersError != ""),
+		"  ",
+		halfField(tr("form.repeat"), inputRepeatField, m.repeatError != ""),
+	) + "\n")
+	switch {
+	case m.remindersError != "":
+		b.WriteString(ErrStyle("   ✗ "+m.remindersError) + "\n")
+	case m.repeatError != "":
+		b.WriteString(ErrStyle("   ✗ "+m.repeatError) + "\n")
+	case !short:
+		b.WriteString(formHint(tr("form.reminders_hint"), contentWidth) + "\n")
+		b.WriteString(formHint(tr("form.repeat_hint"), contentWidth) + "\n")
+	}
+
+	kind := "‹ " + kindLabel(m.formKind) + " ›"
+	if m.focus == int(inputKindField) {
+		kind = FocusedStyle.Render(kind)
+	} else {
+		kind = BrightTextStyle(kind)
+	}
+	kindLabelStyle := labelStyle
+	if short {
+		kindLabelStyle = kindLabelStyle.MarginTop(0)
+	}
+	kindRow := lipgloss.JoinHorizontal(lipgloss.Bottom, kindLabelStyle.Render(tr("form.kind")), "  ", kind)
+	if m.config.grouped() {
+		group := "‹ " + groupLabel(m.formGroup) + " ›"
+		if m.focus == int(inputGroupField) {
+			group = FocusedStyle.Render(group)
+		} else {
+			group = BrightTextStyle(group)
+		}
+		kindRow = lipgloss.JoinHorizontal(lipgloss.Bottom, kindRow, "    ", kindLabelStyle.Render(tr("form.group")), "  ", group)
+	}
+	b.WriteString(kindRow + "\n")
+
 	cancelButton := ButtonStyle
 	if m.focus == int(inputCancelButton) {
 		cancelButton = ButtonFocusedStyle
@@ -938,37 +1968,61 @@ func (m MainModel) inputView(title string) string {
 		submitButton = ButtonFocusedStyle
 	}
 
-	submitLabel := "✓ Create"
+	submitLabel := tr("form.create")
 	if m.state == showEdit {
-		submitLabel = "✓ Update"
+		submitLabel = tr("form.update")
+	}
+	confirming := m.pastConfirmed != "" && m.pastConfirmed == m.inputs[inputTimeField].Value()
+	if confirming {
+		submitLabel = tr("form.create_past")
+		if m.state == showEdit {
+			submitLabel = tr("form.update_past")
+		}
+		submitButton = submitButton.BorderForeground(paletteColor(cWarning)).Foreground(paletteColor(cWarning))
 	}
 
 	buttons := lipgloss.JoinHorizontal(
 		lipgloss.Center,
-		cancelButton.Render("✗ Cancel"),
+		cancelButton.Render(tr("form.cancel")),
 		"  ",
 		submitButton.Render(submitLabel),
 	)
-	b.WriteString("\n" + buttons + "\n")
+	if lipgloss.Width(buttons) > contentWidth {
+		buttons = lipgloss.JoinVertical(lipgloss.Left, submitButton.Render(submitLabel), cancelButton.Render(tr("form.cancel")))
+	}
+	if !short {
+		b.WriteString("\n")
+	}
+	b.WriteString(buttons + "\n")
+	if confirming {
+		b.WriteString("\n" + WarningStyle(tr("form.past_confirm")))
+	}
 
 	if m.inputStatus != "" {
 		b.WriteString("\n" + ErrStyle(m.inputStatus))
 	}
 
-	b.WriteString("\n\n" + HintStyle("Tab: next field • Shift+Tab: previous • Enter: select • Esc: cancel"))
+	if !short {
+		b.WriteString("\n\n" + formHint(tr("form.help"), contentWidth))
+	}
 
 	inputStyle := lipgloss.NewStyle().
-		Width(inputWidth).
+		Width(size.width).
 		Margin(1, 1).
 		Padding(1, 2).
 		Border(lipgloss.RoundedBorder(), true, true, true, true).
-		BorderForeground(lipgloss.Color(cPromptBorder))
-
-	// Center the input form
-	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, inputStyle.Render(b.String()))
+		BorderForeground(paletteColor(cPromptBorder))
+	if m.compact() {
+		inputStyle = inputStyle.Margin(0).Padding(1, 1)
+	}
+	if short {
+		inputStyle = inputStyle.MarginTop(0).MarginBottom(0).PaddingTop(0).PaddingBottom(0)
+	}
+	return inputStyle.Render(strings.TrimSuffix(b.String(), "\n"))
 }
 
 func (m *MainModel) updateDatePreview() {
+	m.dateConflict = ""
 	dateStr := m.inputs[inputTimeField].Value()
 	if dateStr == "" {
 		m.datePreview = ""
@@ -976,23 +2030,48 @@ func (m *MainModel) updateDatePreview() {
 		return
 	}
 
-	timeFormat := inputTimeFormLong
-	if len(dateStr) <= len(inputTimeFormShort) {
-		timeFormat = inputTimeFormShort
-	}
-
-	ts, err := time.ParseInLocation(timeFormat, dateStr, time.Local)
+	ts, err := parseDateInput(dateStr, m.now())
 	if err != nil {
-		m.datePreview = "Invalid date format"
+		m.datePreview = tr("form.invalid_date")
+		m.dateValid = false
+		return
+	}
+	if err := m.checkYear(ts); err != nil {
+		m.datePreview = err.Error()
 		m.dateValid = false
 		return
 	}
 
 	m.dateValid = true
-	if ts.Before(time.Now()) {
-		m.datePreview = ts.Format("Mon, Jan 2, 2006 at 3:04 PM") + " (past event)"
+	now := m.now()
+	if ts.Before(now) {
+		m.datePreview = trf("form.past_event", ts.Format(m.config.dateTimeLayout()))
 	} else {
-		m.datePreview = ts.Format("Mon, Jan 2, 2006 at 3:04 PM")
+		m.datePreview = ts.Format(m.config.dateTimeLayout())
+	}
+	m.datePreview += " · " + formatRelative(ts, now)
+	if _, ok := parseUnixTimestamp(strings.TrimSpace(dateStr)); ok {
+		m.datePreview += " " + tr("form.unix_timestamp")
+	}
+	m.previewColor = getUrgencyColor(ts.Unix(), now)
+
+	editing := m.formBase()
+	skip := func(e Event) bool { return m.state == showEdit && sameEvent(e, editing) }
+	if e, ok := nearestEvent(m.formEvents, ts, conflictWindow, skip); ok {
+		m.dateConflict = conflictHint(e, ts)
+	}
+}
+
+// updateRemindersStatus checks the reminders field as it is typed, so a bad
+// lead time is marked before the form is submitted.
+func (m *MainModel) updateRemindersStatus() {
+	m.remindersError = ""
+	if _, err := parseReminders(m.inputs[inputRemindersField].Value()); err != nil {
+		m.remindersError = trf("form.reminders_invalid", err)
+	}
+	m.repeatError = ""
+	if _, _, err := parseRecurrence(m.inputs[inputRepeatField].Value()); err != nil {
+		m.repeatError = trf("form.repeat_invalid", err)
 	}
 }
 
@@ -1012,48 +2091,175 @@ func (m *MainModel) updateInputs() []tea.Cmd {
 	return cmds
 }
 
+// returnToList shows the events list again, falling back to the tag list
+// when the current tag has no events left and to the empty screen when
+// there are no events at all.
+func (m *MainModel) returnToList() {
+	switch {
+	case len(m.events.Items()) > 0:
+		m.state = showEvents
+	case len(m.hiddenEvents) > 0:
+		m.openTags()
+	default:
+		if m.tagScope != "" {
+			m.leaveTagScope()
+		}
+		m.events.ResetFilter()
+		m.filterReturn = nil
+		m.state = noEvents
+	}
+}
+
+// saveForm puts the event from the form in the list, in place of the one
+// being edited if edit is set, saves and returns to the list.
+func (m *MainModel) saveForm(e Event, edit bool) tea.Cmd {
+	before := m.storedEvents()
+	if edit {
+		// Keep its place among same-time events unless the time changed.
+		old := m.events.Items()[m.editIndex].(Event)
+		if old.Time != e.Time {
+			e.Order = nextOrder(m.events.Items(), e.Time)
+		}
+		if m.compareMark != nil && sameEvent(*m.compareMark, old) {
+			m.compareMark = &e
+		}
+		m.events.RemoveItem(m.editIndex)
+	} else {
+		e.Order = nextOrder(m.events.Items(), e.Time)
+		e.Created = m.now().Unix()
+		if m.tagScope != "" && m.tagScope != untaggedLabel {
+			e.Tags = []string{m.tagScope}
+		}
+	}
+
+	m.events.InsertItem(m.insertIndex(e), e)
+	m.regroup()
+	cmd := m.saved(m.saveChange(before))
+	m.resetInputs()
+	m.state = showEvents
+	return cmd
+}
+
 func (m *MainModel) resetInputs() {
 	m.inputs[inputNameField].Reset()
+	m.inputs[inputNameField].CharLimit = nameCharLimit
 	m.inputs[inputTimeField].Reset()
+	m.inputs[inputRemindersField].Reset()
+	m.inputs[inputRepeatField].Reset()
 	m.focus = 0
 	m.inputStatus = ""
+	m.remindersError = ""
+	m.repeatError = ""
 	m.datePreview = ""
 	m.dateValid = false
+	m.dateConflict = ""
+	m.pastConfirmed = ""
 	m.editIndex = -1
+	m.editOriginal = Event{}
+	m.editPending = Event{}
+	m.formKind = kindGeneric
+	m.formGroup = defaultGroup
 }
 
+// checkYear rejects dates outside the configured year range. Dates before
+// 1970 are fine; they are stored as negative Unix timestamps.
+func (m MainModel) checkYear(t time.Time) error {
+	return m.config.checkYear(t)
+}
+
+func (c Config) checkYear(t time.Time) error {
+	minYear, maxYear := c.MinYear, c.MaxYear
+	if minYear == 0 && maxYear == 0 {
+		minYear, maxYear = defaultConfig().MinYear, defaultConfig().MaxYear
+	}
+	if t.Year() < minYear || t.Year() > maxYear {
+		return errors.New(trf("form.year_range", t.Year(), minYear, maxYear))
+	}
+	return nil
+}
+
+// formBase is the event the form starts from: a copy of the one being
+// edited, so fields the form does not show survive, or a new event.
+func (m MainModel) formBase() Event {
+	if m.state == showEdit && m.editIndex >= 0 && m.editIndex < len(m.events.Items()) {
+		return m.events.Items()[m.editIndex].(Event)
+	}
+	return Event{}
+}
+
+// validateInputs returns formBase with the form's fields applied.
 func (m MainModel) validateInputs() (Event, error) {
-	var event Event
 	name := m.inputs[0].Value()
 	t := m.inputs[1].Value()
 	if name == "" {
-		return event, fmt.Errorf("event name is required")
+		return Event{}, errors.New(tr("form.name_required"))
 	}
 	if t == "" {
-		return event, fmt.Errorf("date/time is required")
+		return Event{}, errors.New(tr("form.date_required"))
 	}
-	timeFormat := inputTimeFormLong
-	if len(t) < len(inputTimeFormLong) {
-		timeFormat = inputTimeFormShort
+	ts, err := parseDateInput(t, m.now())
+	if err != nil {
+		return Event{}, errors.New(tr("form.date_invalid"))
 	}
-	ts, err := time.ParseInLocation(timeFormat, t, time.Local)
+	if err := m.checkYear(ts); err != nil {
+		return Event{}, err
+	}
+	reminders, err := parseReminders(m.inputs[inputRemindersField].Value())
+	if err != nil {
+		return Event{}, errors.New(trf("form.reminders_invalid", err))
+	}
+	repeat, yearly, err := parseRecurrence(m.inputs[inputRepeatField].Value())
 	if err != nil {
-		return event, fmt.Errorf("invalid date format")
+		return Event{}, errors.New(trf("form.repeat_invalid", err))
 	}
-	event = Event{Name: name, Time: ts.Unix()}
+	if repeat != nil && repeat.Unit == repeatMonthly {
+		if repeat.Day == 0 {
+			// Remember the day, or the 31st would stay the 28th after February.
+			repeat.Day = ts.Day()
+		}
+		ts = repeat.next(ts, ts.Add(-time.Second))
+	}
+	event := m.formBase()
+	event.Name, event.Time = name, ts.Unix()
+	event.Reminders, event.Yearly, event.Repeat = reminders, yearly, repeat
+	// A leap-day birthday shown on the 28th stays one unless moved.
+	event.LeapDay = event.LeapDay && yearly && ts.Month() == time.February && ts.Day() == 28
+	event.Kind = m.formKind
+	event.Group = m.formGroup
+	event.AllDay = isAllDayInput(t)
 	return event, nil
 }
 
-func nextGolangAnniversary() Event {
-	nameStr := "Golang's Birthday"
-	now := time.Now()
-	year := now.Year()
-	thisYear := time.Date(year, 11, 10, 0, 0, 0, 0, time.Local)
-	nextYear := time.Date(year+1, 11, 10, 0, 0, 0, 0, time.Local)
-	if now.Before(thisYear) {
-		return Event{nameStr, thisYear.Unix()}
+// unconfirmedPast reports whether submitting e would save a past date the
+// user has not confirmed yet, so a mistyped year does not quietly create a
+// past event. Editing an event without changing its time never asks.
+func (m MainModel) unconfirmedPast(e Event) bool {
+	if e.Time >= m.now().Unix() || m.pastConfirmed == m.inputs[inputTimeField].Value() {
+		return false
+	}
+	if m.state == showEdit && m.editIndex >= 0 && m.editIndex < len(m.events.Items()) {
+		return m.events.Items()[m.editIndex].(Event).Time != e.Time
 	}
-	return Event{nameStr, nextYear.Unix()}
+	return true
+}
+
+// nextAnniversary returns the first occurrence of month/day at midnight that
+// is not before now. February 29th falls back to the 28th in common years.
+func nextAnniversary(month time.Month, day int, now time.Time) time.Time {
+	for year := now.Year(); ; year++ {
+		d := day
+		if month == time.February && day == 29 && !isLeapYear(year) {
+			d = 28
+		}
+		t := time.Date(year, month, d, 0, 0, 0, 0, now.Location())
+		if !t.Before(now) {
+			return t
+		}
+	}
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
 
 func max(a, b int) int {
@@ -1063,6 +2269,20 @@ func max(a, b int) int {
 	return b
 }
 
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 type WikiOnThisDay struct {
 	Selected []WikiEvent `json:"selected"`
 	Events   []WikiEvent `json:"events"`
@@ -1079,21 +2299,54 @@ type WikiEvent struct {
 type WikiPage struct {
 	Title   string `json:"title"`
 	Extract string `json:"extract"`
+	label   string // Title as the panel shows it, set by sanitizeWikiEvents
 }
 
+// wikiFeed is the part of On This Day the panel shows.
+type wikiFeed int
+
+const (
+	feedSelected wikiFeed = iota
+	feedBirths
+	feedDeaths
+	feedCount
+)
+
+func (f wikiFeed) String() string {
+	return []string{"selected", "births", "deaths"}[f]
+}
+
+// OnThisDayMsg is the reply for the feed of a day.
 type OnThisDayMsg struct {
+	day    time.Time
+	feed   wikiFeed
 	events []WikiEvent
 	err    error
 }
 
-func fetchOnThisDay() tea.Msg {
-	now := time.Now()
-	month := int(now.Month())
-	day := now.Day()
+// fetchOnThisDay returns the command that loads the feed for date. Tests
+// replace it to count fetches without going to the network.
+var fetchOnThisDay = func(date time.Time, feed wikiFeed) tea.Cmd {
+	return func() tea.Msg { return fetchOnThisDayAt(date, feed, 10*time.Second) }
+}
+
+func fetchOnThisDayAt(date time.Time, feed wikiFeed, timeout time.Duration) tea.Msg {
+	msg := fetchWikiFeed(date, feed, timeout)
+	msg.day, msg.feed = date, feed
+	return msg
+}
+
+// wikiFeedURL is where the feeds are fetched from. Tests point it at a local
+// server.
+var wikiFeedURL = "https://api.wikimedia.org/feed/v1/wikipedia/en/onthisday"
 
-	url := fmt.Sprintf("https://api.wikimedia.org/feed/v1/wikipedia/en/onthisday/selected/%02d/%02d", month, day)
+func fetchWikiFeed(date time.Time, feed wikiFeed, timeout time.Duration) OnThisDayMsg {
+	month := int(date.Month())
+	day := date.Day()
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	url := fmt.Sprintf("%s/%s/%02d/%02d", wikiFeedURL, feed, month, day)
+
+	client := &http.Client{Timeout: timeout}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return OnThisDayMsg{err: err}
@@ -1106,105 +2359,67 @@ func fetchOnThisDay() tea.Msg {
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return OnThisDayMsg{err: err}
-	}
+	events, err := decodeWikiFeed(resp.Body, feed)
+	return OnThisDayMsg{events: events, err: err}
+}
+
+// A day's feed runs to a few hundred KB. Anything past maxWikiResponse, as
+// from a misbehaving proxy, is not read. The full "events" list only stands
+// in for an empty "selected" one, and is cut to maxWikiFallback entries.
+const (
+	maxWikiResponse = 2 << 20
+	maxWikiFallback = 100
+)
 
+func decodeWikiFeed(r io.Reader, feed wikiFeed) ([]WikiEvent, error) {
+	body := &io.LimitedReader{R: r, N: maxWikiResponse + 1}
 	var data WikiOnThisDay
-	if err := json.Unmarshal(body, &data); err != nil {
-		return OnThisDayMsg{err: err}
+	if err := json.NewDecoder(body).Decode(&data); err != nil {
+		if body.N <= 0 {
+			return nil, fmt.Errorf("response larger than %d MB", maxWikiResponse>>20)
+		}
+		return nil, err
 	}
 
 	events := data.Selected
-	if len(events) == 0 {
+	switch {
+	case feed == feedBirths:
+		events = data.Births
+	case feed == feedDeaths:
+		events = data.Deaths
+	case len(events) == 0:
 		events = data.Events
+		if len(events) > maxWikiFallback {
+			// Copy, so the rest of the list can be freed.
+			events = append([]WikiEvent(nil), events[:maxWikiFallback]...)
+		}
 	}
-
-	return OnThisDayMsg{events: events}
+	return events, nil
 }
 
-func (m MainModel) renderOnThisDay() string {
-	var b strings.Builder
-
-	now := time.Now()
-	titleStyle := TimelineTitleStyle.Copy().Width(m.timelineWidth - 4)
-	b.WriteString("\n" + titleStyle.Render(fmt.Sprintf("📜 On This Day - %s", now.Format("January 2"))) + "\n\n")
-
-	if m.onThisDayLoading {
-		b.WriteString(HintStyle("  Loading historical events...") + "\n")
-		return m.timelineStyle().Render(b.String())
-	}
-
-	if m.onThisDayErr != nil {
-		b.WriteString(ErrStyle("  Failed to load events") + "\n")
-		b.WriteString(HintStyle("  "+m.onThisDayErr.Error()) + "\n")
-		return m.timelineStyle().Render(b.String())
-	}
-
-	if len(m.onThisDay) == 0 {
-		b.WriteString(HintStyle("  No historical events found") + "\n")
-		return m.timelineStyle().Render(b.String())
-	}
-
-	availableLines := m.windowHeight - 8
-	linesPerEvent := 4
-	maxEvents := availableLines / linesPerEvent
-	if maxEvents < 3 {
-		maxEvents = 3
-	}
-
-	yearStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(cTimelineSelected)).
-		Bold(true)
-
-	textStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: cDimmedTitleLight, Dark: cDimmedDescDark})
-
-	separatorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(cTimelineTrack))
-
-	maxTextWidth := m.timelineWidth - 12
-	if maxTextWidth < 20 {
-		maxTextWidth = 20
-	}
-
-	for i, event := range m.onThisDay {
-		if i >= maxEvents {
-			remaining := len(m.onThisDay) - maxEvents
-			b.WriteString(HintStyle(fmt.Sprintf("  ... and %d more events", remaining)) + "\n")
-			break
-		}
-
-		yearsAgo := now.Year() - event.Year
-		yearLabel := fmt.Sprintf("%d (%d yrs ago)", event.Year, yearsAgo)
-		b.WriteString("  " + yearStyle.Render(yearLabel) + "\n")
-
-		text := event.Text
-
-		wrappedLines := wrapText(text, maxTextWidth)
-
-		if len(wrappedLines) > 2 {
-			wrappedLines = wrappedLines[:2]
-			lastLine := wrappedLines[1]
-			if len(lastLine) > maxTextWidth-3 {
-				lastLine = lastLine[:maxTextWidth-3]
-			}
-			wrappedLines[1] = lastLine + "..."
-		}
-
-		for _, line := range wrappedLines {
-			b.WriteString("  " + textStyle.Render(line) + "\n")
-		}
-
-		if i < maxEvents-1 && i < len(m.onThisDay)-1 {
-			b.WriteString(separatorStyle.Render("  ─────────") + "\n")
+// fitTitle fits a title into width columns as the terminal counts them, so
+// wide characters and emoji cannot push the panel sideways. A title up to
+// twice as wide goes on two lines, broken at a space where there is one;
+// anything longer is cut off with an ellipsis at the end of the second.
+func fitTitle(title string, width int) string {
+	width = max(width, 1)
+	if ansi.StringWidth(title) <= width {
+		return title
+	}
+	head := ansi.Truncate(title, width, "")
+	if head == "" {
+		return ansi.Truncate(title, width, "…")
+	}
+	if len(head) < len(title) && title[len(head)] != ' ' {
+		if i := strings.LastIndex(head, " "); i > 0 {
+			head = head[:i]
 		}
 	}
-
-	b.WriteString("\n" + HintStyle("  Source: Wikipedia"))
-
-	return m.timelineStyle().Render(b.String())
+	rest := strings.TrimLeft(title[len(head):], " ")
+	if ansi.StringWidth(rest) > width {
+		rest = ansi.Truncate(rest, width, "…")
+	}
+	return strings.TrimRight(head, " ") + "\n" + rest
 }
 
 func wrapText(text string, maxWidth int) []string {
@@ -1,20 +1,26 @@
 package main
 
 import (
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // testHelper provides utilities for testing with config directories
 type testHelper struct {
 	originalConfigDir string
 	testConfigDir     string
+	testDataDir       string
 }
 
 func newTestHelper(t *testing.T) *testHelper {
@@ -30,9 +36,19 @@ func newTestHelper(t *testing.T) *testHelper {
 	// Set test config directory
 	os.Setenv("XDG_CONFIG_HOME", testDir)
 
+	// Events and state go to the data directory
+	dataDir := filepath.Join(testDir, "data")
+	t.Setenv("XDG_DATA_HOME", dataDir)
+
+	// Keep UI strings in English whatever the developer's locale
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		t.Setenv(env, "")
+	}
+
 	return &testHelper{
 		originalConfigDir: originalConfigDir,
 		testConfigDir:     testDir,
+		testDataDir:       dataDir,
 	}
 }
 
@@ -55,69 +71,6 @@ func (th *testHelper) removeEventsFile() {
 	}
 }
 
-func TestNextGolangAnniversary(t *testing.T) {
-	tests := []struct {
-		name     string
-		now      time.Time
-		expected time.Time
-	}{
-		{
-			name:     "Before November 10th",
-			now:      time.Date(2023, 6, 15, 12, 0, 0, 0, time.Local),
-			expected: time.Date(2023, 11, 10, 0, 0, 0, 0, time.Local),
-		},
-		{
-			name:     "On November 10th",
-			now:      time.Date(2023, 11, 10, 12, 0, 0, 0, time.Local),
-			expected: time.Date(2024, 11, 10, 0, 0, 0, 0, time.Local),
-		},
-		{
-			name:     "After November 10th",
-			now:      time.Date(2023, 11, 15, 12, 0, 0, 0, time.Local),
-			expected: time.Date(2024, 11, 10, 0, 0, 0, 0, time.Local),
-		},
-		{
-			name:     "December 31st",
-			now:      time.Date(2023, 12, 31, 23, 59, 59, 0, time.Local),
-			expected: time.Date(2024, 11, 10, 0, 0, 0, 0, time.Local),
-		},
-		{
-			name:     "January 1st",
-			now:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
-			expected: time.Date(2024, 11, 10, 0, 0, 0, 0, time.Local),
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Test the logic manually since we can't override time.Now
-			year := tt.now.Year()
-			thisYear := time.Date(year, 11, 10, 0, 0, 0, 0, time.Local)
-			nextYear := time.Date(year+1, 11, 10, 0, 0, 0, 0, time.Local)
-
-			var expectedEvent Event
-			if tt.now.Before(thisYear) {
-				expectedEvent = Event{"Golang's Birthday", thisYear.Unix()}
-			} else {
-				expectedEvent = Event{"Golang's Birthday", nextYear.Unix()}
-			}
-
-			// For testing purposes, we'll manually calculate what the function should return
-			// given the current time logic in nextGolangAnniversary()
-			expectedUnix := tt.expected.Unix()
-			if expectedEvent.Time != expectedUnix {
-				t.Errorf("Expected time %d (%s), got %d (%s)",
-					expectedUnix, tt.expected.Format(time.RFC3339),
-					expectedEvent.Time, time.Unix(expectedEvent.Time, 0).Format(time.RFC3339))
-			}
-
-			if expectedEvent.Name != "Golang's Birthday" {
-				t.Errorf("Expected name 'Golang's Birthday', got '%s'", expectedEvent.Name)
-			}
-		})
-	}
-}
-
 func TestCountdownParser(t *testing.T) {
 	// Use current time as base to ensure tests work regardless of when they're run
 	now := time.Now()
@@ -161,7 +114,7 @@ func TestCountdownParser(t *testing.T) {
 		{
 			name:           "Past event",
 			target:         now.Add(-1 * time.Hour),
-			expectedPrefix: "Expired",
+			expectedPrefix: "1h",
 			shouldExpire:   true,
 		},
 		{
@@ -174,24 +127,18 @@ func TestCountdownParser(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := countdownParser(tt.target.Unix())
+			result := eventDelegate{now: now}.countdown(tt.target.Unix())
 			if tt.name == "Future event - seconds only" {
 				if !(result == "45s" || result == "44s") {
 					t.Errorf("Expected '45s' or '44s', got '%s'", result)
 				}
 				return
 			}
-			if tt.shouldExpire {
-				if !strings.Contains(result, "Expired") {
-					t.Errorf("Expected result to contain 'Expired', got '%s'", result)
-				}
-			} else {
-				if strings.Contains(result, "Expired") {
-					t.Errorf("Expected non-expired result, got result containing 'Expired': '%s'", result)
-				}
-				if !strings.HasPrefix(result, tt.expectedPrefix) {
-					t.Errorf("Expected result to start with '%s', got '%s'", tt.expectedPrefix, result)
-				}
+			if tt.shouldExpire != strings.HasSuffix(result, " ago") {
+				t.Errorf("Expected past events and only past events to end in ' ago', got '%s'", result)
+			}
+			if !strings.HasPrefix(result, tt.expectedPrefix) {
+				t.Errorf("Expected result to start with '%s', got '%s'", tt.expectedPrefix, result)
 			}
 		})
 	}
@@ -224,7 +171,7 @@ func TestEventMethods(t *testing.T) {
 
 	t.Run("Description", func(t *testing.T) {
 		// Description should return the countdown string
-		desc := event.Description()
+		desc := eventDelegate{now: time.Now()}.description(event)
 		if desc == "" {
 			t.Error("Description should not be empty")
 		}
@@ -241,6 +188,8 @@ func TestValidateInputs(t *testing.T) {
 		name        string
 		eventName   string
 		timeString  string
+		reminders   string
+		saved       string
 		expectError bool
 		errorMsg    string
 	}{
@@ -261,7 +210,7 @@ func TestValidateInputs(t *testing.T) {
 			eventName:   "",
 			timeString:  "",
 			expectError: true,
-			errorMsg:    "empty fields",
+			errorMsg:    "event name is required",
 		},
 		{
 			name:        "Invalid time format",
@@ -273,15 +222,42 @@ func TestValidateInputs(t *testing.T) {
 			name:        "Past time",
 			eventName:   "Test Event",
 			timeString:  "2020-01-01 12:00:00",
+			expectError: false,
+		},
+		{
+			name:        "Before 1970",
+			eventName:   "Moon landing",
+			timeString:  "1969-07-20 20:17:40",
+			expectError: false,
+		},
+		{
+			name:        "Year out of range",
+			eventName:   "Test Event",
+			timeString:  "0000-01-01",
+			expectError: true,
+		},
+		{
+			name:        "Reminders",
+			eventName:   "Test Event",
+			timeString:  "2030-01-01",
+			reminders:   "1w, 1d,2h",
+			saved:       "1w, 1d, 2h",
+			expectError: false,
+		},
+		{
+			name:        "Invalid reminder",
+			eventName:   "Test Event",
+			timeString:  "2030-01-01",
+			reminders:   "1d, 2x",
 			expectError: true,
-			errorMsg:    "event time is in the past",
+			errorMsg:    `invalid reminder "2x": unknown unit "x", use w, d, h, m or s`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			model := MainModel{
-				inputs: make([]textinput.Model, 2),
+				inputs: make([]textinput.Model, 4),
 			}
 
 			// Set up input values
@@ -293,6 +269,10 @@ func TestValidateInputs(t *testing.T) {
 			timeInput.SetValue(tt.timeString)
 			model.inputs[1] = timeInput
 
+			remindersInput := textinput.New()
+			remindersInput.SetValue(tt.reminders)
+			model.inputs[2] = remindersInput
+
 			event, err := model.validateInputs()
 
 			if tt.expectError {
@@ -311,11 +291,76 @@ func TestValidateInputs(t *testing.T) {
 				if event.Name != tt.eventName {
 					t.Errorf("Expected event name '%s', got '%s'", tt.eventName, event.Name)
 				}
+				if got := formatReminders(event.Reminders); got != tt.saved {
+					t.Errorf("Expected reminders '%s', got '%s'", tt.saved, got)
+				}
 			}
 		})
 	}
 }
 
+func TestEditKeepsHiddenFields(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	original := Event{
+		Name:      "Standup",
+		Time:      time.Date(2026, 6, 4, 0, 0, 0, 0, time.Local).Unix(),
+		Tags:      []string{"work"},
+		Repeat:    &Recurrence{Unit: repeatWeekly, Every: 2},
+		Since:     2019,
+		Source:    "cal-123",
+		Order:     2,
+		Created:   now.AddDate(-1, 0, 0).Unix(),
+		Reminders: []int64{3600},
+		Notes:     "Room 4",
+		Kind:      kindDeadline,
+		AllDay:    true,
+		Group:     "Admin",
+	}
+	// Every field the form can leave alone must be set, so a new one that
+	// edit drops fails here. Read-only events cannot be edited at all.
+	v := reflect.ValueOf(original)
+	for i := 0; i < v.NumField(); i++ {
+		switch name := v.Type().Field(i).Name; name {
+		case "Yearly", "LeapDay", "ReadOnly", "Virtual":
+		default:
+			if v.Field(i).IsZero() {
+				t.Fatalf("Set %s in the original event", name)
+			}
+		}
+	}
+
+	m := newRefreshTestModel(t, &now, original)
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = model.(MainModel)
+	if m.state != showEdit {
+		t.Fatalf("Expected the edit form, got state %v", m.state)
+	}
+	m.inputs[inputNameField].SetValue("Weekly sync")
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = model.(MainModel)
+	if m.state != showEditReview {
+		t.Fatalf("Expected the changes to be shown for review, got %q", m.inputStatus)
+	}
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(MainModel)
+	if m.state != showEvents {
+		t.Fatalf("Expected the form to close, got %q", m.inputStatus)
+	}
+
+	expected := original
+	expected.Name = "Weekly sync"
+	events, err := readEventsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || !reflect.DeepEqual(events[0], expected) {
+		t.Errorf("Expected only the name to change:\n%+v\ngot\n%+v", expected, events)
+	}
+}
+
 func TestReadEventsFile(t *testing.T) {
 	// Test with non-existent file
 	t.Run("Non-existent file", func(t *testing.T) {
@@ -329,12 +374,11 @@ func TestReadEventsFile(t *testing.T) {
 			t.Errorf("Unexpected error: %v", err)
 		}
 
-		if len(events) != 1 {
-			t.Errorf("Expected 1 event (default Golang birthday), got %d", len(events))
+		if len(events) != 0 {
+			t.Errorf("Expected a new empty events file, got %d events", len(events))
 		}
-
-		if events[0].Name != "Golang's Birthday" {
-			t.Errorf("Expected 'Golang's Birthday', got '%s'", events[0].Name)
+		if _, err := os.Stat(filepath.Join(os.Getenv("XDG_DATA_HOME"), appName, eventsFileName)); err != nil {
+			t.Errorf("Expected the events file created: %v", err)
 		}
 
 		// Clean up
@@ -385,11 +429,14 @@ func TestMainModelInitialization(t *testing.T) {
 	defer th.cleanup()
 	th.removeEventsFile()
 
-	model := NewMainModel()
+	model, err := NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
 
-	// Test initial state
-	if model.state != showEvents {
-		t.Errorf("Expected initial state to be showEvents, got %v", model.state)
+	// A first run starts with no events.
+	if model.state != noEvents {
+		t.Errorf("Expected initial state to be noEvents, got %v", model.state)
 	}
 
 	// Test timer initialization
@@ -398,8 +445,8 @@ func TestMainModelInitialization(t *testing.T) {
 	}
 
 	// Test inputs initialization
-	if len(model.inputs) != 2 {
-		t.Errorf("Expected 2 inputs, got %d", len(model.inputs))
+	if len(model.inputs) != 4 {
+		t.Errorf("Expected 4 inputs, got %d", len(model.inputs))
 	}
 
 	// Test events list initialization
@@ -419,7 +466,7 @@ func TestGetEventsFilePath(t *testing.T) {
 	}
 
 	// Verify the path structure
-	expectedDir := filepath.Join(th.testConfigDir, appName)
+	expectedDir := filepath.Join(th.testDataDir, appName)
 	expectedFile := filepath.Join(expectedDir, eventsFileName)
 
 	if eventsPath != expectedFile {
@@ -428,7 +475,7 @@ func TestGetEventsFilePath(t *testing.T) {
 
 	// Verify the directory was created
 	if _, err := os.Stat(expectedDir); os.IsNotExist(err) {
-		t.Errorf("Expected config directory to be created: %s", expectedDir)
+		t.Errorf("Expected data directory to be created: %s", expectedDir)
 	}
 
 	// Test that calling it again doesn't fail (idempotent)
@@ -476,3 +523,205 @@ func TestConstants(t *testing.T) {
 		t.Errorf("Expected eventsFileName to be 'events.json', got '%s'", eventsFileName)
 	}
 }
+
+func TestNewMainModelErrors(t *testing.T) {
+	t.Run("Bad config", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		writeConfigFile(t, "fiscal_year_start_month = 0\n")
+
+		_, err := NewMainModel()
+		var cfgErr configError
+		if !errors.As(err, &cfgErr) {
+			t.Fatalf("Expected a config error, got %v", err)
+		}
+		configFile, _ := getConfigFilePath()
+		if !strings.Contains(err.Error(), configFile) {
+			t.Errorf("Expected the error to name %s, got '%v'", configFile, err)
+		}
+	})
+
+	t.Run("Corrupt events file", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		eventsFile, _ := getEventsFilePath()
+		if err := os.WriteFile(eventsFile, []byte("{not json"), 0644); err != nil {
+			t.Fatalf("Failed to write events file: %v", err)
+		}
+
+		_, err := NewMainModel()
+		if err == nil {
+			t.Fatal("Expected an error for a corrupt events file, got nil")
+		}
+		var cfgErr configError
+		if errors.As(err, &cfgErr) {
+			t.Errorf("Expected a runtime error, got a config error: %v", err)
+		}
+		if !strings.Contains(err.Error(), eventsFile) {
+			t.Errorf("Expected the error to name %s, got '%v'", eventsFile, err)
+		}
+	})
+}
+
+func TestRenderTimeBlocks(t *testing.T) {
+	rows := func(out string) []string {
+		var labels []string
+		for _, line := range strings.Split(stripANSI(out), "\n") {
+			labels = append(labels, strings.Fields(line)[0])
+		}
+		return labels
+	}
+
+	tests := []struct {
+		name     string
+		years    int
+		days     int
+		hours    int
+		minutes  int
+		seconds  int
+		expected string
+	}{
+		{"30 seconds", 0, 0, 0, 0, 30, "Seconds"},
+		{"3 hours", 0, 0, 3, 0, 0, "Hours Minutes Seconds"},
+		{"40 days", 0, 40, 0, 5, 0, "Days Hours Minutes Seconds"},
+		{"25 years", 25, 0, 1, 0, 0, "Years Days Hours Minutes Seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := renderTimeBlocks(tt.years, tt.days, tt.hours, tt.minutes, tt.seconds, cUrgency1, 50)
+			if got := strings.Join(rows(out), " "); got != tt.expected {
+				t.Errorf("Expected rows '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+
+	full := func(out string) string { return strings.Split(stripANSI(out), "\n")[0] }
+	tenYears := full(renderTimeBlocks(10, 0, 0, 0, 0, cUrgency1, 50))
+	twentyFive := full(renderTimeBlocks(25, 0, 0, 0, 0, cUrgency1, 50))
+	if strings.Contains(twentyFive, "·") || !strings.Contains(twentyFive, "25") {
+		t.Errorf("Expected a full bar labelled 25, got '%s'", twentyFive)
+	}
+	if strings.Contains(tenYears, "+") || strings.Contains(twentyFive, "+") {
+		t.Errorf("Expected years to scale rather than overflow, got '%s' and '%s'", tenYears, twentyFive)
+	}
+}
+
+func TestPre1970Events(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	moonLanding := Event{Name: "Moon landing", Time: time.Date(1969, 7, 20, 20, 17, 40, 0, time.UTC).Unix()}
+	if moonLanding.Time >= 0 {
+		t.Fatalf("Expected a negative timestamp, got %d", moonLanding.Time)
+	}
+
+	if err := writeEventsFile([]Event{moonLanding}); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) != 1 || events[0].Time != moonLanding.Time {
+		t.Errorf("Expected the negative timestamp to round-trip, got %+v", events)
+	}
+
+	if got := formatCountdown(moonLanding.Time, time.Date(2026, 7, 20, 20, 17, 40, 0, time.UTC)); got != "57y 0d 0h 0m 0s ago" {
+		t.Errorf("Expected exactly 57 years ago, got '%s'", got)
+	}
+	if bucket := urgencyBucket(moonLanding.Time, time.Now()); bucket != 0 {
+		t.Errorf("Expected the past urgency bucket, got %d", bucket)
+	}
+
+	m := MainModel{detailWidth: 50, config: defaultConfig()}
+	out := stripANSI(m.renderDetails(moonLanding))
+	for _, want := range []string{"Sunday, July 20, 1969", "Time Since", "Total years:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the detail view, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestYearRange(t *testing.T) {
+	m := MainModel{config: defaultConfig()}
+	m.config.MinYear = 1900
+	m.config.MaxYear = 2100
+
+	if err := m.checkYear(time.Date(1850, 1, 1, 0, 0, 0, 0, time.Local)); err == nil || err.Error() != "year 1850 is outside 1900-2100" {
+		t.Errorf("Expected a year range error, got %v", err)
+	}
+	if err := m.checkYear(time.Date(2100, 12, 31, 0, 0, 0, 0, time.Local)); err != nil {
+		t.Errorf("Expected the last year to be accepted, got %v", err)
+	}
+}
+
+func TestFitTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		width    int
+		expected string
+	}{
+		{"Fits", "Launch", 10, "Launch"},
+		{"Two lines at a space", "Summer vacation in Portugal", 20, "Summer vacation in\nPortugal"},
+		{"Cut on the second line", "Summer vacation in Portugal", 10, "Summer\nvacation …"},
+		{"CJK breaks anywhere", "夏休みのポルトガル旅行", 20, "夏休みのポルトガル旅\n行"},
+		{"CJK cut", "夏休みのポルトガル旅行", 10, "夏休みのポ\nルトガル…"},
+		{"Emoji", "🎉🎂🎈 Birthday party 🎉🎂🎈 with everyone", 20, "🎉🎂🎈 Birthday\nparty 🎉🎂🎈 with e…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitTitle(tt.title, tt.width)
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+			for _, line := range strings.Split(got, "\n") {
+				if w := ansi.StringWidth(line); w > tt.width {
+					t.Errorf("Line %q is %d wide, more than %d", line, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeWikiFeed(t *testing.T) {
+	entry := `{"text": "Something happened", "year": 1900, "pages": [{"title": "Something", "extract": "` + strings.Repeat("x", 1000) + `"}]}`
+	feedOf := func(key string, n int) string {
+		return `{"` + key + `": [` + strings.TrimSuffix(strings.Repeat(entry+",", n), ",") + `]}`
+	}
+
+	events, err := decodeWikiFeed(strings.NewReader(feedOf("events", 500)), feedSelected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != maxWikiFallback {
+		t.Errorf("Expected the fallback list cut to %d entries, got %d", maxWikiFallback, len(events))
+	}
+	if events, err := decodeWikiFeed(strings.NewReader(feedOf("births", 500)), feedBirths); err != nil || len(events) != 500 {
+		t.Errorf("Expected a feed asked for to be kept whole, got %d, %v", len(events), err)
+	}
+
+	// A body past the cap is not read to the end.
+	huge := &countingReader{r: strings.NewReader(feedOf("selected", 5000))}
+	if _, err := decodeWikiFeed(huge, feedSelected); err == nil || !strings.Contains(err.Error(), "larger than 2 MB") {
+		t.Errorf("Expected an oversized response to fail, got %v", err)
+	}
+	if huge.n > maxWikiResponse+64<<10 {
+		t.Errorf("Expected reading to stop near %d bytes, read %d", maxWikiResponse, huge.n)
+	}
+	if _, err := decodeWikiFeed(strings.NewReader("<html>"), feedSelected); err == nil || strings.Contains(err.Error(), "larger") {
+		t.Errorf("Expected a plain decode error, got %v", err)
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
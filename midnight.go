@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// midnightNoteLifetime is how long the status bar names the events of a
+// day that just began.
+const midnightNoteLifetime = 30 * time.Second
+
+// midnightMsg arrives at the local midnight it carries. The per-second
+// timer pauses while the terminal is blurred, so this is what moves the
+// list on to a new day.
+type midnightMsg time.Time
+
+// nextMidnight returns the start of the day after now's, in now's zone.
+func nextMidnight(now time.Time) time.Time {
+	y, mo, d := now.Date()
+	return time.Date(y, mo, d+1, 0, 0, 0, 0, now.Location())
+}
+
+// midnightTick waits for the next local midnight after now.
+func midnightTick(now time.Time) tea.Cmd {
+	at := nextMidnight(now)
+	return tea.Tick(at.Sub(now), func(time.Time) tea.Msg { return midnightMsg(at) })
+}
+
+// handleMidnight refreshes everything that depends on the date once the
+// clock passes the midnight in msg, and waits for the next one. Waiting
+// starts again after a clock jump, so several ticks can be on their way;
+// each midnight is handled once, and one that is not due yet is waited for
+// again.
+func (m *MainModel) handleMidnight(msg midnightMsg) tea.Cmd {
+	now, at := m.now(), time.Time(msg)
+	if now.Before(at) {
+		return tea.Tick(at.Sub(now), func(time.Time) tea.Msg { return msg })
+	}
+	if !at.After(m.lastMidnight) {
+		return nil
+	}
+	m.lastMidnight = at
+	cmds := []tea.Cmd{m.refreshUnlessEditing(), midnightTick(now)}
+	if names := eventsOnDay(m.storedEvents(), now); len(names) > 0 {
+		cmds = append(cmds, m.longStatus(SuccessStyle(trf("midnight.today", strings.Join(names, ", "))), midnightNoteLifetime))
+	}
+	return tea.Batch(cmds...)
+}
+
+// eventsOnDay names the events falling on now's calendar day.
+func eventsOnDay(events []Event, now time.Time) []string {
+	var names []string
+	for _, e := range events {
+		if daysUntil(e.Time, now) == 0 {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
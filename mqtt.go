@@ -0,0 +1,357 @@
+package main
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+type MQTTConfig struct {
+	// Broker is the broker URL, e.g. "mqtt://homeassistant.local" or
+	// "mqtts://broker.example.com:8883". Empty disables publishing.
+	Broker   string `toml:"broker"`
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+	// ClientID defaults to "countdown-<pid>".
+	ClientID string `toml:"client_id"`
+	// TopicPrefix is put in front of "next" and "events/<id>".
+	TopicPrefix string `toml:"topic_prefix"`
+	// Interval is how often the state is republished when nothing changed.
+	Interval string `toml:"interval"`
+}
+
+func (c MQTTConfig) validate() error {
+	if c.Broker != "" {
+		if _, _, err := brokerAddress(c.Broker); err != nil {
+			return fmt.Errorf("mqtt.broker: %w", err)
+		}
+	}
+	if c.TopicPrefix == "" || strings.ContainsAny(c.TopicPrefix, "+#") {
+		return fmt.Errorf("mqtt.topic_prefix must be set and must not contain + or #, got %q", c.TopicPrefix)
+	}
+	if d, err := parseLeadTime(c.Interval); err != nil {
+		return fmt.Errorf("invalid mqtt.interval %q: %w", c.Interval, err)
+	} else if d < time.Second {
+		return fmt.Errorf("mqtt.interval must be at least 1s, got %q", c.Interval)
+	}
+	return nil
+}
+
+func (c MQTTConfig) interval() time.Duration {
+	d, err := parseLeadTime(c.Interval)
+	if err != nil {
+		return time.Minute
+	}
+	return d
+}
+
+// brokerAddress returns host:port for a broker URL, filling in the standard
+// port, and whether to use TLS, which mqtts, ssl and tls URLs do.
+func brokerAddress(broker string) (string, bool, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return "", false, err
+	}
+	if u.Hostname() == "" {
+		return "", false, fmt.Errorf("no host in %q, use e.g. mqtt://host:1883", broker)
+	}
+	port, useTLS := u.Port(), false
+	switch u.Scheme {
+	case "mqtt", "tcp":
+		if port == "" {
+			port = "1883"
+		}
+	case "mqtts", "ssl", "tls":
+		if port == "" {
+			port = "8883"
+		}
+		useTLS = true
+	default:
+		return "", false, fmt.Errorf("unsupported scheme %q, use mqtt or mqtts", u.Scheme)
+	}
+	return net.JoinHostPort(u.Hostname(), port), useTLS, nil
+}
+
+// mqttTimeout bounds connecting and each write to the broker.
+const mqttTimeout = 10 * time.Second
+
+// mqttClient is the small part of MQTT 3.1.1 countdown needs: connect, then
+// publish at QoS 0. Nothing is subscribed, so the broker never sends
+// anything after the CONNACK.
+type mqttClient struct {
+	conn net.Conn
+}
+
+var mqttConnectErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client ID rejected",
+	3: "server unavailable",
+	4: "bad username or password",
+	5: "not authorized",
+}
+
+func dialMQTT(cfg MQTTConfig, keepAlive time.Duration) (*mqttClient, error) {
+	addr, useTLS, err := brokerAddress(cfg.Broker)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: mqttTimeout}
+	var conn net.Conn
+	if useTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, nil)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = fmt.Sprintf("countdown-%d", os.Getpid())
+	}
+	c := &mqttClient{conn: conn}
+	if err := c.connect(clientID, cfg.Username, cfg.Password, keepAlive); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *mqttClient) connect(clientID, username, password string, keepAlive time.Duration) error {
+	seconds := keepAlive / time.Second
+	if seconds > 0xffff {
+		seconds = 0xffff
+	}
+	flags := byte(0x02) // clean session
+	payload := mqttString(clientID)
+	if username != "" {
+		flags |= 0x80
+		payload = append(payload, mqttString(username)...)
+		if password != "" {
+			flags |= 0x40
+			payload = append(payload, mqttString(password)...)
+		}
+	}
+	body := append(mqttString("MQTT"), 4, flags, byte(seconds>>8), byte(seconds))
+	if err := c.write(0x10, append(body, payload...)); err != nil {
+		return err
+	}
+
+	c.conn.SetReadDeadline(time.Now().Add(mqttTimeout))
+	defer c.conn.SetReadDeadline(time.Time{})
+	var ack [4]byte
+	if _, err := io.ReadFull(c.conn, ack[:]); err != nil {
+		return fmt.Errorf("no answer to connect: %w", err)
+	}
+	if ack[0] != 0x20 || ack[1] != 2 {
+		return fmt.Errorf("unexpected answer to connect: % x", ack)
+	}
+	if ack[3] != 0 {
+		if reason, ok := mqttConnectErrors[ack[3]]; ok {
+			return fmt.Errorf("broker refused connection: %s", reason)
+		}
+		return fmt.Errorf("broker refused connection: code %d", ack[3])
+	}
+	return nil
+}
+
+// Publish sends payload to topic at QoS 0. Retained messages are kept by the
+// broker and handed to every later subscriber.
+func (c *mqttClient) Publish(topic string, payload []byte, retain bool) error {
+	header := byte(0x30)
+	if retain {
+		header |= 0x01
+	}
+	return c.write(header, append(mqttString(topic), payload...))
+}
+
+func (c *mqttClient) Close() error {
+	c.write(0xe0, nil)
+	return c.conn.Close()
+}
+
+func (c *mqttClient) write(header byte, body []byte) error {
+	packet := append([]byte{header}, mqttLength(len(body))...)
+	c.conn.SetWriteDeadline(time.Now().Add(mqttTimeout))
+	_, err := c.conn.Write(append(packet, body...))
+	return err
+}
+
+func mqttString(s string) []byte {
+	b := make([]byte, 2, 2+len(s))
+	binary.BigEndian.PutUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// mqttLength encodes the remaining length of a packet, 7 bits per byte.
+func mqttLength(n int) []byte {
+	var b []byte
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if n == 0 {
+			return b
+		}
+	}
+}
+
+// mqttEvent is the JSON published for the next event and for each event.
+type mqttEvent struct {
+	Name             string `json:"name"`
+	Time             int64  `json:"ts"`
+	SecondsRemaining int64  `json:"seconds_remaining"`
+	Urgency          int    `json:"urgency"`
+}
+
+type mqttMessage struct {
+	Topic   string
+	Payload []byte
+	// state is the part of the payload that changes on transitions, as
+	// opposed to the seconds ticking down.
+	state string
+}
+
+// mqttMessages builds the next-event message followed by one message per
+// event, under the event's ID. The next topic carries {} when nothing is
+// upcoming.
+func mqttMessages(prefix string, events []Event, now time.Time) []mqttMessage {
+	prefix = strings.TrimRight(prefix, "/")
+	state := func(e Event) (mqttEvent, string) {
+		s := mqttEvent{
+			Name:             e.Title(),
+			Time:             e.Time,
+			SecondsRemaining: e.Time - now.Unix(),
+			Urgency:          urgencyBucket(e.Time, now),
+		}
+		return s, fmt.Sprintf("%s|%d|%d", s.Name, s.Time, s.Urgency)
+	}
+
+	nextMsg := mqttMessage{Topic: prefix + "/next", Payload: []byte("{}"), state: "none"}
+	if next, ok := nextEvent(events, now, nextOptions{}); ok {
+		s, key := state(next)
+		nextMsg.Payload, _ = json.Marshal(s)
+		nextMsg.state = key
+	}
+	messages := []mqttMessage{nextMsg}
+
+	ids := eventIDs(events)
+	for i, e := range events {
+		id := ids[i]
+		s, key := state(e)
+		payload, _ := json.Marshal(s)
+		messages = append(messages, mqttMessage{Topic: prefix + "/events/" + id, Payload: payload, state: key})
+	}
+	return messages
+}
+
+// mqttRetryDelay is how long to wait before reconnecting after the given
+// number of failures in a row, doubling from 1s up to 5 minutes.
+func mqttRetryDelay(failures int) time.Duration {
+	d := time.Second
+	for i := 1; i < failures && d < 5*time.Minute; i++ {
+		d *= 2
+	}
+	if d > 5*time.Minute {
+		d = 5 * time.Minute
+	}
+	return d
+}
+
+// mqttPublisher keeps the retained topics up to date: everything is
+// published every interval and whenever an event changes state, and topics
+// of removed events are cleared. A lost connection is retried with backoff.
+type mqttPublisher struct {
+	cfg      MQTTConfig
+	interval time.Duration
+	dial     func(MQTTConfig, time.Duration) (*mqttClient, error)
+
+	client      *mqttClient
+	failures    int
+	retryAt     time.Time
+	lastPublish time.Time
+	published   map[string]string // topic -> state last published
+}
+
+func newMQTTPublisher(cfg MQTTConfig) *mqttPublisher {
+	return &mqttPublisher{cfg: cfg, interval: cfg.interval(), dial: dialMQTT}
+}
+
+// Update publishes the state of events if it changed or the interval is up.
+// It returns the number of messages sent; errors are returned after the
+// connection is dropped, and Update does nothing until the retry delay has
+// passed.
+func (p *mqttPublisher) Update(events []Event, now time.Time) (int, error) {
+	if p.client == nil {
+		if now.Before(p.retryAt) {
+			return 0, nil
+		}
+		// Keep-alive leaves room for one missed publish.
+		client, err := p.dial(p.cfg, 2*p.interval+mqttTimeout)
+		if err != nil {
+			return 0, p.failed(now, err)
+		}
+		// Republish everything, the broker may have lost retained messages.
+		p.client, p.failures, p.lastPublish = client, 0, time.Time{}
+	}
+
+	messages := mqttMessages(p.cfg.TopicPrefix, events, now)
+	changed := len(messages) != len(p.published)
+	for _, msg := range messages {
+		if p.published[msg.Topic] != msg.state {
+			changed = true
+		}
+	}
+	if !changed && now.Sub(p.lastPublish) < p.interval {
+		return 0, nil
+	}
+
+	published := make(map[string]string, len(messages))
+	sent := 0
+	for _, msg := range messages {
+		if err := p.client.Publish(msg.Topic, msg.Payload, true); err != nil {
+			return sent, p.failed(now, err)
+		}
+		published[msg.Topic] = msg.state
+		sent++
+	}
+	// An empty retained message deletes the one the broker kept.
+	for topic := range p.published {
+		if _, ok := published[topic]; !ok {
+			if err := p.client.Publish(topic, nil, true); err != nil {
+				return sent, p.failed(now, err)
+			}
+			sent++
+		}
+	}
+	p.published, p.lastPublish = published, now
+	return sent, nil
+}
+
+func (p *mqttPublisher) failed(now time.Time, err error) error {
+	if p.client != nil {
+		p.client.conn.Close()
+		p.client = nil
+	}
+	p.failures++
+	p.retryAt = now.Add(mqttRetryDelay(p.failures))
+	return err
+}
+
+func (p *mqttPublisher) Close() {
+	if p.client != nil {
+		p.client.Close()
+		p.client = nil
+	}
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMQTTLength(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "80 01"},
+		{16383, "ff 7f"},
+		{2097152, "80 80 80 01"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("% x", mqttLength(tt.n)); got != tt.expected {
+			t.Errorf("%d: expected %s, got %s", tt.n, tt.expected, got)
+		}
+	}
+}
+
+func TestMQTTMessages(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	events := []Event{
+		{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()},
+		{Name: "Standup", Time: now.Add(time.Hour).Unix()},
+		{Name: "launch", Time: now.Add(-time.Hour).Unix()},
+	}
+
+	messages := mqttMessages("home/countdown/", events, now)
+	var topics []string
+	for _, msg := range messages {
+		topics = append(topics, msg.Topic)
+	}
+	expected := "home/countdown/next,home/countdown/events/launch,home/countdown/events/standup,home/countdown/events/launch-2"
+	if got := strings.Join(topics, ","); got != expected {
+		t.Fatalf("Expected topics %s, got %s", expected, got)
+	}
+
+	var next mqttEvent
+	if err := json.Unmarshal(messages[0].Payload, &next); err != nil {
+		t.Fatalf("Failed to parse next payload: %v", err)
+	}
+	if next.Name != "Standup" || next.SecondsRemaining != 3600 || next.Urgency != 6 {
+		t.Errorf("Unexpected next payload: %s", messages[0].Payload)
+	}
+	if got := string(messages[3].Payload); !strings.Contains(got, `"seconds_remaining":-3600,"urgency":0`) {
+		t.Errorf("Expected a past event payload, got %s", got)
+	}
+
+	if got := string(mqttMessages("countdown", events[2:], now)[0].Payload); got != "{}" {
+		t.Errorf("Expected {} with nothing upcoming, got %s", got)
+	}
+}
+
+func TestMQTTRetryDelay(t *testing.T) {
+	tests := map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 20: 5 * time.Minute}
+	for failures, expected := range tests {
+		if got := mqttRetryDelay(failures); got != expected {
+			t.Errorf("%d failures: expected %s, got %s", failures, expected, got)
+		}
+	}
+}
+
+func TestTickIntervalWithBroker(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.MQTT.Interval = "1m"
+	if got := tickInterval(10*time.Minute, cfg); got != 10*time.Minute {
+		t.Errorf("Expected the daemon's interval without a broker, got %s", got)
+	}
+	cfg.MQTT.Broker = "mqtt://localhost"
+	if got := tickInterval(10*time.Minute, cfg); got != time.Minute {
+		t.Errorf("Expected ticks at the MQTT interval, within the keep-alive, got %s", got)
+	}
+	if got := tickInterval(30*time.Second, cfg); got != 30*time.Second {
+		t.Errorf("Expected a shorter interval kept, got %s", got)
+	}
+}
+
+func TestMQTTConfigValidate(t *testing.T) {
+	tests := []struct {
+		change   func(*MQTTConfig)
+		expected string
+	}{
+		{func(c *MQTTConfig) {}, ""},
+		{func(c *MQTTConfig) { c.Broker = "mqtts://broker.example.com" }, ""},
+		{func(c *MQTTConfig) { c.Broker = "http://broker.example.com" }, `unsupported scheme "http"`},
+		{func(c *MQTTConfig) { c.Broker = "mqtt://" }, "no host"},
+		{func(c *MQTTConfig) { c.TopicPrefix = "home/#" }, "must not contain + or #"},
+		{func(c *MQTTConfig) { c.Interval = "0s" }, "at least 1s"},
+		{func(c *MQTTConfig) { c.Interval = "soon" }, "invalid mqtt.interval"},
+	}
+	for _, tt := range tests {
+		cfg := defaultConfig().MQTT
+		tt.change(&cfg)
+		err := cfg.validate()
+		if tt.expected == "" && err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", cfg, err)
+		} else if tt.expected != "" && (err == nil || !strings.Contains(err.Error(), tt.expected)) {
+			t.Errorf("Expected an error containing '%s' for %+v, got %v", tt.expected, cfg, err)
+		}
+	}
+}
+
+type mqttPacket struct {
+	header byte
+	body   []byte
+}
+
+// startFakeBroker accepts connections, answers CONNECT with returnCode and
+// sends every packet received after it to the returned channel.
+func startFakeBroker(t *testing.T, returnCode byte) (string, <-chan mqttPacket) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	packets := make(chan mqttPacket, 100)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				header, body, err := readMQTTPacket(r)
+				if err != nil {
+					return
+				}
+				packets <- mqttPacket{header, body}
+				conn.Write([]byte{0x20, 2, 0, returnCode})
+				for {
+					header, body, err := readMQTTPacket(r)
+					if err != nil {
+						return
+					}
+					packets <- mqttPacket{header, body}
+				}
+			}()
+		}
+	}()
+	return "mqtt://" + ln.Addr().String(), packets
+}
+
+// receive collects the topics and payloads of the next n publishes.
+func receive(t *testing.T, packets <-chan mqttPacket, n int) map[string]string {
+	t.Helper()
+	got := make(map[string]string)
+	for i := 0; i < n; i++ {
+		select {
+		case p := <-packets:
+			if p.header&0xf0 != 0x30 || p.header&0x01 == 0 {
+				t.Fatalf("Expected a retained publish, got header %#x", p.header)
+			}
+			length := int(p.body[0])<<8 | int(p.body[1])
+			got[string(p.body[2:2+length])] = string(p.body[2+length:])
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out after %d of %d publishes", i, n)
+		}
+	}
+	return got
+}
+
+func TestMQTTPublisher(t *testing.T) {
+	broker, packets := startFakeBroker(t, 0)
+	cfg := defaultConfig().MQTT
+	cfg.Broker, cfg.Username, cfg.Password, cfg.ClientID = broker, "home", "secret", "test-client"
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	events := []Event{
+		{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()},
+		{Name: "Standup", Time: now.Add(90 * time.Second).Unix()},
+	}
+	p := newMQTTPublisher(cfg)
+	defer p.Close()
+
+	if sent, err := p.Update(events, now); err != nil || sent != 3 {
+		t.Fatalf("Expected 3 messages, got %d, %v", sent, err)
+	}
+	connect := <-packets
+	for _, want := range []string{"\x00\x04MQTT\x04\xc2", "test-client", "home", "secret"} {
+		if connect.header != 0x10 || !strings.Contains(string(connect.body), want) {
+			t.Errorf("Expected %q in the CONNECT packet, got %q", want, connect.body)
+		}
+	}
+	got := receive(t, packets, 3)
+	if !strings.Contains(got["countdown/next"], `"name":"Standup"`) || got["countdown/events/launch"] == "" {
+		t.Errorf("Unexpected first publish: %v", got)
+	}
+
+	if sent, _ := p.Update(events, now.Add(10*time.Second)); sent != 0 {
+		t.Errorf("Expected nothing published within the interval, got %d", sent)
+	}
+	if sent, _ := p.Update(events, now.Add(61*time.Second)); sent != 3 {
+		t.Errorf("Expected a republish after the interval, got %d", sent)
+	}
+	receive(t, packets, 3)
+
+	// Standup passes: a transition, published before the interval is up.
+	if sent, _ := p.Update(events, now.Add(91*time.Second)); sent != 3 {
+		t.Fatalf("Expected a publish on the transition, got %d", sent)
+	}
+	got = receive(t, packets, 3)
+	if !strings.Contains(got["countdown/next"], `"name":"Launch"`) {
+		t.Errorf("Expected Launch next, got %s", got["countdown/next"])
+	}
+
+	// Removing an event clears its retained topic.
+	if sent, _ := p.Update(events[:1], now.Add(92*time.Second)); sent != 3 {
+		t.Fatalf("Expected 2 messages and 1 cleared topic, got %d", sent)
+	}
+	got = receive(t, packets, 3)
+	if cleared, ok := got["countdown/events/standup"]; !ok || cleared != "" {
+		t.Errorf("Expected standup cleared with an empty payload, got %v", got)
+	}
+}
+
+func TestMQTTPublisherReconnects(t *testing.T) {
+	broker, _ := startFakeBroker(t, 4)
+	cfg := defaultConfig().MQTT
+	cfg.Broker = broker
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	p := newMQTTPublisher(cfg)
+	dials := 0
+	p.dial = func(cfg MQTTConfig, keepAlive time.Duration) (*mqttClient, error) {
+		dials++
+		return dialMQTT(cfg, keepAlive)
+	}
+
+	if _, err := p.Update(nil, now); err == nil || !strings.Contains(err.Error(), "bad username or password") {
+		t.Fatalf("Expected the broker to refuse, got %v", err)
+	}
+	if _, err := p.Update(nil, now.Add(500*time.Millisecond)); err != nil || dials != 1 {
+		t.Errorf("Expected no dial before the retry delay, got %d dials, %v", dials, err)
+	}
+	p.Update(nil, now.Add(time.Second))
+	if dials != 2 || !p.retryAt.Equal(now.Add(3*time.Second)) {
+		t.Errorf("Expected a second dial and a 2s backoff, got %d dials, retry at %s", dials, p.retryAt.Sub(now))
+	}
+
+	// A dropped connection is noticed on the next publish.
+	broker, packets := startFakeBroker(t, 0)
+	p.cfg.Broker, p.retryAt = broker, time.Time{}
+	if _, err := p.Update(nil, now); err != nil {
+		t.Fatalf("Expected to connect, got %v", err)
+	}
+	<-packets
+	receive(t, packets, 1)
+	p.client.conn.Close()
+	if _, err := p.Update(nil, now.Add(time.Minute)); err == nil || p.client != nil {
+		t.Fatalf("Expected the closed connection to fail and be dropped, got %v", err)
+	}
+	if _, err := p.Update(nil, now.Add(time.Minute+time.Second)); err != nil {
+		t.Fatalf("Expected to reconnect, got %v", err)
+	}
+	<-packets
+	receive(t, packets, 1)
+}
+
+func TestMQTTTestFlag(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = stdout }()
+
+	if code := runDaemon([]string{"--mqtt-test"}); code != 2 {
+		t.Errorf("Expected exit code 2 without a broker, got %d", code)
+	}
+
+	broker, packets := startFakeBroker(t, 0)
+	writeConfigFile(t, "[mqtt]\nbroker = \""+broker+"\"\ntopic_prefix = \"home/countdown\"\n")
+	if err := writeEventsFile([]Event{{Name: "Launch", Time: time.Now().Add(time.Hour).Unix()}}); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+	if code := runDaemon([]string{"--mqtt-test"}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	<-packets
+	got := receive(t, packets, 2)
+	if !strings.Contains(got["home/countdown/next"], `"name":"Launch"`) || got["home/countdown/events/launch"] == "" {
+		t.Errorf("Unexpected messages: %v", got)
+	}
+	if p := <-packets; p.header != 0xe0 {
+		t.Errorf("Expected a DISCONNECT, got header %#x", p.header)
+	}
+}
+
+// readMQTTPacket reads one packet, returning its header byte and body.
+func readMQTTPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7f) * multiplier
+		if digit&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	_, err = io.ReadFull(r, body)
+	return header, body, err
+}
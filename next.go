@@ -0,0 +1,67 @@
+package main
+
+import (
+	"time"
+)
+
+// nextOptions scopes which events count when looking for the next one. The
+// zero value counts every saved event.
+type nextOptions struct {
+	// Tag counts only events with this tag, or with none for
+	// untaggedLabel.
+	Tag string
+	// Horizon counts only events at most this far ahead; zero is no limit.
+	Horizon time.Duration
+	// Virtual counts computed events such as quarter ends and sunsets too.
+	Virtual bool
+	// NoReadOnly leaves out events owned by a subscription or an import.
+	NoReadOnly bool
+}
+
+func (o nextOptions) match(e Event, now time.Time) bool {
+	switch {
+	case e.Time < now.Unix():
+		return false
+	case e.Virtual && !o.Virtual:
+		return false
+	case e.ReadOnly && o.NoReadOnly:
+		return false
+	case o.Horizon > 0 && e.Time > now.Add(o.Horizon).Unix():
+		return false
+	case o.Tag == untaggedLabel:
+		return len(e.Tags) == 0
+	case o.Tag != "":
+		return hasTag(e, o.Tag)
+	}
+	return true
+}
+
+// nextEvents returns up to n of events still to come, soonest first, or all
+// of them if n is 0. Recurring events count at their next occurrence, and
+// events at the same time in their manual order, whatever order the list
+// is sorted in. Everything that shows "the next event" goes through here.
+func nextEvents(events []Event, now time.Time, opts nextOptions, n int) []Event {
+	events = append([]Event(nil), events...)
+	rollForwardRecurring(events, now, 0)
+	var next []Event
+	for _, e := range events {
+		if opts.match(e, now) {
+			next = append(next, e)
+		}
+	}
+	sortEventsByTime(next)
+	if n > 0 && len(next) > n {
+		next = next[:n]
+	}
+	return next
+}
+
+// nextEvent returns the soonest of events still to come, and false if
+// there is none.
+func nextEvent(events []Event, now time.Time, opts nextOptions) (Event, bool) {
+	next := nextEvents(events, now, opts, 1)
+	if len(next) == 0 {
+		return Event{}, false
+	}
+	return next[0], true
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextEvents(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	events := []Event{
+		{Name: "Past", Time: now.Add(-time.Hour).Unix()},
+		{Name: "Soon", Time: now.Add(time.Hour).Unix()},
+		{Name: "End of Q1 FY26", Time: now.Add(2 * time.Hour).Unix(), Virtual: true},
+		{Name: "Later", Time: now.AddDate(0, 0, 3).Unix()},
+		{Name: "Much later", Time: now.AddDate(0, 1, 0).Unix()},
+		{Name: "Next year", Time: now.AddDate(1, 0, 0).Unix()},
+	}
+
+	if got := storedNames(nextEvents(events, now, nextOptions{}, 3)); got != "Soon,Later,Much later" {
+		t.Errorf("Expected Soon, Later and Much later, got %s", got)
+	}
+	if got := storedNames(nextEvents(events, now, nextOptions{Virtual: true}, 2)); got != "Soon,End of Q1 FY26" {
+		t.Errorf("Expected computed events counted when asked for, got %s", got)
+	}
+	if got := storedNames(nextEvents(events, now, nextOptions{Horizon: 7 * 24 * time.Hour}, 0)); got != "Soon,Later" {
+		t.Errorf("Expected only the coming week, got %s", got)
+	}
+	if got := storedNames(nextEvents(events[:1], now, nextOptions{}, 0)); got != "" {
+		t.Errorf("Expected nothing without upcoming events, got %s", got)
+	}
+}
+
+func TestNextEventPrecedence(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	at := now.Add(time.Hour).Unix()
+	tests := []struct {
+		name   string
+		events []Event
+		opts   nextOptions
+		want   string
+	}{
+		{
+			// Moving an event to the top of the list does not make it next.
+			name:   "Soonest over list position",
+			events: []Event{{Name: "Moved up", Time: at + 60, Order: -1}, {Name: "Sooner", Time: at}},
+			want:   "Sooner",
+		},
+		{
+			name:   "Manual order at the same time",
+			events: []Event{{Name: "Second", Time: at, Order: 2}, {Name: "First", Time: at, Order: 1}},
+			want:   "First",
+		},
+		{
+			name:   "Read-only events count",
+			events: []Event{{Name: "Subscribed", Time: at, ReadOnly: true}, {Name: "Mine", Time: at + 60}},
+			want:   "Subscribed",
+		},
+		{
+			name:   "Unless left out",
+			events: []Event{{Name: "Subscribed", Time: at, ReadOnly: true}, {Name: "Mine", Time: at + 60}},
+			opts:   nextOptions{NoReadOnly: true},
+			want:   "Mine",
+		},
+		{
+			name:   "Tag",
+			events: []Event{{Name: "Dentist", Time: at}, {Name: "Ada", Time: at + 60, Tags: []string{"birthday"}}},
+			opts:   nextOptions{Tag: "birthday"},
+			want:   "Ada",
+		},
+		{
+			name:   "Untagged",
+			events: []Event{{Name: "Ada", Time: at, Tags: []string{"birthday"}}, {Name: "Dentist", Time: at + 60}},
+			opts:   nextOptions{Tag: untaggedLabel},
+			want:   "Dentist",
+		},
+		{
+			name:   "Recurring at its next occurrence",
+			events: []Event{{Name: "Standup", Time: now.Add(-23 * time.Hour).Unix(), Repeat: &Recurrence{Unit: repeatDaily, Every: 1}}, {Name: "Launch", Time: now.Add(2 * time.Hour).Unix()}},
+			want:   "Standup",
+		},
+		{
+			name:   "Starting now",
+			events: []Event{{Name: "Now", Time: now.Unix()}, {Name: "Soon", Time: at}},
+			want:   "Now",
+		},
+		{
+			name:   "Beyond the horizon",
+			events: []Event{{Name: "Launch", Time: now.AddDate(0, 0, 2).Unix()}},
+			opts:   nextOptions{Horizon: 24 * time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := nextEvent(tt.events, now, tt.opts)
+			if ok != (tt.want != "") || e.Name != tt.want {
+				t.Errorf("Expected %q, got %q (%v)", tt.want, e.Name, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// eventBefore orders events by time, then by their manual Order among
+// events at the same time.
+func eventBefore(a, b Event) bool {
+	if a.Time != b.Time {
+		return a.Time < b.Time
+	}
+	return a.Order < b.Order
+}
+
+// sortOrder is how the list orders its events. Loading, refreshing and
+// adding an event all go through its less, so the list never disagrees with
+// itself. Events at the same time keep their manual Order in every mode.
+type sortOrder int
+
+const (
+	sortByDate      sortOrder = iota // earliest first
+	sortNewestFirst                  // latest first
+	sortPastLast                     // upcoming soonest first, then past events most recent first
+)
+
+func parseSortOrder(s string) (sortOrder, error) {
+	switch s {
+	case "", "date":
+		return sortByDate, nil
+	case "newest":
+		return sortNewestFirst, nil
+	case "past_last":
+		return sortPastLast, nil
+	}
+	return sortByDate, fmt.Errorf(`unknown sort order %q, want "date", "newest" or "past_last"`, s)
+}
+
+// less reports whether a goes before b in the list at now.
+func (o sortOrder) less(a, b Event, now time.Time) bool {
+	if o == sortPastLast {
+		pastA, pastB := a.Time < now.Unix(), b.Time < now.Unix()
+		if pastA != pastB {
+			return pastB
+		}
+		if pastA {
+			return sortNewestFirst.less(a, b, now)
+		}
+	}
+	if o == sortNewestFirst && a.Time != b.Time {
+		return a.Time > b.Time
+	}
+	return eventBefore(a, b)
+}
+
+func (o sortOrder) sort(events []Event, now time.Time) {
+	sort.SliceStable(events, func(i, j int) bool { return o.less(events[i], events[j], now) })
+}
+
+// insertIndex returns where e goes in the list: after every event that does
+// not come after it. In sections regroup moves it into its own.
+func (m MainModel) insertIndex(e Event) int {
+	order, now := m.config.sortOrder(), m.now()
+	items := m.events.Items()
+	return sort.Search(len(items), func(i int) bool {
+		other, ok := items[i].(Event)
+		return ok && order.less(e, other, now)
+	})
+}
+
+// listInOrder reports whether the list, or each of its sections, is still
+// sorted at now. With past events last, it stops being so as soon as an
+// event passes.
+func (m MainModel) listInOrder(now time.Time) bool {
+	order := m.config.sortOrder()
+	var prev *Event
+	for _, item := range m.events.Items() {
+		e, ok := item.(Event)
+		if !ok {
+			prev = nil
+			continue
+		}
+		if prev != nil && order.less(e, *prev, now) {
+			return false
+		}
+		prev = &e
+	}
+	return true
+}
+
+// nextOrder returns an Order placing a new event after every existing event
+// at the same time.
+func nextOrder(items []list.Item, ts int64) int {
+	order := 0
+	for _, e := range eventsOf(items) {
+		if e.Time == ts && e.Order >= order {
+			order = e.Order + 1
+		}
+	}
+	return order
+}
+
+// moveEvent swaps the selected event with its neighbour in direction delta
+// (-1 up, +1 down) if both happen at the same time. Siblings are numbered in
+// their current order first, so the swap always sticks. It reports whether
+// anything moved.
+func (m *MainModel) moveEvent(delta int) bool {
+	if m.events.FilterState() != list.Unfiltered {
+		return false
+	}
+	items := m.events.Items()
+	i, j := m.events.Index(), m.events.Index()+delta
+	if j < 0 || j >= len(items) {
+		return false
+	}
+	a, okA := items[i].(Event)
+	b, okB := items[j].(Event)
+	if !okA || !okB || a.Time != b.Time || a.Virtual || b.Virtual {
+		return false
+	}
+
+	n := 0
+	for k, item := range items {
+		if e, ok := item.(Event); ok && e.Time == a.Time {
+			e.Order = n
+			n++
+			m.events.SetItem(k, e)
+		}
+	}
+	a, b = m.events.Items()[i].(Event), m.events.Items()[j].(Event)
+	a.Order, b.Order = b.Order, a.Order
+	m.events.SetItem(i, b)
+	m.events.SetItem(j, a)
+	m.events.Select(j)
+	return true
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func eventNames(items []list.Item) string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.(Event).Name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSortUsesOrderTiebreaker(t *testing.T) {
+	deadline := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local).Unix()
+	events := []Event{
+		{Name: "Taxes", Time: deadline, Order: 2},
+		{Name: "Later", Time: deadline + 60},
+		{Name: "Report", Time: deadline, Order: 0},
+		{Name: "Invoice", Time: deadline, Order: 1},
+	}
+	sortEventsByTime(events)
+
+	var names []string
+	for _, e := range events {
+		names = append(names, e.Name)
+	}
+	if got := strings.Join(names, ","); got != "Report,Invoice,Taxes,Later" {
+		t.Errorf("Expected Report,Invoice,Taxes,Later, got %s", got)
+	}
+}
+
+func TestSortOrders(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(day int) int64 { return time.Date(2026, 6, day, 12, 0, 0, 0, time.UTC).Unix() }
+	events := []Event{
+		{Name: "Soon", Time: at(2)},
+		{Name: "Long ago", Time: at(-30)},
+		{Name: "Later", Time: at(9)},
+		{Name: "Yesterday B", Time: at(-1), Order: 1},
+		{Name: "Yesterday A", Time: at(-1)},
+	}
+	tests := []struct {
+		order    sortOrder
+		expected string
+	}{
+		{sortByDate, "Long ago,Yesterday A,Yesterday B,Soon,Later"},
+		{sortNewestFirst, "Later,Soon,Yesterday A,Yesterday B,Long ago"},
+		{sortPastLast, "Soon,Later,Yesterday A,Yesterday B,Long ago"},
+	}
+	for _, tt := range tests {
+		sorted := append([]Event(nil), events...)
+		tt.order.sort(sorted, now)
+		if got := storedNames(sorted); got != tt.expected {
+			t.Errorf("Sort order %d: expected %s, got %s", tt.order, tt.expected, got)
+		}
+	}
+}
+
+// TestListStaysSorted adds, edits and removes events at random through the
+// form and checks after every step that the list agrees with its sort order.
+func TestListStaysSorted(t *testing.T) {
+	withFixedLocal(t)
+	for _, mode := range []string{"date", "newest", "past_last"} {
+		t.Run(mode, func(t *testing.T) {
+			th := newTestHelper(t)
+			defer th.cleanup()
+			writeConfigFile(t, fmt.Sprintf("sort_order = %q\n", mode))
+
+			now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+			m := newRefreshTestModel(t, &now)
+			rng := rand.New(rand.NewSource(1))
+			// A handful of times, so that many events share one.
+			randomTime := func() string {
+				return now.Add(time.Duration(rng.Intn(9)-4) * 24 * time.Hour).Format(inputTimeFormLong)
+			}
+			update := func(msg tea.Msg) {
+				model, _ := m.Update(msg)
+				m = model.(MainModel)
+			}
+			submit := func() {
+				update(tea.KeyMsg{Type: tea.KeyCtrlS})
+				if m.state == showInput || m.state == showEdit {
+					update(tea.KeyMsg{Type: tea.KeyCtrlS}) // confirm a past date
+				}
+				if m.state == showEditReview {
+					update(tea.KeyMsg{Type: tea.KeyEnter})
+				}
+			}
+
+			for step := 0; step < 200; step++ {
+				n := len(m.events.Items())
+				switch op := rng.Intn(4); {
+				case op < 2 || n == 0:
+					m.openForm(showInput)
+					m.inputs[inputNameField].SetValue(fmt.Sprintf("Event %d", step))
+					m.inputs[inputTimeField].SetValue(randomTime())
+					submit()
+				case op == 2:
+					m.events.Select(rng.Intn(n))
+					update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+					m.inputs[inputTimeField].SetValue(randomTime())
+					submit()
+				default:
+					m.events.Select(rng.Intn(n))
+					update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+				}
+				if m.state != showEvents && m.state != noEvents {
+					t.Fatalf("Step %d: form did not close: %s", step, m.inputStatus)
+				}
+				if !m.listInOrder(now) {
+					t.Fatalf("Step %d: list out of order: %s", step, eventNames(m.events.Items()))
+				}
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			saved, err := readEventsFile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			reloaded := newMainModel(cfg, saved, now)
+			if got, expected := eventNames(reloaded.events.Items()), eventNames(m.events.Items()); got != expected {
+				t.Errorf("Expected a restart to keep the order\n%s\ngot\n%s", expected, got)
+			}
+		})
+	}
+}
+
+func TestPassedEventResorts(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	writeConfigFile(t, "sort_order = \"past_last\"\n")
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Now", Time: now.Add(time.Second).Unix()},
+		Event{Name: "Later", Time: now.Add(time.Hour).Unix()},
+		Event{Name: "Past", Time: now.Add(-time.Hour).Unix()},
+	)
+	if !m.listInOrder(now) {
+		t.Fatal("Expected the list in order to begin with")
+	}
+	now = now.Add(2 * time.Second)
+	if m.listInOrder(now) {
+		t.Error("Expected the passed event to put the list out of order")
+	}
+	m.refreshEvents()
+	if got := eventNames(m.events.Items()); got != "Later,Now,Past" {
+		t.Errorf("Expected the passed event among the past ones, got %s", got)
+	}
+}
+
+func TestMoveEventPersists(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	deadline := time.Now().AddDate(0, 1, 0).Truncate(time.Hour).Unix()
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Report", Time: deadline},
+		Event{Name: "Invoice", Time: deadline},
+		Event{Name: "Taxes", Time: deadline},
+		Event{Name: "Later", Time: deadline + 60},
+	)
+
+	m.events.Select(2)
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlUp})
+	m = model.(MainModel)
+	if got := eventNames(m.events.Items()); got != "Report,Taxes,Invoice,Later" {
+		t.Errorf("Expected Taxes moved up, got %s", got)
+	}
+	if got := m.events.SelectedItem().(Event).Name; got != "Taxes" {
+		t.Errorf("Expected the selection to follow Taxes, got %s", got)
+	}
+
+	// Later is at a different time, so Taxes cannot move past Invoice into it.
+	m.events.Select(2)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlDown})
+	m = model.(MainModel)
+	if got := eventNames(m.events.Items()); got != "Report,Taxes,Invoice,Later" {
+		t.Errorf("Expected no move across times, got %s", got)
+	}
+
+	reloaded, err := NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if got := eventNames(reloaded.events.Items()); got != "Report,Taxes,Invoice,Later" {
+		t.Errorf("Expected order to survive a restart, got %s", got)
+	}
+}
+
+func TestNewSameTimeEventGoesLast(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	day := time.Now().AddDate(0, 1, 0)
+	deadline := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local).Unix()
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Report", Time: deadline, Order: 0},
+		Event{Name: "Invoice", Time: deadline, Order: 1},
+	)
+
+	m.state = showInput
+	m.inputs[inputNameField].SetValue("Taxes")
+	m.inputs[inputTimeField].SetValue(time.Unix(deadline, 0).Format(inputTimeFormShort))
+	m.focus = int(inputSubmitButton)
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(MainModel)
+
+	if got := eventNames(m.events.Items()); got != "Report,Invoice,Taxes" {
+		t.Errorf("Expected Taxes after its same-time siblings, got %s", got)
+	}
+	if order := m.events.Items()[2].(Event).Order; order != 2 {
+		t.Errorf("Expected order 2, got %d", order)
+	}
+}
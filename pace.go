@@ -0,0 +1,37 @@
+package main
+
+import (
+	"time"
+)
+
+const week = 7 * 24 * time.Hour
+
+// pace describes how fast the time left before an event is being used up.
+type pace struct {
+	DailyShare float64   // percent of the remaining time that one day is
+	Halfway    time.Time // when half of the remaining time will be gone
+	WeeklyBurn float64   // percent of the created-to-event interval per week
+	HasBurn    bool      // WeeklyBurn is only known for events with Created
+}
+
+// eventPace computes the pace of an upcoming event. It reports false within
+// a day of the event, where a day would be more than all the time left.
+// WeeklyBurn is skipped for intervals shorter than a week for the same reason.
+func eventPace(created, ts int64, now time.Time) (pace, bool) {
+	remaining := time.Unix(ts, 0).Sub(now)
+	if remaining <= 24*time.Hour {
+		return pace{}, false
+	}
+
+	p := pace{
+		DailyShare: float64(24*time.Hour) / float64(remaining) * 100,
+		Halfway:    now.Add(remaining / 2),
+	}
+	if created > 0 && created < now.Unix() {
+		if total := time.Unix(ts, 0).Sub(time.Unix(created, 0)); total >= week {
+			p.WeeklyBurn = float64(week) / float64(total) * 100
+			p.HasBurn = true
+		}
+	}
+	return p, true
+}
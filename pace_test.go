@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEventPace(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+	tests := []struct {
+		name    string
+		created int64
+		ts      int64
+		ok      bool
+		daily   float64
+		halfway time.Time
+		hasBurn bool
+		burn    float64
+	}{
+		{"Forty days left", 0, now.Unix() + 40*day, true, 2.5, now.AddDate(0, 0, 20), false, 0},
+		{"Created ten weeks before", now.Unix() - 30*day, now.Unix() + 40*day, true, 2.5, now.AddDate(0, 0, 20), true, 10},
+		{"Interval under a week", now.Unix() - 2*day, now.Unix() + 4*day, true, 25, now.AddDate(0, 0, 2), false, 0},
+		{"Created in the future", now.Unix() + day, now.Unix() + 40*day, true, 2.5, now.AddDate(0, 0, 20), false, 0},
+		{"Exactly one day left", 0, now.Unix() + day, false, 0, time.Time{}, false, 0},
+		{"Seconds left", now.Unix() - 30*day, now.Unix() + 5, false, 0, time.Time{}, false, 0},
+		{"Past event", now.Unix() - 30*day, now.Unix() - day, false, 0, time.Time{}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := eventPace(tt.created, tt.ts, now)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(p.DailyShare-tt.daily) > 0.001 {
+				t.Errorf("Expected daily share %.3f, got %.3f", tt.daily, p.DailyShare)
+			}
+			if !p.Halfway.Equal(tt.halfway) {
+				t.Errorf("Expected halfway %v, got %v", tt.halfway, p.Halfway)
+			}
+			if p.HasBurn != tt.hasBurn || math.Abs(p.WeeklyBurn-tt.burn) > 0.001 {
+				t.Errorf("Expected burn %v/%.3f, got %v/%.3f", tt.hasBurn, tt.burn, p.HasBurn, p.WeeklyBurn)
+			}
+		})
+	}
+}
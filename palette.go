@@ -0,0 +1,44 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+// fallbackColors are the palette colors picked by hand for terminals
+// without truecolor. Downsampled, the urgency levels run together (dark red
+// turns brown, orange and yellow both become yellow), so each gets a 256-
+// and a 16-color stand-in of its own.
+var fallbackColors = []lipgloss.CompleteColor{
+	{TrueColor: cPast, ANSI256: "133", ANSI: "5"},
+	{TrueColor: cUrgency1, ANSI256: "29", ANSI: "2"},
+	{TrueColor: cUrgency2, ANSI256: "78", ANSI: "10"},
+	{TrueColor: cUrgency3, ANSI256: "220", ANSI: "11"},
+	{TrueColor: cUrgency4, ANSI256: "208", ANSI: "3"},
+	{TrueColor: cUrgency5, ANSI256: "203", ANSI: "9"},
+	{TrueColor: cUrgency6, ANSI256: "124", ANSI: "1"},
+	{TrueColor: cTextLightGray, ANSI256: "255", ANSI: "15"},
+	{TrueColor: cTitle, ANSI256: "32", ANSI: "4"},
+	{TrueColor: cDetailTitle, ANSI256: "162", ANSI: "5"},
+	{TrueColor: cError, ANSI256: "160", ANSI: "9"},
+	{TrueColor: cSuccess, ANSI256: "22", ANSI: "2"},
+	{TrueColor: cHint, ANSI256: "245", ANSI: "8"},
+	{TrueColor: cWhatIf, ANSI256: "37", ANSI: "6"},
+	{TrueColor: cTimelineFuture, ANSI256: "68", ANSI: "12"},
+	{TrueColor: cTimelineTrack, ANSI256: "238", ANSI: "8"},
+	{TrueColor: cBarEmpty, ANSI256: "236", ANSI: "8"},
+}
+
+var fallbackColorsByHex = func() map[string]lipgloss.CompleteColor {
+	colors := make(map[string]lipgloss.CompleteColor, len(fallbackColors))
+	for _, c := range fallbackColors {
+		colors[c.TrueColor] = c
+	}
+	return colors
+}()
+
+// paletteColor is the palette color c as lipgloss should draw it: with its
+// stand-ins from fallbackColors, or left to lipgloss to downsample.
+func paletteColor(c string) lipgloss.TerminalColor {
+	if complete, ok := fallbackColorsByHex[c]; ok {
+		return complete
+	}
+	return lipgloss.Color(c)
+}
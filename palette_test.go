@@ -0,0 +1,73 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+func TestPaletteHexColors(t *testing.T) {
+	hex := regexp.MustCompile(`^#[0-9A-F]{6}$`)
+	for _, c := range []string{
+		cError, cItemTitleDark, cItemTitleLight, cItemDescDark, cItemDescLight,
+		cTitle, cDetailTitle, cPromptBorder, cDimmedTitleDark, cDimmedTitleLight,
+		cDimmedDescDark, cDimmedDescLight, cTextLightGray, cSuccess, cWarning,
+		cHint, cUrgency1, cUrgency2, cUrgency3, cUrgency4, cUrgency5, cUrgency6,
+		cPast, cWhatIf, cTodayBadge, cTodayDark, cTodayLight, cBarEmpty,
+		cTimelineTrack, cTimelineNow, cTimelineFuture, cTimelineSelected,
+	} {
+		if !hex.MatchString(c) {
+			t.Errorf("Expected palette color %q in #RRGGBB form", c)
+		}
+	}
+}
+
+func TestPaletteUnderColorProfiles(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	d := eventDelegate{now: now}
+
+	// One event per urgency level, from past to less than a day away.
+	times := []time.Time{
+		now.Add(-time.Hour),
+		now.AddDate(0, 0, 60),
+		now.AddDate(0, 0, 20),
+		now.AddDate(0, 0, 10),
+		now.AddDate(0, 0, 5),
+		now.AddDate(0, 0, 2),
+		now.Add(2 * time.Hour),
+	}
+	sgr := regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	for _, p := range []struct {
+		name    string
+		profile termenv.Profile
+	}{
+		{"ansi", termenv.ANSI},
+		{"ansi256", termenv.ANSI256},
+		{"truecolor", termenv.TrueColor},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			got := withColorProfile(p.profile, func() string {
+				var b strings.Builder
+				b.WriteString(TitleStyle.Render("Countdown") + "\n")
+				seen := map[string]bool{}
+				for _, ts := range times {
+					line := d.countdown(ts.Unix())
+					seen[sgr.FindString(line)] = true
+					b.WriteString(line + "\n")
+				}
+				if len(seen) != len(times) || seen[""] {
+					t.Errorf("Expected %d distinct urgency colors, got %v", len(times), seen)
+				}
+				return b.String()
+			})
+			assertGolden(t, "palette_"+p.name+".golden", got)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package when_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+func ExampleBreakdown() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	launch := time.Date(2027, 4, 2, 16, 5, 0, 0, time.UTC)
+
+	c := when.Breakdown(now, launch)
+	fmt.Println(c.Years, c.Days, c.Hours, c.Minutes)
+	fmt.Println(when.FormatCompact(c))
+	// Output:
+	// 1 23 4 5
+	// 1y 23d 4h 5m 0s
+}
+
+func ExampleFormatCompact() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	fmt.Println(when.FormatCompact(when.Breakdown(now, now.Add(-50*time.Hour))))
+	// Output: 2d 2h 0m 0s ago
+}
+
+func ExampleHumanize() {
+	fmt.Println(when.Humanize(45 * time.Second))
+	fmt.Println(when.Humanize(26 * time.Hour))
+	fmt.Println(when.Humanize(-21 * 24 * time.Hour))
+	// Output:
+	// now
+	// 1 day
+	// 3 weeks
+}
+
+func ExampleUrgencyBucket() {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	fmt.Println(when.UrgencyBucket(now, now.AddDate(0, 0, -1)))
+	fmt.Println(when.UrgencyBucket(now, now.AddDate(0, 0, 2)))
+	fmt.Println(when.UrgencyBucket(now, now.AddDate(0, 2, 0)))
+	// Output:
+	// 0
+	// 5
+	// 1
+}
@@ -0,0 +1,183 @@
+// Package when is countdown's date arithmetic: how far one instant is from
+// another, broken down the way a calendar counts it, written out the way
+// countdown shows it, and how urgent that is.
+//
+// Nothing here knows about terminals or translations; text is English.
+package when
+
+import (
+	"fmt"
+	"time"
+)
+
+// Components is the distance between two instants in calendar years and
+// days and the real hours, minutes and seconds left after them.
+type Components struct {
+	Years, Days, Hours, Minutes, Seconds int
+	// Past is set when the target was at least a second before now.
+	Past bool
+}
+
+// Breakdown splits the time between now and target into Components, in
+// now's time zone. The instant counted to is exact; only the breakdown
+// follows the calendar, so one day before a noon event is "1d 0h" across a
+// DST change, and the 25-hour day of a fall-back can leave 24h. Partial
+// seconds are cut off, and less than a second in the past counts as now.
+func Breakdown(now, target time.Time) Components {
+	from, to := now, target.In(now.Location())
+	past := to.Sub(from) <= -time.Second
+	if past {
+		from, to = to, from
+	} else if to.Before(from) {
+		to = from
+	}
+
+	years := to.Year() - from.Year()
+	for years > 0 && addYears(from, years).After(to) {
+		years--
+	}
+	anchor := addYears(from, years)
+	days := WholeDays(anchor, to)
+	rest := int(to.Sub(anchor.AddDate(0, 0, days)) / time.Second)
+	return Components{
+		Years:   years,
+		Days:    days,
+		Hours:   rest / 3600,
+		Minutes: rest % 3600 / 60,
+		Seconds: rest % 60,
+		Past:    past,
+	}
+}
+
+// WholeDays counts the days from from to to on the wall clock, so noon to
+// noon the next day is one day even when a DST change makes it 23 or 25
+// hours long. to must not be before from.
+func WholeDays(from, to time.Time) int {
+	days := int(to.Sub(from) / (24 * time.Hour))
+	for days > 0 && from.AddDate(0, 0, days).After(to) {
+		days--
+	}
+	for !from.AddDate(0, 0, days+1).After(to) {
+		days++
+	}
+	return days
+}
+
+// addYears moves t by n years, keeping the time of day. February 29 becomes
+// February 28 in other years.
+func addYears(t time.Time, n int) time.Time {
+	first := time.Date(t.Year()+n, t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := t.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// Units writes c from its largest nonzero unit down, e.g. "23d 4h 5m 6s".
+// Seconds are left off unless seconds is set or less than a minute
+// remains. Whether c is past is not shown.
+func (c Components) Units(seconds bool) string {
+	var s string
+	switch {
+	case c.Years > 0:
+		s = fmt.Sprintf("%dy %dd %dh %dm", c.Years, c.Days, c.Hours, c.Minutes)
+	case c.Days > 0:
+		s = fmt.Sprintf("%dd %dh %dm", c.Days, c.Hours, c.Minutes)
+	case c.Hours > 0:
+		s = fmt.Sprintf("%dh %dm", c.Hours, c.Minutes)
+	case c.Minutes > 0:
+		s = fmt.Sprintf("%dm", c.Minutes)
+	default:
+		seconds = true
+	}
+	if seconds {
+		if s != "" {
+			s += " "
+		}
+		s += fmt.Sprintf("%ds", c.Seconds)
+	}
+	return s
+}
+
+// FormatCompact writes c as countdown's list does with seconds shown:
+// "1y 23d 4h 5m 6s", "5m 0s", or "2d 0h 0m 0s ago" when c is past.
+func FormatCompact(c Components) string {
+	if c.Past {
+		return c.Units(true) + " ago"
+	}
+	return c.Units(true)
+}
+
+// Unit is a unit Approximate rounds to.
+type Unit int
+
+const (
+	Minute Unit = iota
+	Hour
+	Day
+	Week
+	Year
+)
+
+var unitNames = [...]string{"minute", "hour", "day", "week", "year"}
+
+func (u Unit) String() string { return unitNames[u] }
+
+// Approximate rounds d down to whole units of the largest one that reads
+// naturally: years from 365 days on, weeks from 14 days, then days, hours
+// and minutes. The sign of d is ignored.
+func Approximate(d time.Duration) (int, Unit) {
+	if d < 0 {
+		d = -d
+	}
+	const day = 24 * time.Hour
+	switch {
+	case d >= 365*day:
+		return int(d / (365 * day)), Year
+	case d >= 14*day:
+		return int(d / (7 * day)), Week
+	case d >= day:
+		return int(d / day), Day
+	case d >= time.Hour:
+		return int(d / time.Hour), Hour
+	}
+	return int(d / time.Minute), Minute
+}
+
+// Humanize writes d roughly, as Approximate rounds it: "3 weeks", "1 day",
+// or "now" under a minute. The sign of d is ignored.
+func Humanize(d time.Duration) string {
+	n, unit := Approximate(d)
+	if n == 0 && unit == Minute {
+		return "now"
+	}
+	if n == 1 {
+		return "1 " + unit.String()
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// UrgencyBucket sorts target into one of countdown's urgency levels as of
+// now: 0 once it has passed, then 1 for 30 days or more away, 2 from 14
+// days, 3 from 7, 4 from 3, 5 from 1 and 6 within a day.
+func UrgencyBucket(now, target time.Time) int {
+	diff := target.Sub(now)
+	if diff < 0 {
+		return 0
+	}
+	days := diff.Hours() / 24
+	switch {
+	case days < 1:
+		return 6
+	case days < 3:
+		return 5
+	case days < 7:
+		return 4
+	case days < 14:
+		return 3
+	case days < 30:
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,110 @@
+package when
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakdown(t *testing.T) {
+	now := time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC)
+	leapDay := time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC)
+	if c := Breakdown(now, leapDay); c != (Components{Years: 1, Past: true}) {
+		t.Errorf("Expected Feb 29 to be a year before Feb 28, got %+v", c)
+	}
+
+	now = time.Date(2026, 3, 1, 9, 0, 0, 600_000_000, time.UTC)
+	if c := Breakdown(now, now.Truncate(time.Second)); c != (Components{}) {
+		t.Errorf("Expected less than a second ago to count as now, got %+v", c)
+	}
+	if c := Breakdown(now, now.Truncate(time.Second).Add(10*time.Second)); c.Seconds != 9 {
+		t.Errorf("Expected partial seconds to be cut off, got %+v", c)
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// Clocks go back on October 25, 2026.
+	event := time.Date(2026, 10, 25, 11, 30, 0, 0, berlin)
+	if c := Breakdown(time.Date(2026, 10, 24, 12, 0, 0, 0, berlin), event); c != (Components{Hours: 24, Minutes: 30}) {
+		t.Errorf("Expected the long fall back day to leave 24h 30m, got %+v", c)
+	}
+}
+
+func TestWholeDays(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// Clocks go forward on March 29, 2026, so this day is 23 hours long.
+	from := time.Date(2026, 3, 28, 12, 0, 0, 0, berlin)
+	if got := WholeDays(from, from.AddDate(0, 0, 1)); got != 1 {
+		t.Errorf("Expected a full day across spring forward, got %d", got)
+	}
+	if got := WholeDays(from, from.Add(22*time.Hour)); got != 0 {
+		t.Errorf("Expected 22 hours to be no whole day, got %d", got)
+	}
+}
+
+func TestFormatCompact(t *testing.T) {
+	tests := []struct {
+		c        Components
+		expected string
+	}{
+		{Components{Years: 1, Days: 23, Hours: 4, Minutes: 5, Seconds: 6}, "1y 23d 4h 5m 6s"},
+		{Components{Hours: 2}, "2h 0m 0s"},
+		{Components{Seconds: 42}, "42s"},
+		{Components{Days: 2, Past: true}, "2d 0h 0m 0s ago"},
+	}
+	for _, tt := range tests {
+		if got := FormatCompact(tt.c); got != tt.expected {
+			t.Errorf("FormatCompact(%+v) = %q, want %q", tt.c, got, tt.expected)
+		}
+	}
+	if got := (Components{Days: 3, Hours: 1, Seconds: 9}).Units(false); got != "3d 1h 0m" {
+		t.Errorf("Expected seconds left off, got %q", got)
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{30 * time.Second, "now"},
+		{time.Minute, "1 minute"},
+		{90 * time.Minute, "1 hour"},
+		{-3 * day, "3 days"},
+		{13 * day, "13 days"},
+		{20 * day, "2 weeks"},
+		{800 * day, "2 years"},
+	}
+	for _, tt := range tests {
+		if got := Humanize(tt.d); got != tt.expected {
+			t.Errorf("Humanize(%s) = %q, want %q", tt.d, got, tt.expected)
+		}
+	}
+}
+
+func TestUrgencyBucket(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		target   time.Time
+		expected int
+	}{
+		{now.Add(-time.Second), 0},
+		{now, 6},
+		{now.Add(23 * time.Hour), 6},
+		{now.AddDate(0, 0, 2), 5},
+		{now.AddDate(0, 0, 5), 4},
+		{now.AddDate(0, 0, 10), 3},
+		{now.AddDate(0, 0, 20), 2},
+		{now.AddDate(0, 0, 30), 1},
+	}
+	for _, tt := range tests {
+		if got := UrgencyBucket(now, tt.target); got != tt.expected {
+			t.Errorf("UrgencyBucket(%s) = %d, want %d", tt.target, got, tt.expected)
+		}
+	}
+}
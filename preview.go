@@ -0,0 +1,86 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+// conflictWindow is how close another event must be to the date typed into
+// the form for the preview to mention it.
+const conflictWindow = 24 * time.Hour
+
+// relativeUnits are the message IDs for formatRelative's units.
+var relativeUnits = [...]string{
+	when.Minute: "relative.minutes",
+	when.Hour:   "relative.hours",
+	when.Day:    "relative.days",
+	when.Week:   "relative.weeks",
+	when.Year:   "relative.years",
+}
+
+// formatRelative describes ts relative to now in its largest whole unit,
+// e.g. "in 6 weeks" or "3 days ago".
+func formatRelative(ts time.Time, now time.Time) string {
+	d := ts.Sub(now)
+	n, unit := when.Approximate(d)
+	if n == 0 && unit == when.Minute {
+		return tr("relative.now")
+	}
+	s := trn(relativeUnits[unit], n)
+	if d < 0 {
+		return trf("relative.ago", s)
+	}
+	return trf("relative.in", s)
+}
+
+// nearestEvent returns the event in sorted events closest to ts and no more
+// than window away, skipping any for which skip is true. It binary searches
+// for the window, so it stays fast on every keystroke however many events
+// there are.
+func nearestEvent(events []Event, ts time.Time, window time.Duration, skip func(Event) bool) (Event, bool) {
+	from, to := ts.Add(-window).Unix(), ts.Add(window).Unix()
+	i := sort.Search(len(events), func(i int) bool { return events[i].Time >= from })
+
+	var nearest Event
+	var found bool
+	var best int64
+	for ; i < len(events) && events[i].Time <= to; i++ {
+		e := events[i]
+		if skip(e) {
+			continue
+		}
+		gap := e.Time - ts.Unix()
+		if gap < 0 {
+			gap = -gap
+		}
+		if !found || gap < best {
+			nearest, best, found = e, gap, true
+		}
+	}
+	return nearest, found
+}
+
+// conflictHint names the event nearest to ts, saying whether it falls on the
+// same calendar day.
+func conflictHint(e Event, ts time.Time) string {
+	other := time.Unix(e.Time, 0).In(ts.Location())
+	if other.YearDay() == ts.YearDay() && other.Year() == ts.Year() {
+		return trf("form.conflict_same_day", e.Title())
+	}
+	return trf("form.conflict_near", e.Title())
+}
+
+// openForm shows the add or edit form, taking a sorted copy of the saved
+// events for the date preview to check against while typing.
+func (m *MainModel) openForm(state sessionState) {
+	m.formEvents = nil
+	for _, e := range m.allEvents() {
+		if !e.Virtual {
+			m.formEvents = append(m.formEvents, e)
+		}
+	}
+	m.state = state
+	m.layoutForm()
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		ts       time.Time
+		expected string
+	}{
+		{"Now", now.Add(20 * time.Second), "now"},
+		{"Minutes", now.Add(45 * time.Minute), "in 45 minutes"},
+		{"One hour", now.Add(90 * time.Minute), "in 1 hour"},
+		{"Days", now.AddDate(0, 0, 13), "in 13 days"},
+		{"Weeks", now.AddDate(0, 0, 43), "in 6 weeks"},
+		{"Years", now.AddDate(2, 1, 0), "in 2 years"},
+		{"Past", now.AddDate(0, 0, -3), "3 days ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatRelative(tt.ts, now); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+
+	useLanguage(t, "de")
+	if got := formatRelative(now.AddDate(0, 0, 3), now); got != "in 3 Tagen" {
+		t.Errorf("Expected 'in 3 Tagen', got '%s'", got)
+	}
+	if got := formatRelative(now.AddDate(0, 0, -1), now); got != "vor 1 Tag" {
+		t.Errorf("Expected 'vor 1 Tag', got '%s'", got)
+	}
+}
+
+func TestNearestEvent(t *testing.T) {
+	base := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	var events []Event
+	for i := 0; i < 10000; i++ {
+		events = append(events, Event{Name: "Filler", Time: base.AddDate(0, 0, -20000+i).Unix()})
+	}
+	events = append(events,
+		Event{Name: "Dentist", Time: base.Unix()},
+		Event{Name: "Lunch", Time: base.Add(3 * time.Hour).Unix()},
+		Event{Name: "Far", Time: base.AddDate(0, 0, 5).Unix()},
+	)
+	none := func(Event) bool { return false }
+
+	if e, ok := nearestEvent(events, base.Add(2*time.Hour), conflictWindow, none); !ok || e.Name != "Lunch" {
+		t.Errorf("Expected Lunch to be nearest, got %v, %v", e.Name, ok)
+	}
+	if e, ok := nearestEvent(events, base.Add(2*time.Hour), conflictWindow, func(e Event) bool { return e.Name == "Lunch" }); !ok || e.Name != "Dentist" {
+		t.Errorf("Expected Dentist when Lunch is skipped, got %v, %v", e.Name, ok)
+	}
+	if _, ok := nearestEvent(events, base.AddDate(0, 0, 2), conflictWindow, none); ok {
+		t.Error("Expected nothing within a day")
+	}
+	if _, ok := nearestEvent(nil, base, conflictWindow, none); ok {
+		t.Error("Expected nothing in an empty list")
+	}
+}
+
+func TestConflictHint(t *testing.T) {
+	ts := time.Date(2026, 3, 10, 23, 0, 0, 0, time.UTC)
+	if got := conflictHint(Event{Name: "Dentist", Time: ts.Add(-10 * time.Hour).Unix()}, ts); got != "same day as 'Dentist'" {
+		t.Errorf("Unexpected hint '%s'", got)
+	}
+	if got := conflictHint(Event{Name: "Dentist", Time: ts.Add(2 * time.Hour).Unix()}, ts); got != "within a day of 'Dentist'" {
+		t.Errorf("Unexpected hint '%s'", got)
+	}
+}
+
+func TestFormPreviewConflicts(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	dentist := time.Date(now.Year()+1, 3, 10, 9, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now, Event{Name: "Dentist", Time: dentist.Unix()})
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m = model.(MainModel)
+	m.inputs[inputTimeField].SetValue(dentist.Add(3 * time.Hour).Format(inputTimeFormLong))
+	m.updateDatePreview()
+	if m.dateConflict != "same day as 'Dentist'" {
+		t.Errorf("Expected a same-day hint, got '%s'", m.dateConflict)
+	}
+	if !strings.Contains(m.datePreview, " · in ") {
+		t.Errorf("Expected the relative distance in the preview, got '%s'", m.datePreview)
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "⚠ same day as 'Dentist'") {
+		t.Errorf("Expected the hint in the form, got:\n%s", view)
+	}
+	if strings.Contains(m.datePreview, "Unix timestamp") {
+		t.Errorf("Expected no timestamp note for a date, got '%s'", m.datePreview)
+	}
+	m.inputs[inputTimeField].SetValue(strconv.FormatInt(dentist.Add(3*time.Hour).UnixMilli(), 10))
+	m.updateDatePreview()
+	if !m.dateValid || m.dateConflict == "" || !strings.HasSuffix(m.datePreview, "(interpreted as Unix timestamp)") {
+		t.Errorf("Expected a millisecond timestamp to preview as a date, got '%s'", m.datePreview)
+	}
+
+	m.resetInputs()
+	m.returnToList()
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = model.(MainModel)
+	if m.state != showEdit {
+		t.Fatalf("Expected the edit form, got state %v", m.state)
+	}
+	if m.dateConflict != "" {
+		t.Errorf("Expected an event not to conflict with itself, got '%s'", m.dateConflict)
+	}
+}
+
+func TestPastDateNeedsConfirmation(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	lastYear := now.AddDate(-1, 0, 0).Format(inputTimeFormShort)
+	m := newRefreshTestModel(t, &now, Event{Name: "Dentist", Time: now.AddDate(0, 1, 0).Unix()})
+	m.windowWidth, m.windowHeight = 100, 50
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	m = model.(MainModel)
+	m.inputs[inputNameField].SetValue("Launch")
+	m.inputs[inputTimeField].SetValue(lastYear)
+	m.focus = int(inputSubmitButton)
+	enter := func() {
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = model.(MainModel)
+	}
+
+	enter()
+	if m.state != showInput || len(m.events.Items()) != 1 {
+		t.Fatalf("Expected the first Enter on a past date to ask, got state %v with %d events", m.state, len(m.events.Items()))
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "Create past event?") {
+		t.Errorf("Expected the submit button to ask, got:\n%s", view)
+	}
+
+	// Changing the date asks again.
+	m.inputs[inputTimeField].SetValue(now.AddDate(-2, 0, 0).Format(inputTimeFormShort))
+	enter()
+	if m.state != showInput {
+		t.Fatalf("Expected a changed date to be confirmed again, got state %v", m.state)
+	}
+	enter()
+	if m.state != showEvents || len(m.events.Items()) != 2 {
+		t.Fatalf("Expected the second Enter to save, got state %v with %d events", m.state, len(m.events.Items()))
+	}
+
+	// Editing a past event without touching its date does not ask about it.
+	m.events.Select(0)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = model.(MainModel)
+	if m.state != showEdit {
+		t.Fatalf("Expected the edit form, got state %v", m.state)
+	}
+	m.inputs[inputNameField].SetValue("Old launch")
+	m.focus = int(inputSubmitButton)
+	enter()
+	if m.state != showEditReview {
+		t.Fatalf("Expected an unchanged past date to go on to the review, got state %v", m.state)
+	}
+	enter()
+	if m.state != showEvents {
+		t.Errorf("Expected the reviewed edit to save, got state %v", m.state)
+	}
+	if got := eventNames(m.events.Items()); got != "Old launch,Dentist" {
+		t.Errorf("Expected the renamed event, got %s", got)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PushConfig struct {
+	NtfyURL     string `toml:"ntfy_url"`
+	GotifyURL   string `toml:"gotify_url"`
+	GotifyToken string `toml:"gotify_token"`
+}
+
+type notification struct {
+	Title   string
+	Message string
+	Urgency int // urgencyBucket of the event
+}
+
+type notifier interface {
+	Name() string
+	Notify(n notification) error
+}
+
+var pushClient = &http.Client{Timeout: 10 * time.Second}
+
+// retryDelays are the waits between push attempts after a failure.
+var retryDelays = []time.Duration{2 * time.Second, 10 * time.Second, 30 * time.Second}
+
+func pushNotifiers(cfg PushConfig) []notifier {
+	var notifiers []notifier
+	if cfg.NtfyURL != "" {
+		notifiers = append(notifiers, ntfyNotifier{url: cfg.NtfyURL})
+	}
+	if cfg.GotifyURL != "" {
+		notifiers = append(notifiers, gotifyNotifier{url: cfg.GotifyURL, token: cfg.GotifyToken})
+	}
+	return notifiers
+}
+
+// ntfyPriority maps an urgency bucket to ntfy's 1 (min) - 5 (max) scale.
+func ntfyPriority(urgency int) int {
+	switch {
+	case urgency >= 6:
+		return 5
+	case urgency == 5:
+		return 4
+	case urgency == 0 || urgency == 4:
+		return 3
+	case urgency >= 2:
+		return 2
+	default:
+		return 1
+	}
+}
+
+type ntfyNotifier struct {
+	url string
+}
+
+func (ntfyNotifier) Name() string { return "ntfy" }
+
+func (n ntfyNotifier) Notify(msg notification) error {
+	req, err := http.NewRequest("POST", n.url, strings.NewReader(msg.Message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", msg.Title)
+	req.Header.Set("Priority", strconv.Itoa(ntfyPriority(msg.Urgency)))
+	req.Header.Set("Tags", "hourglass")
+	return doPush(req)
+}
+
+type gotifyNotifier struct {
+	url   string
+	token string
+}
+
+func (gotifyNotifier) Name() string { return "gotify" }
+
+func (g gotifyNotifier) Notify(msg notification) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"title":    msg.Title,
+		"message":  msg.Message,
+		"priority": ntfyPriority(msg.Urgency) * 2, // Gotify uses 0-10
+	})
+	if err != nil {
+		return err
+	}
+	endpoint := strings.TrimRight(g.url, "/") + "/message?token=" + url.QueryEscape(g.token)
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doPush(req)
+}
+
+func doPush(req *http.Request) error {
+	req.Header.Set("User-Agent", "CountdownApp/1.0 (https://github.com/countdown)")
+	resp, err := pushClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
+
+// notifyWithRetry delivers msg, retrying with backoff. It returns the last
+// error if every attempt failed.
+func notifyWithRetry(n notifier, msg notification) error {
+	err := n.Notify(msg)
+	for _, delay := range retryDelays {
+		if err == nil {
+			return nil
+		}
+		time.Sleep(delay)
+		err = n.Notify(msg)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNtfyNotifier(t *testing.T) {
+	var gotBody, gotTitle, gotPriority string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotTitle = r.Header.Get("Title")
+		gotPriority = r.Header.Get("Priority")
+	}))
+	defer server.Close()
+
+	n := ntfyNotifier{url: server.URL + "/mytopic"}
+	err := n.Notify(notification{Title: "Launch", Message: "Launch in 2h 0m 0s", Urgency: 6})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotBody != "Launch in 2h 0m 0s" || gotTitle != "Launch" || gotPriority != "5" {
+		t.Errorf("Unexpected request: body=%q title=%q priority=%q", gotBody, gotTitle, gotPriority)
+	}
+}
+
+func TestGotifyNotifier(t *testing.T) {
+	var payload map[string]interface{}
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.URL.Query().Get("token")
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	g := gotifyNotifier{url: server.URL + "/", token: "secret"}
+	if err := g.Notify(notification{Title: "Launch", Message: "soon", Urgency: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token != "secret" || payload["title"] != "Launch" || payload["priority"] != float64(2) {
+		t.Errorf("Unexpected request: token=%q payload=%v", token, payload)
+	}
+}
+
+func TestPushErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if err := (ntfyNotifier{url: server.URL}).Notify(notification{}); err == nil {
+		t.Error("Expected error for 403 response, got nil")
+	}
+}
+
+type flakyNotifier struct {
+	failures int
+	calls    int
+}
+
+func (f *flakyNotifier) Name() string { return "flaky" }
+
+func (f *flakyNotifier) Notify(notification) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("unreachable")
+	}
+	return nil
+}
+
+func TestNotifyWithRetry(t *testing.T) {
+	saved := retryDelays
+	retryDelays = []time.Duration{0, 0}
+	defer func() { retryDelays = saved }()
+
+	recovers := &flakyNotifier{failures: 2}
+	if err := notifyWithRetry(recovers, notification{}); err != nil {
+		t.Errorf("Expected success after retries, got %v", err)
+	}
+	if recovers.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", recovers.calls)
+	}
+
+	broken := &flakyNotifier{failures: 10}
+	if err := notifyWithRetry(broken, notification{}); err == nil {
+		t.Error("Expected error after exhausting retries, got nil")
+	}
+	if broken.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", broken.calls)
+	}
+}
+
+func TestNtfyPriority(t *testing.T) {
+	expected := []int{3, 1, 2, 2, 3, 4, 5}
+	for urgency, want := range expected {
+		if got := ntfyPriority(urgency); got != want {
+			t.Errorf("Urgency %d: expected priority %d, got %d", urgency, want, got)
+		}
+	}
+}
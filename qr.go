@@ -0,0 +1,435 @@
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// A minimal QR code encoder: byte mode, error correction level L, versions
+// 1 to 10 (up to 271 bytes), which is plenty for a share link.
+
+// qrVersions holds, for each version at level L, the error correction
+// codewords per block, the blocks as {count, data codewords each}, and the
+// alignment pattern centers.
+var qrVersions = []struct {
+	ecPerBlock int
+	groups     [][2]int
+	align      []int
+}{
+	{7, [][2]int{{1, 19}}, nil},
+	{10, [][2]int{{1, 34}}, []int{6, 18}},
+	{15, [][2]int{{1, 55}}, []int{6, 22}},
+	{20, [][2]int{{1, 80}}, []int{6, 26}},
+	{26, [][2]int{{1, 108}}, []int{6, 30}},
+	{18, [][2]int{{2, 68}}, []int{6, 34}},
+	{20, [][2]int{{2, 78}}, []int{6, 22, 38}},
+	{24, [][2]int{{2, 97}}, []int{6, 24, 42}},
+	{30, [][2]int{{2, 116}}, []int{6, 26, 46}},
+	{18, [][2]int{{2, 68}, {2, 69}}, []int{6, 28, 50}},
+}
+
+var errQRTooLong = errors.New("too long for a QR code")
+
+type qrCode struct {
+	size     int
+	dark     [][]bool
+	function [][]bool // finder, timing, alignment and format modules
+}
+
+var gfExp, gfLog = gfTables()
+
+// gfTables builds exponent and log tables for GF(256) with the QR
+// polynomial x^8 + x^4 + x^3 + x^2 + 1.
+func gfTables() (exp [512]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+	return exp, log
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// rsEncode returns the n Reed-Solomon error correction codewords for data.
+func rsEncode(data []byte, n int) []byte {
+	gen := []byte{1}
+	for i := 0; i < n; i++ {
+		next := make([]byte, len(gen)+1)
+		for j, c := range gen {
+			next[j] ^= c
+			next[j+1] ^= gfMul(c, gfExp[i])
+		}
+		gen = next
+	}
+
+	rem := make([]byte, n)
+	for _, d := range data {
+		factor := d ^ rem[0]
+		copy(rem, rem[1:])
+		rem[n-1] = 0
+		for j := 0; j < n; j++ {
+			rem[j] ^= gfMul(gen[j+1], factor)
+		}
+	}
+	return rem
+}
+
+// qrFormatBits returns the 15 format information bits for level L and mask.
+func qrFormatBits(mask int) int {
+	data := 1<<3 | mask // level L is 01
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// qrVersionBits returns the 18 version information bits used from version 7.
+func qrVersionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+	return version<<12 | rem
+}
+
+// encodeQR encodes data in the smallest version that holds it.
+func encodeQR(data []byte) (*qrCode, error) {
+	version := 0
+	var dataCodewords int
+	for v := 1; v <= len(qrVersions); v++ {
+		dataCodewords = 0
+		for _, g := range qrVersions[v-1].groups {
+			dataCodewords += g[0] * g[1]
+		}
+		if 4+qrCountBits(v)+8*len(data) <= dataCodewords*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, errQRTooLong
+	}
+
+	var bits []bool
+	put := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, v>>i&1 == 1)
+		}
+	}
+	put(0b0100, 4)
+	put(len(data), qrCountBits(version))
+	for _, b := range data {
+		put(int(b), 8)
+	}
+	capacity := dataCodewords * 8
+	if terminator := capacity - len(bits); terminator > 4 {
+		put(0, 4)
+	} else {
+		put(0, terminator)
+	}
+	for len(bits)%8 != 0 {
+		put(0, 1)
+	}
+	for pad := 0; len(bits) < capacity; pad++ {
+		put([]int{0xec, 0x11}[pad%2], 8)
+	}
+	codewords := make([]byte, dataCodewords)
+	for i, bit := range bits {
+		if bit {
+			codewords[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+
+	q := newQRCode(version)
+	q.placeData(qrInterleave(codewords, version))
+	q.applyBestMask()
+	return q, nil
+}
+
+func qrCountBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// qrInterleave splits codewords into blocks, adds error correction to each
+// and interleaves them in the order they are placed in the symbol.
+func qrInterleave(codewords []byte, version int) []byte {
+	info := qrVersions[version-1]
+	var blocks, ecBlocks [][]byte
+	for _, g := range info.groups {
+		for i := 0; i < g[0]; i++ {
+			block := codewords[:g[1]]
+			codewords = codewords[g[1]:]
+			blocks = append(blocks, block)
+			ecBlocks = append(ecBlocks, rsEncode(block, info.ecPerBlock))
+		}
+	}
+
+	var out []byte
+	longest := len(blocks[len(blocks)-1])
+	for i := 0; i < longest; i++ {
+		for _, b := range blocks {
+			if i < len(b) {
+				out = append(out, b[i])
+			}
+		}
+	}
+	for i := 0; i < info.ecPerBlock; i++ {
+		for _, b := range ecBlocks {
+			out = append(out, b[i])
+		}
+	}
+	return out
+}
+
+// newQRCode draws the function patterns of a symbol and reserves the format
+// and version areas.
+func newQRCode(version int) *qrCode {
+	size := 17 + 4*version
+	q := &qrCode{size: size, dark: make([][]bool, size), function: make([][]bool, size)}
+	for i := range q.dark {
+		q.dark[i] = make([]bool, size)
+		q.function[i] = make([]bool, size)
+	}
+
+	for i := 0; i < size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+	for _, corner := range [][2]int{{0, 0}, {0, size - 7}, {size - 7, 0}} {
+		for dr := -1; dr <= 7; dr++ {
+			for dc := -1; dc <= 7; dc++ {
+				r, c := corner[0]+dr, corner[1]+dc
+				if r < 0 || r >= size || c < 0 || c >= size {
+					continue
+				}
+				ring := dr == 0 || dr == 6 || dc == 0 || dc == 6
+				core := dr >= 2 && dr <= 4 && dc >= 2 && dc <= 4
+				inside := dr >= 0 && dr <= 6 && dc >= 0 && dc <= 6
+				q.set(r, c, inside && (ring || core))
+			}
+		}
+	}
+	align := qrVersions[version-1].align
+	for i, r := range align {
+		for j, c := range align {
+			if (i == 0 && j == 0) || (i == 0 && j == len(align)-1) || (i == len(align)-1 && j == 0) {
+				continue
+			}
+			for dr := -2; dr <= 2; dr++ {
+				for dc := -2; dc <= 2; dc++ {
+					q.set(r+dr, c+dc, max(abs(dr), abs(dc)) != 1)
+				}
+			}
+		}
+	}
+
+	q.drawFormat(0)
+	if version >= 7 {
+		bits := qrVersionBits(version)
+		for i := 0; i < 18; i++ {
+			dark := bits>>i&1 == 1
+			a, b := size-11+i%3, i/3
+			q.set(b, a, dark)
+			q.set(a, b, dark)
+		}
+	}
+	return q
+}
+
+func (q *qrCode) set(r, c int, dark bool) {
+	q.dark[r][c] = dark
+	q.function[r][c] = true
+}
+
+// drawFormat writes both copies of the format information for mask, along
+// with the dark module next to the lower one.
+func (q *qrCode) drawFormat(mask int) {
+	bits := qrFormatBits(mask)
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+	for i := 0; i <= 5; i++ {
+		q.set(i, 8, bit(i))
+	}
+	q.set(7, 8, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(8, 7, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(8, 14-i, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.set(8, q.size-1-i, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(q.size-15+i, 8, bit(i))
+	}
+	q.set(q.size-8, 8, true)
+}
+
+// placeData fills the non-function modules in the standard zigzag, two
+// columns at a time from the bottom right, skipping the vertical timing
+// pattern.
+func (q *qrCode) placeData(codewords []byte) {
+	i := 0
+	upward := true
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			r := vert
+			if upward {
+				r = q.size - 1 - vert
+			}
+			for c := right; c >= right-1; c-- {
+				if q.function[r][c] {
+					continue
+				}
+				if i < len(codewords)*8 {
+					q.dark[r][c] = codewords[i/8]>>(7-i%8)&1 == 1
+				}
+				i++
+			}
+		}
+		upward = !upward
+	}
+}
+
+var qrMasks = []func(r, c int) bool{
+	func(r, c int) bool { return (r+c)%2 == 0 },
+	func(r, c int) bool { return r%2 == 0 },
+	func(r, c int) bool { return c%3 == 0 },
+	func(r, c int) bool { return (r+c)%3 == 0 },
+	func(r, c int) bool { return (r/2+c/3)%2 == 0 },
+	func(r, c int) bool { return r*c%2+r*c%3 == 0 },
+	func(r, c int) bool { return (r*c%2+r*c%3)%2 == 0 },
+	func(r, c int) bool { return ((r+c)%2+r*c%3)%2 == 0 },
+}
+
+func (q *qrCode) applyMask(mask int) {
+	for r := 0; r < q.size; r++ {
+		for c := 0; c < q.size; c++ {
+			if !q.function[r][c] && qrMasks[mask](r, c) {
+				q.dark[r][c] = !q.dark[r][c]
+			}
+		}
+	}
+}
+
+// applyBestMask tries every mask and keeps the one with the lowest penalty.
+func (q *qrCode) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := range qrMasks {
+		q.applyMask(mask)
+		q.drawFormat(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormat(best)
+}
+
+// penalty scores how hard the symbol is to scan, following the four rules
+// of the QR specification: long runs, 2x2 blocks, finder-like patterns and
+// an unbalanced share of dark modules.
+func (q *qrCode) penalty() int {
+	penalty := 0
+	at := func(r, c int, transposed bool) bool {
+		if transposed {
+			return q.dark[c][r]
+		}
+		return q.dark[r][c]
+	}
+
+	for _, transposed := range []bool{false, true} {
+		for r := 0; r < q.size; r++ {
+			run := 1
+			var line strings.Builder
+			for c := 0; c < q.size; c++ {
+				if at(r, c, transposed) {
+					line.WriteByte('1')
+				} else {
+					line.WriteByte('0')
+				}
+				if c > 0 && at(r, c, transposed) == at(r, c-1, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				penalty += run - 2
+			}
+			s := line.String()
+			penalty += 40 * (strings.Count(s, "10111010000") + strings.Count(s, "00001011101"))
+		}
+	}
+
+	dark := 0
+	for r := 0; r < q.size; r++ {
+		for c := 0; c < q.size; c++ {
+			if q.dark[r][c] {
+				dark++
+			}
+			if r > 0 && c > 0 {
+				d := q.dark[r][c]
+				if q.dark[r-1][c] == d && q.dark[r][c-1] == d && q.dark[r-1][c-1] == d {
+					penalty += 3
+				}
+			}
+		}
+	}
+	percent := dark * 100 / (q.size * q.size)
+	penalty += abs(percent-50) / 5 * 10
+	return penalty
+}
+
+// String draws the symbol with half blocks, two module rows per line and a
+// four-module quiet zone. Block characters are the dark modules, so it needs
+// dark text on a light background to scan.
+func (q *qrCode) String() string {
+	const quiet = 4
+	dark := func(r, c int) bool {
+		r, c = r-quiet, c-quiet
+		return r >= 0 && r < q.size && c >= 0 && c < q.size && q.dark[r][c]
+	}
+
+	var b strings.Builder
+	total := q.size + 2*quiet
+	for r := 0; r < total; r += 2 {
+		for c := 0; c < total; c++ {
+			switch top, bottom := dark(r, c), dark(r+1, c); {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
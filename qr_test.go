@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRSEncode(t *testing.T) {
+	// "HELLO WORLD" as 1-M, from the worked example most QR tutorials use.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsEncode(data, 10); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestQRFormatAndVersionBits(t *testing.T) {
+	expected := []string{
+		"111011111000100", "111001011110011", "111110110101010", "111100010011101",
+		"110011000101111", "110001100011000", "110110001000001", "110100101110110",
+	}
+	for mask, want := range expected {
+		if got := fmt.Sprintf("%015b", qrFormatBits(mask)); got != want {
+			t.Errorf("Mask %d: expected %s, got %s", mask, want, got)
+		}
+	}
+	if got := fmt.Sprintf("%018b", qrVersionBits(7)); got != "000111110010010100" {
+		t.Errorf("Version 7: expected 000111110010010100, got %s", got)
+	}
+}
+
+func TestEncodeQR(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{17, 1},
+		{18, 2},
+		{78, 4},
+		{154, 7},
+		{271, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d bytes", tt.length), func(t *testing.T) {
+			q, err := encodeQR([]byte(strings.Repeat("a", tt.length)))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if want := 17 + 4*tt.version; q.size != want {
+				t.Fatalf("Expected version %d (%d modules), got %d modules", tt.version, want, q.size)
+			}
+
+			// Finder patterns in three corners, timing patterns between them.
+			for _, corner := range [][2]int{{0, 0}, {0, q.size - 7}, {q.size - 7, 0}} {
+				for i := 0; i < 7; i++ {
+					if !q.dark[corner[0]][corner[1]+i] || !q.dark[corner[0]+6][corner[1]+i] {
+						t.Errorf("Finder at %v is broken", corner)
+					}
+				}
+				if !q.dark[corner[0]+3][corner[1]+3] || q.dark[corner[0]+1][corner[1]+1] {
+					t.Errorf("Finder at %v is broken", corner)
+				}
+			}
+			for i := 8; i < q.size-8; i++ {
+				if q.dark[6][i] != (i%2 == 0) || q.dark[i][6] != (i%2 == 0) {
+					t.Fatalf("Timing pattern broken at %d", i)
+				}
+			}
+			if !q.dark[q.size-8][8] {
+				t.Error("Expected the dark module")
+			}
+
+			// Both copies of the format information must agree.
+			var first, second int
+			for i := 0; i <= 5; i++ {
+				first |= b2i(q.dark[i][8]) << i
+			}
+			first |= b2i(q.dark[7][8])<<6 | b2i(q.dark[8][8])<<7 | b2i(q.dark[8][7])<<8
+			for i := 9; i < 15; i++ {
+				first |= b2i(q.dark[8][14-i]) << i
+			}
+			for i := 0; i < 8; i++ {
+				second |= b2i(q.dark[8][q.size-1-i]) << i
+			}
+			for i := 8; i < 15; i++ {
+				second |= b2i(q.dark[q.size-15+i][8]) << i
+			}
+			if first != second || first>>13 != 0b11 {
+				t.Errorf("Format copies %015b and %015b disagree or are not level L", first, second)
+			}
+		})
+	}
+
+	if _, err := encodeQR(make([]byte, 272)); err != errQRTooLong {
+		t.Errorf("Expected errQRTooLong, got %v", err)
+	}
+}
+
+func TestQRString(t *testing.T) {
+	q, err := encodeQR([]byte("hi"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(q.String(), "\n")
+	if len(lines) != (21+8+1)/2 {
+		t.Errorf("Expected %d lines, got %d", (21+8+1)/2, len(lines))
+	}
+	if strings.TrimSpace(lines[0]) != "" || !strings.HasPrefix(lines[2], "    █▀▀▀▀▀█ ") {
+		t.Errorf("Expected a quiet zone and the top finder edge, got:\n%s", q.String())
+	}
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// eventRank places an event among the others: its rank by date and its
+// nearest neighbours on either side.
+type eventRank struct {
+	Rank, Of      int
+	Past          bool   // ranked among past events, most recent first
+	Before, After *Event // nearest earlier and later event
+}
+
+// rankEvent ranks e among events, which must be sorted by time. Upcoming
+// events are ranked and neighboured among upcoming events only; past events
+// are ranked among past events and may have any event as a neighbour. It
+// reports false when there is nothing to compare with.
+func rankEvent(events []Event, e Event, now time.Time) (eventRank, bool) {
+	var saved []Event
+	for _, other := range events {
+		if !other.Virtual {
+			saved = append(saved, other)
+		}
+	}
+	if e.Virtual || len(saved) < 2 {
+		return eventRank{}, false
+	}
+
+	r := eventRank{Past: e.Time < now.Unix()}
+	for i, other := range saved {
+		if sameEvent(other, e) {
+			if i > 0 && (r.Past || saved[i-1].Time >= now.Unix()) {
+				r.Before = &saved[i-1]
+			}
+			if i+1 < len(saved) {
+				r.After = &saved[i+1]
+			}
+			continue
+		}
+		if (other.Time < now.Unix()) != r.Past {
+			continue
+		}
+		r.Of++
+		if (!r.Past && eventBefore(other, e)) || (r.Past && eventBefore(e, other)) {
+			r.Rank++
+		}
+	}
+	r.Rank++
+	r.Of++
+	return r, true
+}
+
+// renderRank is the detail pane's line on how e compares to the others.
+func (m MainModel) renderRank(e Event, now time.Time) string {
+	r, ok := rankEvent(m.allEvents(), e, now)
+	if !ok {
+		return ""
+	}
+	var line string
+	switch {
+	case r.Of == 1 && r.Past:
+		line = tr("rank.only_past")
+	case r.Of == 1:
+		line = tr("rank.only_upcoming")
+	case r.Past:
+		line = trf("rank.past", ordinal(r.Rank), trn("rank.past_events", r.Of))
+	default:
+		line = trf("rank.upcoming", ordinal(r.Rank), trn("rank.upcoming_events", r.Of))
+	}
+	wrap := lipgloss.NewStyle().Width(m.detailWidth - 6)
+	s := wrap.Render(NormalTextStyle("📍 ")+BrightTextStyle(line)) + "\n"
+	for _, n := range []*Event{r.Before, r.After} {
+		if n == nil {
+			continue
+		}
+		gap := time.Unix(e.Time, 0).Sub(time.Unix(n.Time, 0))
+		var text string
+		switch {
+		case gap > 0:
+			text = trf("rank.after", formatGap(gap), n.Name)
+		case gap < 0:
+			text = trf("rank.before", formatGap(gap), n.Name)
+		default:
+			text = trf("rank.same", n.Name)
+		}
+		s += wrap.Render(NormalTextStyle("   "+text)) + "\n"
+	}
+	return s
+}
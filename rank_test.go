@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRankEvent(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(day int) int64 { return time.Date(2026, 6, day, 12, 0, 0, 0, time.UTC).Unix() }
+	events := []Event{
+		{Name: "Kickoff", Time: at(-9)},
+		{Name: "Retro", Time: at(-2)},
+		{Name: "Sprint end", Time: at(3)},
+		{Name: "Midsummer", Time: at(10), Virtual: true},
+		{Name: "Review", Time: at(15)},
+		{Name: "Vacation", Time: at(55)},
+	}
+
+	name := func(e *Event) string {
+		if e == nil {
+			return "-"
+		}
+		return e.Name
+	}
+	tests := []struct {
+		event    Event
+		expected string
+	}{
+		// The first upcoming event has no neighbour among past events.
+		{events[2], "1/3 upcoming - Review"},
+		{events[4], "2/3 upcoming Sprint end Vacation"},
+		{events[5], "3/3 upcoming Review -"},
+		// Past events count from the most recent and see upcoming ones.
+		{events[1], "1/2 past Kickoff Sprint end"},
+		{events[0], "2/2 past - Retro"},
+	}
+	for _, tt := range tests {
+		r, ok := rankEvent(events, tt.event, now)
+		if !ok {
+			t.Errorf("%s: expected a rank", tt.event.Name)
+			continue
+		}
+		kind := "upcoming"
+		if r.Past {
+			kind = "past"
+		}
+		got := fmt.Sprintf("%d/%d %s %s %s", r.Rank, r.Of, kind, name(r.Before), name(r.After))
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.event.Name, tt.expected, got)
+		}
+	}
+
+	if _, ok := rankEvent(events, events[3], now); ok {
+		t.Error("Expected no rank for a virtual event")
+	}
+	if _, ok := rankEvent(events[:1], events[0], now); ok {
+		t.Error("Expected no rank for a lone event")
+	}
+}
+
+func TestRenderRank(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Sprint end", Time: now.AddDate(0, 0, 3).Unix()},
+		Event{Name: "Review", Time: now.AddDate(0, 0, 15).Unix()},
+		Event{Name: "Vacation", Time: now.AddDate(0, 0, 55).Unix()},
+	)
+	m.detailWidth = 60
+	got := stripANSI(m.renderRank(m.events.Items()[1].(Event), now))
+	for _, want := range []string{"2nd soonest of 3 upcoming events", "12 days after “Sprint end”", "40 days before “Vacation”"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import "strings"
+
+// origin names the system a read-only event belongs to, from the prefix of
+// its Source.
+func (e Event) origin() string {
+	kind, _, _ := strings.Cut(e.Source, ":")
+	switch kind {
+	case "":
+		return tr("readonly.unknown")
+	case "todotxt":
+		return "todo.txt"
+	case "taskwarrior":
+		return "Taskwarrior"
+	case "gcal":
+		return "Google Calendar"
+	}
+	return kind
+}
+
+func setReadOnly(events []Event, readOnly bool) {
+	for i := range events {
+		events[i].ReadOnly = readOnly
+	}
+}
+
+func (m MainModel) selectedReadOnly() bool {
+	e, ok := m.events.SelectedItem().(Event)
+	return ok && e.ReadOnly
+}
+
+// readOnlyLine is the detail pane line of a read-only event. After a
+// refused edit or remove it explains why, until the next key.
+func (m MainModel) readOnlyLine(e Event) string {
+	if m.readOnlyRefused {
+		return WarningStyle(trf("readonly.refused", e.Title(), e.origin()))
+	}
+	return BrightTextStyle(trf("readonly.detail", e.origin()))
+}
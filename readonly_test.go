@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestReadOnlyEvents(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Release", Time: now.Add(48 * time.Hour).Unix(), Source: "taskwarrior:abc", ReadOnly: true},
+		Event{Name: "Launch", Time: now.Add(72 * time.Hour).Unix()},
+	)
+
+	for _, k := range []string{"e", "-"} {
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = model.(MainModel)
+		if m.state != showEvents || len(m.events.Items()) != 2 {
+			t.Fatalf("%s: expected the read-only event left alone, got state %v and %d events", k, m.state, len(m.events.Items()))
+		}
+		if view := strings.Join(strings.Fields(strings.ReplaceAll(stripANSI(m.detailsString()), "┃", "")), " "); !strings.Contains(view, `🔒 "Release" comes from Taskwarrior and is read-only`) {
+			t.Errorf("%s: expected the refusal in the details, got:\n%s", k, m.detailsString())
+		}
+	}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = model.(MainModel)
+	if view := strings.Join(strings.Fields(strings.ReplaceAll(stripANSI(m.detailsString()), "┃", "")), " "); !strings.Contains(view, "🔒 Read-only, from Taskwarrior") {
+		t.Errorf("Expected the next key to bring back the read-only marker, got:\n%s", view)
+	}
+
+	// Other keys still work, and the next event can be removed.
+	m.events.Select(1)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	m = model.(MainModel)
+	if names := eventNames(m.events.Items()); names != "Release" {
+		t.Errorf("Expected only Release left, got %s", names)
+	}
+}
+
+func TestEventOrigin(t *testing.T) {
+	tests := map[string]string{
+		"":                 "another program",
+		"todotxt:1a2b":     "todo.txt",
+		"taskwarrior:uuid": "Taskwarrior",
+		"ics:team":         "ics",
+	}
+	for source, expected := range tests {
+		if got := (Event{Source: source}).origin(); got != expected {
+			t.Errorf("%q: expected %s, got %s", source, expected, got)
+		}
+	}
+}
+
+func TestMergeBySourceReadOnly(t *testing.T) {
+	existing := []Event{{Name: "Task", Time: 100, Source: "todotxt:1"}}
+	incoming := []Event{{Name: "Task", Time: 100, Source: "todotxt:1"}}
+	setReadOnly(incoming, true)
+
+	events, stats := mergeBySource(existing, incoming)
+	if !events[0].ReadOnly || stats.Updated != 1 {
+		t.Errorf("Expected the task marked read-only and counted as updated, got %+v, %+v", events[0], stats)
+	}
+	if _, stats := mergeBySource(events, incoming); stats.Duplicates != 1 {
+		t.Errorf("Expected no change on the second import, got %+v", stats)
+	}
+}
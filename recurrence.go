@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence units.
+const (
+	repeatDaily   = "day"
+	repeatWeekly  = "week"
+	repeatMonthly = "month"
+)
+
+// Recurrence repeats an event more often than yearly. Monthly events fall
+// on Day of the month, or on the last day of months too short for it;
+// weekly and daily ones every Every weeks or days from the event's time,
+// keeping the time of day across DST changes.
+type Recurrence struct {
+	Unit  string `json:"unit"`
+	Every int    `json:"every,omitempty"` // 1 when unset
+	Day   int    `json:"day,omitempty"`   // day of the month, for monthly events
+}
+
+func (r Recurrence) every() int {
+	if r.Every < 1 {
+		return 1
+	}
+	return r.Every
+}
+
+// monthDay returns the day-th of the month month months after t's, at t's
+// time of day, clamped to the month's last day.
+func monthDay(t time.Time, months, day int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// next returns the first occurrence after after, counting from the
+// occurrence t. When t itself is after after, that is t.
+func (r Recurrence) next(t, after time.Time) time.Time {
+	n := r.every()
+	switch r.Unit {
+	case repeatMonthly:
+		day := r.Day
+		if day == 0 {
+			day = t.Day()
+		}
+		// Skip whole periods, then step; the estimate can only be short.
+		months := (after.Year()-t.Year())*12 + int(after.Month()-t.Month())
+		k := 0
+		if months > n {
+			k = (months - n) / n * n
+		}
+		for {
+			if c := monthDay(t, k, day); c.After(after) {
+				return c
+			}
+			k += n
+		}
+	case repeatWeekly, repeatDaily:
+		days := n
+		if r.Unit == repeatWeekly {
+			days = 7 * n
+		}
+		k := 0
+		if gap := int(after.Sub(t).Hours() / 24); gap > days {
+			k = (gap - days) / days * days
+		}
+		for {
+			if c := t.AddDate(0, 0, k); c.After(after) {
+				return c
+			}
+			k += days
+		}
+	}
+	return t
+}
+
+// recurs reports whether e repeats at all.
+func (e Event) recurs() bool {
+	return e.Yearly || e.Repeat != nil
+}
+
+// yearlyDate returns month/day in year at t's time of day. February 29th
+// falls on the 28th in common years.
+func yearlyDate(year int, month time.Month, day int, t time.Time) time.Time {
+	if month == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+}
+
+// nextOccurrence returns the first occurrence of e after after. Events that
+// do not repeat only have the one.
+func nextOccurrence(e Event, after time.Time) time.Time {
+	t := time.Unix(e.Time, 0).In(after.Location())
+	switch {
+	case e.Repeat != nil:
+		return e.Repeat.next(t, after)
+	case e.Yearly:
+		// Each year is worked out from the month and day rather than
+		// from the last one, so a leap day does not drift to March 1st.
+		month, day := t.Month(), t.Day()
+		if e.LeapDay && month == time.February && day == 28 {
+			day = 29
+		}
+		year := t.Year()
+		if after.Year()-1 > year {
+			year = after.Year() - 1
+		}
+		for ; ; year++ {
+			if c := yearlyDate(year, month, day, t); !c.Before(t) && c.After(after) {
+				return c
+			}
+		}
+	}
+	return t
+}
+
+// over reports whether the occurrence of e is over at now, so that a
+// recurring event can move on to its next one. An event at midnight, as
+// all-day events and birthdays are, lasts its whole day; any other lasts
+// hold past its time, as the detail view stays at zero.
+func (e Event) over(now time.Time, hold time.Duration) bool {
+	t := time.Unix(e.Time, 0).In(now.Location())
+	if h, m, s := t.Clock(); e.AllDay || h == 0 && m == 0 && s == 0 {
+		return !now.Before(nextMidnight(t))
+	}
+	return now.Sub(t) > hold
+}
+
+// rollForwardRecurring moves recurring events whose occurrence is over to
+// their next occurrence. It reports whether anything changed.
+func rollForwardRecurring(events []Event, now time.Time, hold time.Duration) bool {
+	changed := false
+	for i := range events {
+		e := &events[i]
+		if !e.recurs() || !e.over(now, hold) {
+			continue
+		}
+		next := nextOccurrence(*e, now).Unix()
+		if next == e.Time {
+			continue
+		}
+		if t := time.Unix(e.Time, 0).In(now.Location()); e.Yearly && t.Month() == time.February && t.Day() == 29 {
+			e.LeapDay = true
+		}
+		e.Time = next
+		changed = true
+	}
+	return changed
+}
+
+// parseRecurrence reads the form's repeat field: "yearly", "monthly",
+// "every 14th", "every 3 months on the 14th", "weekly", "every 2 weeks",
+// "daily" or "every 3 days". Empty means the event happens once. yearly
+// is true for yearly events, which have no Recurrence.
+func parseRecurrence(s string) (r *Recurrence, yearly bool, err error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return nil, false, nil
+	}
+	switch strings.Join(fields, " ") {
+	case "yearly", "annually", "every year":
+		return nil, true, nil
+	case "monthly", "every month":
+		return &Recurrence{Unit: repeatMonthly}, false, nil
+	case "weekly", "every week":
+		return &Recurrence{Unit: repeatWeekly}, false, nil
+	case "daily", "every day":
+		return &Recurrence{Unit: repeatDaily}, false, nil
+	}
+	if fields[0] != "every" || len(fields) < 2 {
+		return nil, false, fmt.Errorf("%q", s)
+	}
+	if day, ok := parseOrdinal(fields[1]); ok && len(fields) == 2 {
+		return &Recurrence{Unit: repeatMonthly, Day: day}, false, nil
+	}
+	n, convErr := strconv.Atoi(fields[1])
+	if convErr != nil || n < 1 || len(fields) < 3 {
+		return nil, false, fmt.Errorf("%q", s)
+	}
+	r = &Recurrence{Every: n}
+	switch strings.TrimSuffix(fields[2], "s") {
+	case "day":
+		r.Unit = repeatDaily
+	case "week":
+		r.Unit = repeatWeekly
+	case "month":
+		r.Unit = repeatMonthly
+	default:
+		return nil, false, fmt.Errorf("%q", s)
+	}
+	rest := fields[3:]
+	if r.Unit == repeatMonthly && len(rest) == 3 && rest[0] == "on" && rest[1] == "the" {
+		day, ok := parseOrdinal(rest[2])
+		if !ok {
+			return nil, false, fmt.Errorf("%q", s)
+		}
+		r.Day, rest = day, nil
+	}
+	if len(rest) > 0 {
+		return nil, false, fmt.Errorf("%q", s)
+	}
+	if r.Every == 1 {
+		r.Every = 0
+	}
+	return r, false, nil
+}
+
+// parseOrdinal reads "1st" to "31st".
+func parseOrdinal(s string) (int, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-2])
+	if err != nil || n < 1 || n > 31 || s[len(s)-2:] != ordinalSuffix(n) {
+		return 0, false
+	}
+	return n, true
+}
+
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// formatRecurrence is the inverse of parseRecurrence, for filling in the
+// form when an event is edited.
+func formatRecurrence(r *Recurrence, yearly bool) string {
+	switch {
+	case yearly:
+		return "yearly"
+	case r == nil:
+		return ""
+	}
+	n := r.every()
+	switch r.Unit {
+	case repeatMonthly:
+		if r.Day == 0 {
+			if n == 1 {
+				return "monthly"
+			}
+			return fmt.Sprintf("every %d months", n)
+		}
+		day := fmt.Sprintf("%d%s", r.Day, ordinalSuffix(r.Day))
+		if n == 1 {
+			return "every " + day
+		}
+		return fmt.Sprintf("every %d months on the %s", n, day)
+	case repeatWeekly:
+		if n == 1 {
+			return "weekly"
+		}
+		return fmt.Sprintf("every %d weeks", n)
+	}
+	if n == 1 {
+		return "daily"
+	}
+	return fmt.Sprintf("every %d days", n)
+}
+
+// describeRecurrence says how e repeats, for the details; empty when it
+// does not.
+func describeRecurrence(e Event) string {
+	switch {
+	case e.Yearly:
+		return tr("repeat.yearly")
+	case e.Repeat == nil:
+		return ""
+	}
+	n := e.Repeat.every()
+	switch e.Repeat.Unit {
+	case repeatMonthly:
+		day := e.Repeat.Day
+		if day == 0 {
+			day = time.Unix(e.Time, 0).Day()
+		}
+		if n == 1 {
+			return trf("repeat.monthly", day)
+		}
+		return trf("repeat.months", n, day)
+	case repeatWeekly:
+		if n == 1 {
+			return tr("repeat.weekly")
+		}
+		return trf("repeat.weeks", n)
+	}
+	if n == 1 {
+		return tr("repeat.daily")
+	}
+	return trf("repeat.days", n)
+}
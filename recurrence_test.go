@@ -0,0 +1,156 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecurrenceNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name     string
+		rule     Recurrence
+		from     time.Time
+		after    time.Time
+		expected time.Time
+	}{
+		{"Not yet passed", Recurrence{Unit: repeatMonthly, Day: 14}, at(2026, 5, 14, 9), at(2026, 5, 1, 0), at(2026, 5, 14, 9)},
+		{"Monthly", Recurrence{Unit: repeatMonthly, Day: 14}, at(2026, 5, 14, 9), at(2026, 5, 14, 9), at(2026, 6, 14, 9)},
+		{"31st in February", Recurrence{Unit: repeatMonthly, Day: 31}, at(2026, 1, 31, 9), at(2026, 2, 1, 0), at(2026, 2, 28, 9)},
+		{"31st after February", Recurrence{Unit: repeatMonthly, Day: 31}, at(2026, 2, 28, 9), at(2026, 3, 1, 0), at(2026, 3, 31, 9)},
+		{"31st in a leap February", Recurrence{Unit: repeatMonthly, Day: 31}, at(2028, 1, 31, 9), at(2028, 2, 1, 0), at(2028, 2, 29, 9)},
+		{"Every 3 months", Recurrence{Unit: repeatMonthly, Every: 3, Day: 30}, at(2025, 11, 30, 9), at(2026, 1, 10, 0), at(2026, 2, 28, 9)},
+		{"Monthly years later", Recurrence{Unit: repeatMonthly, Day: 15}, at(2020, 1, 15, 9), at(2026, 7, 20, 0), at(2026, 8, 15, 9)},
+		{"Weekly across spring forward", Recurrence{Unit: repeatWeekly}, at(2026, 3, 25, 18), at(2026, 3, 26, 0), at(2026, 4, 1, 18)},
+		{"Every 2 weeks across fall back", Recurrence{Unit: repeatWeekly, Every: 2}, at(2026, 10, 17, 18), at(2026, 10, 20, 0), at(2026, 10, 31, 18)},
+		{"Every 3 days", Recurrence{Unit: repeatDaily, Every: 3}, at(2026, 5, 1, 7), at(2026, 5, 5, 8), at(2026, 5, 7, 7)},
+		{"Daily years later", Recurrence{Unit: repeatDaily}, at(2020, 5, 1, 7), at(2026, 5, 5, 8), at(2026, 5, 6, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.next(tt.from, tt.after); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"", ""},
+		{"Yearly", "yearly"},
+		{"every month", "monthly"},
+		{"every 14th", "every 14th"},
+		{"every 31st", "every 31st"},
+		{"every 1 month", "monthly"},
+		{"every 3 months on the 2nd", "every 3 months on the 2nd"},
+		{"weekly", "weekly"},
+		{"every 2 weeks", "every 2 weeks"},
+		{"daily", "daily"},
+		{"every 3 days", "every 3 days"},
+	}
+	for _, tt := range tests {
+		r, yearly, err := parseRecurrence(tt.in)
+		if err != nil {
+			t.Errorf("parseRecurrence(%q): %v", tt.in, err)
+			continue
+		}
+		if got := formatRecurrence(r, yearly); got != tt.expected {
+			t.Errorf("parseRecurrence(%q): expected %q, got %q", tt.in, tt.expected, got)
+		}
+	}
+	for _, bad := range []string{"fortnightly", "every", "every 0 days", "every 32nd", "every 2nd week", "every 2 weeks on the 3rd", "every 11st"} {
+		if _, _, err := parseRecurrence(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestRollForwardRecurring(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Name: "Rent", Time: time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC).Unix(), Repeat: &Recurrence{Unit: repeatMonthly, Day: 31}},
+		{Name: "Standup", Time: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC).Unix(), Repeat: &Recurrence{Unit: repeatWeekly}},
+		{Name: "Once", Time: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC).Unix()},
+	}
+	if !rollForwardRecurring(events, now, 0) {
+		t.Fatal("Expected the recurring events to roll forward")
+	}
+	if got := time.Unix(events[0].Time, 0).UTC(); got.Day() != 31 || got.Month() != time.March {
+		t.Errorf("Expected rent on March 31, got %s", got)
+	}
+	if got := time.Unix(events[1].Time, 0).UTC(); got.Day() != 16 || got.Hour() != 9 {
+		t.Errorf("Expected standup on March 16 at 9, got %s", got)
+	}
+	if got := time.Unix(events[2].Time, 0).UTC(); got.Day() != 1 {
+		t.Errorf("Expected the one-off event to stay, got %s", got)
+	}
+
+	// A unit from a newer version, or a typo, leaves the event where it is.
+	odd := []Event{{Name: "Odd", Time: events[2].Time, Repeat: &Recurrence{Unit: "fortnight"}}}
+	if rollForwardRecurring(odd, now, 0) {
+		t.Error("Expected no change for a recurrence that cannot move")
+	}
+}
+
+func TestDueNotificationsRecurring(t *testing.T) {
+	now := time.Date(2026, 6, 8, 8, 0, 0, 0, time.Local)
+	last := now.Add(-30 * time.Second)
+	// Last week's standup is still in the file; this week's reminder is due.
+	standup := Event{
+		Name:      "Standup",
+		Time:      time.Date(2026, 6, 1, 9, 0, 0, 0, time.Local).Unix(),
+		Reminders: []int64{60 * 60},
+		Repeat:    &Recurrence{Unit: repeatWeekly},
+	}
+	due := dueNotifications([]Event{standup}, nil, defaultConfig().dateTimeLayout(), last, now)
+	if len(due) != 1 || !strings.HasPrefix(due[0].Message, "Standup in 1h") {
+		t.Errorf("Expected this week's reminder, got %+v", due)
+	}
+}
+
+func TestFormSavesRecurrence(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	withFixedLocal(t)
+
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now)
+	m.openForm(showInput)
+	m.inputs[inputNameField].SetValue("Rent")
+	m.inputs[inputTimeField].SetValue("2026-01-25")
+	m.inputs[inputRepeatField].SetValue("every 31st")
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = model.(MainModel)
+	events := m.storedEvents()
+	if len(events) != 1 || events[0].Repeat == nil || events[0].Repeat.Day != 31 {
+		t.Fatalf("Expected a monthly event on the 31st, got %+v", events)
+	}
+	if got := time.Unix(events[0].Time, 0).UTC(); got.Day() != 31 || got.Month() != time.January {
+		t.Errorf("Expected the first occurrence on January 31, got %s", got)
+	}
+	if !strings.Contains(stripANSI(m.renderDetails(events[0])), "monthly on day 31") {
+		t.Error("Expected the details to say how the event repeats")
+	}
+
+	m.inputs[inputRepeatField].SetValue("every other week")
+	m.updateRemindersStatus()
+	if m.repeatError == "" {
+		t.Error("Expected an invalid repeat to be marked")
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/timer"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clockJumpThreshold is how far apart two one-second ticks may be before we
+// assume the machine slept and everything time-derived is stale. It is also
+// how far the wall clock may stray from the time that passed before we
+// take it to have been changed.
+const clockJumpThreshold = 10 * time.Second
+
+// clockNoteLifetime is how long the status bar says the clock changed.
+const clockNoteLifetime = 10 * time.Second
+
+// clockJump returns how much further the wall clock moved from last to now
+// than the time that passed, or 0 within clockJumpThreshold. When both
+// times carry monotonic readings, which clock changes do not move, those
+// tell how much time passed; otherwise, as with a test clock, interval is
+// taken to have passed.
+func clockJump(last, now time.Time, interval time.Duration) time.Duration {
+	elapsed := interval
+	// Round(0) strips the monotonic reading, so only a time with one
+	// differs from its rounded self.
+	if now != now.Round(0) && last != last.Round(0) {
+		elapsed = now.Sub(last)
+	}
+	jump := now.Round(0).Sub(last.Round(0)) - elapsed
+	if jump > clockJumpThreshold || jump < -clockJumpThreshold {
+		return jump
+	}
+	return 0
+}
+
+// describeClockJump says which way the clock moved and by how much.
+func describeClockJump(jump time.Duration) string {
+	id := "clock.ahead"
+	if jump < 0 {
+		id, jump = "clock.back", -jump
+	}
+	if jump >= time.Minute {
+		jump = jump.Round(time.Minute)
+	}
+	return trf(id, formatLeadTime(jump.Round(time.Second)))
+}
+
+// clockChanged starts over from now after the wall clock jumped: the list
+// is rebuilt and midnight waited for afresh, and hints for thresholds that
+// only the jump crossed, like the day turning or an event coming within a
+// day, are not shown.
+func (m *MainModel) clockChanged(now time.Time, jump time.Duration) tea.Cmd {
+	y, mo, d := now.Date()
+	m.lastMidnight = time.Date(y, mo, d, 0, 0, 0, 0, now.Location())
+	m.imminentSuggested = hasUpcomingImminent(m.storedEvents(), now)
+	return tea.Batch(
+		m.refreshUnlessEditing(),
+		midnightTick(now),
+		m.longStatus(HintStyle(describeClockJump(jump)), clockNoteLifetime),
+	)
+}
+
+// setEvents replaces the list contents, keeping the current tag scope, the
+// sections, an active filter and, where it still exists, the selected event
+// or section.
+func (m *MainModel) setEvents(events []Event) {
+	selected := m.events.SelectedItem()
+
+	scope := m.tagScope
+	var inScope []Event
+	m.hiddenEvents = nil
+	for _, e := range events {
+		if scope == "" || hasTag(e, scope) {
+			inScope = append(inScope, e)
+		} else {
+			m.hiddenEvents = append(m.hiddenEvents, e)
+		}
+	}
+	visible, folded := m.sectionItems(inScope, m.now())
+	m.hiddenEvents = append(m.hiddenEvents, folded...)
+	// With a filter active the list drops its matches and refilters in a
+	// command. Matching is quick, so it is done here rather than leaving the
+	// list empty until the next key.
+	if cmd := m.events.SetItems(visible); cmd != nil {
+		m.events, _ = m.events.Update(cmd())
+	}
+
+	// A recurring event that rolled on has a new time, so the time only
+	// picks between events that are otherwise alike.
+	previous, _ := selected.(Event)
+	match := -1
+	for i, item := range m.events.VisibleItems() {
+		if !sameItem(item, selected) {
+			continue
+		}
+		if match < 0 {
+			match = i
+		}
+		if e, ok := item.(Event); !ok || e.Time == previous.Time {
+			match = i
+			break
+		}
+	}
+	if match >= 0 {
+		m.events.Select(match)
+	}
+}
+
+// sameItem reports whether a and b are the same event or the same section,
+// whatever the time of the event.
+func sameItem(a, b list.Item) bool {
+	switch a := a.(type) {
+	case Event:
+		b, ok := b.(Event)
+		return ok && a.Name == b.Name && a.Source == b.Source && a.Order == b.Order
+	case sectionHeader:
+		b, ok := b.(sectionHeader)
+		return ok && a.Group == b.Group
+	}
+	return false
+}
+
+// refreshEvents recomputes everything that depends on the current time:
+// recurring events roll forward, virtual events are computed afresh and the
+// list is re-sorted. When events rolled forward it saves them, returning
+// the status of a failed save.
+func (m *MainModel) refreshEvents() tea.Cmd {
+	now := m.now()
+
+	var events []Event
+	for _, e := range m.allEvents() {
+		if !e.Virtual {
+			events = append(events, e)
+		}
+	}
+	rolled := rollForwardRecurring(events, now, m.config.completionHold())
+	events = append(events, virtualEvents(now, m.config)...)
+	m.config.sortOrder().sort(events, now)
+	m.setEvents(events)
+
+	if rolled {
+		return m.saved(m.saveEventsToFile())
+	}
+	return nil
+}
+
+// editing reports whether the form or its review is open. The form holds
+// the list index of the event being edited, so the list must not be
+// re-sorted under it.
+func (m MainModel) editing() bool {
+	switch m.state {
+	case showInput, showEdit, showEditReview:
+		return true
+	}
+	return false
+}
+
+// refreshUnlessEditing refreshes the list, or while the form is open leaves
+// the refresh to Update once the form closes.
+func (m *MainModel) refreshUnlessEditing() tea.Cmd {
+	if m.editing() {
+		m.refreshPending = true
+		return nil
+	}
+	return m.refreshEvents()
+}
+
+// virtualEvents returns the computed events the config asks for.
+func virtualEvents(now time.Time, cfg Config) []Event {
+	var events []Event
+	if cfg.PeriodEvents {
+		events = append(events, periodEvents(now, cfg.FiscalYearStartMonth)...)
+	}
+	if cfg.SkyEvents {
+		events = append(events, skyEvents(now, cfg)...)
+	}
+	return events
+}
+
+// virtualPassed reports whether a virtual event has passed and been held
+// at zero long enough, so the next one can take its place: today's sunset
+// gives way to tomorrow's.
+func (m MainModel) virtualPassed(now time.Time) bool {
+	for _, e := range m.allEvents() {
+		if e.Virtual && now.Sub(time.Unix(e.Time, 0)) > m.config.completionHold() {
+			return true
+		}
+	}
+	return false
+}
+
+// handleFocus reacts to the terminal gaining or losing focus and to clock
+// jumps between ticks, e.g. after the laptop slept.
+func (m *MainModel) handleFocus(msg tea.Msg) tea.Cmd {
+	switch msg.(type) {
+	case tea.FocusMsg:
+		m.lastTick = time.Time{}
+		cmds := []tea.Cmd{m.refreshUnlessEditing()}
+		if !m.timer.Running() {
+			cmds = append(cmds, m.timer.Start())
+		}
+		return tea.Batch(cmds...)
+	case tea.BlurMsg:
+		if m.config.PauseWhenBlurred {
+			return m.timer.Stop()
+		}
+	case timer.TickMsg:
+		now, last := m.now(), m.lastTick
+		m.lastTick = now
+		if last.IsZero() {
+			break
+		}
+		if jump := clockJump(last, now, m.timer.Interval); jump != 0 {
+			return m.clockChanged(now, jump)
+		}
+		if now.Sub(last) > clockJumpThreshold {
+			// A timer that slept with the machine fires late, so wait for
+			// midnight afresh.
+			return tea.Batch(m.refreshUnlessEditing(), midnightTick(now))
+		}
+		if m.virtualPassed(now) || !m.listInOrder(now) {
+			return m.refreshUnlessEditing()
+		}
+	}
+	return nil
+}
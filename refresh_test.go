@@ -0,0 +1,432 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/timer"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newRefreshTestModel(t *testing.T, now *time.Time, events ...Event) MainModel {
+	m, err := NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	m.clock = func() time.Time { return *now }
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort)})
+	items := make([]list.Item, len(events))
+	for i := range events {
+		items[i] = events[i]
+	}
+	m.events.SetItems(items)
+	m.state = showEvents
+	return m
+}
+
+func TestFocusRefetchesOnNewDay(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Later", Time: now.Add(time.Hour).Unix()})
+
+	model, _ := m.Update(tea.FocusMsg{})
+	m = model.(MainModel)
+	if m.wiki().loading {
+		t.Error("Expected no refetch when the date did not change")
+	}
+
+	now = now.Add(24 * time.Hour)
+	model, cmd := m.Update(tea.FocusMsg{})
+	m = model.(MainModel)
+	if !m.wiki().loading || cmd == nil {
+		t.Error("Expected on-this-day refetch after the date changed")
+	}
+	if m.wiki().date != now.Format(inputTimeFormShort) {
+		t.Errorf("Expected fetch date %s, got %s", now.Format(inputTimeFormShort), m.wiki().date)
+	}
+}
+
+func TestClockJumpRollsYearlyEvents(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	birthday := Event{Name: "Birthday", Time: now.Add(time.Hour).Unix(), Yearly: true}
+	m := newRefreshTestModel(t, &now,
+		birthday,
+		Event{Name: "Next week", Time: now.Add(7 * 24 * time.Hour).Unix()},
+	)
+
+	tick := timer.TickMsg{ID: m.timer.ID()}
+	model, _ := m.Update(tick)
+	m = model.(MainModel)
+
+	// Sleep through the birthday; the next tick arrives two hours later.
+	now = now.Add(2 * time.Hour)
+	model, _ = m.Update(tick)
+	m = model.(MainModel)
+
+	items := m.events.Items()
+	if items[0].(Event).Name != "Next week" {
+		t.Errorf("Expected 'Next week' first after the birthday rolled over, got '%s'", items[0].(Event).Name)
+	}
+	rolled := items[1].(Event)
+	if want := time.Unix(birthday.Time, 0).AddDate(1, 0, 0).Unix(); rolled.Time != want {
+		t.Errorf("Expected birthday moved to %d, got %d", want, rolled.Time)
+	}
+}
+
+func TestRefreshWaitsForEditForm(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Daily", Time: now.Add(time.Minute).Unix(), Repeat: &Recurrence{Unit: repeatDaily}},
+		Event{Name: "Other", Time: now.Add(time.Hour).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		t.Helper()
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+
+	m.events.Select(1)
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	// Daily passes and would roll to tomorrow, below Other.
+	now = now.Add(2 * time.Minute)
+	update(tea.FocusMsg{})
+	if got := storedNames(eventsOf(m.events.Items())); got != "Daily,Other" {
+		t.Fatalf("Expected the list left alone while editing, got %s", got)
+	}
+
+	m.inputs[inputNameField].SetValue("Other renamed")
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state == showEditReview {
+		update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	events, _ := readEventsFile()
+	if got := storedNames(events); got != "Other renamed,Daily" {
+		t.Errorf("Expected the edited event saved and Daily rolled on after the form closed, got %s", got)
+	}
+}
+
+func TestTickWaitsForEditForm(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Daily", Time: now.Add(time.Minute).Unix(), Repeat: &Recurrence{Unit: repeatDaily}},
+		Event{Name: "Other", Time: now.Add(time.Hour).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		t.Helper()
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+
+	m.config.SortOrder = "past_last"
+	m.events.Select(1)
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	// An ordinary tick, a second after the last, finds the list out of
+	// order once Daily passed.
+	now = now.Add(2 * time.Minute)
+	m.lastTick = now.Add(-time.Second)
+	update(timer.TickMsg{ID: m.timer.ID()})
+	if got := storedNames(eventsOf(m.events.Items())); got != "Daily,Other" {
+		t.Fatalf("Expected the list left alone while editing, got %s", got)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := storedNames(eventsOf(m.events.Items())); got != "Other,Daily" {
+		t.Errorf("Expected the list refreshed once the form closed, got %s", got)
+	}
+}
+
+func TestRefreshKeepsSelectedDuplicate(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Dentist", Time: now.Add(time.Hour).Unix()},
+		Event{Name: "Dentist", Time: now.Add(48 * time.Hour).Unix()},
+	)
+	m.events.Select(1)
+	m.refreshEvents()
+	if m.events.Index() != 1 {
+		t.Errorf("Expected the second Dentist still selected, got index %d", m.events.Index())
+	}
+}
+
+func TestRefreshKeepsSelectedRecurring(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Daily", Time: now.Add(time.Minute).Unix(), Repeat: &Recurrence{Unit: repeatDaily}},
+		Event{Name: "Other", Time: now.Add(time.Hour).Unix()},
+	)
+	m.events.Select(0)
+	now = now.Add(2 * time.Minute)
+	m.refreshEvents()
+	if got := storedNames(eventsOf(m.events.Items())); got != "Other,Daily" {
+		t.Fatalf("Expected Daily rolled on below Other, got %s", got)
+	}
+	if e, ok := m.events.SelectedItem().(Event); !ok || e.Name != "Daily" {
+		t.Errorf("Expected Daily still selected after rolling on, got %v", m.events.SelectedItem())
+	}
+}
+
+func TestBlurPausesTimer(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Later", Time: now.Add(time.Hour).Unix()})
+
+	model, cmd := m.Update(tea.BlurMsg{})
+	m = model.(MainModel)
+	model, _ = m.Update(findTimerMsg(t, cmd))
+	m = model.(MainModel)
+	if m.timer.Running() {
+		t.Error("Expected timer to stop while blurred")
+	}
+
+	model, cmd = m.Update(tea.FocusMsg{})
+	m = model.(MainModel)
+	model, _ = m.Update(findTimerMsg(t, cmd))
+	m = model.(MainModel)
+	if !m.timer.Running() {
+		t.Error("Expected timer to resume on focus")
+	}
+
+	m.config.PauseWhenBlurred = false
+	_, cmd = m.Update(tea.BlurMsg{})
+	if msg := findTimerMsg(nil, cmd); msg != nil {
+		t.Error("Expected timer to keep running with pause_when_blurred disabled")
+	}
+}
+
+// findTimerMsg runs cmd (expanding batches) and returns the timer
+// start/stop message it produces, if any.
+func findTimerMsg(t *testing.T, cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if found := findTimerMsg(t, c); found != nil {
+				return found
+			}
+		}
+	case timer.StartStopMsg:
+		return msg
+	}
+	if t != nil {
+		t.Fatal("Expected a timer start/stop command")
+	}
+	return nil
+}
+
+func TestMidnightRefresh(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 23, 59, 50, 0, time.Local)
+	midnight := nextMidnight(now)
+	if want := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local); !midnight.Equal(want) {
+		t.Fatalf("Expected the next midnight at %s, got %s", want, midnight)
+	}
+	anniversary := Event{Name: "Anniversary", Time: now.Add(5 * time.Second).Unix(), Yearly: true}
+	m := newRefreshTestModel(t, &now,
+		anniversary,
+		Event{Name: "Dentist", Time: time.Date(2026, 3, 11, 10, 0, 0, 0, time.Local).Unix()},
+		Event{Name: "Launch", Time: time.Date(2026, 3, 20, 9, 0, 0, 0, time.Local).Unix()},
+	)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = model.(MainModel)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+
+	// A tick that comes early, as after the clock was set back, waits again.
+	update(midnightMsg(midnight))
+	if !m.lastMidnight.IsZero() {
+		t.Fatal("Expected nothing refreshed before midnight")
+	}
+
+	// The terminal is blurred, so no per-second ticks arrive in between.
+	now = midnight.Add(time.Second)
+	update(midnightMsg(midnight))
+	if !m.lastMidnight.Equal(midnight) {
+		t.Fatalf("Expected the new day handled, got %s", m.lastMidnight)
+	}
+	if got := storedNames(m.storedEvents()); got != "Dentist,Launch,Anniversary" {
+		t.Errorf("Expected the passed anniversary rolled to next year, got %s", got)
+	}
+	if m.wiki().date != "2026-03-11" || !m.wiki().loading {
+		t.Errorf("Expected On This Day fetched for the new day, got %q", m.wiki().date)
+	}
+	if status := stripANSI(m.events.View()); !strings.Contains(status, "Today: D") {
+		t.Errorf("Expected today's events in the status bar:\n%s", status)
+	}
+
+	// A second tick for the same midnight, left over from a clock jump.
+	if m.handleMidnight(midnightMsg(midnight)) != nil {
+		t.Error("Expected a midnight to be handled once")
+	}
+}
+
+func TestMidnightWaitsForEditForm(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 23, 59, 50, 0, time.Local)
+	midnight := nextMidnight(now)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Anniversary", Time: now.Add(5 * time.Second).Unix(), Yearly: true},
+		Event{Name: "Launch", Time: time.Date(2026, 3, 20, 9, 0, 0, 0, time.Local).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		t.Helper()
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+
+	m.events.Select(1)
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	now = midnight.Add(time.Second)
+	update(midnightMsg(midnight))
+	if got := storedNames(eventsOf(m.events.Items())); got != "Anniversary,Launch" {
+		t.Fatalf("Expected the list left alone while editing, got %s", got)
+	}
+
+	m.inputs[inputNameField].SetValue("Product launch")
+	update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state == showEditReview {
+		update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	events, _ := readEventsFile()
+	if got := storedNames(events); got != "Product launch,Anniversary" {
+		t.Errorf("Expected the edit saved and the anniversary rolled on after the form closed, got %s", got)
+	}
+}
+
+func TestClockJump(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Trip", Time: time.Date(2026, 3, 12, 9, 0, 0, 0, time.Local).Unix()},
+		Event{Name: "Checkup", Time: time.Date(2026, 3, 11, 8, 0, 0, 0, time.Local).Unix()},
+	)
+	tick := func() tea.Cmd {
+		model, cmd := m.Update(timer.TickMsg{ID: m.timer.ID()})
+		m = model.(MainModel)
+		return cmd
+	}
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = model.(MainModel)
+	tick()
+	m.imminentSuggested = false
+
+	// The clock is set ahead past midnight and past Checkup, bringing Trip
+	// within a day.
+	now = time.Date(2026, 3, 11, 12, 0, 0, 0, time.Local)
+	tick()
+	view := stripANSI(m.View())
+	for _, want := range []string{"Clock ch…", "[EARLIER] Checkup", "21h 0m 0s"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q after the jump in:\n%s", want, view)
+		}
+	}
+	if !m.imminentSuggested {
+		t.Error("Expected no imminent hint for an event only the jump brought within a day")
+	}
+	model, cmd := m.Update(midnightMsg(time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)))
+	m = model.(MainModel)
+	if cmd != nil {
+		t.Error("Expected the midnight the jump passed not to be announced")
+	}
+
+	now = time.Date(2026, 3, 10, 21, 0, 0, 0, time.Local)
+	tick()
+	if !m.lastMidnight.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected midnight waited for afresh after going back, got %s", m.lastMidnight)
+	}
+	if view := stripANSI(m.View()); strings.Contains(view, "[EARLIER]") {
+		t.Errorf("Expected Checkup upcoming again in:\n%s", view)
+	}
+
+	for jump, expected := range map[time.Duration]string{
+		16*time.Hour - time.Second:  "Clock changed: moved ahead 16h",
+		-15*time.Hour - time.Second: "Clock changed: moved back 15h",
+		-42 * time.Second:           "Clock changed: moved back 42s",
+	} {
+		if got := describeClockJump(jump); got != expected {
+			t.Errorf("describeClockJump(%s) = %q, want %q", jump, got, expected)
+		}
+	}
+}
+
+func TestClockJumpDetection(t *testing.T) {
+	wall := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	mono := time.Now()
+	tests := []struct {
+		name      string
+		last, now time.Time
+		expected  time.Duration
+	}{
+		{"regular tick", wall, wall.Add(time.Second), 0},
+		{"small drift", wall, wall.Add(8 * time.Second), 0},
+		{"set ahead", wall, wall.Add(time.Hour), time.Hour - time.Second},
+		{"set back", wall, wall.Add(-time.Hour), -time.Hour - time.Second},
+		// The monotonic clock saw the two hours pass, as after sleep.
+		{"slept", mono, mono.Add(2 * time.Hour), 0},
+	}
+	for _, tt := range tests {
+		if got := clockJump(tt.last, tt.now, time.Second); got != tt.expected {
+			t.Errorf("%s: clockJump = %s, want %s", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestReminderClock(t *testing.T) {
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	c := newReminderClock(start)
+
+	now := start.Add(time.Minute)
+	if from, jump := c.advance(now, time.Minute); !from.Equal(start) || jump != 0 {
+		t.Errorf("Expected a regular tick to cover the last minute, got %s, %s", from, jump)
+	}
+
+	// Set ahead an hour: reminders in between are skipped.
+	now = now.Add(time.Hour + time.Minute)
+	if from, jump := c.advance(now, time.Minute); !from.Equal(now) || jump != time.Hour {
+		t.Errorf("Expected the hour skipped, got %s, %s", from, jump)
+	}
+
+	// Set back an hour: nothing is sent until the clock catches up.
+	sent := now
+	now = now.Add(-time.Hour + time.Minute)
+	if from, jump := c.advance(now, time.Minute); !from.Equal(sent) || jump != -time.Hour {
+		t.Errorf("Expected reminders sent before the jump not repeated, got %s, %s", from, jump)
+	}
+	now = now.Add(time.Minute)
+	if from, jump := c.advance(now, time.Minute); !from.Equal(sent) || jump != 0 {
+		t.Errorf("Expected to wait for the clock to catch up, got %s, %s", from, jump)
+	}
+	if dueNotifications([]Event{{Name: "Call", Time: now.Add(-time.Second).Unix()}}, nil, inputTimeFormLong, sent, now) != nil {
+		t.Error("Expected no reminders for a window ending before it starts")
+	}
+}
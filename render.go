@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+func runRender(args []string) int {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	width := fs.Int("width", 120, "width of the frame in columns")
+	height := fs.Int("height", 40, "height of the frame in lines")
+	view := fs.String("view", "full", "what to draw: full, list, detail or side")
+	selectName := fs.String("select", "", "select the event with this name instead of the first")
+	at := fs.String("at", "", "draw the frame as of this time instead of now, e.g. \"2026-03-01 09:30:00\"")
+	wiki := fs.Bool("wiki", false, "fetch On This Day before drawing")
+	wikiTimeout := fs.Duration("wiki-timeout", 5*time.Second, "how long to wait for On This Day with --wiki")
+	plain := fs.Bool("plain", false, "print without colors")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	switch *view {
+	case "full", "list", "detail", "side":
+	default:
+		fmt.Fprintf(os.Stderr, "render: unknown view %q, use full, list, detail or side\n", *view)
+		return 2
+	}
+	if *width < 20 || *height < 10 {
+		fmt.Fprintln(os.Stderr, "render: the frame must be at least 20 columns by 10 lines")
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+	setListFormat(cfg.ListFormat)
+	now := time.Now()
+	if *at != "" {
+		if now, err = parseDateInput(*at, now); err != nil {
+			fmt.Fprintf(os.Stderr, "render: --at: %v\n", err)
+			return 2
+		}
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		return 1
+	}
+	// Roll recurring events forward as the program would, but leave the file.
+	if rollForwardRecurring(events, now, cfg.completionHold()) {
+		sortEventsByTime(events)
+	}
+
+	m := newMainModel(cfg, events, now)
+	m.clock = func() time.Time { return now }
+	if *selectName != "" {
+		e, ok := findEventByName(events, *selectName)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "render: no event named %q\n", *selectName)
+			return 1
+		}
+		m.selectEvent(e)
+	}
+	if *view == "detail" && !m.hasSelectedEvent() {
+		fmt.Fprintln(os.Stderr, "render: no events to show")
+		return 1
+	}
+	w := onThisDayPanel{date: now.Format(inputTimeFormShort)}
+	if *wiki {
+		msg := fetchOnThisDayAt(now, feedSelected, *wikiTimeout).(OnThisDayMsg)
+		w.events, w.err = sanitizeWikiEvents(msg.events), msg.err
+	} else {
+		w.skipped = true
+	}
+	m.setSide(w)
+
+	profile := termenv.TrueColor
+	if *plain {
+		profile = termenv.Ascii
+	}
+	frame := renderFrame(m, *view, *width, *height, profile)
+	if *plain {
+		frame = stripANSI(frame)
+	}
+	fmt.Println(frame)
+	return 0
+}
+
+// selectEvent moves the list selection to e, if the list holds it.
+func (m *MainModel) selectEvent(e Event) {
+	for i, item := range m.events.Items() {
+		if other, ok := item.(Event); ok && sameEvent(other, e) {
+			m.events.Select(i)
+			return
+		}
+	}
+}
+
+// renderFrame draws one frame of the program at the given size, or one of
+// its columns, without running it. Given the same model, size and profile
+// the output is always the same.
+func renderFrame(m MainModel, view string, width, height int, profile termenv.Profile) string {
+	return withColorProfile(profile, func() string {
+		model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+		m = model.(MainModel)
+		switch view {
+		case "list":
+			h, _ := AppStyle.GetFrameSize()
+			m.listWidth = width - h
+			m.layoutLists()
+			return AppStyle.Render(m.listView(m.events))
+		case "detail":
+			m.detailWidth = width
+			return m.detailsString()
+		case "side":
+			m.timelineWidth = width
+			return m.renderSide()
+		}
+		// The terminal would cut off whatever does not fit.
+		lines := strings.Split(m.View(), "\n")
+		if len(lines) > height {
+			lines = lines[:height]
+		}
+		return strings.Join(lines, "\n")
+	})
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func renderTestModel(now time.Time) MainModel {
+	events := []Event{
+		{Name: "Launch", Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix()},
+		{Name: "Conference", Time: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC).Unix(), Tags: []string{"work"}},
+		{Name: "Kickoff", Time: time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC).Unix()},
+	}
+	sortEventsByTime(events)
+	m := newMainModel(defaultConfig(), events, now)
+	m.clock = func() time.Time { return now }
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort), skipped: true})
+	return m
+}
+
+func TestRenderFrameGolden(t *testing.T) {
+	withFixedLocal(t)
+	useLanguage(t, "en")
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	got := renderFrame(renderTestModel(now), "full", 110, 30, termenv.Ascii)
+	if lines := strings.Count(got, "\n") + 1; lines != 30 {
+		t.Errorf("Expected the frame cut to 30 lines, got %d", lines)
+	}
+	assertGolden(t, "render_full.golden", stripANSI(got))
+
+	if again := renderFrame(renderTestModel(now), "full", 110, 30, termenv.Ascii); again != got {
+		t.Error("Expected the same frame from the same inputs")
+	}
+}
+
+func TestRenderFrameViews(t *testing.T) {
+	withFixedLocal(t)
+	useLanguage(t, "en")
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	m := renderTestModel(now)
+	m.selectEvent(Event{Name: "Conference", Time: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC).Unix()})
+
+	detail := stripANSI(renderFrame(m, "detail", 40, 30, termenv.Ascii))
+	if !strings.Contains(detail, "Conference") || !strings.Contains(detail, "63d 23h 30m 0s") || strings.Contains(detail, "On This Day") {
+		t.Errorf("Expected only the Conference details, got:\n%s", detail)
+	}
+	for _, line := range strings.Split(detail, "\n") {
+		if w := len([]rune(line)); w > 42 {
+			t.Fatalf("Expected the detail panel 40 columns wide, got a line of %d: %q", w, line)
+		}
+	}
+
+	// A frame this narrow is in compact mode, with the list's countdowns cut
+	// short as the program shows them.
+	list := stripANSI(renderFrame(m, "list", 30, 20, termenv.Ascii))
+	if !strings.Contains(list, "│ Conference") || !strings.Contains(list, "│ 63d 23h ") || strings.Contains(list, "63d 23h 30m") {
+		t.Errorf("Expected the list with Conference selected and short countdowns, got:\n%s", list)
+	}
+
+	side := stripANSI(renderFrame(m, "side", 50, 20, termenv.Ascii))
+	if !strings.Contains(side, "On This Day - March 1") || !strings.Contains(side, "add --wiki") {
+		t.Errorf("Expected the skipped On This Day panel, got:\n%s", side)
+	}
+}
+
+func TestRunRender(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	withFixedLocal(t)
+
+	if err := writeEventsFile([]Event{{Name: "Launch", Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix()}}); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+	run := func(args ...string) (string, int) {
+		t.Helper()
+		r, w, _ := os.Pipe()
+		stdout := os.Stdout
+		os.Stdout = w
+		code := runRender(args)
+		os.Stdout = stdout
+		w.Close()
+		var b bytes.Buffer
+		io.Copy(&b, r)
+		return b.String(), code
+	}
+
+	out, code := run("--view", "detail", "--select", "launch", "--at", "2026-03-01 09:30:00", "--width", "44", "--plain")
+	if code != 0 || !strings.Contains(out, "14d 8h 30m 0s") || strings.Contains(out, "\x1b[") {
+		t.Errorf("Expected a plain detail frame, got code %d:\n%s", code, out)
+	}
+	colored, _ := run("--view", "detail", "--at", "2026-03-01 09:30:00", "--width", "44")
+	if !strings.Contains(colored, "\x1b[") {
+		t.Error("Expected ANSI colors without --plain")
+	}
+
+	for _, args := range [][]string{
+		{"--view", "timeline"},
+		{"--width", "5"},
+		{"--at", "someday"},
+	} {
+		if _, code := run(args...); code != 2 {
+			t.Errorf("%v: expected exit code 2, got %d", args, code)
+		}
+	}
+	if _, code := run("--select", "nothing"); code != 1 {
+		t.Errorf("Expected exit code 1 for an unknown event, got %d", code)
+	}
+}
+
+func TestRunRenderSanitizesWiki(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	withFixedLocal(t)
+	useLanguage(t, "en")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"selected": [{"year": 1969, "text": "Moon\u001b[2J landing\u202e", "pages": [{"title": "Apollo_11\u200f"}]}]}`)
+	}))
+	defer server.Close()
+	defer func(url string) { wikiFeedURL = url }(wikiFeedURL)
+	wikiFeedURL = server.URL
+
+	r, w, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = w
+	code := runRender([]string{"--view", "side", "--wiki", "--at", "2026-03-01 09:30:00", "--width", "60"})
+	os.Stdout = stdout
+	w.Close()
+	var b bytes.Buffer
+	io.Copy(&b, r)
+
+	out := b.String()
+	if code != 0 || strings.Contains(out, "\x1b[2J") || strings.ContainsAny(out, "\u202e\u200f") {
+		t.Errorf("Expected no control characters from the feed, got code %d: %q", code, out)
+	}
+	if plain := stripANSI(out); !strings.Contains(plain, "Moon[2J landing") {
+		t.Errorf("Expected the cleaned entry in the panel, got:\n%s", plain)
+	}
+}
+
+func TestLongTitlesFitPanels(t *testing.T) {
+	withFixedLocal(t)
+	useLanguage(t, "en")
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	names := []string{
+		"夏休みのポルトガル旅行とリスボン観光とポルト",
+		"🎉🎂🎈 Birthday party 🎉🎂🎈 with everyone from the old office",
+		"Quarterly planning review with the extended leadership team",
+	}
+
+	model := func(name string) MainModel {
+		events := []Event{{Name: name, Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix()}}
+		m := newMainModel(defaultConfig(), events, now)
+		m.clock = func() time.Time { return now }
+		m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort), skipped: true})
+		return m
+	}
+	frameWidth := func(frame string) int {
+		w := 0
+		for _, line := range strings.Split(frame, "\n") {
+			w = max(w, ansi.StringWidth(line))
+		}
+		return w
+	}
+
+	for _, width := range []int{70, 110, 160} {
+		short := frameWidth(renderFrame(model("Launch"), "full", width, 40, termenv.Ascii))
+		for _, name := range names {
+			if got := frameWidth(renderFrame(model(name), "full", width, 40, termenv.Ascii)); got > short {
+				t.Errorf("%q at width %d: frame is %d wide, %d with a short name", name, width, got, short)
+			}
+		}
+	}
+	for _, width := range []int{24, 40} {
+		for _, name := range names {
+			list := renderFrame(model(name), "list", width, 20, termenv.Ascii)
+			if got := frameWidth(list); got > width {
+				t.Errorf("%q in a %d wide list: line is %d wide", name, width, got)
+			}
+			if !strings.Contains(stripANSI(list), "…") {
+				t.Errorf("%q in a %d wide list: expected the title to end in an ellipsis", name, width)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// promLabel escapes a label value for the Prometheus text format.
+func promLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// writeMetrics writes the Prometheus text format for events. Recurring events
+// count down to their next occurrence, as in the list.
+func writeMetrics(w io.Writer, events []Event, valid bool, now time.Time) {
+	events = append([]Event(nil), events...)
+	rollForwardRecurring(events, now, 0)
+
+	past := 0
+	for _, e := range events {
+		if e.Time <= now.Unix() {
+			past++
+		}
+	}
+	ok := 0
+	if valid {
+		ok = 1
+	}
+
+	fmt.Fprintln(w, "# HELP countdown_events_total Number of events.")
+	fmt.Fprintln(w, "# TYPE countdown_events_total gauge")
+	fmt.Fprintf(w, "countdown_events_total %d\n", len(events))
+	fmt.Fprintln(w, "# HELP countdown_events_past_total Number of events that have passed.")
+	fmt.Fprintln(w, "# TYPE countdown_events_past_total gauge")
+	fmt.Fprintf(w, "countdown_events_past_total %d\n", past)
+	fmt.Fprintln(w, "# HELP countdown_events_file_valid Whether the events file could be read at the last scrape. The previous events are served while it is 0.")
+	fmt.Fprintln(w, "# TYPE countdown_events_file_valid gauge")
+	fmt.Fprintf(w, "countdown_events_file_valid %d\n", ok)
+	if len(events) == 0 {
+		return
+	}
+
+	ids := eventIDs(events)
+	fmt.Fprintln(w, "# HELP countdown_event_seconds_remaining Seconds until the event, negative once it has passed.")
+	fmt.Fprintln(w, "# TYPE countdown_event_seconds_remaining gauge")
+	for i, e := range events {
+		fmt.Fprintf(w, "countdown_event_seconds_remaining{id=\"%s\",name=\"%s\"} %d\n", promLabel(ids[i]), promLabel(e.Name), e.Time-now.Unix())
+	}
+	fmt.Fprintln(w, "# HELP countdown_event_timestamp_seconds Time of the event as a Unix timestamp.")
+	fmt.Fprintln(w, "# TYPE countdown_event_timestamp_seconds gauge")
+	for i, e := range events {
+		fmt.Fprintf(w, "countdown_event_timestamp_seconds{id=\"%s\",name=\"%s\"} %d\n", promLabel(ids[i]), promLabel(e.Name), e.Time)
+	}
+}
+
+func metricsHandler(store *eventStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events, err := store.Events()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, events, err == nil, time.Now())
+	})
+}
+
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := fs.String("listen", "localhost:9184", "address to serve /metrics on")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+		return 1
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler(&eventStore{path: eventsFile}))
+
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Serving metrics on http://%s/metrics\n", ln.Addr())
+	if err := http.Serve(ln, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "serve: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()},
+		{Name: `Say "hi" \ bye`, Time: now.Add(-time.Hour).Unix()},
+		{Name: "launch", Time: now.Add(time.Hour).Unix()},
+		{Name: "Birthday", Time: time.Date(1990, 5, 1, 0, 0, 0, 0, time.Local).Unix(), Yearly: true},
+	}
+
+	var b bytes.Buffer
+	writeMetrics(&b, events, true, now)
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE countdown_events_total gauge\ncountdown_events_total 4\n",
+		"countdown_events_past_total 1\n",
+		"countdown_events_file_valid 1\n",
+		`countdown_event_seconds_remaining{id="launch",name="Launch"} 172800` + "\n",
+		`countdown_event_seconds_remaining{id="say-hi-bye",name="Say \"hi\" \\ bye"} -3600` + "\n",
+		`countdown_event_seconds_remaining{id="launch-2",name="launch"} 3600` + "\n",
+		fmt.Sprintf(`countdown_event_timestamp_seconds{id="birthday",name="Birthday"} %d`+"\n", time.Date(2027, 5, 1, 0, 0, 0, 0, time.Local).Unix()),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	if events[3].Time != time.Date(1990, 5, 1, 0, 0, 0, 0, time.Local).Unix() {
+		t.Error("Expected the caller's events to be left alone")
+	}
+
+	b.Reset()
+	writeMetrics(&b, nil, false, now)
+	if strings.Contains(b.String(), "countdown_event_seconds_remaining") || !strings.Contains(b.String(), "countdown_events_file_valid 0") {
+		t.Errorf("Expected only totals for no events, got:\n%s", b.String())
+	}
+}
+
+func TestEventStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	store := &eventStore{path: path}
+
+	if events, err := store.Events(); err != nil || len(events) != 0 {
+		t.Fatalf("Expected no events for a missing file, got %v, %v", events, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the store not to create the file")
+	}
+
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write events: %v", err)
+		}
+	}
+	write(`[{"name": "Launch", "ts": 1773597600}]`)
+	events, err := store.Events()
+	if err != nil || len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %v, %v", events, err)
+	}
+
+	write(`[{"name": "Launch", "ts": 1773597600}, {"name": "Standup", "ts": 1773600000}]`)
+	if events, _ := store.Events(); len(events) != 2 {
+		t.Errorf("Expected the changed file to be reloaded, got %v", events)
+	}
+
+	write(`[{"name": "Launch"`)
+	if events, err := store.Events(); err == nil || len(events) != 2 {
+		t.Errorf("Expected the last good events and an error, got %v, %v", events, err)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	if err := os.WriteFile(path, []byte(`[{"name": "Launch", "ts": 4102444800}]`), 0644); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	metricsHandler(&eventStore{path: path}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Expected the Prometheus content type, got %s", ct)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `countdown_event_timestamp_seconds{id="launch",name="Launch"} 4102444800`) {
+		t.Errorf("Unexpected metrics:\n%s", body)
+	}
+}
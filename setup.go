@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// eventsFileEnv names an events file to use instead of the one in the data
+// directory.
+const eventsFileEnv = "COUNTDOWN_EVENTS_FILE"
+
+// exitSetup is the exit status when the first run cannot set up the events
+// file.
+const exitSetup = 3
+
+// setupError is a failure to create the data directory or the first events
+// file. Its message names the path and what to do about it.
+type setupError struct {
+	Path string
+	Err  error
+}
+
+func (e *setupError) Error() string {
+	return fmt.Sprintf("cannot set up %s: %v\n"+
+		"To keep the events somewhere you can write to, either\n"+
+		"  set %s to the events file to use, or\n"+
+		"  set XDG_DATA_HOME to a directory for countdown's data", e.Path, e.Err, eventsFileEnv)
+}
+
+func (e *setupError) Unwrap() error { return e.Err }
+
+// configuredEventsFile is the events file named by events_file in the
+// config file, or "" if it names none. Only that key is read, so a config
+// with mistakes elsewhere still finds the events.
+func configuredEventsFile() string {
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		EventsFile string `toml:"events_file"`
+	}
+	if _, err := toml.DecodeFile(configFile, &cfg); err != nil {
+		return ""
+	}
+	return expandHome(cfg.EventsFile)
+}
+
+// expandHome replaces a leading ~ with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// seedEventsFile creates the empty events file of a first run.
+func seedEventsFile(path string) ([]Event, error) {
+	events := []Event{}
+	bytes, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, &setupError{Path: filepath.Dir(path), Err: err}
+	}
+	if err := writeFileAtomic(path, bytes); err != nil {
+		return nil, &setupError{Path: path, Err: err}
+	}
+	return events, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so a failed write cannot leave path empty or cut short. A
+// symlink keeps pointing at the file, which is replaced instead, with the
+// temporary file in the target's directory so the rename stays on its file
+// system. A file that is replaced keeps its permissions.
+func writeFileAtomic(path string, data []byte) error {
+	path, err := linkTarget(path)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", path)
+		}
+		mode = info.Mode().Perm()
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// linkTarget follows path through any symlinks to the file they point at,
+// which need not exist yet.
+func linkTarget(path string) (string, error) {
+	for i := 0; i < 40; i++ {
+		target, err := os.Readlink(path)
+		if err != nil {
+			// Not a link, or nothing there yet.
+			return path, nil
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = target
+	}
+	return "", fmt.Errorf("%s: too many levels of symbolic links", path)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetupFailureNamesPath(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	// A file where the data directory should go cannot be made a directory.
+	blocker := filepath.Join(t.TempDir(), "blocked")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_DATA_HOME", blocker)
+
+	_, err := readEventsFile()
+	var setupErr *setupError
+	if !errors.As(err, &setupErr) {
+		t.Fatalf("Expected a setup error, got %v", err)
+	}
+	dir := filepath.Join(blocker, appName)
+	if setupErr.Path != dir {
+		t.Errorf("Expected the path %s, got %s", dir, setupErr.Path)
+	}
+	for _, want := range []string{dir, eventsFileEnv, "XDG_DATA_HOME"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err)
+		}
+	}
+}
+
+func TestEventsFileEnv(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	file := filepath.Join(t.TempDir(), "elsewhere", "mine.json")
+	t.Setenv(eventsFileEnv, file)
+	if got, err := getEventsFilePath(); err != nil || got != file {
+		t.Fatalf("Expected %s, got %s (%v)", file, got, err)
+	}
+
+	events, err := readEventsFile()
+	if err != nil || len(events) != 0 {
+		t.Fatalf("Expected no events, got %v (%v)", events, err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Expected the events file to be written: %v", err)
+	}
+	var saved []Event
+	if err := json.Unmarshal(data, &saved); err != nil || saved == nil || len(saved) != 0 {
+		t.Errorf("Expected an empty list in the file, got %s (%v)", data, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(file)); len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left, got %d entries", len(entries))
+	}
+}
+
+func TestEventsFileKeptForSession(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	path, err := resolveEventsFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventsFilePath = path
+	defer func() { eventsFilePath = "" }()
+
+	// The config is edited to point elsewhere while the program runs.
+	elsewhere := filepath.Join(t.TempDir(), "moved.json")
+	writeConfigFile(t, fmt.Sprintf("events_file = %q\n", elsewhere))
+	if err := writeEventsFile([]Event{{Name: "Launch", Time: 1900000000}}); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := readEventsFile(); storedNames(events) != "Launch" {
+		t.Errorf("Expected the events saved to %s, got %v", path, events)
+	}
+	if _, err := os.Stat(elsewhere); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing written to %s, got %v", elsewhere, err)
+	}
+}
+
+func TestWriteFileAtomicLeavesNothingOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "missing", "events.json")
+	if err := writeFileAtomic(path, []byte("[]")); err == nil {
+		t.Fatal("Expected writing into a missing directory to fail")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no file to be left, got %v", err)
+	}
+
+	path = filepath.Join(dir, "events.json")
+	os.WriteFile(path, []byte("old"), 0644)
+	if err := writeFileAtomic(path, []byte("[]")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "[]" {
+		t.Errorf("Expected the file to be replaced, got %q", data)
+	}
+}
+
+func TestEventsFileArrangements(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	launch := []Event{{Name: "Launch", Time: 1780000000}}
+
+	t.Run("Directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "events.json")
+		t.Setenv(eventsFileEnv, file)
+		if err := os.MkdirAll(filepath.Join(file, "restored"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readEventsFile(); err == nil || !strings.Contains(err.Error(), file+" is a directory") {
+			t.Errorf("Expected the directory named, got %v", err)
+		}
+		if err := writeEventsFile(launch); err == nil {
+			t.Error("Expected saving over a directory to fail")
+		}
+		if _, err := os.Stat(filepath.Join(file, "restored")); err != nil {
+			t.Errorf("Expected the directory left alone: %v", err)
+		}
+	})
+
+	t.Run("Symlink", func(t *testing.T) {
+		linkDir, share := t.TempDir(), t.TempDir()
+		target := filepath.Join(share, "events.json")
+		if err := os.WriteFile(target, []byte("[]"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(linkDir, "events.json")
+		if err := os.Symlink(filepath.Join("..", filepath.Base(share), "events.json"), file); err != nil {
+			t.Skipf("no symlinks: %v", err)
+		}
+		t.Setenv(eventsFileEnv, file)
+
+		if events, err := readEventsFile(); err != nil || len(events) != 0 {
+			t.Fatalf("Expected the empty file read through the link, got %v (%v)", events, err)
+		}
+		if err := writeEventsFile(launch); err != nil {
+			t.Fatal(err)
+		}
+		if info, err := os.Lstat(file); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("Expected the link kept, got %v (%v)", info, err)
+		}
+		if events, err := readEventsFile(); err != nil || storedNames(events) != "Launch" {
+			t.Errorf("Expected the save written to the target, got %v (%v)", events, err)
+		}
+		info, err := os.Stat(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("Expected the file to stay 0600, got %o", perm)
+		}
+		for _, dir := range []string{linkDir, share} {
+			if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+				t.Errorf("Expected no temporary files left in %s, got %d entries", dir, len(entries))
+			}
+		}
+	})
+
+	t.Run("Dangling symlink", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "synced", "events.json")
+		if err := os.Mkdir(filepath.Dir(target), 0755); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(dir, "events.json")
+		if err := os.Symlink(target, file); err != nil {
+			t.Skipf("no symlinks: %v", err)
+		}
+		t.Setenv(eventsFileEnv, file)
+
+		if events, err := readEventsFile(); err != nil || len(events) != 0 {
+			t.Fatalf("Expected no events, got %v (%v)", events, err)
+		}
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected the new file created where the link points: %v", err)
+		}
+		if info, err := os.Lstat(file); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("Expected the link kept, got %v (%v)", info, err)
+		}
+	})
+
+	t.Run("Read-only target", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can write anywhere")
+		}
+		linkDir, share := t.TempDir(), t.TempDir()
+		target := filepath.Join(share, "events.json")
+		if err := os.WriteFile(target, []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(linkDir, "events.json")
+		if err := os.Symlink(target, file); err != nil {
+			t.Skipf("no symlinks: %v", err)
+		}
+		os.Chmod(share, 0555)
+		defer os.Chmod(share, 0755)
+		t.Setenv(eventsFileEnv, file)
+
+		if err := writeEventsFile(launch); err == nil {
+			t.Error("Expected saving into a read-only directory to fail")
+		}
+		if entries, _ := os.ReadDir(linkDir); len(entries) != 1 {
+			t.Errorf("Expected nothing written next to the link, got %d entries", len(entries))
+		}
+	})
+}
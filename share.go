@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shareVersion prefixes every share payload, so the format can change
+// without breaking links that are already out there.
+const shareVersion = "1"
+
+type sharePayload struct {
+	Name string `json:"n"`
+	Time int64  `json:"t"`
+}
+
+// writeClipboard is a variable so tests can keep off the real clipboard.
+var writeClipboard = clipboard.WriteAll
+
+type shareCopiedMsg struct{ err error }
+
+// encodeShare packs an event into a URL-safe payload: the version, a dot,
+// then the unpadded base64url of a small JSON object.
+func encodeShare(e Event) string {
+	data, _ := json.Marshal(sharePayload{Name: e.Name, Time: e.Time})
+	return shareVersion + "." + base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeShare reads a payload made by encodeShare. It also accepts the
+// whole link, taking the payload from after the last '/', '#', '?' or '=',
+// none of which occur in base64url.
+func decodeShare(s string) (Event, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexAny(s, "/#?="); i >= 0 {
+		s = s[i+1:]
+	}
+	version, data, ok := strings.Cut(s, ".")
+	if !ok {
+		return Event{}, fmt.Errorf("not a countdown share link")
+	}
+	if version != shareVersion {
+		return Event{}, fmt.Errorf("share link version %q is not supported, update countdown", version)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("share link is damaged: %w", err)
+	}
+	var p sharePayload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return Event{}, fmt.Errorf("share link is damaged: %w", err)
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return Event{}, fmt.Errorf("share link has no event name")
+	}
+	return Event{Name: p.Name, Time: p.Time}, nil
+}
+
+func (c Config) shareLink(e Event) string {
+	return c.ShareBaseURL + encodeShare(e)
+}
+
+// openShare shows the share modal for e and copies its link.
+func (m *MainModel) openShare(e Event) tea.Cmd {
+	m.shareLink = m.config.shareLink(e)
+	m.shareQR = ""
+	m.shareStatus = ""
+	if q, err := encodeQR([]byte(m.shareLink)); err == nil {
+		m.shareQR = q.String()
+	} else {
+		m.shareStatus = tr("share.qr_too_long")
+	}
+	m.state = showShare
+
+	link := m.shareLink
+	return func() tea.Msg { return shareCopiedMsg{writeClipboard(link)} }
+}
+
+func (m MainModel) shareView() string {
+	var b strings.Builder
+	width := max(50, lipgloss.Width(m.shareQR))
+
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("share.title")) + "\n\n")
+	b.WriteString(lipgloss.NewStyle().Width(width).Render(BrightTextStyle(m.shareLink)) + "\n\n")
+	if m.shareQR != "" {
+		// Dark modules on light, whatever the terminal's own colors are.
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#FFFFFF")).
+			Render(m.shareQR) + "\n\n")
+	}
+	if m.shareStatus != "" {
+		b.WriteString(m.shareStatus + "\n\n")
+	}
+	b.WriteString(HintStyle(tr("share.close")))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
+
+// hasSharedEvent reports whether an event with e's name and time is already
+// saved, so importing the same link twice adds it once.
+func hasSharedEvent(events []Event, e Event) bool {
+	for _, existing := range events {
+		if existing.Name == e.Name && existing.Time == e.Time {
+			return true
+		}
+	}
+	return false
+}
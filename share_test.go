@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShareRoundTrip(t *testing.T) {
+	events := []Event{
+		{Name: "Launch", Time: 1773597600},
+		{Name: "Moon landing", Time: -14182940},
+		{Name: "Geburtstag 🎂 / \"quotes\" & more", Time: 0},
+	}
+
+	for _, e := range events {
+		t.Run(e.Name, func(t *testing.T) {
+			payload := encodeShare(e)
+			if !strings.HasPrefix(payload, "1.") || strings.ContainsAny(payload, "+/=") {
+				t.Errorf("Expected a versioned base64url payload, got '%s'", payload)
+			}
+			for _, input := range []string{payload, "https://example.com/countdown#" + payload, "https://example.com/?e=" + payload, " " + payload + "\n"} {
+				got, err := decodeShare(input)
+				if err != nil {
+					t.Fatalf("Failed to decode '%s': %v", input, err)
+				}
+				if got.Name != e.Name || got.Time != e.Time {
+					t.Errorf("Expected %+v, got %+v", e, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeShareErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"hello", "not a countdown share link"},
+		{"2.eyJuIjoiYSIsInQiOjF9", `version "2" is not supported`},
+		{"1.!!!", "damaged"},
+		{"1.bm90IGpzb24", "damaged"},
+		{"1.eyJ0IjoxfQ", "no event name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := decodeShare(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing '%s', got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestShareModal(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	var copied string
+	saved := writeClipboard
+	writeClipboard = func(s string) error { copied = s; return nil }
+	t.Cleanup(func() { writeClipboard = saved })
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()})
+	m.config.ShareBaseURL = "https://example.com/c#"
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = model.(MainModel)
+	if m.state != showShare || !strings.HasPrefix(m.shareLink, "https://example.com/c#1.") {
+		t.Fatalf("Expected the share modal with a link, got state %v and '%s'", m.state, m.shareLink)
+	}
+	model, _ = m.Update(cmd())
+	m = model.(MainModel)
+	if copied != m.shareLink {
+		t.Errorf("Expected the link on the clipboard, got '%s'", copied)
+	}
+	view := stripANSI(m.View())
+	for _, want := range []string{"Share", "https://example.com/c#1.", "█▀▀▀▀▀█", "Link copied to the clipboard"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the modal, got:\n%s", want, view)
+		}
+	}
+
+	writeClipboard = func(string) error { return errors.New("no clipboard") }
+	model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(MainModel)
+	if m.state != showEvents {
+		t.Fatalf("Expected any key to close the modal, got state %v", m.state)
+	}
+	model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = model.(MainModel)
+	model, _ = m.Update(cmd())
+	m = model.(MainModel)
+	if !strings.Contains(stripANSI(m.shareStatus), "Could not copy the link: no clipboard") {
+		t.Errorf("Expected the copy error, got '%s'", m.shareStatus)
+	}
+}
+
+func TestImportShare(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	if err := writeEventsFile([]Event{}); err != nil {
+		t.Fatalf("Failed to write events: %v", err)
+	}
+	link := "https://example.com/c#" + encodeShare(Event{Name: "Launch", Time: 1773597600})
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = stdout }()
+
+	for i := 0; i < 2; i++ {
+		if code := runImport([]string{"--share", link}); code != 0 {
+			t.Fatalf("Expected exit code 0, got %d", code)
+		}
+	}
+	events, err := readEventsFile()
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) != 1 || events[0].Name != "Launch" || events[0].Time != 1773597600 || events[0].Created == 0 {
+		t.Errorf("Expected Launch imported once, got %+v", events)
+	}
+
+	if code := runImport([]string{"--share", "garbage"}); code != 1 {
+		t.Errorf("Expected exit code 1 for a bad link, got %d", code)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// shutdownSignals end the program the way q does: SIGTERM from kill or a
+// service manager, SIGHUP when the terminal window closes, and an interrupt
+// when ctrl+c does not arrive as a key because input is not a terminal.
+var shutdownSignals = []os.Signal{syscall.SIGTERM, syscall.SIGHUP, os.Interrupt}
+
+// shutdownMsg asks the program to stop because of a signal.
+type shutdownMsg struct {
+	sig os.Signal
+}
+
+// forwardSignals sends p a shutdownMsg for the first shutdown signal, in
+// place of Bubble Tea's own handler, which quits without asking the model
+// and does not catch SIGHUP. It stops listening after that one, so a second
+// signal kills a shutdown that hangs, e.g. on a slow save. The returned
+// function stops listening.
+func forwardSignals(p *tea.Program) func() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, shutdownSignals...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigs:
+			signal.Stop(sigs)
+			p.Send(shutdownMsg{sig})
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
+
+// shutdown ends the program on a signal. Like quit it tries once more to
+// save after a failed save, but with no one left to ask, the events go to
+// the alternate file rather than to the unsaved prompt.
+func (m *MainModel) shutdown() tea.Cmd {
+	if m.saveErr != nil {
+		m.saveErr = m.saveEventsToFile()
+	}
+	if m.saveErr != nil {
+		path := alternateSavePath(m.now())
+		if err := writeEventsTo(path, m.storedEvents()); err != nil {
+			m.err = fmt.Errorf("changes not saved: %w", m.saveErr)
+		} else {
+			m.savedTo = path
+		}
+	}
+	return tea.Quit
+}
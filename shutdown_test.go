@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShutdownSavesAfterFailedSave(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	t.Setenv("HOME", t.TempDir())
+
+	now := time.Date(2026, 8, 1, 9, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.AddDate(0, 0, 2).Unix()})
+	m.saveErr = errors.New("disk full")
+	path, _ := getEventsFilePath()
+	os.Remove(path)
+	os.Mkdir(path, 0755) // a directory where the file goes, so saving fails again
+
+	model, cmd := m.Update(shutdownMsg{syscall.SIGHUP})
+	m = model.(MainModel)
+	if cmd == nil || m.state == showUnsaved {
+		t.Fatal("Expected a signal to quit without asking")
+	}
+	if m.savedTo == "" {
+		t.Fatalf("Expected the events saved elsewhere, got %v", m.err)
+	}
+	data, _ := os.ReadFile(m.savedTo)
+	events, err := decodeEvents(m.savedTo, data)
+	if err != nil || len(events) != 1 {
+		t.Errorf("Expected the event in %s, got %v (%v)", m.savedTo, events, err)
+	}
+}
+
+// startSignalChild runs this test binary again as the program in mode,
+// with its files in dir, and waits until its screen shows want.
+func startSignalChild(t *testing.T, mode, dir, want string) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestShutdownOnSignal$")
+	cmd.Env = append(os.Environ(),
+		"COUNTDOWN_SIGNAL_CHILD="+mode,
+		"XDG_CONFIG_HOME="+filepath.Join(dir, "config"),
+		"XDG_DATA_HOME="+filepath.Join(dir, "data"),
+		"HOME="+dir,
+	)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { stdin.Close() })
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan bool, 1)
+	go func() {
+		r := bufio.NewReader(stdout)
+		seen := ""
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				started <- false
+				return
+			}
+			seen += string(b)
+			if strings.Contains(seen, want) {
+				started <- true
+				break
+			}
+		}
+		// Keep reading so the child never blocks on its output.
+		for {
+			if _, err := r.ReadByte(); err != nil {
+				return
+			}
+		}
+	}()
+	select {
+	case ok := <-started:
+		if !ok {
+			cmd.Wait()
+			t.Fatal("Expected the program to start")
+		}
+	case <-time.After(10 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("Timed out waiting for the program to start")
+	}
+	return cmd
+}
+
+// waitChild waits up to ten seconds for cmd to exit.
+func waitChild(t *testing.T, cmd *exec.Cmd) error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(10 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("Timed out waiting for the program to quit")
+	}
+	return nil
+}
+
+// hungModel never quits by itself, like a shutdown stuck on a slow save.
+type hungModel struct{}
+
+func (hungModel) Init() tea.Cmd                         { return nil }
+func (m hungModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (hungModel) View() string                          { return "hung" }
+
+// TestShutdownOnSignal runs the program in a child process, as a closed
+// terminal or a service manager would stop it, and checks that it still
+// quits the way q does.
+func TestShutdownOnSignal(t *testing.T) {
+	switch os.Getenv("COUNTDOWN_SIGNAL_CHILD") {
+	case "tui":
+		tuiInput = os.Stdin
+		os.Exit(runTUI([]string{"--no-wizard"}))
+	case "hung":
+		p := tea.NewProgram(hungModel{}, tea.WithInput(struct{ io.Reader }{os.Stdin}), tea.WithoutSignalHandler())
+		forwardSignals(p)
+		p.Run()
+		os.Exit(0)
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("no SIGHUP or SIGTERM on Windows")
+	}
+	for _, sig := range []syscall.Signal{syscall.SIGHUP, syscall.SIGTERM} {
+		t.Run(sig.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			// The alternate screen opens once the program runs, with the
+			// signals caught.
+			cmd := startSignalChild(t, "tui", dir, "\x1b[?1049h")
+
+			stateFile := filepath.Join(dir, "data", appName, stateFileName)
+			if err := os.WriteFile(stateFile, []byte(`{"last_exit": 1}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := cmd.Process.Signal(sig); err != nil {
+				t.Fatal(err)
+			}
+			if err := waitChild(t, cmd); err != nil {
+				t.Fatalf("Expected a clean exit on %v, got %v", sig, err)
+			}
+
+			var st appState
+			data, err := os.ReadFile(stateFile)
+			if err == nil {
+				err = json.Unmarshal(data, &st)
+			}
+			if err != nil || st.LastExit <= 1 {
+				t.Errorf("Expected the exit time saved in %s, got %s (%v)", stateFile, data, err)
+			}
+		})
+	}
+
+	t.Run("repeated", func(t *testing.T) {
+		cmd := startSignalChild(t, "hung", t.TempDir(), "hung")
+		cmd.Process.Signal(syscall.SIGTERM)
+		// Give the first signal time to arrive before the second.
+		time.Sleep(200 * time.Millisecond)
+		cmd.Process.Signal(syscall.SIGTERM)
+		err := waitChild(t, cmd)
+		var exit *exec.ExitError
+		if !errors.As(err, &exit) {
+			t.Fatalf("Expected a second signal to kill a hung shutdown, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,598 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sideContext is what the side panel gets to know about the rest of the
+// program.
+type sideContext struct {
+	Now      time.Time
+	Events   []Event // the list as shown, sorted by time
+	Selected *Event
+	Config   Config
+	Focused  bool // the side panel gets the keys
+}
+
+// sideProvider is content for the right-hand column. Providers are values
+// like Bubble Tea models: Update returns the provider with its changes.
+type sideProvider interface {
+	// Name is how the side_panel setting refers to the provider.
+	Name() string
+	// Init starts whatever the provider loads in the background.
+	Init() tea.Cmd
+	// Update sees every message except keys, which only reach the provider
+	// on screen, and only while the side panel has focus.
+	Update(msg tea.Msg, ctx sideContext) (sideProvider, tea.Cmd)
+	// Show is called after every update while the provider is on screen at
+	// a width it can be read at, so it can load what it shows only then.
+	Show(ctx sideContext) (sideProvider, tea.Cmd)
+	// View renders the provider at most width columns wide and height lines
+	// high.
+	View(ctx sideContext, width, height int) string
+}
+
+// newSideProviders returns the providers in the order the side panel key
+// cycles through them.
+func newSideProviders() []sideProvider {
+	return []sideProvider{onThisDayPanel{}, timelinePanel{}, notesPanel{}}
+}
+
+// sideProviderIndex finds a provider by its name in the side_panel setting.
+func sideProviderIndex(name string) (int, error) {
+	var names []string
+	for i, p := range newSideProviders() {
+		if p.Name() == name {
+			return i, nil
+		}
+		names = append(names, p.Name())
+	}
+	return 0, fmt.Errorf("unknown side panel %q, use %s", name, strings.Join(names, ", "))
+}
+
+func (m MainModel) sideContext() sideContext {
+	ctx := sideContext{Now: m.now(), Config: m.config, Focused: m.panelFocus == sidePanel}
+	ctx.Events = eventsOf(m.events.Items())
+	sortEventsByTime(ctx.Events)
+	if e, ok := m.events.SelectedItem().(Event); ok {
+		ctx.Selected = &e
+	}
+	return ctx
+}
+
+// updateSides passes msg on to the providers. The slice is copied rather
+// than changed in place, since earlier copies of the model share it.
+func (m *MainModel) updateSides(msg tea.Msg) tea.Cmd {
+	ctx := m.sideContext()
+	_, isKey := msg.(tea.KeyMsg)
+	sides := make([]sideProvider, len(m.sides))
+	var cmds []tea.Cmd
+	for i, p := range m.sides {
+		if isKey && i != m.side {
+			sides[i] = p
+			continue
+		}
+		var cmd tea.Cmd
+		sides[i], cmd = p.Update(msg, ctx)
+		cmds = append(cmds, cmd)
+	}
+	m.sides = sides
+	return tea.Batch(cmds...)
+}
+
+// setSide replaces the provider with the same name as p.
+func (m *MainModel) setSide(p sideProvider) {
+	sides := append([]sideProvider(nil), m.sides...)
+	for i := range sides {
+		if sides[i].Name() == p.Name() {
+			sides[i] = p
+		}
+	}
+	m.sides = sides
+}
+
+// wiki returns the On This Day provider, which the info screen and render
+// look into.
+func (m MainModel) wiki() onThisDayPanel {
+	for _, p := range m.sides {
+		if w, ok := p.(onThisDayPanel); ok {
+			return w
+		}
+	}
+	return onThisDayPanel{}
+}
+
+// sideVisible reports whether the side panel is on screen. Narrower than
+// all three columns need, it is past the right edge of the terminal.
+func (m MainModel) sideVisible() bool {
+	switch m.state {
+	case showEvents, showGoTo:
+		if (!m.hasSelectedEvent() && !m.noMatches()) || len(m.digest) > 0 {
+			return false
+		}
+	case showTags:
+	default:
+		return false
+	}
+	return !m.compact() && m.windowWidth-6 >= minListWidth+minDetailWidth+minTimelineWidth
+}
+
+// showSide lets the provider on screen know it is seen.
+func (m *MainModel) showSide() tea.Cmd {
+	if !m.sideVisible() {
+		return nil
+	}
+	p, cmd := m.sides[m.side].Show(m.sideContext())
+	m.setSide(p)
+	return cmd
+}
+
+func (m MainModel) renderSide() string {
+	return m.timelineStyle().Render(m.sides[m.side].View(m.sideContext(), m.timelineWidth-4, max(1, m.windowHeight-6)))
+}
+
+// onThisDayPanel shows what happened on today's date in history, from
+// Wikipedia, or who was born or died on the day of the selected event.
+// Nothing is fetched until the panel is first seen, and then again only
+// once the day changed or on R.
+type onThisDayPanel struct {
+	date    string // day the events are for, empty before the first fetch
+	feed    wikiFeed
+	events  []WikiEvent
+	err     error
+	loading bool
+	retried bool // the automatic retry after a network error was used
+	skipped bool // render was run without --wiki
+	spinner spinner.Model
+	cursor  int    // highlighted entry while the panel has focus
+	status  string // outcome of opening an article
+	era     wikiEra
+	oldest  bool // oldest entries first instead of newest
+}
+
+// wikiEra limits On This Day to entries from recent years.
+type wikiEra int
+
+const (
+	eraAll wikiEra = iota
+	eraLast100
+	eraLast50
+	eraThisCentury
+	eraCount
+)
+
+// includes reports whether an entry from year is in the era.
+func (e wikiEra) includes(year int, now time.Time) bool {
+	switch e {
+	case eraLast100:
+		return year >= now.Year()-100
+	case eraLast50:
+		return year >= now.Year()-50
+	case eraThisCentury:
+		return year >= now.Year()/100*100
+	}
+	return true
+}
+
+func (e wikiEra) String() string {
+	return tr([]string{"onthisday.era_all", "onthisday.era_100", "onthisday.era_50", "onthisday.era_century"}[e])
+}
+
+// shown returns the entries in the era, sorted by year in the chosen
+// order. The fetched slice is left as it is.
+func (p onThisDayPanel) shown(now time.Time) []WikiEvent {
+	var events []WikiEvent
+	for _, e := range p.events {
+		if p.era.includes(e.Year, now) {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if p.oldest {
+			return events[i].Year < events[j].Year
+		}
+		return events[i].Year > events[j].Year
+	})
+	return events
+}
+
+// wikiRetryDelay is how long a fetch that failed on the network waits
+// before its one automatic retry.
+var wikiRetryDelay = 3 * time.Second
+
+// onThisDayRetryMsg starts the automatic retry.
+type onThisDayRetryMsg struct {
+	day  time.Time
+	feed wikiFeed
+}
+
+// isNetworkError reports whether err is one a retry may get past, such as a
+// timeout or a failed DNS lookup, rather than a reply that made no sense.
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (p onThisDayPanel) Name() string  { return "onthisday" }
+func (p onThisDayPanel) Init() tea.Cmd { return nil }
+
+func (p onThisDayPanel) Update(msg tea.Msg, ctx sideContext) (sideProvider, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OnThisDayMsg:
+		// A reply for another day or feed was overtaken by a later fetch.
+		if msg.feed != p.feed || msg.day.Format(inputTimeFormShort) != p.date {
+			return p, nil
+		}
+		if isNetworkError(msg.err) && !p.retried {
+			p.retried = true
+			retry := onThisDayRetryMsg{day: msg.day, feed: msg.feed}
+			return p, tea.Tick(wikiRetryDelay, func(time.Time) tea.Msg { return retry })
+		}
+		p.loading = false
+		p.events, p.err = sanitizeWikiEvents(msg.events), msg.err
+		p.cursor, p.status = 0, ""
+	case urlOpenedMsg:
+		if msg.err != nil {
+			p.status = HintStyle(tr("onthisday.open_it")) + "\n  " + msg.url
+		} else {
+			p.status = SuccessStyle(tr("onthisday.opened"))
+		}
+	case onThisDayRetryMsg:
+		if p.loading && msg.feed == p.feed && msg.day.Format(inputTimeFormShort) == p.date {
+			return p, fetchOnThisDay(msg.day, msg.feed)
+		}
+	case spinner.TickMsg:
+		// Dropping the tick once loaded stops the spinner.
+		if p.loading {
+			var cmd tea.Cmd
+			p.spinner, cmd = p.spinner.Update(msg)
+			return p, cmd
+		}
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, Keymap.Reload, Keymap.Retry):
+			return p.reload(ctx)
+		case key.Matches(msg, Keymap.Feed) && !p.skipped:
+			p.feed = (p.feed + 1) % feedCount
+			p.cursor, p.status = 0, ""
+			return p.fetch(p.day(ctx))
+		}
+		return p.updateEntries(msg, ctx.Now)
+	}
+	return p, nil
+}
+
+// updateEntries filters and sorts the entries, moves the highlight between
+// them and opens the article of the one it is on: its first page on Enter
+// or o, or another by number when the entry links more than one.
+func (p onThisDayPanel) updateEntries(msg tea.KeyMsg, now time.Time) (sideProvider, tea.Cmd) {
+	if p.loading || p.err != nil {
+		return p, nil
+	}
+	switch {
+	case key.Matches(msg, Keymap.Era):
+		p.era = (p.era + 1) % eraCount
+		p.cursor, p.status = 0, ""
+		return p, nil
+	case key.Matches(msg, Keymap.Order):
+		p.oldest = !p.oldest
+		p.cursor, p.status = 0, ""
+		return p, nil
+	}
+	events := p.shown(now)
+	if len(events) == 0 {
+		return p, nil
+	}
+	pages := events[p.cursor].Pages
+	switch {
+	case key.Matches(msg, Keymap.ScrollUp):
+		p.cursor = max(p.cursor-1, 0)
+		p.status = ""
+	case key.Matches(msg, Keymap.ScrollDown):
+		p.cursor = min(p.cursor+1, len(events)-1)
+		p.status = ""
+	case key.Matches(msg, Keymap.Open) && len(pages) > 0:
+		return p, openBrowser(wikiURL(pages[0].Title))
+	case len(pages) > 1 && len(msg.Runes) == 1 && msg.Runes[0] >= '1' && msg.Runes[0] <= '9':
+		if n := int(msg.Runes[0] - '1'); n < len(pages) {
+			return p, openBrowser(wikiURL(pages[n].Title))
+		}
+	}
+	return p, nil
+}
+
+// day is the day the feed is for: today, or for births and deaths the day
+// of the selected event.
+func (p onThisDayPanel) day(ctx sideContext) time.Time {
+	if p.feed != feedSelected && ctx.Selected != nil {
+		return time.Unix(ctx.Selected.Time, 0)
+	}
+	return ctx.Now
+}
+
+func (p onThisDayPanel) Show(ctx sideContext) (sideProvider, tea.Cmd) {
+	day := p.day(ctx)
+	if p.skipped || p.loading || p.date == day.Format(inputTimeFormShort) {
+		return p, nil
+	}
+	return p.fetch(day)
+}
+
+// reload fetches again on request, with a fresh automatic retry. It does
+// nothing before the first fetch, which is left to Show, or while one runs.
+func (p onThisDayPanel) reload(ctx sideContext) (sideProvider, tea.Cmd) {
+	if p.date == "" || p.loading || p.skipped {
+		return p, nil
+	}
+	return p.fetch(p.day(ctx))
+}
+
+func (p onThisDayPanel) fetch(day time.Time) (sideProvider, tea.Cmd) {
+	p.date = day.Format(inputTimeFormShort)
+	p.events, p.err, p.loading, p.retried = nil, nil, true, false
+	if p.spinner.ID() == 0 {
+		p.spinner = spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(lipgloss.NewStyle().Foreground(paletteColor(cTimelineSelected))))
+	}
+	return p, tea.Batch(fetchOnThisDay(day, p.feed), p.spinner.Tick)
+}
+
+// yearPattern finds years written out in event names and notes.
+var yearPattern = regexp.MustCompile(`\b\d{4}\b`)
+
+// mentionedYears collects the years the events mention, so entries from
+// the same years stand out.
+func mentionedYears(events []Event) map[int]bool {
+	years := map[int]bool{}
+	for _, e := range events {
+		for _, y := range yearPattern.FindAllString(e.Name+" "+e.Notes, -1) {
+			n, _ := strconv.Atoi(y)
+			years[n] = true
+		}
+	}
+	return years
+}
+
+func (p onThisDayPanel) View(ctx sideContext, width, height int) string {
+	var b strings.Builder
+
+	now := ctx.Now
+	title := trf([]string{"onthisday.title", "onthisday.births", "onthisday.deaths"}[p.feed], p.day(ctx).Format("January 2"))
+	if p.era != eraAll {
+		title += " · " + p.era.String()
+	}
+	titleStyle := TimelineTitleStyle.Width(width)
+	b.WriteString("\n" + titleStyle.Render(title) + "\n\n")
+
+	if p.skipped {
+		b.WriteString(HintStyle(tr("onthisday.skipped")) + "\n")
+		return b.String()
+	}
+
+	if p.loading || p.date == "" {
+		b.WriteString("  ")
+		if p.loading {
+			b.WriteString(p.spinner.View() + " ")
+		}
+		b.WriteString(HintStyle(tr("onthisday.loading")) + "\n")
+		return b.String()
+	}
+
+	if p.err != nil {
+		b.WriteString(ErrStyle(tr("onthisday.failed")) + "\n")
+		b.WriteString(HintStyle("  "+p.err.Error()) + "\n\n")
+		b.WriteString(HintStyle(tr("onthisday.retry")) + "\n")
+		return b.String()
+	}
+
+	if len(p.events) == 0 {
+		b.WriteString(HintStyle(tr("onthisday.none")) + "\n")
+		return b.String()
+	}
+
+	events := p.shown(now)
+	availableLines := height - 2
+	if p.era != eraAll || p.oldest {
+		order := tr("onthisday.newest")
+		if p.oldest {
+			order = tr("onthisday.oldest")
+		}
+		b.WriteString(HintStyle(trf("onthisday.count", len(events), len(p.events), order)) + "\n\n")
+		availableLines -= 2
+	}
+	if len(events) == 0 {
+		b.WriteString(HintStyle(trf("onthisday.none_in_era", p.era)) + "\n")
+		return b.String()
+	}
+	if pages := len(events[p.cursor].Pages); ctx.Focused && pages > 1 {
+		availableLines -= 1 + min(pages, 9)
+	}
+	linesPerEvent := 4
+	maxEvents := availableLines / linesPerEvent
+	if maxEvents < 3 {
+		maxEvents = 3
+	}
+
+	yearStyle := lipgloss.NewStyle().
+		Foreground(paletteColor(cTimelineSelected)).
+		Bold(true)
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: cDimmedTitleLight, Dark: cDimmedDescDark})
+
+	separatorStyle := lipgloss.NewStyle().
+		Foreground(paletteColor(cTimelineTrack))
+
+	maxTextWidth := width - 8
+	if maxTextWidth < 20 {
+		maxTextWidth = 20
+	}
+
+	mentioned := mentionedYears(ctx.Events)
+	// Scroll just far enough to keep the highlighted entry in view.
+	first := max(p.cursor-maxEvents+1, 0)
+	for i := first; i < len(events); i++ {
+		event := events[i]
+		if i >= first+maxEvents {
+			remaining := len(events) - i
+			b.WriteString(HintStyle(trf("onthisday.more", remaining)) + "\n")
+			break
+		}
+
+		yearsAgo := now.Year() - event.Year
+		yearLabel := trf("onthisday.year", event.Year, yearsAgo)
+		yearLabel = yearStyle.Render(yearLabel)
+		if mentioned[event.Year] {
+			yearLabel += TimelineNowStyle.Render(" ★")
+		}
+		highlighted := ctx.Focused && i == p.cursor
+		if highlighted {
+			b.WriteString(FocusedStyle.Render("▸ ") + yearLabel + "\n")
+		} else {
+			b.WriteString("  " + yearLabel + "\n")
+		}
+
+		text := event.Text
+
+		wrappedLines := wrapText(text, maxTextWidth)
+
+		if len(wrappedLines) > 2 {
+			wrappedLines = wrappedLines[:2]
+			lastLine := wrappedLines[1]
+			if len(lastLine) > maxTextWidth-3 {
+				lastLine = lastLine[:maxTextWidth-3]
+			}
+			wrappedLines[1] = lastLine + "..."
+		}
+
+		for _, line := range wrappedLines {
+			b.WriteString("  " + textStyle.Render(line) + "\n")
+		}
+		if highlighted && len(event.Pages) > 1 {
+			b.WriteString(HintStyle(trf("onthisday.pages", min(len(event.Pages), 9))) + "\n")
+			for n, page := range event.Pages[:min(len(event.Pages), 9)] {
+				title := ansi.Truncate(page.label, maxTextWidth-2, "…")
+				b.WriteString(HintStyle(fmt.Sprintf("  %d %s", n+1, title)) + "\n")
+			}
+		}
+
+		if i < first+maxEvents-1 && i < len(events)-1 {
+			b.WriteString(separatorStyle.Render("  ─────────") + "\n")
+		}
+	}
+
+	if p.status != "" {
+		b.WriteString("\n" + p.status + "\n")
+	}
+	b.WriteString("\n" + HintStyle(tr("onthisday.source")))
+
+	return b.String()
+}
+
+// timelinePanel shows the upcoming events on a line from now, each with a
+// bar as long as its distance relative to the farthest one shown.
+type timelinePanel struct{}
+
+func (timelinePanel) Name() string  { return "timeline" }
+func (timelinePanel) Init() tea.Cmd { return nil }
+
+func (p timelinePanel) Update(tea.Msg, sideContext) (sideProvider, tea.Cmd) { return p, nil }
+func (p timelinePanel) Show(sideContext) (sideProvider, tea.Cmd)            { return p, nil }
+
+func (timelinePanel) View(ctx sideContext, width, height int) string {
+	var b strings.Builder
+	b.WriteString("\n" + TimelineTitleStyle.Width(width).Render(tr("timeline.title")) + "\n\n")
+
+	now := ctx.Now.Unix()
+	var upcoming []Event
+	for _, e := range ctx.Events {
+		if e.Time > now {
+			upcoming = append(upcoming, e)
+		}
+	}
+	if len(upcoming) == 0 {
+		b.WriteString(HintStyle(tr("timeline.none")) + "\n")
+		return b.String()
+	}
+
+	// Title, the now marker and the end marker take six lines.
+	shown := max(1, (height-6)/4)
+	more := 0
+	if len(upcoming) > shown {
+		more = len(upcoming) - shown
+		upcoming = upcoming[:shown]
+	}
+	farthest := upcoming[len(upcoming)-1].Time - now
+	barWidth := max(10, width-4)
+
+	track := TimelineTrackStyle.Render
+	b.WriteString(TimelineNowStyle.Render("▼ "+tr("timeline.now")) + "\n")
+	b.WriteString(track("│") + "\n")
+	for _, e := range upcoming {
+		blocks := int(float64(e.Time-now) / float64(farthest) * float64(barWidth))
+		blocks = max(1, blocks)
+		if blocks > barWidth {
+			blocks = barWidth
+		}
+		bar := lipgloss.NewStyle().Foreground(paletteColor(getUrgencyColor(e.Time, ctx.Now))).Render(strings.Repeat("■", blocks))
+		b.WriteString(track("├─") + bar + track(strings.Repeat("·", barWidth-blocks)) + "\n")
+
+		name := ansi.Truncate(e.Title(), width-4, "…")
+		if ctx.Selected != nil && sameEvent(*ctx.Selected, e) {
+			b.WriteString(track("│ ") + TimelineSelectedStyle.Render("◆ "+name) + "\n")
+		} else {
+			b.WriteString(track("│ ") + BrightTextStyle("● "+name) + "\n")
+		}
+		ts := time.Unix(e.Time, 0)
+		when := ansi.Truncate(ts.Format(ctx.Config.dateLayout())+" • "+formatRelative(ts, ctx.Now), width-4, "…")
+		b.WriteString(track("│   ") + NormalTextStyle(when) + "\n")
+		b.WriteString(track("│") + "\n")
+	}
+	if more > 0 {
+		b.WriteString(track("│ ") + HintStyle(trn("timeline.more", more)) + "\n")
+	}
+	b.WriteString(track("▽ " + tr("timeline.future")))
+	return b.String()
+}
+
+// notesPanel shows the notes of the selected event, which are kept in the
+// events file.
+type notesPanel struct{}
+
+func (notesPanel) Name() string  { return "notes" }
+func (notesPanel) Init() tea.Cmd { return nil }
+
+func (p notesPanel) Update(tea.Msg, sideContext) (sideProvider, tea.Cmd) { return p, nil }
+func (p notesPanel) Show(sideContext) (sideProvider, tea.Cmd)            { return p, nil }
+
+func (notesPanel) View(ctx sideContext, width, height int) string {
+	var b strings.Builder
+	b.WriteString("\n" + TimelineTitleStyle.Width(width).Render(tr("notes.title")) + "\n\n")
+	if ctx.Selected == nil {
+		return b.String()
+	}
+	b.WriteString(BrightTextStyle(fitTitle(ctx.Selected.Title(), width)) + "\n\n")
+	if strings.TrimSpace(ctx.Selected.Notes) == "" {
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(HintStyle(tr("notes.none"))) + "\n")
+		return b.String()
+	}
+	notes := lipgloss.NewStyle().Width(width).Render(NormalTextStyle(ctx.Selected.Notes))
+	lines := strings.Split(notes, "\n")
+	if limit := height - 5; len(lines) > limit {
+		lines = append(lines[:max(1, limit-1)], HintStyle("…"))
+	}
+	b.WriteString(strings.Join(lines, "\n") + "\n")
+	return b.String()
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSideProviderIndex(t *testing.T) {
+	for i, name := range []string{"onthisday", "timeline", "notes"} {
+		if got, err := sideProviderIndex(name); err != nil || got != i {
+			t.Errorf("Expected %s at %d, got %d (%v)", name, i, got, err)
+		}
+	}
+	if _, err := sideProviderIndex("weather"); err == nil || !strings.Contains(err.Error(), "onthisday, timeline, notes") {
+		t.Errorf("Expected an error listing the providers, got %v", err)
+	}
+
+	cfg := defaultConfig()
+	cfg.SidePanel = "weather"
+	if err := cfg.validate(); err == nil || !strings.Contains(err.Error(), "side_panel") {
+		t.Errorf("Expected side_panel to be validated, got %v", err)
+	}
+}
+
+func TestSidePanelCycles(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	launch := Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix(), Notes: "Bring the slides."}
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Kickoff", Time: now.Add(-time.Hour).Unix()},
+		launch,
+		Event{Name: "Standup", Time: now.Add(72 * time.Hour).Unix()},
+	)
+	m.events.Select(1)
+	press := func(k string) {
+		t.Helper()
+		model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = model.(MainModel)
+	}
+
+	if view := stripANSI(m.View()); !strings.Contains(view, "On This Day") {
+		t.Fatalf("Expected On This Day first, got:\n%s", view)
+	}
+
+	press("p")
+	view := stripANSI(m.View())
+	for _, want := range []string{"Timeline", "▼ NOW", "◆ Launch", "● Standup", "▽ future"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the timeline, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "● Kickoff") {
+		t.Error("Expected past events to be left off the timeline")
+	}
+
+	// The fetch result reaches On This Day while another provider is shown.
+	model, _ := m.Update(OnThisDayMsg{day: now, events: []WikiEvent{{Year: 1969, Text: "Moon landing"}}})
+	m = model.(MainModel)
+	if len(m.wiki().events) != 1 {
+		t.Errorf("Expected the hidden provider to keep its data, got %v", m.wiki().events)
+	}
+
+	press("p")
+	if view := stripANSI(m.View()); !strings.Contains(view, "Notes") || !strings.Contains(view, "Bring the slides.") {
+		t.Errorf("Expected the notes of the selected event, got:\n%s", view)
+	}
+	m.events.Select(2)
+	if view := stripANSI(m.View()); !strings.Contains(view, "No notes") {
+		t.Errorf("Expected a hint for an event without notes, got:\n%s", view)
+	}
+
+	press("p")
+	if m.sides[m.side].Name() != "onthisday" {
+		t.Errorf("Expected p to wrap around to On This Day, got %s", m.sides[m.side].Name())
+	}
+}
+
+func TestSidePanelKeys(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()})
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort), err: errors.New("offline")})
+	reload := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}
+
+	model, _ := m.Update(reload)
+	m = model.(MainModel)
+	if m.wiki().loading || m.wiki().err == nil {
+		t.Error("Expected r to be left to the list while it has focus")
+	}
+
+	m.focusPanel(int(sidePanel - m.panelFocus))
+	model, cmd := m.Update(reload)
+	m = model.(MainModel)
+	if !m.wiki().loading || cmd == nil {
+		t.Error("Expected r to reload On This Day while the side panel has focus")
+	}
+}
+
+func TestRetryKeyReloadsFromList(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+	count := countFetches(t)
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()})
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort), err: errors.New("offline")})
+	if view := stripANSI(m.View()); !strings.Contains(view, "Press R to retry") {
+		t.Errorf("Expected the error to say how to retry, got:\n%s", view)
+	}
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = model.(MainModel)
+	if *count != 1 || !m.wiki().loading || cmd == nil {
+		t.Errorf("Expected R to fetch again from the list, got %d fetches", *count)
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "Loading historical events") || strings.Contains(view, "offline") {
+		t.Errorf("Expected the loading state again, got:\n%s", view)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if *count != 1 {
+		t.Errorf("Expected R to wait for the running fetch, got %d fetches", *count)
+	}
+}
+
+func TestOnThisDayRetriesNetworkErrorOnce(t *testing.T) {
+	count := countFetches(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ctx := sideContext{Now: now, Config: defaultConfig()}
+	offline := OnThisDayMsg{day: now, err: &net.DNSError{Err: "no such host", Name: "api.wikimedia.org"}}
+
+	p, _ := onThisDayPanel{}.fetch(now)
+	p, cmd := p.Update(offline, ctx)
+	if w := p.(onThisDayPanel); !w.loading || w.err != nil || cmd == nil {
+		t.Fatalf("Expected a network error to be retried, got %+v", w)
+	}
+	p, _ = p.Update(onThisDayRetryMsg{day: now}, ctx)
+	if *count != 2 {
+		t.Errorf("Expected the retry to fetch again, got %d fetches", *count)
+	}
+	p, cmd = p.Update(offline, ctx)
+	if w := p.(onThisDayPanel); w.loading || w.err == nil || cmd != nil {
+		t.Errorf("Expected the second network error to be shown, got %+v", w)
+	}
+
+	// A reply that is not JSON will not get better by asking again.
+	p, _ = p.(onThisDayPanel).reload(ctx)
+	p, cmd = p.Update(OnThisDayMsg{day: now, err: errors.New("invalid character '<'")}, ctx)
+	if w := p.(onThisDayPanel); w.loading || w.err == nil || cmd != nil {
+		t.Errorf("Expected other errors to be shown at once, got %+v", w)
+	}
+
+	// The spinner only keeps ticking while loading.
+	p, _ = p.(onThisDayPanel).reload(ctx)
+	w := p.(onThisDayPanel)
+	if _, cmd = w.Update(w.spinner.Tick(), ctx); cmd == nil {
+		t.Error("Expected the spinner to tick while loading")
+	}
+	w.loading = false
+	if _, cmd = w.Update(w.spinner.Tick(), ctx); cmd != nil {
+		t.Error("Expected the spinner to stop once loaded")
+	}
+}
+
+func TestTimelineView(t *testing.T) {
+	useLanguage(t, "en")
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var events []Event
+	for i := 1; i <= 6; i++ {
+		events = append(events, Event{Name: "Sprint " + string(rune('0'+i)), Time: now.AddDate(0, 0, 7*i).Unix()})
+	}
+	ctx := sideContext{Now: now, Events: events, Config: defaultConfig()}
+
+	view := stripANSI(timelinePanel{}.View(ctx, 40, 16))
+	if !strings.Contains(view, "Sprint 2") || strings.Contains(view, "Sprint 3") {
+		t.Errorf("Expected two events to fit in 16 lines, got:\n%s", view)
+	}
+	if !strings.Contains(view, "... and 4 more events") {
+		t.Errorf("Expected the rest to be counted, got:\n%s", view)
+	}
+	// The farthest event shown has a full bar, the one halfway half of it.
+	if !strings.Contains(view, "├─"+strings.Repeat("■", 36)+"\n") || !strings.Contains(view, "├─"+strings.Repeat("■", 18)+strings.Repeat("·", 18)) {
+		t.Errorf("Expected bars proportional to the distance, got:\n%s", view)
+	}
+
+	ctx.Events = nil
+	if view := stripANSI(timelinePanel{}.View(ctx, 40, 16)); !strings.Contains(view, "No upcoming events") {
+		t.Errorf("Expected a hint without events, got:\n%s", view)
+	}
+}
+
+// countFetches replaces the On This Day fetch with one that counts how often
+// it is started and answers at once.
+func countFetches(t *testing.T) *int {
+	count := 0
+	saved := fetchOnThisDay
+	fetchOnThisDay = func(date time.Time, feed wikiFeed) tea.Cmd {
+		count++
+		return func() tea.Msg {
+			return OnThisDayMsg{day: date, feed: feed, events: []WikiEvent{{Year: 1969, Text: "Moon landing"}}}
+		}
+	}
+	t.Cleanup(func() { fetchOnThisDay = saved })
+	return &count
+}
+
+func TestOnThisDayFetchesLazily(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	launch := []Event{{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()}}
+	startup := func(events []Event, width, height int) (MainModel, *int) {
+		count := countFetches(t)
+		m := newMainModel(defaultConfig(), events, now)
+		m.clock = func() time.Time { return now }
+		m.Init()
+		model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+		return model.(MainModel), count
+	}
+
+	tests := []struct {
+		name   string
+		events []Event
+		width  int
+		want   int
+	}{
+		{"Wide", launch, 160, 1},
+		{"Just wide enough", launch, minListWidth + minDetailWidth + minTimelineWidth + 6, 1},
+		{"Too narrow for the panel", launch, 80, 0},
+		{"No events", nil, 160, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, count := startup(tt.events, tt.width, 40); *count != tt.want {
+				t.Errorf("Expected %d fetches, got %d", tt.want, *count)
+			}
+		})
+	}
+
+	m, count := startup(launch, 80, 40)
+	update := func(msg tea.Msg) {
+		t.Helper()
+		model, cmd := m.Update(msg)
+		m = model.(MainModel)
+		// Deliver what a started fetch would answer.
+		if cmd != nil && m.wiki().loading {
+			model, _ = m.Update(OnThisDayMsg{day: now, events: []WikiEvent{{Year: 1969, Text: "Moon landing"}}})
+			m = model.(MainModel)
+		}
+	}
+	update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	if *count != 1 {
+		t.Fatalf("Expected a fetch once the window is wide enough, got %d", *count)
+	}
+	for _, width := range []int{150, 80, 170, 160} {
+		update(tea.WindowSizeMsg{Width: width, Height: 40})
+	}
+	if *count != 1 || len(m.wiki().events) != 1 {
+		t.Errorf("Expected resizing not to fetch again, got %d fetches", *count)
+	}
+
+	// Another provider on screen does not fetch on a new day; coming back does.
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	now = now.Add(24 * time.Hour)
+	update(tea.FocusMsg{})
+	if *count != 1 {
+		t.Errorf("Expected no fetch while the timeline is shown, got %d", *count)
+	}
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if *count != 2 || m.wiki().date != now.Format(inputTimeFormShort) {
+		t.Errorf("Expected one fetch for the new day, got %d for %s", *count, m.wiki().date)
+	}
+}
+
+func TestOnThisDayOpensArticles(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+
+	var opened []string
+	var openErr error
+	saved := openURL
+	openURL = func(link string) error {
+		opened = append(opened, link)
+		return openErr
+	}
+	t.Cleanup(func() { openURL = saved })
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()})
+	m.setSide(onThisDayPanel{date: now.Format(inputTimeFormShort), events: sanitizeWikiEvents([]WikiEvent{
+		{Year: 1903, Text: "First powered flight", Pages: []WikiPage{{Title: "Wright_Flyer"}}},
+		{Year: 1969, Text: "Moon landing", Pages: []WikiPage{{Title: "Apollo_11"}, {Title: "Neil_Armstrong"}}},
+	})})
+	press := func(k tea.KeyMsg) {
+		t.Helper()
+		model, cmd := m.Update(k)
+		m = model.(MainModel)
+		if cmd != nil {
+			if msg, ok := cmd().(urlOpenedMsg); ok {
+				model, _ = m.Update(msg)
+				m = model.(MainModel)
+			}
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	if strings.Contains(stripANSI(m.View()), "▸") {
+		t.Error("Expected no highlight while the list has focus")
+	}
+	press(runes("o"))
+	if len(opened) != 0 {
+		t.Errorf("Expected o to be left to the list, opened %v", opened)
+	}
+
+	// Newest first, so the moon landing comes before the flight.
+	m.focusPanel(int(sidePanel - m.panelFocus))
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "▸ 1969") || !strings.Contains(view, "1 Apollo 11") || !strings.Contains(view, "2 Neil Armstrong") {
+		t.Errorf("Expected the pages of the highlighted entry to choose from, got:\n%s", view)
+	}
+	press(runes("2"))
+	press(runes("j"))
+	if view := stripANSI(m.View()); !strings.Contains(view, "▸ 1903") || strings.Contains(view, "Neil Armstrong") {
+		t.Errorf("Expected the second entry highlighted without a chooser, got:\n%s", view)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	press(runes("3"))
+	want := []string{"https://en.wikipedia.org/wiki/Neil_Armstrong", "https://en.wikipedia.org/wiki/Wright_Flyer"}
+	if strings.Join(opened, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected %v to be opened, got %v", want, opened)
+	}
+	if !strings.Contains(stripANSI(m.View()), "Opened in your browser") {
+		t.Error("Expected the panel to confirm the opened article")
+	}
+
+	openErr = errNoBrowser
+	press(runes("o"))
+	if view := stripANSI(m.View()); !strings.Contains(view, "Open in a browser:") || !strings.Contains(view, "https://en.wikipedia.org/wiki/Wright_Flyer") {
+		t.Errorf("Expected the link to copy without a browser, got:\n%s", view)
+	}
+	press(runes("k"))
+	if view := stripANSI(m.View()); !strings.Contains(view, "▸ 1969") || strings.Contains(view, "Open in a browser:") {
+		t.Errorf("Expected moving up to clear the link, got:\n%s", view)
+	}
+}
+
+func TestOnThisDayEras(t *testing.T) {
+	useLanguage(t, "en")
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ctx := sideContext{Now: now, Config: defaultConfig(), Focused: true}
+	events := []WikiEvent{{Year: 1815, Text: "Waterloo"}, {Year: 2004, Text: "Launch"}, {Year: 1969, Text: "Moon"}, {Year: 1989, Text: "Wall"}, {Year: 1926, Text: "Television"}}
+	var p sideProvider = onThisDayPanel{date: now.Format(inputTimeFormShort), events: events}
+	years := func() string {
+		var s []string
+		for _, e := range p.(onThisDayPanel).shown(now) {
+			s = append(s, fmt.Sprint(e.Year))
+		}
+		return strings.Join(s, " ")
+	}
+	press := func(k string) {
+		t.Helper()
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}, ctx)
+	}
+
+	tests := []struct {
+		era   string
+		years string
+	}{
+		{"last 100 years", "2004 1989 1969 1926"},
+		{"last 50 years", "2004 1989"},
+		{"this century", "2004"},
+		{"", "2004 1989 1969 1926 1815"},
+	}
+	if got := years(); got != "2004 1989 1969 1926 1815" {
+		t.Errorf("Expected all entries newest first, got %s", got)
+	}
+	for _, tt := range tests {
+		press("f")
+		if got := years(); got != tt.years {
+			t.Errorf("Expected %s for %q, got %s", tt.years, tt.era, got)
+		}
+		view := stripANSI(p.View(ctx, 60, 40))
+		if tt.era != "" && !strings.Contains(view, "On This Day - March 1 · "+tt.era) {
+			t.Errorf("Expected %q in the title, got:\n%s", tt.era, view)
+		}
+	}
+
+	press("f")
+	press("O")
+	if got := years(); got != "1926 1969 1989 2004" {
+		t.Errorf("Expected oldest first, got %s", got)
+	}
+	if view := stripANSI(p.View(ctx, 60, 40)); !strings.Contains(view, "4 of 5, oldest first") || !strings.Contains(view, "▸ 1926") {
+		t.Errorf("Expected the count and order under the title, got:\n%s", view)
+	}
+	if events[0].Year != 1815 || len(p.(onThisDayPanel).events) != 5 {
+		t.Error("Expected the fetched entries to be left as they are")
+	}
+
+	p = onThisDayPanel{date: now.Format(inputTimeFormShort), events: events[:1], era: eraLast50}
+	if view := stripANSI(p.View(ctx, 60, 40)); !strings.Contains(view, "Nothing from the last 50 years") {
+		t.Errorf("Expected a hint for an empty era, got:\n%s", view)
+	}
+}
+
+func TestMentionedYears(t *testing.T) {
+	years := mentionedYears([]Event{
+		{Name: "Class of 1999 reunion"},
+		{Name: "Trip", Notes: "Booked in 2025, flight 12345"},
+	})
+	if len(years) != 2 || !years[1999] || !years[2025] {
+		t.Errorf("Expected 1999 and 2025, got %v", years)
+	}
+}
+
+func TestOnThisDayBirthsAndDeaths(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+
+	type fetch struct {
+		day  string
+		feed wikiFeed
+	}
+	var fetches []fetch
+	saved := fetchOnThisDay
+	fetchOnThisDay = func(date time.Time, feed wikiFeed) tea.Cmd {
+		fetches = append(fetches, fetch{date.Format(inputTimeFormShort), feed})
+		return func() tea.Msg { return nil }
+	}
+	t.Cleanup(func() { fetchOnThisDay = saved })
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	moon := time.Date(2026, 7, 20, 20, 17, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Moon landing party", Time: moon.Unix(), Notes: "Like in 1969"},
+		Event{Name: "Trip", Time: moon.AddDate(0, 1, 0).Unix()},
+	)
+	m.windowWidth = 160
+	m.calculateWidths()
+	m.events.Select(0)
+	m.focusPanel(int(sidePanel - m.panelFocus))
+	update := func(msg tea.Msg) {
+		t.Helper()
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if len(fetches) != 1 || fetches[0] != (fetch{"2026-07-20", feedBirths}) {
+		t.Fatalf("Expected births for the selected event's day, got %v", fetches)
+	}
+	update(OnThisDayMsg{day: now, feed: feedSelected, events: []WikiEvent{{Year: 1066, Text: "Hastings"}}})
+	if !m.wiki().loading {
+		t.Error("Expected a reply for another feed to be dropped")
+	}
+	update(OnThisDayMsg{day: moon, feed: feedBirths, events: []WikiEvent{
+		{Year: 1969, Text: "Somebody, astronaut's child"},
+		{Year: 1950, Text: "Somebody else"},
+	}})
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "Born on July 20") {
+		t.Errorf("Expected births in the title, got:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "1969 (") != strings.Contains(line, "★") {
+			t.Errorf("Expected only 1969 to be marked, got %q", line)
+		}
+	}
+
+	// Births follow the selection.
+	m.events.Select(1)
+	update(tea.FocusMsg{})
+	if len(fetches) != 2 || fetches[1] != (fetch{"2026-08-20", feedBirths}) {
+		t.Errorf("Expected births for the newly selected day, got %v", fetches)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if len(fetches) != 4 || fetches[2].feed != feedDeaths || fetches[3] != (fetch{"2026-03-01", feedSelected}) {
+		t.Errorf("Expected deaths, then today's selection, got %v", fetches)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Solar calculations, all local: solstices and equinoxes after Meeus,
+// Astronomical Algorithms ch. 27, and sunrise and sunset with NOAA's solar
+// calculator equations. Both are good to a minute or two.
+
+func deg2rad(d float64) float64 { return d * math.Pi / 180 }
+func rad2deg(r float64) float64 { return r * 180 / math.Pi }
+
+// julianDay converts t to a Julian day number.
+func julianDay(t time.Time) float64 {
+	return float64(t.UTC().Unix())/86400 + 2440587.5
+}
+
+func fromJulianDay(jd float64) time.Time {
+	return time.Unix(int64(math.Round((jd-2440587.5)*86400)), 0).UTC()
+}
+
+// deltaT is the difference between the dynamical time the solstice
+// formulas give and UT, close enough for this century.
+const deltaT = 69 * time.Second
+
+// seasonTerms are the periodic terms of Meeus table 27.C: A, B and C.
+var seasonTerms = [24][3]float64{
+	{485, 324.96, 1934.136}, {203, 337.23, 32964.467}, {199, 342.08, 20.186},
+	{182, 27.85, 445267.112}, {156, 73.14, 45036.886}, {136, 171.52, 22518.443},
+	{77, 222.54, 65928.934}, {74, 296.72, 3034.906}, {70, 243.58, 9037.513},
+	{58, 119.81, 33718.147}, {52, 297.17, 150.678}, {50, 21.02, 2281.226},
+	{45, 247.54, 29929.562}, {44, 325.15, 31555.956}, {29, 60.93, 4443.417},
+	{18, 155.12, 67555.328}, {17, 288.79, 4562.452}, {16, 198.04, 62894.029},
+	{14, 199.76, 31436.921}, {12, 95.39, 14577.848}, {12, 287.11, 31931.756},
+	{12, 320.81, 34777.259}, {9, 227.73, 1222.114}, {8, 15.45, 16859.074},
+}
+
+// season is a solstice or equinox, numbered from the March equinox.
+type season int
+
+const (
+	marchEquinox season = iota
+	juneSolstice
+	septemberEquinox
+	decemberSolstice
+)
+
+// seasonStart returns the moment of s in year, for years 1000 to 3000.
+func seasonStart(year int, s season) time.Time {
+	y := (float64(year) - 2000) / 1000
+	var jde0 float64
+	switch s {
+	case marchEquinox:
+		jde0 = 2451623.80984 + 365242.37404*y + 0.05169*y*y - 0.00411*y*y*y - 0.00057*y*y*y*y
+	case juneSolstice:
+		jde0 = 2451716.56767 + 365241.62603*y + 0.00325*y*y + 0.00888*y*y*y - 0.00030*y*y*y*y
+	case septemberEquinox:
+		jde0 = 2451810.21715 + 365242.01767*y - 0.11575*y*y + 0.00337*y*y*y + 0.00078*y*y*y*y
+	default:
+		jde0 = 2451900.05952 + 365242.74049*y - 0.06223*y*y - 0.00823*y*y*y + 0.00032*y*y*y*y
+	}
+	t := (jde0 - 2451545.0) / 36525
+	w := deg2rad(35999.373*t - 2.47)
+	dl := 1 + 0.0334*math.Cos(w) + 0.0007*math.Cos(2*w)
+	sum := 0.0
+	for _, term := range seasonTerms {
+		sum += term[0] * math.Cos(deg2rad(term[1]+term[2]*t))
+	}
+	return fromJulianDay(jde0 + 0.00001*sum/dl).Add(-deltaT)
+}
+
+// nextSeason returns the first solstice or equinox of the given kind
+// after now.
+func nextSeason(now time.Time, kinds ...season) (time.Time, season) {
+	for year := now.Year(); ; year++ {
+		for _, s := range kinds {
+			if t := seasonStart(year, s); t.After(now) {
+				return t, s
+			}
+		}
+	}
+}
+
+// sunTimes returns sunrise and sunset on the UTC calendar day of day at
+// lat and lon, in degrees north and east. ok is false on days the sun does
+// not rise or does not set there.
+func sunTimes(day time.Time, lat, lon float64) (rise, set time.Time, ok bool) {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	// Evaluate the sun at local solar noon, which is close enough to both.
+	noon := midnight.Add(time.Duration((12 - lon/15) * float64(time.Hour)))
+	t := (julianDay(noon) - 2451545) / 36525
+
+	l0 := math.Mod(280.46646+t*(36000.76983+t*0.0003032), 360)
+	m := 357.52911 + t*(35999.05029-0.0001537*t)
+	e := 0.016708634 - t*(0.000042037+0.0000001267*t)
+	c := math.Sin(deg2rad(m))*(1.914602-t*(0.004817+0.000014*t)) +
+		math.Sin(deg2rad(2*m))*(0.019993-0.000101*t) +
+		math.Sin(deg2rad(3*m))*0.000289
+	omega := deg2rad(125.04 - 1934.136*t)
+	lambda := deg2rad(l0 + c - 0.00569 - 0.00478*math.Sin(omega))
+	eps0 := 23 + (26+(21.448-t*(46.815+t*(0.00059-t*0.001813)))/60)/60
+	eps := deg2rad(eps0 + 0.00256*math.Cos(omega))
+	decl := math.Asin(math.Sin(eps) * math.Sin(lambda))
+
+	y := math.Tan(eps/2) * math.Tan(eps/2)
+	l0r, mr := deg2rad(l0), deg2rad(m)
+	eqTime := 4 * rad2deg(y*math.Sin(2*l0r)-2*e*math.Sin(mr)+4*e*y*math.Sin(mr)*math.Cos(2*l0r)-
+		0.5*y*y*math.Sin(4*l0r)-1.25*e*e*math.Sin(2*mr))
+
+	latr := deg2rad(lat)
+	cosHA := math.Cos(deg2rad(90.833))/(math.Cos(latr)*math.Cos(decl)) - math.Tan(latr)*math.Tan(decl)
+	if cosHA < -1 || cosHA > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	ha := rad2deg(math.Acos(cosHA))
+
+	solarNoon := 720 - 4*lon - eqTime // minutes after midnight UTC
+	at := func(minutes float64) time.Time {
+		return midnight.Add(time.Duration(minutes * float64(time.Minute))).Round(time.Second)
+	}
+	return at(solarNoon - 4*ha), at(solarNoon + 4*ha), true
+}
+
+// nextSunEvents returns the first sunrise and the first sunset after now,
+// either of them zero when the sun stays up or down for the next days.
+func nextSunEvents(now time.Time, lat, lon float64) (rise, set time.Time) {
+	for d := -1; d <= 2; d++ {
+		r, s, ok := sunTimes(now.UTC().AddDate(0, 0, d), lat, lon)
+		if !ok {
+			continue
+		}
+		if rise.IsZero() && r.After(now) {
+			rise = r
+		}
+		if set.IsZero() && s.After(now) {
+			set = s
+		}
+	}
+	return rise, set
+}
+
+// skyEvents returns the virtual events for the next solstice and equinox
+// and, when the config has a location, the next sunrise and sunset.
+func skyEvents(now time.Time, cfg Config) []Event {
+	solstice, s := nextSeason(now, juneSolstice, decemberSolstice)
+	equinox, q := nextSeason(now, marchEquinox, septemberEquinox)
+	events := []Event{
+		{Name: tr(seasonMessages[s]), Time: solstice.Unix(), Virtual: true},
+		{Name: tr(seasonMessages[q]), Time: equinox.Unix(), Virtual: true},
+	}
+	if cfg.Latitude != nil && cfg.Longitude != nil {
+		rise, set := nextSunEvents(now, *cfg.Latitude, *cfg.Longitude)
+		if !rise.IsZero() {
+			events = append(events, Event{Name: tr("sky.sunrise"), Time: rise.Unix(), Virtual: true})
+		}
+		if !set.IsZero() {
+			events = append(events, Event{Name: tr("sky.sunset"), Time: set.Unix(), Virtual: true})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+	return events
+}
+
+var seasonMessages = map[season]string{
+	marchEquinox:     "sky.march_equinox",
+	juneSolstice:     "sky.june_solstice",
+	septemberEquinox: "sky.september_equinox",
+	decemberSolstice: "sky.december_solstice",
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// nearTime reports whether got is within two minutes of want.
+func nearTime(got, want time.Time) bool {
+	d := got.Sub(want)
+	return d > -2*time.Minute && d < 2*time.Minute
+}
+
+func TestSeasonStart(t *testing.T) {
+	utc := func(month time.Month, day, hour, min int, year int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+	// Reference times from the US Naval Observatory.
+	tests := []struct {
+		year     int
+		season   season
+		expected time.Time
+	}{
+		{2024, marchEquinox, utc(3, 20, 3, 6, 2024)},
+		{2024, juneSolstice, utc(6, 20, 20, 51, 2024)},
+		{2024, septemberEquinox, utc(9, 22, 12, 44, 2024)},
+		{2024, decemberSolstice, utc(12, 21, 9, 21, 2024)},
+		{2026, marchEquinox, utc(3, 20, 14, 46, 2026)},
+		{2026, juneSolstice, utc(6, 21, 8, 24, 2026)},
+		{2026, septemberEquinox, utc(9, 23, 0, 5, 2026)},
+		{2026, decemberSolstice, utc(12, 21, 20, 50, 2026)},
+	}
+	for _, tt := range tests {
+		if got := seasonStart(tt.year, tt.season); !nearTime(got, tt.expected) {
+			t.Errorf("seasonStart(%d, %d): expected %s, got %s", tt.year, tt.season, tt.expected, got)
+		}
+	}
+}
+
+func TestSunTimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		day      time.Time
+		lat, lon float64
+		rise     time.Time
+		set      time.Time
+	}{
+		// 04:43 and 21:21 BST.
+		{"London midsummer", time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), 51.5074, -0.1278,
+			time.Date(2026, 6, 21, 3, 43, 0, 0, time.UTC), time.Date(2026, 6, 21, 20, 21, 0, 0, time.UTC)},
+		// 05:41 and 20:05 AEDT; sunrise is the evening before in UTC.
+		{"Sydney midsummer", time.Date(2026, 12, 21, 0, 0, 0, 0, time.UTC), -33.8688, 151.2093,
+			time.Date(2026, 12, 20, 18, 41, 0, 0, time.UTC), time.Date(2026, 12, 21, 9, 5, 0, 0, time.UTC)},
+		// 06:59 and 19:08 EDT.
+		{"New York equinox", time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), 40.7128, -74.0060,
+			time.Date(2026, 3, 20, 10, 59, 0, 0, time.UTC), time.Date(2026, 3, 20, 23, 8, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rise, set, ok := sunTimes(tt.day, tt.lat, tt.lon)
+			if !ok || !nearTime(rise, tt.rise) || !nearTime(set, tt.set) {
+				t.Errorf("Expected %s to %s, got %s to %s (%v)", tt.rise, tt.set, rise, set, ok)
+			}
+		})
+	}
+
+	if _, _, ok := sunTimes(time.Date(2026, 6, 21, 0, 0, 0, 0, time.UTC), 69.65, 18.96); ok {
+		t.Error("Expected no sunset in Tromsø at midsummer")
+	}
+}
+
+func TestSkyEvents(t *testing.T) {
+	useLanguage(t, "en")
+	// An hour after sunset in London.
+	now := time.Date(2026, 6, 21, 21, 21, 0, 0, time.UTC)
+
+	cfg := defaultConfig()
+	events := skyEvents(now, cfg)
+	if got := storedNames(events); got != "September equinox,December solstice" {
+		t.Errorf("Expected only the seasons without a location, got %s", got)
+	}
+
+	lat, lon := 51.5074, -0.1278
+	cfg.Latitude, cfg.Longitude = &lat, &lon
+	events = skyEvents(now, cfg)
+	if got := storedNames(events); got != "Sunrise,Sunset,September equinox,December solstice" {
+		t.Fatalf("Expected the sun events first, got %s", got)
+	}
+	if set := time.Unix(events[1].Time, 0).UTC(); set.Day() != 22 {
+		t.Errorf("Expected tomorrow's sunset after today's, got %s", set)
+	}
+	for _, e := range events {
+		if !e.Virtual {
+			t.Errorf("Expected %s to be virtual", e.Name)
+		}
+	}
+
+	lat = 69.65
+	if got := storedNames(skyEvents(now, cfg)); got != "September equinox,December solstice" {
+		t.Errorf("Expected no sun events under the midnight sun, got %s", got)
+	}
+}
+
+func TestPassedSunsetGivesWayToNext(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 6, 21, 20, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now)
+	lat, lon := 51.5074, -0.1278
+	m.config.SkyEvents = true
+	m.config.Latitude, m.config.Longitude = &lat, &lon
+	m.refreshEvents()
+	sunset := func() time.Time {
+		for _, e := range m.allEvents() {
+			if e.Name == "Sunset" {
+				return time.Unix(e.Time, 0).UTC()
+			}
+		}
+		t.Fatal("Expected a sunset event")
+		return time.Time{}
+	}
+	if sunset().Day() != 21 {
+		t.Fatalf("Expected today's sunset, got %s", sunset())
+	}
+
+	now = now.Add(30 * time.Minute)
+	if !m.virtualPassed(now) {
+		t.Fatal("Expected the passed sunset to be noticed")
+	}
+	m.refreshEvents()
+	if sunset().Day() != 22 {
+		t.Errorf("Expected tomorrow's sunset, got %s", sunset())
+	}
+}
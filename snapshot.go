@@ -0,0 +1,262 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+const (
+	svgCellWidth  = 8.4
+	svgLineHeight = 18
+	svgForeground = "#DDDDDD"
+	svgBackground = "#1E1E1E"
+)
+
+func runSnapshot(args []string) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	out := fs.String("out", "", "write the ANSI-colored detail view to this file")
+	svg := fs.String("svg", "", "write the detail view as an SVG image to this file")
+	plain := fs.Bool("plain", false, "strip colors from --out or stdout output")
+	width := fs.Int("width", 50, "width of the detail view in columns")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: countdown snapshot <event name> [--out file | --svg file] [--width n] [--plain]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	// Allow flags after the event name as well as before it.
+	name := fs.Arg(0)
+	if fs.NArg() > 1 {
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return 2
+		}
+	}
+	if name == "" {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return 2
+	}
+	setListFormat(cfg.ListFormat)
+	setLanguage(cfg.Language)
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return 1
+	}
+	event, ok := findEventByName(events, name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "snapshot: no event named %q\n", name)
+		return 1
+	}
+
+	profile := termenv.TrueColor
+	if *plain && *svg == "" {
+		profile = termenv.Ascii
+	}
+	rendered := renderSnapshot(event, cfg, *width, profile)
+	if profile == termenv.Ascii {
+		rendered = stripANSI(rendered)
+	}
+
+	switch {
+	case *svg != "":
+		err = os.WriteFile(*svg, []byte(ansiToSVG(rendered)), 0644)
+	case *out != "":
+		err = os.WriteFile(*out, []byte(rendered+"\n"), 0644)
+	default:
+		fmt.Println(rendered)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// findEventByName matches case-insensitively, preferring an exact name over
+// the first event whose name contains the query.
+func findEventByName(events []Event, name string) (Event, bool) {
+	query := strings.ToLower(name)
+	for _, e := range events {
+		if strings.ToLower(e.Name) == query {
+			return e, true
+		}
+	}
+	for _, e := range events {
+		if strings.Contains(strings.ToLower(e.Name), query) {
+			return e, true
+		}
+	}
+	return Event{}, false
+}
+
+// renderSnapshot renders the detail view without a running program, using
+// a fixed color profile so output does not depend on the terminal.
+func renderSnapshot(event Event, cfg Config, width int, profile termenv.Profile) string {
+	return renderSnapshotAt(MainModel{detailWidth: width, config: cfg}, event, profile)
+}
+
+func renderSnapshotAt(m MainModel, event Event, profile termenv.Profile) string {
+	return withColorProfile(profile, func() string { return m.renderDetails(event) })
+}
+
+// withColorProfile runs render with a fixed color profile and a dark
+// background, so output does not depend on the terminal.
+func withColorProfile(profile termenv.Profile, render func() string) string {
+	savedProfile := lipgloss.ColorProfile()
+	savedDark := lipgloss.HasDarkBackground()
+	lipgloss.SetColorProfile(profile)
+	lipgloss.SetHasDarkBackground(true)
+	defer func() {
+		lipgloss.SetColorProfile(savedProfile)
+		lipgloss.SetHasDarkBackground(savedDark)
+	}()
+
+	return render()
+}
+
+type ansiSegment struct {
+	text string
+	fg   string
+	bg   string
+	bold bool
+}
+
+// parseANSILine splits a line into runs of uniformly styled text. Only the
+// SGR codes lipgloss emits for true color output are understood.
+func parseANSILine(line string) []ansiSegment {
+	var segments []ansiSegment
+	var cur ansiSegment
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			cur.text = text.String()
+			segments = append(segments, cur)
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		if line[i] != '\x1b' || i+1 >= len(line) || line[i+1] != '[' {
+			text.WriteByte(line[i])
+			continue
+		}
+		end := i + 2
+		for end < len(line) && (line[end] < 0x40 || line[end] > 0x7e) {
+			end++
+		}
+		if end >= len(line) {
+			break
+		}
+		if line[end] == 'm' {
+			flush()
+			cur = applySGR(cur, line[i+2:end])
+		}
+		i = end
+	}
+	flush()
+	return segments
+}
+
+func applySGR(seg ansiSegment, params string) ansiSegment {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		switch codes[i] {
+		case "", "0":
+			seg = ansiSegment{}
+		case "1":
+			seg.bold = true
+		case "22":
+			seg.bold = false
+		case "39":
+			seg.fg = ""
+		case "49":
+			seg.bg = ""
+		case "38", "48":
+			if i+4 < len(codes) && codes[i+1] == "2" {
+				r, _ := strconv.Atoi(codes[i+2])
+				g, _ := strconv.Atoi(codes[i+3])
+				b, _ := strconv.Atoi(codes[i+4])
+				color := fmt.Sprintf("#%02X%02X%02X", r, g, b)
+				if codes[i] == "38" {
+					seg.fg = color
+				} else {
+					seg.bg = color
+				}
+				i += 4
+			}
+		}
+	}
+	return seg
+}
+
+func stripANSI(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		var b strings.Builder
+		for _, seg := range parseANSILine(line) {
+			b.WriteString(seg.text)
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ansiToSVG draws styled terminal output as text over colored rectangles.
+func ansiToSVG(rendered string) string {
+	lines := strings.Split(rendered, "\n")
+	cols := 0
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > cols {
+			cols = w
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%d" font-family="monospace" font-size="14">`+"\n",
+		float64(cols)*svgCellWidth, len(lines)*svgLineHeight)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgBackground)
+
+	for row, line := range lines {
+		col := 0
+		y := row * svgLineHeight
+		for _, seg := range parseANSILine(line) {
+			w := runewidth.StringWidth(seg.text)
+			x := float64(col) * svgCellWidth
+			if seg.bg != "" {
+				fmt.Fprintf(&b, `<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"/>`+"\n",
+					x, y, float64(w)*svgCellWidth, svgLineHeight, seg.bg)
+			}
+			if strings.TrimSpace(seg.text) != "" {
+				fg := seg.fg
+				if fg == "" {
+					fg = svgForeground
+				}
+				weight := ""
+				if seg.bold {
+					weight = ` font-weight="bold"`
+				}
+				fmt.Fprintf(&b, `<text x="%.1f" y="%d" fill="%s"%s xml:space="preserve">%s</text>`+"\n",
+					x, y+svgLineHeight-5, fg, weight, html.EscapeString(seg.text))
+			}
+			col += w
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("Output does not match %s (run with -update to accept):\n%s", path, got)
+	}
+}
+
+// withFixedLocal pins time.Local so rendered dates and zone names are stable.
+func withFixedLocal(t *testing.T) {
+	saved := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = saved })
+}
+
+func TestRenderSnapshotGolden(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	event := Event{Name: "Launch", Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix()}
+
+	m := MainModel{detailWidth: 50, config: defaultConfig(), clock: func() time.Time { return now }}
+	got := stripANSI(renderSnapshotAt(m, event, termenv.Ascii))
+	assertGolden(t, "snapshot_detail.golden", got)
+}
+
+func TestAnsiToSVG(t *testing.T) {
+	rendered := "\x1b[1;38;2;255;0;0;48;2;0;0;255mHi\x1b[0m <there>\nplain"
+	svg := ansiToSVG(rendered)
+
+	for _, want := range []string{
+		`<rect x="0.0" y="0" width="16.8" height="18" fill="#0000FF"/>`,
+		`fill="#FF0000" font-weight="bold" xml:space="preserve">Hi</text>`,
+		`fill="#DDDDDD" xml:space="preserve"> &lt;there&gt;</text>`,
+		`<text x="0.0" y="31" fill="#DDDDDD" xml:space="preserve">plain</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Expected SVG to contain %s, got:\n%s", want, svg)
+		}
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	got := stripANSI("\x1b[1;38;2;1;2;3mbold\x1b[0m text\n\x1b[;mnext\x1b[0m")
+	if got != "bold text\nnext" {
+		t.Errorf("Expected 'bold text\\nnext', got %q", got)
+	}
+}
+
+func TestFindEventByName(t *testing.T) {
+	events := []Event{{Name: "Product Launch"}, {Name: "Launch"}}
+
+	if e, ok := findEventByName(events, "launch"); !ok || e.Name != "Launch" {
+		t.Errorf("Expected exact match 'Launch', got '%s'", e.Name)
+	}
+	if e, ok := findEventByName(events, "product"); !ok || e.Name != "Product Launch" {
+		t.Errorf("Expected substring match 'Product Launch', got '%s'", e.Name)
+	}
+	if _, ok := findEventByName(events, "missing"); ok {
+		t.Error("Expected no match")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shortGap is the time from one event to the next in the list in its
+// largest unit, e.g. "+3d", or "-5h" when the list is not in date order.
+func shortGap(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	for _, u := range []string{"d", "h"} {
+		if unit := leadTimeUnits[u]; d >= unit {
+			return fmt.Sprintf("%s%d%s", sign, d/unit, u)
+		}
+	}
+	return fmt.Sprintf("%s%dm", sign, d/time.Minute)
+}
+
+// listGap is the gap since the event above the one at index in the list as
+// shown, sorted and filtered, in the spacing display mode. The first event
+// and the first of each section have none.
+func (d eventDelegate) listGap(m list.Model, index int) (string, bool) {
+	if d.format.display != displayGaps || index == 0 {
+		return "", false
+	}
+	items := m.VisibleItems()
+	if index >= len(items) {
+		return "", false
+	}
+	prev, ok := items[index-1].(Event)
+	e, ok2 := items[index].(Event)
+	if !ok || !ok2 {
+		return "", false
+	}
+	return shortGap(time.Unix(e.Time, 0).Sub(time.Unix(prev.Time, 0))), true
+}
+
+// renderEvent draws an event with the default delegate, its gap after the
+// description when there is room for it, so that narrow lists do not cut
+// off the countdown instead.
+func (d eventDelegate) renderEvent(w io.Writer, m list.Model, index int, row listRow) {
+	if gap, ok := d.listGap(m, index); ok {
+		desc := row.desc + "  " + gap
+		if lipgloss.Width(desc) <= m.Width()-d.Styles.NormalDesc.GetHorizontalFrameSize() {
+			row.desc = desc
+		}
+	}
+	d.DefaultDelegate.Render(w, m, index, row)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestShortGap(t *testing.T) {
+	tests := []struct {
+		gap  time.Duration
+		want string
+	}{
+		{3*24*time.Hour + 5*time.Hour, "+3d"},
+		{5*time.Hour + 59*time.Minute, "+5h"},
+		{20 * time.Minute, "+20m"},
+		{0, "+0m"},
+		{-2 * 24 * time.Hour, "-2d"},
+	}
+	for _, tt := range tests {
+		if got := shortGap(tt.gap); got != tt.want {
+			t.Errorf("shortGap(%v) = %q, want %q", tt.gap, got, tt.want)
+		}
+	}
+}
+
+func TestSpacingGaps(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Dentist", Time: now.AddDate(0, 0, 1).Unix()},
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 4).Unix()},
+		Event{Name: "Party", Time: now.AddDate(0, 0, 4).Add(5 * time.Hour).Unix()},
+	)
+	resize := func(width int) string {
+		t.Helper()
+		model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 40})
+		m = model.(MainModel)
+		return stripANSI(m.View())
+	}
+
+	if view := resize(58); strings.Contains(view, "+3d") {
+		t.Errorf("Expected no gaps before the spacing mode, got:\n%s", view)
+	}
+	m.config.DisplayMode = displayGaps.String()
+	view := resize(58)
+	if !strings.Contains(view, "+3d") || !strings.Contains(view, "+5h") {
+		t.Errorf("Expected the gaps to the events above, got:\n%s", view)
+	}
+	if strings.Count(view, "  +") != 2 {
+		t.Errorf("Expected no gap on the first event, got:\n%s", view)
+	}
+
+	// Sorted newest first, each event comes before the one above it.
+	m.config.SortOrder = "newest"
+	m.relist()
+	if view := resize(58); !strings.Contains(view, "-5h") || !strings.Contains(view, "-3d") {
+		t.Errorf("Expected the gaps worked out again after sorting, got:\n%s", view)
+	}
+
+	if view := resize(10); strings.Contains(view, "-5h") {
+		t.Errorf("Expected no gaps in a narrow list, got:\n%s", view)
+	}
+}
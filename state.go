@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const stateFileName = "state.json"
+
+// appState is what the program remembers between runs, kept apart from the
+// events and config the user edits.
+type appState struct {
+	LastExit        int64    `json:"last_exit,omitempty"`        // Unix time the program last stopped showing events
+	CollapsedGroups []string `json:"collapsed_groups,omitempty"` // sections collapsed in the list, "" for the default one
+}
+
+func getStateFilePath() (string, error) {
+	dir, err := appDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, stateFileName), nil
+}
+
+// loadState reads the state file. A missing or unreadable file gives the
+// zero state, as on a first run; nothing in it is worth refusing to start.
+func loadState() appState {
+	var st appState
+	stateFile, err := getStateFilePath()
+	if err != nil {
+		return st
+	}
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "warning: failed to read %s: %v\n", stateFile, err)
+		}
+		return st
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring %s: %v\n", stateFile, err)
+		return appState{}
+	}
+	return st
+}
+
+func saveState(st appState) error {
+	stateFile, err := getStateFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(stateFile, data); err != nil {
+		return fmt.Errorf("failed to save %s: %w", stateFile, err)
+	}
+	return nil
+}
+
+// updateState reads, changes and saves the state while holding its lock,
+// so that two countdowns saving at once do not drop each other's change.
+func updateState(change func(*appState)) error {
+	stateFile, err := getStateFilePath()
+	if err != nil {
+		return err
+	}
+	unlock, err := lockFile(stateFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	st := loadState()
+	change(&st)
+	return saveState(st)
+}
+
+// lockStale is how old a lock is before it is taken to be left behind by
+// a countdown that died holding it. Nothing holds one for longer than a
+// read and a write.
+const lockStale = 10 * time.Second
+
+// lockTimeout is how long lockFile waits for another holder.
+var lockTimeout = 2 * time.Second
+
+// lockFile takes the lock on path by creating path.lock, which fails while
+// it exists, and returns the function that releases it.
+func lockFile(path string) (func(), error) {
+	lock := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > lockStale {
+			os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked; if no other countdown is running, remove %s", path, lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateState(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	// Each update keeps the largest value seen, so a lost update shows as
+	// a smaller one.
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(i int64) {
+			defer wg.Done()
+			err := updateState(func(st *appState) {
+				if i > st.LastExit {
+					st.LastExit = i
+				}
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+	if st := loadState(); st.LastExit != 20 {
+		t.Errorf("Expected every update applied, got %d", st.LastExit)
+	}
+}
+
+func TestLockFile(t *testing.T) {
+	defer func(d time.Duration) { lockTimeout = d }(lockTimeout)
+	lockTimeout = 100 * time.Millisecond
+	path := filepath.Join(t.TempDir(), "state.json")
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := lockFile(path); err == nil {
+		t.Fatal("Expected a held lock to time out")
+	}
+	if waited := time.Since(start); waited < lockTimeout {
+		t.Errorf("Expected to wait %s for the lock, waited %s", lockTimeout, waited)
+	}
+	unlock()
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("Expected the lock removed on unlock, got %v", err)
+	}
+
+	// A lock left behind by a crash is taken over once stale.
+	os.WriteFile(path+".lock", nil, 0644)
+	old := time.Now().Add(-2 * lockStale)
+	os.Chtimes(path+".lock", old, old)
+	unlock, err = lockFile(path)
+	if err != nil {
+		t.Fatalf("Expected a stale lock taken over, got %v", err)
+	}
+	unlock()
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// statsMonths is how many months ahead the stats screen charts.
+const statsMonths = 12
+
+// eventStats is the overview of the events file on the stats screen.
+type eventStats struct {
+	Total    int
+	Start    time.Time               // first day of the current month
+	Months   [statsMonths]int        // events in each month from Start on
+	Tags     []tagGroup              // events per tag, untagged last
+	Buckets  [len(urgencyColors)]int // events per urgencyBucket
+	Farthest *Event                  // upcoming event farthest away
+	AvgDays  float64                 // mean days until the upcoming events
+	Upcoming int
+	Past     int // past events still in the file
+	// First and Last bound the upcoming events, and the longest wait
+	// between two of them starts at QuietAfter. All are nil with fewer
+	// than two upcoming events.
+	First, Last *Event
+	QuietAfter  *Event
+	QuietGap    time.Duration
+}
+
+// computeStats summarizes the saved events as of now. Events that only
+// appear in the list, such as holidays, are left out.
+func computeStats(events []Event, now time.Time) eventStats {
+	s := eventStats{Start: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())}
+	var saved []Event
+	var totalDays float64
+	for _, e := range events {
+		if e.Virtual {
+			continue
+		}
+		saved = append(saved, e)
+		s.Buckets[urgencyBucket(e.Time, now)]++
+		t := time.Unix(e.Time, 0).In(now.Location())
+		if t.Before(now) {
+			s.Past++
+			continue
+		}
+		s.Upcoming++
+		totalDays += t.Sub(now).Hours() / 24
+		if s.Farthest == nil || e.Time > s.Farthest.Time {
+			farthest := e
+			s.Farthest = &farthest
+		}
+		if month := (t.Year()-now.Year())*12 + int(t.Month()-now.Month()); month < statsMonths {
+			s.Months[month]++
+		}
+	}
+	s.Total = len(saved)
+	s.Tags = groupByTag(saved, now)
+	if s.Upcoming > 0 {
+		s.AvgDays = totalDays / float64(s.Upcoming)
+	}
+	s.planningHorizon(saved, now)
+	return s
+}
+
+func (s *eventStats) planningHorizon(saved []Event, now time.Time) {
+	var upcoming []Event
+	for _, e := range saved {
+		if e.Time >= now.Unix() {
+			upcoming = append(upcoming, e)
+		}
+	}
+	if len(upcoming) < 2 {
+		return
+	}
+	sortEventsByTime(upcoming)
+	s.First, s.Last = &upcoming[0], &upcoming[len(upcoming)-1]
+	for i := 1; i < len(upcoming); i++ {
+		if gap := time.Duration(upcoming[i].Time-upcoming[i-1].Time) * time.Second; gap > s.QuietGap {
+			s.QuietAfter, s.QuietGap = &upcoming[i-1], gap
+		}
+	}
+}
+
+// formatSpan says roughly how long from from to to is, in days, weeks,
+// calendar months or years, whichever reads best.
+func formatSpan(from, to time.Time) string {
+	days := int(to.Sub(from) / (24 * time.Hour))
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	switch {
+	case months >= 24:
+		return trn("stats.span_years", months/12)
+	case months >= 2:
+		return trn("stats.span_months", months)
+	case days >= 14:
+		return trn("stats.span_weeks", days/7)
+	}
+	return trn("stats.span_days", days)
+}
+
+// timeHeatmap counts events by the weekday and hour they fall on.
+type timeHeatmap struct {
+	Counts [7][24]int // by time.Weekday, then hour
+	Total  int
+}
+
+// computeHeatmap buckets the saved events by when they fall in now's time
+// zone. Past events only count with includePast.
+func computeHeatmap(events []Event, now time.Time, includePast bool) timeHeatmap {
+	var h timeHeatmap
+	for _, e := range events {
+		if e.Virtual || (!includePast && e.Time < now.Unix()) {
+			continue
+		}
+		t := time.Unix(e.Time, 0).In(now.Location())
+		h.Counts[t.Weekday()][t.Hour()]++
+		h.Total++
+	}
+	return h
+}
+
+// heatLevels color the heatmap's cells from the quietest to the busiest.
+var heatLevels = []string{cUrgency1, cUrgency3, cUrgency4, cUrgency6}
+
+// renderHeatmap draws a row per weekday from start and a column per hour,
+// or per two hours when width is too narrow for 24. Cells are shaded
+// relative to the busiest one, and each row ends with its total.
+func renderHeatmap(h timeHeatmap, start time.Weekday, width int) string {
+	const labelWidth = 5
+	step := 1
+	if width < labelWidth+24+4 {
+		step = 2
+	}
+	cols := 24 / step
+	cell := func(day time.Weekday, col int) int {
+		n := 0
+		for hour := col * step; hour < (col+1)*step; hour++ {
+			n += h.Counts[day][hour]
+		}
+		return n
+	}
+	most := 0
+	for day := range h.Counts {
+		for col := 0; col < cols; col++ {
+			most = max(most, cell(time.Weekday(day), col))
+		}
+	}
+
+	label := lipgloss.NewStyle().Width(labelWidth)
+	axis := []rune(strings.Repeat(" ", cols+1))
+	for hour := 0; hour < 24; hour += 6 {
+		copy(axis[hour/step:], []rune(fmt.Sprint(hour)))
+	}
+	var b strings.Builder
+	b.WriteString(label.Render("") + NormalTextStyle(strings.TrimRight(string(axis), " ")) + "\n")
+
+	names := strings.Fields(tr("stats.weekdays"))
+	empty := lipgloss.NewStyle().Foreground(paletteColor(cBarEmpty))
+	for i := 0; i < 7; i++ {
+		day := (start + time.Weekday(i)) % 7
+		b.WriteString(label.Render(NormalTextStyle(names[day])))
+		total := 0
+		for col := 0; col < cols; col++ {
+			n := cell(day, col)
+			total += n
+			if n == 0 {
+				b.WriteString(empty.Render("·"))
+				continue
+			}
+			level := (n*len(heatLevels) - 1) / most
+			b.WriteString(lipgloss.NewStyle().Foreground(paletteColor(heatLevels[level])).Render("■"))
+		}
+		b.WriteString(" " + BrightTextStyle(fmt.Sprint(total)) + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m MainModel) statsView() string {
+	now := m.now()
+	s := computeStats(m.allEvents(), now)
+	const barWidth = 20
+	labelStyle := lipgloss.NewStyle().Width(18)
+	row := func(label, value string) string {
+		return labelStyle.Render(NormalTextStyle(ansi.Truncate(label, 17, "…"))) + value + "\n"
+	}
+	bar := func(n, most int, color string) string {
+		return renderProgressBar(float64(n), float64(max(most, 1)), barWidth, color) + " " + BrightTextStyle(fmt.Sprint(n))
+	}
+	heading := func(title string) string {
+		return "\n" + TimelineSelectedStyle.Render(title) + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(18+barWidth+6).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("stats.title")) + "\n\n")
+
+	b.WriteString(row(tr("stats.events"), BrightTextStyle(trf("stats.events_split", s.Total, s.Upcoming, s.Past))))
+	if s.Upcoming > 0 {
+		b.WriteString(row(tr("stats.average"), BrightTextStyle(trf("stats.days", s.AvgDays))))
+		left := " · " + formatCountdown(s.Farthest.Time, now)
+		name := ansi.Truncate(s.Farthest.Name, max(barWidth+6-ansi.StringWidth(left), 8), "…")
+		b.WriteString(row(tr("stats.farthest"), BrightTextStyle(name+left)))
+	}
+	if s.Past > 0 {
+		b.WriteString(row(tr("stats.lingering"), BrightTextStyle(trn("stats.lingering_count", s.Past))))
+	}
+	if s.First != nil {
+		first, last := time.Unix(s.First.Time, 0).In(now.Location()), time.Unix(s.Last.Time, 0).In(now.Location())
+		months := first.Format("Jan 2006")
+		if last.Format("Jan 2006") != months {
+			months += " → " + last.Format("Jan 2006")
+		}
+		b.WriteString(row(tr("stats.horizon"), BrightTextStyle(trf("stats.horizon_span", formatSpan(first, last), months))))
+		after := time.Unix(s.QuietAfter.Time, 0)
+		gap := formatSpan(after, after.Add(s.QuietGap))
+		name := ansi.Truncate(s.QuietAfter.Name, max(barWidth+6-ansi.StringWidth(trf("stats.quietest_gap", gap, "")), 12), "…")
+		b.WriteString(row(tr("stats.quietest"), BrightTextStyle(trf("stats.quietest_gap", gap, name))))
+	}
+
+	b.WriteString(heading(tr("stats.by_month")))
+	most := 0
+	for _, n := range s.Months {
+		most = max(most, n)
+	}
+	for i, n := range s.Months {
+		month := s.Start.AddDate(0, i, 0)
+		b.WriteString(row(month.Format("Jan 2006"), bar(n, most, cUrgency1)))
+	}
+
+	b.WriteString(heading(tr("stats.by_urgency")))
+	most = 0
+	for _, n := range s.Buckets {
+		most = max(most, n)
+	}
+	for i := len(s.Buckets) - 1; i >= 0; i-- {
+		b.WriteString(row(tr(fmt.Sprintf("stats.bucket_%d", i)), bar(s.Buckets[i], most, urgencyColors[i])))
+	}
+
+	if len(s.Tags) > 0 {
+		b.WriteString(heading(tr("stats.by_tag")))
+		most = 0
+		for _, g := range s.Tags {
+			most = max(most, g.Count)
+		}
+		for _, g := range s.Tags {
+			tag := g.Tag
+			if tag == untaggedLabel {
+				tag = tr("tags.untagged")
+			}
+			b.WriteString(row(tag, bar(g.Count, most, cTimelineSelected)))
+		}
+	}
+	b.WriteString(heading(tr("stats.by_time")))
+	heat := computeHeatmap(m.allEvents(), now, m.statsPast)
+	if heat.Total == 0 {
+		b.WriteString(HintStyle(tr("stats.no_times")) + "\n")
+	} else {
+		b.WriteString(renderHeatmap(heat, m.config.weekStart(), min(18+barWidth+6, m.windowWidth-8)) + "\n")
+	}
+
+	hint := tr("stats.past_hint")
+	if m.statsPast {
+		hint = tr("stats.upcoming_hint")
+	}
+	b.WriteString("\n" + HintStyle(hint))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(y int, mo time.Month, d int) int64 { return time.Date(y, mo, d, 12, 0, 0, 0, time.UTC).Unix() }
+	events := []Event{
+		{Name: "Retro", Time: at(2026, 2, 1)},
+		{Name: "Standup", Time: now.Add(6 * time.Hour).Unix(), Tags: []string{"work"}},
+		{Name: "Launch", Time: at(2026, 3, 20), Tags: []string{"work"}},
+		{Name: "Trip", Time: at(2026, 5, 1), Tags: []string{"travel", "family"}},
+		{Name: "Wedding", Time: at(2027, 6, 1), Tags: []string{"family"}},
+		{Name: "Easter", Time: at(2026, 4, 5), Virtual: true},
+	}
+	s := computeStats(events, now)
+
+	if s.Total != 5 || s.Upcoming != 4 || s.Past != 1 {
+		t.Errorf("Expected 5 events, 4 upcoming and 1 past, got %d, %d, %d", s.Total, s.Upcoming, s.Past)
+	}
+	if s.Months != [statsMonths]int{2, 0, 1} {
+		t.Errorf("Expected 2 this month and 1 in May, got %v", s.Months)
+	}
+	if s.Buckets != [7]int{1, 2, 0, 1, 0, 0, 1} {
+		t.Errorf("Expected the events by urgency, got %v", s.Buckets)
+	}
+	if s.Farthest == nil || s.Farthest.Name != "Wedding" {
+		t.Errorf("Expected the wedding to be farthest, got %v", s.Farthest)
+	}
+	// 0.25 + 10 + 52 + 448 days over 4 events.
+	if want := (0.25 + 10 + 52 + 448) / 4; s.AvgDays != want {
+		t.Errorf("Expected %.4f days on average, got %.4f", want, s.AvgDays)
+	}
+	var tags []string
+	for _, g := range s.Tags {
+		tags = append(tags, g.Tag+"="+string(rune('0'+g.Count)))
+	}
+	if got := strings.Join(tags, " "); got != "family=2 travel=1 work=2 (untagged)=1" {
+		t.Errorf("Expected the events by tag, got %s", got)
+	}
+
+	if s.First == nil || s.First.Name != "Standup" || s.Last.Name != "Wedding" {
+		t.Errorf("Expected the horizon from the standup to the wedding, got %v to %v", s.First, s.Last)
+	}
+	if s.QuietAfter == nil || s.QuietAfter.Name != "Trip" || s.QuietGap != 396*24*time.Hour {
+		t.Errorf("Expected the longest wait after the trip, got %v %v", s.QuietAfter, s.QuietGap)
+	}
+
+	if s := computeStats(nil, now); s.Total != 0 || s.Farthest != nil || s.AvgDays != 0 {
+		t.Errorf("Expected empty stats without events, got %+v", s)
+	}
+	if s := computeStats(events[:2], now); s.First != nil || s.QuietAfter != nil {
+		t.Errorf("Expected no horizon with one upcoming event, got %+v", s)
+	}
+}
+
+func TestFormatSpan(t *testing.T) {
+	from := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		to   time.Time
+		want string
+	}{
+		{from.Add(20 * time.Hour), "0 days"},
+		{from.AddDate(0, 0, 1), "1 day"},
+		{from.AddDate(0, 0, 13), "13 days"},
+		{from.AddDate(0, 0, 45), "6 weeks"},
+		{from.AddDate(0, 2, -1), "8 weeks"},
+		{from.AddDate(0, 2, 0), "2 months"},
+		{from.AddDate(1, 2, 0), "14 months"},
+		{from.AddDate(3, 1, 0), "3 years"},
+	} {
+		if got := formatSpan(from, tt.to); got != tt.want {
+			t.Errorf("formatSpan to %s = %q, want %q", tt.to.Format(inputTimeFormShort), got, tt.want)
+		}
+	}
+}
+
+func TestStatsScreen(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	useLanguage(t, "en")
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Retro", Time: now.AddDate(0, -1, 0).Unix()},
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 10).Unix(), Tags: []string{"work"}},
+		Event{Name: "Conference", Time: now.AddDate(0, 1, 0).Unix()},
+		Event{Name: "Wedding", Time: now.AddDate(1, 2, 0).Unix()},
+	)
+	m.windowWidth, m.windowHeight = 120, 70
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	m = model.(MainModel)
+	if m.state != showStats {
+		t.Fatalf("Expected S to open the stats, got state %d", m.state)
+	}
+	view := stripANSI(m.View())
+	for _, want := range []string{"Statistics", "4, 3 upcoming, 1 past", "13 months, Mar 2026 → May 2027", "13 months after “Conference”", "Wedding", "1 past event is still in the file", "Mar 2026", "Feb 2027", "work", "7-14 days", "By weekday and hour"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q on the stats screen, got:\n%s", want, view)
+		}
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = model.(MainModel)
+	if m.state != showStats || !m.statsPast || !strings.Contains(stripANSI(m.View()), "upcoming events only") {
+		t.Error("Expected a to count past events in the heatmap and keep the stats open")
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(MainModel).state != showEvents {
+		t.Error("Expected any key to close the stats")
+	}
+}
+
+func TestComputeHeatmap(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, berlin) // a Monday
+	events := []Event{
+		{Name: "Report", Time: time.Date(2026, 6, 5, 23, 59, 0, 0, berlin).Unix()},  // Friday
+		{Name: "Invoice", Time: time.Date(2026, 6, 12, 23, 0, 0, 0, berlin).Unix()}, // Friday
+		// Midnight UTC is 2 a.m. on Saturday in Berlin.
+		{Name: "Deploy", Time: time.Date(2026, 6, 13, 0, 0, 0, 0, time.UTC).Unix()},
+		{Name: "Retro", Time: time.Date(2026, 5, 29, 9, 0, 0, 0, berlin).Unix()},
+		{Name: "Holiday", Time: time.Date(2026, 6, 4, 0, 0, 0, 0, berlin).Unix(), Virtual: true},
+	}
+
+	h := computeHeatmap(events, now, false)
+	if h.Total != 3 || h.Counts[time.Friday][23] != 2 || h.Counts[time.Saturday][2] != 1 {
+		t.Errorf("Expected two Friday 23h and one Saturday 2h, got %d events: %v", h.Total, h.Counts)
+	}
+	if h.Counts[time.Friday][9] != 0 || h.Counts[time.Thursday][0] != 0 {
+		t.Error("Expected past and virtual events to be left out")
+	}
+	if h = computeHeatmap(events, now, true); h.Total != 4 || h.Counts[time.Friday][9] != 1 {
+		t.Errorf("Expected the past retro with includePast, got %d events", h.Total)
+	}
+
+	lines := strings.Split(stripANSI(renderHeatmap(h, time.Monday, 60)), "\n")
+	if len(lines) != 8 || !strings.HasPrefix(lines[1], "Mon") || !strings.HasPrefix(lines[7], "Sun") {
+		t.Fatalf("Expected an axis and a row per weekday from Monday, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if want := "     0     6     12    18"; lines[0] != want {
+		t.Errorf("Expected the hour axis %q, got %q", want, lines[0])
+	}
+	if want := "Fri  ·········■·············■ 3"; lines[5] != want {
+		t.Errorf("Expected %q, got %q", want, lines[5])
+	}
+	narrow := strings.Split(stripANSI(renderHeatmap(h, time.Sunday, 30)), "\n")
+	if want := "Fri  ····■······■ 3"; narrow[6] != want {
+		t.Errorf("Expected two-hour columns when narrow, %q, got %q", want, narrow[6])
+	}
+}
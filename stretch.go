@@ -0,0 +1,89 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stretch is where now sits on the way to an upcoming event: between the
+// most recent past event and it, or, with none, since it was added.
+type stretch struct {
+	From     *Event  // the past event measured from; nil when from Created
+	Progress float64 // 0 at the start, 1 at the event
+}
+
+// sharesTag reports whether a and b have a tag in common, counting two
+// untagged events as sharing one.
+func sharesTag(a, b Event) bool {
+	if len(a.Tags) == 0 {
+		return hasTag(b, untaggedLabel)
+	}
+	for _, tag := range a.Tags {
+		if hasTag(b, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// previousEvent returns the latest event in events that is before now,
+// leaving out e and computed events. With sameTag only events sharing a tag
+// with e count.
+func previousEvent(events []Event, e Event, now time.Time, sameTag bool) *Event {
+	var prev *Event
+	for i, other := range events {
+		if other.Virtual || other.Time >= now.Unix() || sameEvent(other, e) {
+			continue
+		}
+		if sameTag && !sharesTag(e, other) {
+			continue
+		}
+		if prev == nil || eventBefore(*prev, other) {
+			prev = &events[i]
+		}
+	}
+	return prev
+}
+
+// eventStretch measures how far now is along the way to e. It reports false
+// for events that have passed, and for events with neither a previous event
+// nor a creation time before now to measure from.
+func eventStretch(events []Event, e Event, now time.Time, sameTag bool) (stretch, bool) {
+	if e.Time <= now.Unix() {
+		return stretch{}, false
+	}
+	if prev := previousEvent(events, e, now, sameTag); prev != nil {
+		progress, ok := gapProgress(prev.Time, e.Time, now)
+		return stretch{From: prev, Progress: progress}, ok
+	}
+	if e.Created > 0 && e.Created < now.Unix() {
+		progress, ok := gapProgress(e.Created, e.Time, now)
+		return stretch{Progress: progress}, ok
+	}
+	return stretch{}, false
+}
+
+// renderStretch is the detail pane's bar of the way to event from the
+// event before it. It measures against the events listed, so browsing a
+// tag measures against that tag's events.
+func (m MainModel) renderStretch(event Event, now time.Time) string {
+	s, ok := eventStretch(eventsOf(m.events.Items()), event, now, m.config.ProgressSameTag)
+	if !ok {
+		return ""
+	}
+	line := trf("stretch.since_added", s.Progress*100, event.Name)
+	if s.From != nil {
+		line = trf("stretch.between", s.Progress*100, s.From.Name, event.Name)
+	}
+	progressWidth := m.detailWidth - 30
+	if progressWidth < 10 {
+		progressWidth = 10
+	}
+	if progressWidth > 30 {
+		progressWidth = 30
+	}
+	wrap := lipgloss.NewStyle().Width(m.detailWidth - 6)
+	return wrap.Render(NormalTextStyle("🚩 ")+BrightTextStyle(line)) + "\n" +
+		"   " + renderProgressBar(s.Progress, 1.0, progressWidth, getUrgencyColor(event.Time, now)) + "\n"
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventStretch(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	at := func(days float64) int64 { return now.Add(time.Duration(days * 24 * float64(time.Hour))).Unix() }
+	target := Event{Name: "Sprint 15 end", Time: at(6), Tags: []string{"sprint"}}
+	sprint14 := Event{Name: "Sprint 14 end", Time: at(-4), Tags: []string{"sprint"}}
+	dentist := Event{Name: "Dentist", Time: at(-1)}
+	holiday := Event{Name: "Holiday", Time: at(-0.5), Virtual: true}
+
+	tests := []struct {
+		name     string
+		events   []Event
+		event    Event
+		sameTag  bool
+		from     string
+		progress float64
+		ok       bool
+	}{
+		{"Latest past event", []Event{sprint14, dentist, holiday, target}, target, false, "Dentist", 1.0 / 7, true},
+		{"Same tag only", []Event{sprint14, dentist, holiday, target}, target, true, "Sprint 14 end", 0.4, true},
+		{"Untagged with untagged", []Event{sprint14, dentist, {Name: "Call", Time: at(3)}}, Event{Name: "Call", Time: at(3)}, true, "Dentist", 0.25, true},
+		{"From when added", []Event{target}, Event{Name: "Sprint 15 end", Time: at(6), Created: at(-2)}, false, "", 0.25, true},
+		{"Nothing before", []Event{target}, target, false, "", 0, false},
+		{"No tag shared", []Event{dentist, target}, Event{Name: "Sprint 15 end", Time: at(6), Tags: []string{"sprint"}, Created: at(-6)}, true, "", 0.5, true},
+		{"Added later than now", []Event{target}, Event{Name: "Sprint 15 end", Time: at(6), Created: at(1)}, false, "", 0, false},
+		{"Passed", []Event{sprint14, dentist}, dentist, false, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := eventStretch(tt.events, tt.event, now, tt.sameTag)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			from := ""
+			if s.From != nil {
+				from = s.From.Name
+			}
+			if from != tt.from || math.Abs(s.Progress-tt.progress) > 1e-9 {
+				t.Errorf("Expected %.3f from %q, got %.3f from %q", tt.progress, tt.from, s.Progress, from)
+			}
+		})
+	}
+}
+
+func TestRenderStretch(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.Local)
+	sprint15 := Event{Name: "Sprint 15 end", Time: now.AddDate(0, 0, 6).Unix(), Tags: []string{"sprint"}}
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Sprint 14 end", Time: now.AddDate(0, 0, -4).Unix(), Tags: []string{"sprint"}},
+		Event{Name: "Dentist", Time: now.AddDate(0, 0, -1).Unix()},
+		sprint15,
+	)
+	m.detailWidth = 80
+
+	view := func() string { return stripANSI(m.renderDetails(sprint15)) }
+	if v := view(); !strings.Contains(v, "14% of the way from “Dentist” to “Sprint 15 end”") {
+		t.Errorf("Expected the way from the last past event, got:\n%s", v)
+	}
+	m.enterTagScope("sprint")
+	if v := view(); !strings.Contains(v, "40% of the way from “Sprint 14 end” to “Sprint 15 end”") {
+		t.Errorf("Expected browsing a tag to measure against its events, got:\n%s", v)
+	}
+
+	m.leaveTagScope()
+	m.config.ProgressSameTag = true
+	now = now.AddDate(0, 0, 3)
+	if v := view(); !strings.Contains(v, "70% of the way from “Sprint 14 end”") {
+		t.Errorf("Expected progress_same_tag to skip other events as time passes, got:\n%s", v)
+	}
+}
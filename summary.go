@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+const quitSummaryCount = 3
+
+// renderQuitSummary lists the next few events for printing to the terminal
+// once the program has left the alt screen. It returns "" when nothing is
+// coming up.
+func renderQuitSummary(m MainModel) string {
+	now := m.now()
+	upcoming := nextEvents(m.allEvents(), now, nextOptions{}, quitSummaryCount)
+	if len(upcoming) == 0 {
+		return ""
+	}
+
+	nameWidth, dateWidth := 0, 0
+	dates := make([]string, len(upcoming))
+	for i, e := range upcoming {
+		dates[i] = time.Unix(e.Time, 0).Format(m.config.dateTimeLayout())
+		if w := runewidth.StringWidth(e.Title()); w > nameWidth {
+			nameWidth = w
+		}
+		if w := runewidth.StringWidth(dates[i]); w > dateWidth {
+			dateWidth = w
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(tr("summary.title")) + "\n")
+	for i, e := range upcoming {
+		countdown := lipgloss.NewStyle().
+			Foreground(paletteColor(getUrgencyColor(e.Time, now))).
+			Render(m.config.countdownFormat().format(e.Time, now))
+		fmt.Fprintf(&b, "  %s  %s  %s\n",
+			runewidth.FillRight(e.Title(), nameWidth),
+			runewidth.FillRight(dates[i], dateWidth),
+			countdown)
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestRenderQuitSummary(t *testing.T) {
+	withFixedLocal(t)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	m := MainModel{config: defaultConfig(), clock: func() time.Time { return now }}
+	m.events = list.New(nil, list.NewDefaultDelegate(), 0, 0)
+
+	if got := renderQuitSummary(m); got != "" {
+		t.Errorf("Expected no summary without events, got %q", got)
+	}
+
+	m.events.SetItems([]list.Item{
+		Event{Name: "Launch", Time: time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC).Unix()},
+		Event{Name: "Conference", Time: time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC).Unix()},
+	})
+	m.config.DateFormat = "iso"
+	m.config.TimeFormat = "iso"
+
+	expected := "Coming up:\n" +
+		"  Launch      2026-03-15 at 18:00:00  14d 8h 30m 0s\n" +
+		"  Conference  2026-04-02 at 09:00:00  31d 23h 30m 0s\n"
+	if got := stripANSI(renderQuitSummary(m)); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
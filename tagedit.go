@@ -0,0 +1,279 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tagOp is a change made to a tag across many events at once.
+type tagOp int
+
+const (
+	tagNone tagOp = iota
+	tagAdd
+	tagRemove
+	tagRename
+)
+
+// tagEdit adds or removes Tag, or renames it to To.
+type tagEdit struct {
+	Op      tagOp
+	Tag, To string
+}
+
+// checkTagName trims a tag typed by the user and rejects the ones that
+// cannot be told apart in the list.
+func checkTagName(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	switch {
+	case tag == "":
+		return "", errors.New("empty tag")
+	case tag == untaggedLabel:
+		return "", fmt.Errorf("%q is reserved for events without tags", tag)
+	}
+	return tag, nil
+}
+
+// apply returns e with the edit made, and whether that changed it. An
+// event left with both the old and the new name of a renamed tag keeps
+// one of them.
+func (t tagEdit) apply(e Event) (Event, bool) {
+	switch t.Op {
+	case tagAdd:
+		if hasTag(e, t.Tag) {
+			return e, false
+		}
+		e.Tags = append(append([]string(nil), e.Tags...), t.Tag)
+		return e, true
+	case tagRemove, tagRename:
+		if len(e.Tags) == 0 || !hasTag(e, t.Tag) || t.Tag == t.To {
+			return e, false
+		}
+		tags := make([]string, 0, len(e.Tags))
+		for _, tag := range e.Tags {
+			if tag == t.Tag && t.Op == tagRename {
+				tag = t.To
+			}
+			if (tag == t.Tag && t.Op == tagRemove) || containsString(tags, tag) {
+				continue
+			}
+			tags = append(tags, tag)
+		}
+		if len(tags) == 0 {
+			tags = nil
+		}
+		e.Tags = tags
+		return e, true
+	}
+	return e, false
+}
+
+// applyTagEdit makes edit on the events match picks, or on all of them if
+// match is nil, and returns the events with how many changed. Read-only
+// and computed events are left alone.
+func applyTagEdit(events []Event, edit tagEdit, match func(Event) bool) ([]Event, int) {
+	events = append([]Event(nil), events...)
+	changed := 0
+	for i, e := range events {
+		if e.Virtual || e.ReadOnly || (match != nil && !match(e)) {
+			continue
+		}
+		var ok bool
+		if events[i], ok = edit.apply(e); ok {
+			changed++
+		}
+	}
+	return events, changed
+}
+
+// tagEditSummary says what edit did to n events.
+func tagEditSummary(edit tagEdit, n int) string {
+	switch edit.Op {
+	case tagAdd:
+		return trf("tags.added", edit.Tag, trn("tags.changed", n))
+	case tagRemove:
+		return trf("tags.removed", edit.Tag, trn("tags.changed", n))
+	}
+	return trf("tags.renamed", edit.Tag, edit.To, trn("tags.changed", n))
+}
+
+func runTag(args []string) int {
+	fs := flag.NewFlagSet("tag", flag.ContinueOnError)
+	tagged := fs.String("tagged", "", "only events with this tag, or (untagged)")
+	match := fs.String("match", "", "only events whose name contains this text")
+	dryRun := fs.Bool("dry-run", false, "show how many events would change without saving")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: countdown tag add <tag> (--tagged <tag> | --match <text>)")
+		fmt.Fprintln(fs.Output(), "       countdown tag remove <tag> [--tagged <tag>] [--match <text>]")
+		fmt.Fprintln(fs.Output(), "       countdown tag rename <old> <new>")
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return 2
+	}
+	op, args := args[0], args[1:]
+	// Allow flags between and after the tags as well as before them.
+	var names []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	var edit tagEdit
+	switch {
+	case op == "add" && len(names) == 1:
+		if *tagged == "" && *match == "" {
+			fmt.Fprintln(os.Stderr, "tag: add needs --tagged or --match to pick the events")
+			return 2
+		}
+		edit.Op = tagAdd
+	case op == "remove" && len(names) == 1:
+		edit.Op = tagRemove
+	case op == "rename" && len(names) == 2:
+		edit.Op = tagRename
+	default:
+		fs.Usage()
+		return 2
+	}
+	for i, name := range names {
+		tag, err := checkTagName(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "tag: %v\n", err)
+			return 2
+		}
+		if i == 0 {
+			edit.Tag = tag
+		} else {
+			edit.To = tag
+		}
+	}
+	if edit.Op == tagRename && (*tagged != "" || *match != "") {
+		fmt.Fprintln(os.Stderr, "tag: rename applies to every event with the tag")
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tag: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tag: %v\n", err)
+		return 1
+	}
+	query := normalizedName(*match)
+	events, changed := applyTagEdit(events, edit, func(e Event) bool {
+		return (*tagged == "" || hasTag(e, *tagged)) && strings.Contains(normalizedName(e.Name), query)
+	})
+	fmt.Println(tagEditSummary(edit, changed))
+	if changed == 0 || *dryRun {
+		return 0
+	}
+	if err := writeEventsFile(events); err != nil {
+		fmt.Fprintf(os.Stderr, "tag: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// openTagPrompt asks for the tag to add to the events of the selected
+// group, or for the new name of its tag.
+func (m *MainModel) openTagPrompt(op tagOp) tea.Cmd {
+	group, ok := m.tags.SelectedItem().(tagGroup)
+	if !ok || (op == tagRename && group.Tag == untaggedLabel) {
+		return nil
+	}
+	m.tagPrompt = op
+	m.tagInput = textinput.New()
+	m.tagInput.Prompt = tr("tags.add_prompt")
+	if op == tagRename {
+		m.tagInput.Prompt = trf("tags.rename_prompt", group.Tag)
+		m.tagInput.SetValue(group.Tag)
+	}
+	return m.tagInput.Focus()
+}
+
+// updateTagPrompt handles keys while the tag prompt is open.
+func (m *MainModel) updateTagPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.tagPrompt = tagNone
+		return nil
+	case tea.KeyEnter:
+		group := m.tags.SelectedItem().(tagGroup)
+		tag, err := checkTagName(m.tagInput.Value())
+		if err != nil {
+			return m.tags.NewStatusMessage(ErrStyle(err.Error()))
+		}
+		edit := tagEdit{Op: m.tagPrompt, Tag: tag}
+		if m.tagPrompt == tagRename {
+			edit = tagEdit{Op: tagRename, Tag: group.Tag, To: tag}
+		}
+		m.tagPrompt = tagNone
+		return m.editTags(edit, func(e Event) bool { return hasTag(e, group.Tag) })
+	}
+	var cmd tea.Cmd
+	m.tagInput, cmd = m.tagInput.Update(msg)
+	return cmd
+}
+
+// removeSelectedTag takes the selected group's tag off all its events.
+func (m *MainModel) removeSelectedTag() tea.Cmd {
+	group, ok := m.tags.SelectedItem().(tagGroup)
+	if !ok || group.Tag == untaggedLabel {
+		return nil
+	}
+	return m.editTags(tagEdit{Op: tagRemove, Tag: group.Tag}, nil)
+}
+
+// editTags makes edit on the events match picks, saves once and rebuilds
+// the tag groups, keeping the edited tag selected.
+func (m *MainModel) editTags(edit tagEdit, match func(Event) bool) tea.Cmd {
+	before := m.storedEvents()
+	events, changed := applyTagEdit(before, edit, match)
+	summary := m.tags.NewStatusMessage(SuccessStyle(tagEditSummary(edit, changed)))
+	if changed == 0 {
+		return summary
+	}
+	now := m.now()
+	events = append(events, virtualEvents(now, m.config)...)
+	m.config.sortOrder().sort(events, now)
+	m.compareMark = nil
+	m.setEvents(events)
+
+	selected := edit.Tag
+	if edit.Op == tagRename {
+		selected = edit.To
+	}
+	index := m.tags.Index()
+	groups := groupByTag(m.allEvents(), now)
+	items := make([]list.Item, len(groups))
+	for i := range groups {
+		items[i] = groups[i]
+		if groups[i].Tag == selected {
+			index = i
+		}
+	}
+	m.tags.SetItems(items)
+	m.tags.Select(min(index, len(items)-1))
+	if cmd := m.saved(m.saveChange(before)); cmd != nil {
+		return cmd
+	}
+	return summary
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTagEditApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    tagEdit
+		tags    []string
+		want    string
+		changed bool
+	}{
+		{"Add", tagEdit{Op: tagAdd, Tag: "work"}, []string{"home"}, "home,work", true},
+		{"Add present", tagEdit{Op: tagAdd, Tag: "work"}, []string{"work"}, "work", false},
+		{"Add to untagged", tagEdit{Op: tagAdd, Tag: "work"}, nil, "work", true},
+		{"Remove", tagEdit{Op: tagRemove, Tag: "work"}, []string{"a", "work", "b"}, "a,b", true},
+		{"Remove last", tagEdit{Op: tagRemove, Tag: "work"}, []string{"work"}, "", true},
+		{"Remove absent", tagEdit{Op: tagRemove, Tag: "work"}, []string{"home"}, "home", false},
+		{"Rename keeps place", tagEdit{Op: tagRename, Tag: "work", To: "job"}, []string{"a", "work", "b"}, "a,job,b", true},
+		{"Rename collision", tagEdit{Op: tagRename, Tag: "work", To: "job"}, []string{"job", "work"}, "job", true},
+		{"Rename collision after", tagEdit{Op: tagRename, Tag: "work", To: "job"}, []string{"work", "job"}, "job", true},
+		{"Rename to itself", tagEdit{Op: tagRename, Tag: "work", To: "work"}, []string{"work"}, "work", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, changed := tt.edit.apply(Event{Name: "E", Tags: tt.tags})
+			if got := strings.Join(e.Tags, ","); got != tt.want || changed != tt.changed {
+				t.Errorf("Expected %q (changed %v), got %q (changed %v)", tt.want, tt.changed, got, changed)
+			}
+		})
+	}
+
+	tags := make([]string, 1, 2)
+	tags[0] = "work"
+	tagEdit{Op: tagAdd, Tag: "home"}.apply(Event{Tags: tags})
+	tagEdit{Op: tagRename, Tag: "work", To: "job"}.apply(Event{Tags: tags})
+	if tags[0] != "work" || tags[:2][1] != "" {
+		t.Errorf("Expected the original tags left alone, got %v", tags)
+	}
+}
+
+func TestApplyTagEdit(t *testing.T) {
+	events := []Event{
+		{Name: "Standup", Tags: []string{"work"}},
+		{Name: "Review", Tags: []string{"work", "job"}},
+		{Name: "Holiday", Tags: []string{"work"}, ReadOnly: true},
+		{Name: "Quarter end", Tags: []string{"work"}, Virtual: true},
+		{Name: "Dentist"},
+	}
+	got, changed := applyTagEdit(events, tagEdit{Op: tagRename, Tag: "work", To: "job"}, nil)
+	if changed != 2 {
+		t.Errorf("Expected 2 events changed, got %d", changed)
+	}
+	var tags []string
+	for _, e := range got {
+		tags = append(tags, strings.Join(e.Tags, "+"))
+	}
+	if strings.Join(tags, ",") != "job,job,work,work," {
+		t.Errorf("Expected read-only and computed events left alone, got %v", tags)
+	}
+	if strings.Join(events[0].Tags, "+") != "work" {
+		t.Error("Expected the events passed in unchanged")
+	}
+
+	_, changed = applyTagEdit(events, tagEdit{Op: tagAdd, Tag: "later"}, func(e Event) bool { return hasTag(e, untaggedLabel) })
+	if changed != 1 {
+		t.Errorf("Expected only the untagged event tagged, got %d", changed)
+	}
+}
+
+func TestRunTag(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	day := time.Date(2030, 5, 3, 0, 0, 0, 0, time.Local)
+	if err := writeEventsFile([]Event{
+		{Name: "Standup", Time: day.Unix(), Tags: []string{"work"}},
+		{Name: "Team lunch", Time: day.Add(time.Hour).Unix(), Tags: []string{"work", "job"}},
+		{Name: "Dentist", Time: day.Add(2 * time.Hour).Unix()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	devNull, _ := os.Open(os.DevNull)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	tags := func() string {
+		events, err := readEventsFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var all []string
+		for _, e := range events {
+			all = append(all, strings.Join(e.Tags, "+"))
+		}
+		return strings.Join(all, ",")
+	}
+
+	for _, args := range [][]string{
+		nil,
+		{"add", "later"},
+		{"tidy", "work"},
+		{"rename", "work"},
+		{"rename", "work", "job", "--match", "lunch"},
+		{"remove", untaggedLabel},
+		{"add", " ", "--tagged", "work"},
+	} {
+		if code := runTag(args); code != 2 {
+			t.Errorf("%q: expected a usage error, got %d", args, code)
+		}
+	}
+	if code := runTag([]string{"rename", "work", "job", "--dry-run"}); code != 0 || tags() != "work,work+job," {
+		t.Errorf("Expected --dry-run to save nothing, got %d and %s", code, tags())
+	}
+
+	steps := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"rename", "work", "job"}, "job,job,"},
+		{[]string{"add", "--match", "LUNCH", "food"}, "job,job+food,"},
+		{[]string{"add", "later", "--tagged", untaggedLabel}, "job,job+food,later"},
+		{[]string{"remove", "job", "--match", "standup"}, ",job+food,later"},
+		{[]string{"remove", "nothing"}, ",job+food,later"},
+	}
+	for _, s := range steps {
+		if code := runTag(s.args); code != 0 {
+			t.Errorf("%q: expected success, got %d", s.args, code)
+		}
+		if got := tags(); got != s.want {
+			t.Errorf("%q: expected tags %s, got %s", s.args, s.want, got)
+		}
+	}
+}
+
+func TestTagsViewBulkEdit(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Standup", Time: now.Add(time.Hour).Unix(), Tags: []string{"work"}},
+		Event{Name: "Review", Time: now.Add(2 * time.Hour).Unix(), Tags: []string{"job", "work"}},
+		Event{Name: "Dentist", Time: now.Add(3 * time.Hour).Unix()},
+	)
+	send := func(msgs ...tea.Msg) {
+		t.Helper()
+		for _, msg := range msgs {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	groups := func() string {
+		var tags []string
+		for _, item := range m.tags.Items() {
+			g := item.(tagGroup)
+			tags = append(tags, g.Tag+":"+string(rune('0'+g.Count)))
+		}
+		return strings.Join(tags, ",")
+	}
+	selectTag := func(tag string) {
+		t.Helper()
+		for i, item := range m.tags.Items() {
+			if item.(tagGroup).Tag == tag {
+				m.tags.Select(i)
+				return
+			}
+		}
+		t.Fatalf("No group for %s in %s", tag, groups())
+	}
+
+	send(runes("t"))
+	selectTag("work")
+	send(runes("r"))
+	if view := stripANSI(m.View()); !strings.Contains(view, "Rename #work to:") {
+		t.Fatalf("Expected the rename prompt, got:\n%s", view)
+	}
+	m.tagInput.SetValue("job")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := groups(); got != "job:2,(untagged):1" {
+		t.Errorf("Expected work merged into job, got %s", got)
+	}
+	if g, ok := m.tags.SelectedItem().(tagGroup); !ok || g.Tag != "job" {
+		t.Errorf("Expected the renamed tag selected, got %v", m.tags.SelectedItem())
+	}
+	events, err := readEventsFile()
+	if err != nil || len(events) != 3 || strings.Join(events[1].Tags, ",") != "job" {
+		t.Fatalf("Expected the rename saved and deduplicated, got %+v (%v)", events, err)
+	}
+
+	selectTag(untaggedLabel)
+	send(runes("+"))
+	m.tagInput.SetValue("health")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := groups(); got != "health:1,job:2" {
+		t.Errorf("Expected the untagged event tagged, got %s", got)
+	}
+
+	selectTag("job")
+	send(runes("-"))
+	if got := groups(); got != "health:1,(untagged):2" {
+		t.Errorf("Expected job removed everywhere, got %s", got)
+	}
+
+	selectTag(untaggedLabel)
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != showEvents || eventNames(m.events.Items()) != "Standup,Review" {
+		t.Errorf("Expected the tag filter to show the events just untagged, got %s", eventNames(m.events.Items()))
+	}
+}
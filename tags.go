@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+const untaggedLabel = "(untagged)"
+
+type tagGroup struct {
+	Tag   string
+	Count int
+	Next  *Event // nearest upcoming event, nil if all have passed
+}
+
+func (g tagGroup) Title() string {
+	tag := g.Tag
+	if tag == untaggedLabel {
+		tag = tr("tags.untagged")
+	}
+	return fmt.Sprintf("%s (%s)", tag, trn("tags.group", g.Count))
+}
+func (g tagGroup) FilterValue() string { return g.Tag }
+
+func hasTag(e Event, tag string) bool {
+	if tag == untaggedLabel {
+		return len(e.Tags) == 0
+	}
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// groupByTag returns one group per tag, sorted by name, with untagged
+// events collected in a final "(untagged)" group.
+func groupByTag(events []Event, now time.Time) []tagGroup {
+	byTag := map[string]*tagGroup{}
+	add := func(tag string, e Event) {
+		g, ok := byTag[tag]
+		if !ok {
+			g = &tagGroup{Tag: tag}
+			byTag[tag] = g
+		}
+		g.Count++
+	}
+
+	for _, e := range events {
+		if len(e.Tags) == 0 {
+			add(untaggedLabel, e)
+			continue
+		}
+		for _, tag := range e.Tags {
+			add(tag, e)
+		}
+	}
+
+	groups := make([]tagGroup, 0, len(byTag))
+	for _, g := range byTag {
+		if next, ok := nextEvent(events, now, nextOptions{Tag: g.Tag, Virtual: true}); ok {
+			g.Next = &next
+		}
+		if g.Tag != untaggedLabel {
+			groups = append(groups, *g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Tag < groups[j].Tag })
+	if g, ok := byTag[untaggedLabel]; ok {
+		groups = append(groups, *g)
+	}
+	return groups
+}
+
+// allEvents returns every event, including those hidden while browsing a
+// single tag or in collapsed sections, in time order.
+func (m MainModel) allEvents() []Event {
+	events := append(eventsOf(m.events.Items()), m.hiddenEvents...)
+	if len(m.hiddenEvents) > 0 || m.config.sortOrder() != sortByDate || m.config.grouped() {
+		sortEventsByTime(events)
+	}
+	return events
+}
+
+func (m *MainModel) openTags() {
+	if m.tagScope != "" {
+		m.leaveTagScope()
+	}
+	groups := groupByTag(m.allEvents(), m.now())
+	items := make([]list.Item, len(groups))
+	for i := range groups {
+		items[i] = groups[i]
+	}
+	m.tags.SetItems(items)
+	m.state = showTags
+}
+
+// enterTagScope narrows the events list to one tag. Events without it are
+// set aside in hiddenEvents so saving still writes them.
+func (m *MainModel) enterTagScope(tag string) {
+	m.tagScope = tag
+	m.events.ResetFilter()
+	m.relist()
+	m.events.Select(0)
+	if tag == untaggedLabel {
+		tag = tr("tags.untagged")
+	}
+	m.events.Title = trf("list.events_in_tag", tag)
+}
+
+func (m *MainModel) leaveTagScope() {
+	m.tagScope = ""
+	m.events.ResetFilter()
+	m.relist()
+	m.events.Title = tr("list.events")
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGroupByTag(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)
+	events := []Event{
+		{Name: "Past birthday", Time: now.Add(-time.Hour).Unix(), Tags: []string{"birthday"}},
+		{Name: "Ada", Time: now.Add(48 * time.Hour).Unix(), Tags: []string{"birthday", "family"}},
+		{Name: "Grace", Time: now.Add(24 * time.Hour).Unix(), Tags: []string{"birthday"}},
+		{Name: "Dentist", Time: now.Add(time.Hour).Unix()},
+		{Name: "Old", Time: now.Add(-48 * time.Hour).Unix(), Tags: []string{"archive"}},
+	}
+
+	groups := groupByTag(events, now)
+	if len(groups) != 4 {
+		t.Fatalf("Expected 4 groups, got %d", len(groups))
+	}
+
+	expected := []struct {
+		tag   string
+		count int
+		next  string
+	}{
+		{"archive", 1, ""},
+		{"birthday", 3, "Grace"},
+		{"family", 1, "Ada"},
+		{untaggedLabel, 1, "Dentist"},
+	}
+	for i, want := range expected {
+		g := groups[i]
+		if g.Tag != want.tag || g.Count != want.count {
+			t.Errorf("Group %d: expected %s (%d), got %s (%d)", i, want.tag, want.count, g.Tag, g.Count)
+		}
+		next := ""
+		if g.Next != nil {
+			next = g.Next.Name
+		}
+		if next != want.next {
+			t.Errorf("Group %s: expected next '%s', got '%s'", g.Tag, want.next, next)
+		}
+	}
+	if got := (eventDelegate{}).nextLine(groups[0].Next); got != "no upcoming events" {
+		t.Errorf("Unexpected description for group without upcoming events: '%s'", got)
+	}
+}
+
+func TestTagScope(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	m, err := NewMainModel()
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	now := time.Now()
+	events := []Event{
+		{Name: "Ada", Time: now.Add(24 * time.Hour).Unix(), Tags: []string{"birthday"}},
+		{Name: "Dentist", Time: now.Add(48 * time.Hour).Unix()},
+		{Name: "Grace", Time: now.Add(72 * time.Hour).Unix(), Tags: []string{"birthday"}},
+	}
+	items := make([]list.Item, len(events))
+	for i := range events {
+		items[i] = events[i]
+	}
+	m.events.SetItems(items)
+	m.state = showEvents
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = model.(MainModel)
+	if m.state != showTags || len(m.tags.Items()) != 2 {
+		t.Fatalf("Expected tags view with 2 groups, got state %v with %d items", m.state, len(m.tags.Items()))
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(MainModel)
+	if m.state != showEvents || m.tagScope != "birthday" || len(m.events.Items()) != 2 {
+		t.Fatalf("Expected 2 birthday events, got scope '%s' with %d items", m.tagScope, len(m.events.Items()))
+	}
+
+	// Removing inside the scope must keep the hidden events in the file.
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	m = model.(MainModel)
+	saved, err := readEventsFile()
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(saved) != 2 || saved[0].Name != "Dentist" || saved[1].Name != "Grace" {
+		t.Errorf("Expected Dentist and Grace to be saved, got %+v", saved)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(MainModel)
+	if m.state != showTags || m.tagScope != "" || len(m.events.Items()) != 2 {
+		t.Errorf("Expected to pop back to tags with all events restored, got state %v scope '%s' and %d items",
+			m.state, m.tagScope, len(m.events.Items()))
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// takeoutCalendar is one of the ICS files in a Google Takeout export.
+type takeoutCalendar struct {
+	File string
+	Cal  icsCalendar
+	Err  error // why the file could not be read; only fatal if it is picked
+}
+
+// Name is the calendar's own name, or its file name without .ics.
+func (c takeoutCalendar) Name() string {
+	if c.Cal.Name != "" {
+		return c.Cal.Name
+	}
+	return strings.TrimSuffix(path.Base(c.File), path.Ext(c.File))
+}
+
+// readTakeout reads every ICS file in the zip at zipPath, in the order the
+// zip lists them.
+func readTakeout(zipPath string) ([]takeoutCalendar, error) {
+	z, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
+	defer z.Close()
+
+	var cals []takeoutCalendar
+	for _, f := range z.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".ics") {
+			continue
+		}
+		c := takeoutCalendar{File: f.Name}
+		r, err := f.Open()
+		if err == nil {
+			c.Cal, err = parseICS(r)
+			r.Close()
+		}
+		if err != nil {
+			c.Err = fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		cals = append(cals, c)
+	}
+	if len(cals) == 0 {
+		return nil, fmt.Errorf("no calendars in %s", zipPath)
+	}
+	return cals, nil
+}
+
+// findTakeoutCalendar picks the calendar called name, ignoring case, by its
+// own name or its file name.
+func findTakeoutCalendar(cals []takeoutCalendar, name string) (takeoutCalendar, bool) {
+	for _, c := range cals {
+		base := strings.TrimSuffix(path.Base(c.File), path.Ext(c.File))
+		if strings.EqualFold(c.Name(), name) || strings.EqualFold(base, name) {
+			return c, true
+		}
+	}
+	return takeoutCalendar{}, false
+}
+
+// takeoutEvents turns a calendar's events into countdown events at their
+// next occurrence, keeping those after now and no more than years ahead.
+// Repeating events are taken at their next occurrence only. It also
+// returns how many events were skipped: past, too far ahead, untitled, or
+// repeating by rules countdown cannot follow.
+func takeoutEvents(cal icsCalendar, now time.Time, years int) ([]Event, int) {
+	limit := now.AddDate(years, 0, 0)
+	var events []Event
+	skipped := 0
+	for _, ie := range cal.Events {
+		at := ie.Start
+		if ie.RRule != "" {
+			rule, ok := parseRRule(ie.RRule, ie.Start)
+			if !ok {
+				skipped++
+				continue
+			}
+			if at, ok = rule.next(now); !ok {
+				skipped++
+				continue
+			}
+		}
+		if ie.Summary == "" || !at.After(now) || at.After(limit) {
+			skipped++
+			continue
+		}
+		uid := ie.UID
+		if uid == "" {
+			uid = ie.Summary + "@" + ie.Start.UTC().Format("20060102T150405Z")
+		}
+		events = append(events, Event{
+			Name:   ie.Summary,
+			Time:   at.Unix(),
+			AllDay: ie.AllDay,
+			Source: "gcal:" + uid,
+		})
+	}
+	return events, skipped
+}
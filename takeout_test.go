@@ -0,0 +1,102 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTakeout writes a zip holding files, by name, for readTakeout.
+func writeTakeout(t *testing.T, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "takeout.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return path
+}
+
+func TestReadTakeout(t *testing.T) {
+	path := writeTakeout(t, map[string]string{
+		"Takeout/Calendar/ada@example.com.ics": testICS,
+		"Takeout/Calendar/Work.ics":            "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:soon\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"Takeout/archive_browser.html":         "<html></html>",
+	})
+	cals, err := readTakeout(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cals) != 2 {
+		t.Fatalf("Expected the two ICS files, got %d", len(cals))
+	}
+
+	personal, ok := findTakeoutCalendar(cals, "personal")
+	if !ok || personal.Err != nil || len(personal.Cal.Events) != 3 {
+		t.Errorf("Expected Personal found by its own name, got %+v", personal)
+	}
+	if c, ok := findTakeoutCalendar(cals, "ada@example.com"); !ok || c.Name() != "Personal" {
+		t.Errorf("Expected Personal found by its file name, got %+v", c)
+	}
+	work, ok := findTakeoutCalendar(cals, "Work")
+	if !ok || work.Name() != "Work" || work.Err == nil || !strings.Contains(work.Err.Error(), "Work.ics") {
+		t.Errorf("Expected Work's parse error kept for when it is picked, got %+v", work)
+	}
+	if _, ok := findTakeoutCalendar(cals, "Holidays"); ok {
+		t.Error("Expected no calendar named Holidays")
+	}
+
+	if _, err := readTakeout(writeTakeout(t, map[string]string{"notes.txt": "hi"})); err == nil {
+		t.Error("Expected an error for a zip without calendars")
+	}
+}
+
+func TestTakeoutEvents(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cal, err := parseICS(strings.NewReader(testICS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal.Events = append(cal.Events,
+		icsEvent{UID: "old@google.com", Summary: "Last year", Start: now.AddDate(-1, 0, 0)},
+		icsEvent{UID: "far@google.com", Summary: "Far off", Start: now.AddDate(3, 0, 0)},
+		icsEvent{UID: "gym@google.com", Summary: "Gym", Start: now.AddDate(-5, 0, 0), RRule: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		icsEvent{UID: "bday@google.com", Summary: "Ada's birthday", Start: time.Date(1990, 12, 10, 0, 0, 0, 0, time.UTC), RRule: "FREQ=YEARLY"},
+	)
+
+	events, skipped := takeoutEvents(cal, now, 2)
+	if skipped != 3 {
+		t.Errorf("Expected the past, far and irregular events skipped, got %d", skipped)
+	}
+	if got := storedNames(events); got != "Product, launch,Planning,Holiday,Ada's birthday" {
+		t.Errorf("Unexpected events: %s", got)
+	}
+	if bday := events[3]; bday.Time != time.Date(2026, 12, 10, 0, 0, 0, 0, time.UTC).Unix() || bday.Source != "gcal:bday@google.com" || bday.recurs() {
+		t.Errorf("Expected the birthday once, at its next occurrence: %+v", bday)
+	}
+	if !events[2].AllDay || events[0].AllDay {
+		t.Errorf("Expected only the DATE event all-day, got %v and %v", events[2].AllDay, events[0].AllDay)
+	}
+
+	// Importing again changes nothing.
+	merged, stats := mergeBySource(nil, events)
+	merged, stats = mergeBySource(merged, events)
+	if len(merged) != 4 || stats.Added != 0 || stats.Duplicates != 4 {
+		t.Errorf("Expected a re-import to add nothing, got %d events and %+v", len(merged), stats)
+	}
+}
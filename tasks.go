@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const taskwarriorTimeFormat = "20060102T150405Z"
+
+var (
+	todoPriorityRe = regexp.MustCompile(`^\([A-Z]\)\s+`)
+	todoDateRe     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+`)
+	todoKeyValueRe = regexp.MustCompile(`(^|\s)[^\s:]+:[^\s:]+`)
+	todoDueRe      = regexp.MustCompile(`(?:^|\s)due:(\d{4}-\d{2}-\d{2})(?:\s|$)`)
+)
+
+// parseTodoTxt turns every open todo.txt task with a due: tag into an event.
+// It also returns how many lines were skipped for being completed or undated.
+func parseTodoTxt(r io.Reader) ([]Event, int, error) {
+	var events []Event
+	skipped := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "x ") {
+			skipped++
+			continue
+		}
+
+		match := todoDueRe.FindStringSubmatch(line)
+		if match == nil {
+			skipped++
+			continue
+		}
+		due, err := time.ParseInLocation(inputTimeFormShort, match[1], time.Local)
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		text := todoPriorityRe.ReplaceAllString(line, "")
+		text = todoDateRe.ReplaceAllString(text, "")
+		text = strings.Join(strings.Fields(todoKeyValueRe.ReplaceAllString(text, "")), " ")
+		if text == "" {
+			skipped++
+			continue
+		}
+
+		sum := sha1.Sum([]byte(text))
+		events = append(events, Event{
+			Name:   text,
+			Time:   due.Unix(),
+			Tags:   []string{"task"},
+			Source: "todotxt:" + hex.EncodeToString(sum[:])[:12],
+		})
+	}
+	return events, skipped, scanner.Err()
+}
+
+type taskwarriorTask struct {
+	UUID        string `json:"uuid"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Due         string `json:"due"`
+}
+
+// parseTaskwarriorExport reads the JSON written by `task export`, keeping
+// pending tasks that have a due date.
+func parseTaskwarriorExport(data []byte) ([]Event, int, error) {
+	var tasks []taskwarriorTask
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse taskwarrior export: %w", err)
+	}
+
+	var events []Event
+	skipped := 0
+	for _, task := range tasks {
+		if task.Status == "completed" || task.Status == "deleted" || task.Due == "" {
+			skipped++
+			continue
+		}
+		due, err := time.Parse(taskwarriorTimeFormat, task.Due)
+		if err != nil {
+			skipped++
+			continue
+		}
+		events = append(events, Event{
+			Name:   task.Description,
+			Time:   due.Unix(),
+			Tags:   []string{"task"},
+			Source: "taskwarrior:" + task.UUID,
+		})
+	}
+	return events, skipped, nil
+}
+
+func runTaskwarriorExport() ([]byte, error) {
+	out, err := exec.Command("task", "export").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run 'task export': %w", err)
+	}
+	return out, nil
+}
+
+// mergeBySource adds incoming events, or updates the name, time and
+// read-only marker of an existing event imported from the same source.
+func mergeBySource(events, incoming []Event) ([]Event, importStats) {
+	var stats importStats
+
+	bySource := make(map[string]int, len(events))
+	for i, e := range events {
+		if e.Source != "" {
+			bySource[e.Source] = i
+		}
+	}
+
+	for _, e := range incoming {
+		i, ok := bySource[e.Source]
+		if !ok {
+			bySource[e.Source] = len(events)
+			events = append(events, e)
+			stats.Added++
+			continue
+		}
+		if events[i].Time == e.Time && events[i].Name == e.Name && events[i].ReadOnly == e.ReadOnly {
+			stats.Duplicates++
+			continue
+		}
+		events[i].Name = e.Name
+		events[i].Time = e.Time
+		events[i].ReadOnly = e.ReadOnly
+		stats.Updated++
+	}
+
+	sortEventsByTime(events)
+	return events, stats
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTodoTxt(t *testing.T) {
+	input := strings.Join([]string{
+		"(A) 2026-01-05 File taxes +home due:2026-04-15",
+		"x 2026-02-01 Renew passport due:2026-03-01",
+		"Call plumber @phone",
+		"Submit report due:2026-07-01 t:2026-06-20 +work",
+		"",
+		"Broken date due:2026-13-45",
+	}, "\n")
+
+	events, skipped, err := parseTodoTxt(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if skipped != 3 {
+		t.Errorf("Expected 3 skipped lines, got %d", skipped)
+	}
+
+	if events[0].Name != "File taxes +home" {
+		t.Errorf("Expected 'File taxes +home', got '%s'", events[0].Name)
+	}
+	if want := time.Date(2026, 4, 15, 0, 0, 0, 0, time.Local).Unix(); events[0].Time != want {
+		t.Errorf("Expected due %d, got %d", want, events[0].Time)
+	}
+	if events[1].Name != "Submit report +work" {
+		t.Errorf("Expected 'Submit report +work', got '%s'", events[1].Name)
+	}
+	for _, e := range events {
+		if !strings.HasPrefix(e.Source, "todotxt:") {
+			t.Errorf("Expected todo.txt source ID, got '%s'", e.Source)
+		}
+		if len(e.Tags) != 1 || e.Tags[0] != "task" {
+			t.Errorf("Expected 'task' tag, got %v", e.Tags)
+		}
+	}
+
+	// The source ID must not depend on the due date so that moved deadlines update in place.
+	moved, _, _ := parseTodoTxt(strings.NewReader("(A) 2026-01-05 File taxes +home due:2026-05-01"))
+	if moved[0].Source != events[0].Source {
+		t.Errorf("Expected stable source ID, got '%s' and '%s'", moved[0].Source, events[0].Source)
+	}
+}
+
+func TestParseTaskwarriorExport(t *testing.T) {
+	data := []byte(`[
+		{"uuid": "a1", "description": "Ship release", "status": "pending", "due": "20260910T120000Z"},
+		{"uuid": "b2", "description": "Old thing", "status": "completed", "due": "20250101T000000Z"},
+		{"uuid": "c3", "description": "Someday", "status": "pending"}
+	]`)
+
+	events, skipped, err := parseTaskwarriorExport(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != 1 || skipped != 2 {
+		t.Fatalf("Expected 1 event and 2 skipped, got %d and %d", len(events), skipped)
+	}
+	if events[0].Source != "taskwarrior:a1" {
+		t.Errorf("Expected source 'taskwarrior:a1', got '%s'", events[0].Source)
+	}
+	if want := time.Date(2026, 9, 10, 12, 0, 0, 0, time.UTC).Unix(); events[0].Time != want {
+		t.Errorf("Expected due %d, got %d", want, events[0].Time)
+	}
+
+	if _, _, err := parseTaskwarriorExport([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+}
+
+func TestMergeBySource(t *testing.T) {
+	existing := []Event{
+		{Name: "Manual", Time: 100},
+		{Name: "Ship release", Time: 200, Source: "taskwarrior:a1", Tags: []string{"task", "work"}},
+		{Name: "Unchanged", Time: 300, Source: "taskwarrior:b2"},
+	}
+	incoming := []Event{
+		{Name: "Ship release", Time: 500, Source: "taskwarrior:a1"},
+		{Name: "Unchanged", Time: 300, Source: "taskwarrior:b2"},
+		{Name: "New task", Time: 50, Source: "taskwarrior:c3"},
+	}
+
+	events, stats := mergeBySource(existing, incoming)
+	if stats.Added != 1 || stats.Updated != 1 || stats.Duplicates != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(events))
+	}
+	if events[0].Name != "New task" || events[3].Name != "Ship release" {
+		t.Errorf("Expected events sorted by time, got %v", events)
+	}
+	if events[3].Time != 500 || len(events[3].Tags) != 2 {
+		t.Errorf("Expected updated time with tags kept, got %+v", events[3])
+	}
+}
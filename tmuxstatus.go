@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func runTmuxStatus(args []string) int {
+	fs := flag.NewFlagSet("tmux-status", flag.ContinueOnError)
+	out := fs.String("out", "", "write the status to this file instead of stdout")
+	watch := fs.Duration("watch", 0, "rewrite --out at this interval until stopped, e.g. 15s")
+	colors := fs.Bool("tmux-colors", false, "color the countdown by urgency with tmux #[fg=...] styles")
+	tag := fs.String("tag", "", "only count events with this tag")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: countdown tmux-status [--out file [--watch interval]] [--tmux-colors] [--tag name]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 || *watch < 0 || (*watch > 0 && *out == "") {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tmux-status: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+
+	write := func(now time.Time) error {
+		events, err := readEventsFile()
+		if err != nil {
+			return err
+		}
+		line := tmuxStatusLine(events, now, nextOptions{Tag: *tag}, cfg.countdownFormat(), *colors)
+		if *out == "" {
+			fmt.Println(line)
+			return nil
+		}
+		return writeStatusFile(*out, line)
+	}
+	if err := write(time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "tmux-status: %v\n", err)
+		return 1
+	}
+	if *watch == 0 {
+		return 0
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(stop)
+	ticker := time.NewTicker(*watch)
+	defer ticker.Stop()
+	code := watchStatus(write, ticker.C, stop)
+	// Leave an empty status rather than a countdown that no longer moves.
+	if err := writeStatusFile(*out, ""); err != nil {
+		fmt.Fprintf(os.Stderr, "tmux-status: %v\n", err)
+		return 1
+	}
+	return code
+}
+
+// watchStatus rewrites the status on every tick until stop delivers a
+// signal. A failed write is reported and tried again on the next tick, since
+// the events file may be in the middle of being replaced.
+func watchStatus(write func(time.Time) error, ticks <-chan time.Time, stop <-chan os.Signal) int {
+	for {
+		select {
+		case <-stop:
+			return 0
+		case now, ok := <-ticks:
+			if !ok {
+				return 0
+			}
+			if err := write(now); err != nil {
+				fmt.Fprintf(os.Stderr, "tmux-status: %v\n", err)
+			}
+		}
+	}
+}
+
+// writeStatusFile replaces the status file in one step, so tmux never reads
+// it half written.
+func writeStatusFile(path, line string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, []byte(line+"\n"))
+}
+
+// tmuxStatusLine is the next upcoming event and the time left, e.g.
+// "Launch 14d 8h" in countdown's format, or "" with nothing coming up. With
+// colors the countdown is styled with the event's urgency color. A # in the
+// name is doubled so tmux does not read it as the start of a style or format.
+func tmuxStatusLine(events []Event, now time.Time, opts nextOptions, countdown countdownFormat, colors bool) string {
+	e, ok := nextEvent(events, now, opts)
+	if !ok {
+		return ""
+	}
+	name := strings.ReplaceAll(e.Title(), "#", "##")
+	short := countdown.short(e.Time, now)
+	if !colors {
+		return name + " " + short
+	}
+	return fmt.Sprintf("%s #[fg=%s]%s#[default]", name, tmuxColor(getUrgencyColor(e.Time, now)), short)
+}
+
+// tmuxColor turns a palette color into the #rrggbb form tmux takes.
+func tmuxColor(c string) string {
+	return strings.ToLower(c)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestTmuxStatusLine(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	events := []Event{
+		{Name: "Retro", Time: now.Add(-time.Hour).Unix()},
+		{Name: "C# meetup", Time: now.Add(50 * time.Hour).Unix()},
+		{Name: "Launch", Time: now.Add(14*24*time.Hour + 8*time.Hour + 30*time.Minute).Unix()},
+	}
+
+	if got := tmuxStatusLine(events, now, nextOptions{}, countdownFormat{}, false); got != "C## meetup 2d 2h" {
+		t.Errorf("Expected the next event in plain text, got %q", got)
+	}
+	if got := tmuxStatusLine(events, now, nextOptions{}, countdownFormat{}, true); got != "C## meetup #[fg=#e74c3c]2d 2h#[default]" {
+		t.Errorf("Expected the countdown in its urgency color, got %q", got)
+	}
+	if got := tmuxStatusLine(events[2:], now, nextOptions{}, countdownFormat{}, true); got != "Launch #[fg=#58d68d]14d 8h#[default]" {
+		t.Errorf("Expected the two-week color, got %q", got)
+	}
+	if got := tmuxStatusLine(events[:1], now, nextOptions{}, countdownFormat{}, true); got != "" {
+		t.Errorf("Expected nothing without upcoming events, got %q", got)
+	}
+
+	yearly := []Event{{Name: "Anniversary", Time: now.AddDate(-1, 0, 3).Unix(), Yearly: true}}
+	if got := tmuxStatusLine(yearly, now, nextOptions{}, countdownFormat{}, false); got != "Anniversary 3d 0h" {
+		t.Errorf("Expected a passed yearly event at its next occurrence, got %q", got)
+	}
+}
+
+func TestTmuxColor(t *testing.T) {
+	if got := tmuxColor(cUrgency1); got != "#347a51" {
+		t.Errorf("Expected the alpha dropped, got %q", got)
+	}
+	if got := tmuxColor(cUrgency5); got != "#e74c3c" {
+		t.Errorf("Expected the color lowercased, got %q", got)
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	ticks := make(chan time.Time)
+	stop := make(chan os.Signal, 1)
+	var writes []time.Time
+	write := func(now time.Time) error {
+		writes = append(writes, now)
+		if len(writes) == 1 {
+			return errors.New("events file busy")
+		}
+		return nil
+	}
+
+	done := make(chan int)
+	go func() { done <- watchStatus(write, ticks, stop) }()
+	ticks <- start.Add(time.Minute)
+	ticks <- start.Add(2 * time.Minute)
+	stop <- syscall.SIGTERM
+	if code := <-done; code != 0 {
+		t.Errorf("Expected a clean exit on SIGTERM, got %d", code)
+	}
+	if len(writes) != 2 {
+		t.Errorf("Expected a write per tick, going on after a failure, got %d", len(writes))
+	}
+
+	path := filepath.Join(t.TempDir(), "cache", "status")
+	if err := writeStatusFile(path, "Launch 14d 8h"); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "Launch 14d 8h\n" {
+		t.Errorf("Expected the status in the file, got %q", data)
+	}
+}
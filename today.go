@@ -0,0 +1,132 @@
+package main
+
+import (
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// isToday reports whether ts falls on now's calendar day, whether it has
+// passed already or not.
+func isToday(ts int64, now time.Time) bool {
+	return daysUntil(ts, now) == 0
+}
+
+// countToday counts the items falling on now's calendar day.
+func countToday(items []list.Item, now time.Time) int {
+	n := 0
+	for _, item := range items {
+		if e, ok := item.(Event); ok && isToday(e.Time, now) {
+			n++
+		}
+	}
+	return n
+}
+
+// todayBadge marks an event of today in the list, or with passed one that
+// was earlier today. Without colors it is a bracketed word.
+func todayBadge(passed bool) string {
+	text, color := tr("today.badge"), cTodayBadge
+	if passed {
+		text, color = tr("today.earlier"), cHint
+	}
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return "[" + text + "]"
+	}
+	return lipgloss.NewStyle().
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(color)).
+		Padding(0, 1).
+		Render(text)
+}
+
+// eventDelegate draws the event list like the default delegate, with
+// today's events on a tinted row behind a badge. Items cannot see the model,
+// so the delegate carries what their rows show besides the events.
+type eventDelegate struct {
+	list.DefaultDelegate
+	now     time.Time // the time rows count from
+	whatIf  time.Time // the date rows count from in what-if mode, zero for now
+	compact bool      // shorten the countdowns for compact mode
+	marked  []Event   // marked for assigning to a group
+	format  countdownFormat
+}
+
+// delegate returns an eventDelegate drawing with base's styles and help as
+// of the model's current time.
+func (m MainModel) delegate(base list.DefaultDelegate) eventDelegate {
+	return eventDelegate{
+		DefaultDelegate: base,
+		now:             m.now(),
+		whatIf:          m.whatIf,
+		compact:         m.compact(),
+		marked:          m.marked,
+		format:          m.config.countdownFormat(),
+	}
+}
+
+func (d eventDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	switch item := item.(type) {
+	case sectionHeader:
+		d.renderHeader(w, m, index, item)
+		return
+	case tagGroup:
+		d.DefaultDelegate.Render(w, m, index, listRow{item, item.Title(), d.nextLine(item.Next)})
+		return
+	}
+	e, ok := item.(Event)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+	row := listRow{e, e.Title(), d.description(e)}
+	if !isToday(e.Time, d.now) {
+		if isMarked(d.marked, e) && m.FilterState() == list.Unfiltered {
+			row.title = d.markedTitle(e)
+		}
+		d.renderEvent(w, m, index, row)
+		return
+	}
+
+	tint := lipgloss.AdaptiveColor{Light: cTodayLight, Dark: cTodayDark}
+	s := &d.Styles
+	s.NormalTitle = s.NormalTitle.Background(tint).Width(m.Width())
+	s.NormalDesc = s.NormalDesc.Background(tint).Width(m.Width())
+	s.SelectedTitle = s.SelectedTitle.Background(tint).Width(m.Width() - 1)
+	s.SelectedDesc = s.SelectedDesc.Background(tint).Width(m.Width() - 1)
+	// Filter matches are highlighted by their place in the title, so the
+	// badge would throw them off.
+	if m.FilterState() != list.Unfiltered {
+		d.renderEvent(w, m, index, row)
+		return
+	}
+	// The name is styled on its own, as the badge's colors end with a reset.
+	style := s.NormalTitle
+	if index == m.Index() {
+		style = s.SelectedTitle
+	}
+	row.title = todayBadge(e.passed(d.now)) + style.Inline(true).Render(" "+d.markedTitle(e))
+	d.renderEvent(w, m, index, row)
+}
+
+// listRow is an item as the delegate draws it, with the title and
+// description worked out for this render.
+type listRow struct {
+	list.Item
+	title, desc string
+}
+
+func (r listRow) Title() string       { return r.title }
+func (r listRow) Description() string { return r.desc }
+
+// markedTitle is e's title in the list, ticked while it is marked for
+// assigning to a group.
+func (d eventDelegate) markedTitle(e Event) string {
+	if isMarked(d.marked, e) {
+		return "✓ " + e.Title()
+	}
+	return e.Title()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+func TestIsToday(t *testing.T) {
+	now := time.Date(2026, 3, 10, 23, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ts       time.Time
+		expected bool
+	}{
+		{time.Date(2026, 3, 10, 0, 5, 0, 0, time.UTC), true},
+		{time.Date(2026, 3, 10, 23, 59, 0, 0, time.UTC), true},
+		{time.Date(2026, 3, 11, 0, 30, 0, 0, time.UTC), false},
+		{time.Date(2026, 3, 9, 23, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := isToday(tt.ts.Unix(), now); got != tt.expected {
+			t.Errorf("isToday(%s) = %v, want %v", tt.ts, got, tt.expected)
+		}
+	}
+}
+
+func TestTodayBadges(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Standup", Time: now.Add(-3 * time.Hour).Unix()},
+		Event{Name: "Dinner", Time: now.Add(7 * time.Hour).Unix()},
+		Event{Name: "Brunch", Time: now.Add(22 * time.Hour).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	update(tea.WindowSizeMsg{Width: 140, Height: 30})
+
+	view := withColorProfile(termenv.Ascii, m.View)
+	for _, want := range []string{"[EARLIER]", "[TODAY] Dinner", "3 events · 2 today"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "] Brunch") {
+		t.Errorf("Expected no badge for tomorrow, under 24 hours away:\n%s", view)
+	}
+	colored := stripANSI(withColorProfile(termenv.TrueColor, m.View))
+	if strings.Contains(colored, "[TODAY]") || !strings.Contains(colored, " TODAY  Dinner") {
+		t.Errorf("Expected a colored badge instead of brackets:\n%s", colored)
+	}
+
+	// The rollover moves the badge on to the new day.
+	midnight := nextMidnight(now)
+	now = midnight.Add(time.Second)
+	update(midnightMsg(midnight))
+	view = withColorProfile(termenv.Ascii, m.View)
+	if !strings.Contains(view, "[TODAY] Brunch") || !strings.Contains(view, "3 events · 1 today") {
+		t.Errorf("Expected only Brunch marked after midnight:\n%s", view)
+	}
+	if strings.Contains(view, "[EARLIER]") || strings.Contains(view, "] Dinner") {
+		t.Errorf("Expected yesterday's events unmarked:\n%s", view)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// saved notes the outcome of saving the events. A failed save keeps the
+// program running with the changes in memory; the next successful save
+// writes them all, and quitting first asks what to do with them.
+func (m *MainModel) saved(err error) tea.Cmd {
+	m.saveErr = err
+	if err != nil {
+		return m.events.NewStatusMessage(ErrStyle(trf("unsaved.failed", err)))
+	}
+	return nil
+}
+
+// quit ends the program once the events are saved. After a failed save it
+// tries once more, and opens the unsaved prompt if that fails too.
+func (m *MainModel) quit() tea.Cmd {
+	if m.saveErr != nil {
+		m.saveErr = m.saveEventsToFile()
+	}
+	if m.saveErr == nil {
+		return tea.Quit
+	}
+	m.alternatePath = alternateSavePath(m.now())
+	m.alternateErr = nil
+	m.state = showUnsaved
+	return nil
+}
+
+// alternateSavePath suggests a file in the home directory for events that
+// cannot be saved where they belong.
+func alternateSavePath(now time.Time) string {
+	name := "countdown-events-" + now.Format("20060102-150405") + ".json"
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(home, name)
+}
+
+func writeEventsTo(path string, events []Event) error {
+	if events == nil {
+		events = []Event{} // [] rather than null
+	}
+	bytes, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, bytes)
+}
+
+// updateUnsaved handles a key in the unsaved prompt. Nothing but discard
+// quits without the events written somewhere. Either way out of the prompt
+// settles the changes, so the journal is cleared and the next start does
+// not offer them again; a journal that cannot be cleared only means it asks.
+func (m *MainModel) updateUnsaved(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, Keymap.SaveRetry):
+		m.saveErr = m.saveEventsToFile()
+		if m.saveErr == nil {
+			return tea.Quit
+		}
+	case key.Matches(msg, Keymap.SaveElsewhere):
+		m.alternateErr = writeEventsTo(m.alternatePath, m.storedEvents())
+		if m.alternateErr == nil {
+			m.savedTo = m.alternatePath
+			clearJournal()
+			return tea.Quit
+		}
+	case key.Matches(msg, Keymap.SaveDiscard):
+		m.err = fmt.Errorf("changes not saved: %w", m.saveErr)
+		clearJournal()
+		return tea.Quit
+	case key.Matches(msg, Keymap.Back):
+		m.state = showEvents
+	}
+	return nil
+}
+
+func (m MainModel) unsavedView() string {
+	width := min(64, m.windowWidth-8)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("unsaved.title")) + "\n\n")
+
+	b.WriteString(lipgloss.NewStyle().Width(width).Render(ErrStyle(m.saveErr.Error())) + "\n\n")
+	b.WriteString(BrightTextStyle("r") + "  " + NormalTextStyle(tr("unsaved.retry")) + "\n")
+	b.WriteString(BrightTextStyle("a") + "  " + NormalTextStyle(tr("unsaved.elsewhere")) + "\n")
+	b.WriteString(lipgloss.NewStyle().Width(width).PaddingLeft(3).Render(HintStyle(m.alternatePath)) + "\n")
+	if m.alternateErr != nil {
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(ErrStyle(m.alternateErr.Error())) + "\n")
+	}
+	b.WriteString(BrightTextStyle("d") + "  " + NormalTextStyle(tr("unsaved.discard")) + "\n\n")
+	b.WriteString(HintStyle(tr("unsaved.back")))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quits reports whether cmd, or a command batched in it, quits.
+func quits(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if quits(c) {
+				return true
+			}
+		}
+	case tea.QuitMsg:
+		return true
+	}
+	return false
+}
+
+func TestQuitWithUnsavedEvents(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Dentist", Time: now.AddDate(0, 0, 1).Unix()},
+		Event{Name: "Launch", Time: now.AddDate(0, 0, 9).Unix()},
+	)
+	var cmd tea.Cmd
+	update := func(msg tea.Msg) {
+		var model tea.Model
+		model, cmd = m.Update(msg)
+		m = model.(MainModel)
+	}
+	update(tea.WindowSizeMsg{Width: 100, Height: 40})
+
+	// A directory where the events file belongs makes every save fail.
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(eventsFile)
+	if err := os.MkdirAll(filepath.Join(eventsFile, "blocked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	if m.saveErr == nil || m.state != showEvents {
+		t.Fatal("Expected a failed save to keep the program running")
+	}
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if m.state != showUnsaved || quits(cmd) {
+		t.Fatalf("Expected q to ask about the unsaved events, got state %v", m.state)
+	}
+	want := filepath.Join(home, "countdown-events-20260310-120000.json")
+	if view := stripANSI(m.View()); !strings.Contains(view, "Events Not Saved") || m.alternatePath != want {
+		t.Errorf("Expected %s suggested in:\n%s", want, view)
+	}
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if m.state != showUnsaved || quits(cmd) {
+		t.Fatal("Expected a failed retry to stay in the prompt")
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !quits(cmd) {
+		t.Fatal("Expected saving elsewhere to quit")
+	}
+	if m.savedTo != want {
+		t.Errorf("Expected the events saved to %s, got %q", want, m.savedTo)
+	}
+	data, err := os.ReadFile(m.savedTo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := decodeEvents(m.savedTo, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := storedNames(events); got != "Launch" {
+		t.Errorf("Expected the latest events in the fallback file, got %s", got)
+	}
+	if entries, _ := readJournal(); len(entries) != 0 {
+		t.Errorf("Expected the journal cleared once the events are saved elsewhere, got %d entries", len(entries))
+	}
+}
+
+func TestQuitRetriesFailedSave(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.AddDate(0, 0, 9).Unix()})
+	eventsFile, err := getEventsFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The save failed once, but the disk is fine again by the time of quitting.
+	m.saveErr = os.ErrPermission
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = model.(MainModel)
+	if !quits(cmd) || m.saveErr != nil {
+		t.Fatalf("Expected the save retried and the program to quit, got %v", m.saveErr)
+	}
+	if events, err := readEventsFile(); err != nil || storedNames(events) != "Launch" {
+		t.Errorf("Expected %s written before quitting, got %v %v", eventsFile, events, err)
+	}
+
+	m.saveErr = os.ErrPermission
+	m.state = showUnsaved
+	if err := appendJournal(journalEntry{Add: m.storedEvents()}); err != nil {
+		t.Fatal(err)
+	}
+	model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = model.(MainModel)
+	if !quits(cmd) || m.err == nil {
+		t.Error("Expected discarding to quit and report the lost changes")
+	}
+	if entries, _ := readJournal(); len(entries) != 0 {
+		t.Errorf("Expected the discarded changes cleared from the journal, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type vCard struct {
+	Name     string
+	BirthDay int
+	Month    time.Month
+	Year     int // 0 when the BDAY omits the year
+	HasBDay  bool
+}
+
+var (
+	bdayFullRe   = regexp.MustCompile(`^(\d{4})[-/.]?(\d{2})[-/.]?(\d{2})`)
+	bdayNoYearRe = regexp.MustCompile(`^--(\d{2})-?(\d{2})$`)
+)
+
+// parseVCards reads every card in r. Folded lines are unfolded and only the
+// FN and BDAY properties are kept.
+func parseVCards(r io.Reader) ([]vCard, error) {
+	var cards []vCard
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var card *vCard
+	for _, line := range lines {
+		name, _, value, ok := splitContentLine(line)
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "BEGIN":
+			if strings.EqualFold(value, "VCARD") {
+				card = &vCard{}
+			}
+		case "END":
+			if card != nil && strings.EqualFold(value, "VCARD") {
+				cards = append(cards, *card)
+				card = nil
+			}
+		case "FN":
+			if card != nil {
+				card.Name = unescapeVCardText(value)
+			}
+		case "BDAY":
+			if card != nil {
+				card.Year, card.Month, card.BirthDay, card.HasBDay = parseBDay(value)
+			}
+		}
+	}
+	return cards, nil
+}
+
+// parseBDay understands YYYY-MM-DD with '-', '/', '.' or no separators,
+// optionally followed by a time, and the year-less --MMDD / --MM-DD forms.
+func parseBDay(value string) (year int, month time.Month, day int, ok bool) {
+	if m := bdayNoYearRe.FindStringSubmatch(value); m != nil {
+		mm, _ := strconv.Atoi(m[1])
+		dd, _ := strconv.Atoi(m[2])
+		if !validMonthDay(mm, dd) {
+			return 0, 0, 0, false
+		}
+		return 0, time.Month(mm), dd, true
+	}
+	if m := bdayFullRe.FindStringSubmatch(value); m != nil {
+		yy, _ := strconv.Atoi(m[1])
+		mm, _ := strconv.Atoi(m[2])
+		dd, _ := strconv.Atoi(m[3])
+		if !validMonthDay(mm, dd) {
+			return 0, 0, 0, false
+		}
+		// Some exporters write 1604 or 0000 for "year unknown".
+		if yy <= 1604 {
+			yy = 0
+		}
+		return yy, time.Month(mm), dd, true
+	}
+	return 0, 0, 0, false
+}
+
+func validMonthDay(month, day int) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+	// Use a leap year so February 29th is accepted.
+	return day <= time.Date(2000, time.Month(month+1), 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func unescapeVCardText(s string) string {
+	r := strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`)
+	return strings.TrimSpace(r.Replace(s))
+}
+
+// birthdayEvent turns a card into a yearly event at its next occurrence.
+func (c vCard) birthdayEvent(now time.Time) Event {
+	return Event{
+		Name:    c.Name + "'s Birthday",
+		Time:    nextAnniversary(c.Month, c.BirthDay, now).Unix(),
+		Tags:    []string{"birthday"},
+		Yearly:  true,
+		LeapDay: c.Month == time.February && c.BirthDay == 29,
+		Since:   c.Year,
+		Kind:    kindBirthday,
+		AllDay:  true,
+	}
+}
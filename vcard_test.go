@@ -0,0 +1,202 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testVCards = "BEGIN:VCARD\r\n" +
+	"VERSION:3.0\r\n" +
+	"FN:Ada Lovelace\r\n" +
+	"BDAY:1815-12-10\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"VERSION:4.0\r\n" +
+	"FN:Grace\r\n" +
+	"  Hopper\r\n" +
+	"BDAY;VALUE=date:--1209\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"FN:No Birthday\r\n" +
+	"TEL:555-0100\r\n" +
+	"END:VCARD\r\n" +
+	"BEGIN:VCARD\r\n" +
+	"FN:Smith\\, John\r\n" +
+	"item1.BDAY:19900412\r\n" +
+	"END:VCARD\r\n"
+
+func TestParseVCards(t *testing.T) {
+	cards, err := parseVCards(strings.NewReader(testVCards))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cards) != 4 {
+		t.Fatalf("Expected 4 cards, got %d", len(cards))
+	}
+
+	if cards[0].Name != "Ada Lovelace" || cards[0].Year != 1815 || cards[0].Month != time.December || cards[0].BirthDay != 10 {
+		t.Errorf("Unexpected first card: %+v", cards[0])
+	}
+	if cards[1].Name != "Grace Hopper" || cards[1].Year != 0 || cards[1].Month != time.December || cards[1].BirthDay != 9 {
+		t.Errorf("Unexpected folded/year-less card: %+v", cards[1])
+	}
+	if cards[2].HasBDay {
+		t.Errorf("Expected card without BDAY, got %+v", cards[2])
+	}
+	if cards[3].Name != "Smith, John" || cards[3].Year != 1990 || cards[3].Month != time.April {
+		t.Errorf("Unexpected grouped card: %+v", cards[3])
+	}
+}
+
+func TestParseBDay(t *testing.T) {
+	tests := []struct {
+		value string
+		year  int
+		month time.Month
+		day   int
+		ok    bool
+	}{
+		{"1990-04-12", 1990, time.April, 12, true},
+		{"1990/04/12", 1990, time.April, 12, true},
+		{"1990.04.12", 1990, time.April, 12, true},
+		{"19900412", 1990, time.April, 12, true},
+		{"1990-04-12T00:00:00Z", 1990, time.April, 12, true},
+		{"--0412", 0, time.April, 12, true},
+		{"--04-12", 0, time.April, 12, true},
+		{"1604-04-12", 0, time.April, 12, true},
+		{"--0229", 0, time.February, 29, true},
+		{"1990-02-30", 0, 0, 0, false},
+		{"1990-13-01", 0, 0, 0, false},
+		{"April 12", 0, 0, 0, false},
+		{"", 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			year, month, day, ok := parseBDay(tt.value)
+			if ok != tt.ok || year != tt.year || month != tt.month || day != tt.day {
+				t.Errorf("Expected (%d, %v, %d, %v), got (%d, %v, %d, %v)",
+					tt.year, tt.month, tt.day, tt.ok, year, month, day, ok)
+			}
+		})
+	}
+}
+
+func TestMergeBirthdays(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	cards, _ := parseVCards(strings.NewReader(testVCards))
+	existing := []Event{{Name: "Grace Hopper's Birthday", Time: now.Unix()}}
+
+	events, stats := mergeBirthdays(existing, append(cards, cards[0]), now)
+	if stats.Added != 2 || stats.Duplicates != 2 || stats.Skipped != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+
+	var ada Event
+	for _, e := range events {
+		if e.Name == "Ada Lovelace's Birthday" {
+			ada = e
+		}
+	}
+	if want := time.Date(2026, 12, 10, 0, 0, 0, 0, time.Local).Unix(); ada.Time != want {
+		t.Errorf("Expected next occurrence %d, got %d", want, ada.Time)
+	}
+	if !ada.Yearly || len(ada.Tags) != 1 || ada.Tags[0] != "birthday" || ada.Kind != kindBirthday {
+		t.Errorf("Expected yearly birthday-tagged event, got %+v", ada)
+	}
+	if ada.Title() != "Ada Lovelace's Birthday (211)" {
+		t.Errorf("Expected age in title, got '%s'", ada.Title())
+	}
+}
+
+func TestReadVCardPath(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.vcf"), []byte(testVCards), 0644)
+	os.WriteFile(filepath.Join(dir, "b.VCF"), []byte("BEGIN:VCARD\nFN:Alan\nBDAY:1912-06-23\nEND:VCARD\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("BEGIN:VCARD\nFN:Ignored\nEND:VCARD\n"), 0644)
+
+	cards, err := readVCardPath(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cards) != 5 {
+		t.Errorf("Expected 5 cards, got %d", len(cards))
+	}
+}
+
+func TestRollForwardYearly(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	events := []Event{
+		{Name: "Yearly", Time: time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local).Unix(), Yearly: true},
+		{Name: "Once", Time: time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local).Unix()},
+	}
+
+	if !rollForwardRecurring(events, now, 0) {
+		t.Fatal("Expected events to change")
+	}
+	if want := time.Date(2027, 3, 1, 9, 0, 0, 0, time.Local).Unix(); events[0].Time != want {
+		t.Errorf("Expected %d, got %d", want, events[0].Time)
+	}
+	if want := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local).Unix(); events[1].Time != want {
+		t.Errorf("Expected non-yearly event untouched, got %d", events[1].Time)
+	}
+	if rollForwardRecurring(events, now, 0) {
+		t.Error("Expected no change on second pass")
+	}
+}
+
+func TestYearlyLastsItsDay(t *testing.T) {
+	birthday := time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)
+	hold := 3 * time.Second
+	for _, e := range []Event{
+		{Name: "All day", Time: birthday.Unix(), Yearly: true, AllDay: true},
+		{Name: "Midnight", Time: birthday.Unix(), Yearly: true},
+	} {
+		events := []Event{e}
+		if rollForwardRecurring(events, birthday.Add(23*time.Hour), hold) {
+			t.Errorf("Expected %s kept on its day, got %s", e.Name, time.Unix(events[0].Time, 0))
+		}
+		if !rollForwardRecurring(events, birthday.AddDate(0, 0, 1), hold) || events[0].Time != birthday.AddDate(1, 0, 0).Unix() {
+			t.Errorf("Expected %s rolled on once its day is over, got %s", e.Name, time.Unix(events[0].Time, 0))
+		}
+	}
+
+	// A timed event rolls on once the hold at zero has passed.
+	events := []Event{{Name: "Timed", Time: birthday.Add(9 * time.Hour).Unix(), Yearly: true}}
+	if rollForwardRecurring(events, birthday.Add(9*time.Hour+time.Second), hold) {
+		t.Error("Expected a timed event kept during the hold")
+	}
+	if !rollForwardRecurring(events, birthday.Add(9*time.Hour+time.Minute), hold) {
+		t.Error("Expected a timed event rolled on after the hold")
+	}
+}
+
+func TestYearlyLeapDay(t *testing.T) {
+	leap := time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local)
+	events := []Event{{Name: "Leap", Time: leap.Unix(), Yearly: true, AllDay: true}}
+	want := []time.Time{
+		time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local),
+		time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local),
+		time.Date(2027, 2, 28, 0, 0, 0, 0, time.Local),
+		time.Date(2028, 2, 29, 0, 0, 0, 0, time.Local),
+	}
+	now := leap
+	for _, w := range want {
+		now = time.Unix(events[0].Time, 0).AddDate(0, 0, 1)
+		rollForwardRecurring(events, now, 0)
+		if got := time.Unix(events[0].Time, 0); !got.Equal(w) {
+			t.Fatalf("Expected the birthday on %s, got %s", w.Format("2006-01-02"), got.Format("2006-01-02"))
+		}
+	}
+
+	card := vCard{Name: "Leap", Month: time.February, BirthDay: 29}
+	if e := card.birthdayEvent(time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)); !e.LeapDay {
+		t.Error("Expected an imported leap-day birthday to remember its day")
+	}
+}
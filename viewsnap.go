@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewSnapshotMsg reports where ctrl+p wrote the frame, or why it could not.
+type viewSnapshotMsg struct {
+	path string
+	err  error
+}
+
+// viewSnapshotPath names the file for a frame taken at now, in the home
+// directory.
+func viewSnapshotPath(now time.Time) string {
+	name := "countdown-snapshot-" + now.Format("20060102-150405") + ".txt"
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(home, name)
+}
+
+// snapshotView writes the frame on screen, exactly as View returned it or
+// without colors with snapshot_strip_ansi. The file is written by the
+// command, so a slow disk does not hold up the UI.
+func (m MainModel) snapshotView() tea.Cmd {
+	frame := m.View()
+	if m.config.SnapshotStripANSI {
+		frame = stripANSI(frame)
+	}
+	path := viewSnapshotPath(m.now())
+	return func() tea.Msg {
+		return viewSnapshotMsg{path, os.WriteFile(path, []byte(frame), 0644)}
+	}
+}
+
+func (m *MainModel) viewSnapshotStatus(msg viewSnapshotMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.events.NewStatusMessage(ErrStyle(trf("snapshot.failed", msg.err)))
+	}
+	return m.events.NewStatusMessage(SuccessStyle(trf("snapshot.saved", msg.path)))
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+func TestSnapshotView(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	now := time.Date(2026, 4, 2, 9, 30, 15, 0, time.Local)
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.AddDate(0, 0, 3).Unix()})
+	// Narrow enough for the list to take the whole width, and the status
+	// line with it.
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 58, Height: 30})
+	m = model.(MainModel)
+
+	snapshot := func() (viewSnapshotMsg, string) {
+		t.Helper()
+		var cmd tea.Cmd
+		var frame string
+		withColorProfile(termenv.TrueColor, func() string {
+			frame = m.View()
+			cmd = m.snapshotView()
+			return ""
+		})
+		msg := cmd().(viewSnapshotMsg)
+		return msg, frame
+	}
+
+	msg, frame := snapshot()
+	want := filepath.Join(home, "countdown-snapshot-20260402-093015.txt")
+	if msg.err != nil || msg.path != want {
+		t.Fatalf("Expected the snapshot written to %s, got %s (%v)", want, msg.path, msg.err)
+	}
+	data, err := os.ReadFile(want)
+	if err != nil || string(data) != frame || !strings.Contains(frame, "\x1b[") {
+		t.Errorf("Expected the colored frame exactly as rendered, got (%v):\n%s", err, data)
+	}
+	model, _ = m.Update(msg)
+	m = model.(MainModel)
+	if view := stripANSI(m.View()); !strings.Contains(view, "View saved to") {
+		t.Errorf("Expected the path in the status line, got:\n%s", view)
+	}
+
+	m.config.SnapshotStripANSI = true
+	msg, frame = snapshot()
+	if data, _ := os.ReadFile(msg.path); string(data) != stripANSI(frame) {
+		t.Errorf("Expected snapshot_strip_ansi to write plain text, got:\n%q", data)
+	}
+
+	t.Setenv("HOME", filepath.Join(home, "missing"))
+	msg, _ = snapshot()
+	if msg.err == nil {
+		t.Fatal("Expected writing into a missing directory to fail")
+	}
+	model, _ = m.Update(msg)
+	m = model.(MainModel)
+	if view := stripANSI(m.View()); !strings.Contains(view, "Could not save the view") {
+		t.Errorf("Expected the failure in the status line, got:\n%s", view)
+	}
+
+	m.openForm(showInput)
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = model.(MainModel)
+	if cmd == nil || m.state != showInput || m.inputs[inputNameField].Value() != "" {
+		t.Errorf("Expected ctrl+p to snapshot the form without typing into it")
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/rom41572/countdown/pkg/when"
+)
+
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.Duration("interval", time.Second, "how often to update the line")
+	untilZero := fs.Bool("until-zero", false, "exit once the event arrives")
+	format := fs.String("format", "", "Go template for the line, with the list_format fields")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: countdown watch <event name> [--interval 1s] [--until-zero] [--format template]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	// Allow flags after the event name as well as before it.
+	name := fs.Arg(0)
+	if fs.NArg() > 1 {
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return 2
+		}
+	}
+	if name == "" || *interval <= 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var tmpl *template.Template
+	if *format != "" {
+		var err error
+		if tmpl, err = compileFieldsTemplate("format", *format); err != nil {
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+			return 2
+		}
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		return 2
+	}
+	setLanguage(cfg.Language)
+	events, err := readEventsFile()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		return 1
+	}
+	rollForwardRecurring(events, time.Now(), cfg.completionHold())
+	event, ok := findEventByName(events, name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "watch: no event named %q\n", name)
+		return 1
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	w := watcher{
+		out:       os.Stdout,
+		event:     event,
+		format:    tmpl,
+		display:   cfg.countdownFormat().display,
+		tty:       isatty.IsTerminal(os.Stdout.Fd()),
+		untilZero: *untilZero,
+	}
+	return w.run(time.Now(), ticker.C)
+}
+
+// watcher keeps one line counting down to an event.
+type watcher struct {
+	out       io.Writer
+	event     Event
+	format    *template.Template // nil for the built-in line
+	display   displayMode        // how the template's Countdown shows
+	tty       bool               // overwrite the line in place
+	untilZero bool               // stop once the event arrives
+}
+
+// run writes the line as of start and again on every tick. On a terminal
+// the line is rewritten in place; elsewhere, as in a log, each update is a
+// line of its own without control characters.
+func (w watcher) run(start time.Time, ticks <-chan time.Time) int {
+	now := start
+	for {
+		line := w.line(now)
+		if w.tty {
+			// Clear what a longer line before may have left.
+			fmt.Fprintf(w.out, "\r%s\x1b[K", line)
+		} else {
+			fmt.Fprintln(w.out, line)
+		}
+		if w.untilZero && w.event.Time <= now.Unix() {
+			if w.tty {
+				fmt.Fprintln(w.out)
+			}
+			return 0
+		}
+		var ok bool
+		if now, ok = <-ticks; !ok {
+			return 0
+		}
+	}
+}
+
+// line is the text shown for the event at now.
+func (w watcher) line(now time.Time) string {
+	if w.format != nil {
+		var b strings.Builder
+		if err := w.format.Execute(&b, newListFields(w.event, now, countdownFormat{display: w.display})); err != nil {
+			return err.Error()
+		}
+		// A line with a line break in it could not be overwritten.
+		return strings.ReplaceAll(b.String(), "\n", " ")
+	}
+	switch ts := w.event.Time; {
+	case ts == now.Unix() || w.untilZero && ts < now.Unix():
+		return trf("watch.now", w.event.Title())
+	case ts < now.Unix():
+		return trf("watch.ago", w.event.Title(), formatClock(ts, now))
+	default:
+		return trf("watch.remaining", w.event.Title(), formatClock(ts, now))
+	}
+}
+
+// formatClock writes the time between now and ts as whole days and a clock,
+// e.g. "3d 04:12:55", or just the clock within a day.
+func formatClock(ts int64, now time.Time) string {
+	from, to := now, time.Unix(ts, 0).In(now.Location())
+	if to.Before(from) {
+		from, to = to, from
+	}
+	days := when.WholeDays(from, to)
+	rest := int(to.Sub(from.AddDate(0, 0, days)) / time.Second)
+	clock := fmt.Sprintf("%02d:%02d:%02d", rest/secondsPerHour, rest%secondsPerHour/secondsPerMinute, rest%secondsPerMinute)
+	if days > 0 {
+		return fmt.Sprintf("%dd %s", days, clock)
+	}
+	return clock
+}
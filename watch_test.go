@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatClock(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ts       time.Time
+		expected string
+	}{
+		{now.Add(3*24*time.Hour + 4*time.Hour + 12*time.Minute + 55*time.Second), "3d 04:12:55"},
+		{now.Add(59 * time.Second), "00:00:59"},
+		{now.AddDate(1, 0, 0), "365d 00:00:00"},
+		{now.Add(-26 * time.Hour), "1d 02:00:00"},
+	}
+	for _, tt := range tests {
+		if got := formatClock(tt.ts.Unix(), now); got != tt.expected {
+			t.Errorf("formatClock(%s) = %q, want %q", tt.ts, got, tt.expected)
+		}
+	}
+}
+
+// watchTicks returns a closed channel of one tick a second after start.
+func watchTicks(start time.Time, n int) <-chan time.Time {
+	ticks := make(chan time.Time, n)
+	for i := 1; i <= n; i++ {
+		ticks <- start.Add(time.Duration(i) * time.Second)
+	}
+	close(ticks)
+	return ticks
+}
+
+func TestWatchUntilZero(t *testing.T) {
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	launch := Event{Name: "Launch", Time: start.Add(2 * time.Second).Unix()}
+
+	var out bytes.Buffer
+	w := watcher{out: &out, event: launch, untilZero: true}
+	if code := w.run(start, watchTicks(start, 5)); code != 0 {
+		t.Errorf("Expected exit status 0, got %d", code)
+	}
+	expected := "Launch — 00:00:02 remaining\nLaunch — 00:00:01 remaining\nLaunch — now\n"
+	if out.String() != expected {
+		t.Errorf("Expected one line per tick until the launch, got %q", out.String())
+	}
+
+	out.Reset()
+	w.tty = true
+	w.run(start, watchTicks(start, 5))
+	expected = "\rLaunch — 00:00:02 remaining\x1b[K\rLaunch — 00:00:01 remaining\x1b[K\rLaunch — now\x1b[K\n"
+	if out.String() != expected {
+		t.Errorf("Expected the line overwritten in place, got %q", out.String())
+	}
+}
+
+func TestWatchKeepsCounting(t *testing.T) {
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	launch := Event{Name: "Launch", Time: start.Add(time.Second).Unix()}
+
+	var out bytes.Buffer
+	w := watcher{out: &out, event: launch}
+	w.run(start, watchTicks(start, 2))
+	if !strings.HasSuffix(out.String(), "Launch — 00:00:01 ago\n") {
+		t.Errorf("Expected the watch to count on past the launch, got %q", out.String())
+	}
+
+	tmpl, err := compileFieldsTemplate("format", "{{.Name}}: {{.Clock}}\n{{.Relative}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	w = watcher{out: &out, event: Event{Name: "Trip", Time: start.AddDate(0, 0, 21).Unix()}, format: tmpl}
+	w.run(start, watchTicks(start, 0))
+	if out.String() != "Trip: 21d 00:00:00 in 3 weeks\n" {
+		t.Errorf("Expected the template on one line, got %q", out.String())
+	}
+
+	if _, err := compileFieldsTemplate("format", "{{.Nmae}}"); err == nil || !strings.HasPrefix(err.Error(), "format:1:2:") {
+		t.Errorf("Expected the error to name the flag, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func parseWeekStart(s string) (time.Weekday, error) {
+	switch strings.ToLower(s) {
+	case "", "monday":
+		return time.Monday, nil
+	case "sunday":
+		return time.Sunday, nil
+	case "saturday":
+		return time.Saturday, nil
+	}
+	return time.Monday, fmt.Errorf(`week_start must be "monday", "sunday" or "saturday", got %q`, s)
+}
+
+// weekBounds returns the first day of the week containing t and the first
+// day of the following week, as UTC calendar dates so day arithmetic is
+// unaffected by DST.
+func weekBounds(t time.Time, start time.Weekday) (time.Time, time.Time) {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(date.Weekday()) - int(start) + 7) % 7
+	first := date.AddDate(0, 0, -offset)
+	return first, first.AddDate(0, 0, 7)
+}
+
+// weekNumber numbers weeks starting on Monday the ISO 8601 way. For other
+// start days week 1 is the week containing January 1st, as in the US.
+func weekNumber(t time.Time, start time.Weekday) (year, week int) {
+	if start == time.Monday {
+		return t.ISOWeek()
+	}
+	first, _ := weekBounds(t, start)
+	year = t.Year()
+	if next, _ := weekBounds(time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC), start); next.Equal(first) {
+		return year + 1, 1
+	}
+	jan1, _ := weekBounds(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	return year, int(first.Sub(jan1).Hours()/24)/7 + 1
+}
+
+func weekLabel(t time.Time, start time.Weekday) string {
+	year, week := weekNumber(t, start)
+	return trf("weeks.label", week, year)
+}
+
+// weeksBetween counts the complete weeks lying strictly between the weeks
+// containing from and to. Adjacent or identical weeks yield 0.
+func weeksBetween(from, to time.Time, start time.Weekday) int {
+	if to.Before(from) {
+		from, to = to, from
+	}
+	fromWeek, _ := weekBounds(from, start)
+	toWeek, _ := weekBounds(to, start)
+	weeks := int(toWeek.Sub(fromWeek).Hours()/24)/7 - 1
+	if weeks < 0 {
+		return 0
+	}
+	return weeks
+}
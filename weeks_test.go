@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		start    time.Weekday
+		expected string
+	}{
+		{"Mid year", time.Date(2026, 6, 10, 12, 0, 0, 0, time.Local), time.Monday, "week 24 of 2026"},
+		{"Dec 29 belongs to next year", time.Date(2025, 12, 29, 0, 0, 0, 0, time.Local), time.Monday, "week 1 of 2026"},
+		{"Dec 31 belongs to next year", time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local), time.Monday, "week 1 of 2025"},
+		{"Jan 1 belongs to previous year", time.Date(2027, 1, 1, 0, 0, 0, 0, time.Local), time.Monday, "week 53 of 2026"},
+		{"Jan 3 belongs to previous year", time.Date(2021, 1, 3, 0, 0, 0, 0, time.Local), time.Monday, "week 53 of 2020"},
+		{"Jan 4 is always week 1", time.Date(2021, 1, 4, 0, 0, 0, 0, time.Local), time.Monday, "week 1 of 2021"},
+		{"Sunday start", time.Date(2026, 6, 14, 12, 0, 0, 0, time.Local), time.Sunday, "week 25 of 2026"},
+		{"Same Sunday, Monday start", time.Date(2026, 6, 14, 12, 0, 0, 0, time.Local), time.Monday, "week 24 of 2026"},
+		{"Saturday start", time.Date(2026, 6, 13, 12, 0, 0, 0, time.Local), time.Saturday, "week 25 of 2026"},
+		{"Week with Jan 1 is week 1", time.Date(2025, 12, 29, 0, 0, 0, 0, time.Local), time.Sunday, "week 1 of 2026"},
+		{"Last week of the year", time.Date(2025, 12, 27, 0, 0, 0, 0, time.Local), time.Sunday, "week 52 of 2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weekLabel(tt.date, tt.start); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWeeksBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected int
+	}{
+		{"Same week", time.Date(2026, 6, 8, 0, 0, 0, 0, time.Local), time.Date(2026, 6, 14, 23, 0, 0, 0, time.Local), 0},
+		{"Next week", time.Date(2026, 6, 14, 0, 0, 0, 0, time.Local), time.Date(2026, 6, 15, 0, 0, 0, 0, time.Local), 0},
+		{"Three weeks later", time.Date(2026, 6, 10, 0, 0, 0, 0, time.Local), time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local), 2},
+		{"Across year boundary", time.Date(2026, 12, 28, 0, 0, 0, 0, time.Local), time.Date(2027, 1, 11, 0, 0, 0, 0, time.Local), 1},
+		{"Dec 31 to Jan 3 is one ISO week", time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local), time.Date(2027, 1, 3, 0, 0, 0, 0, time.Local), 0},
+		{"Reversed order", time.Date(2026, 7, 1, 0, 0, 0, 0, time.Local), time.Date(2026, 6, 10, 0, 0, 0, 0, time.Local), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weeksBetween(tt.from, tt.to, time.Monday); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWeekBoundsGrouping(t *testing.T) {
+	saturday := time.Date(2026, 6, 13, 12, 0, 0, 0, time.Local)
+	sunday := time.Date(2026, 6, 14, 12, 0, 0, 0, time.Local)
+	monday := time.Date(2026, 6, 15, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		start      time.Weekday
+		firstDay   int // day of June starting the week containing the 14th
+		satWithSun bool
+		sunWithMon bool
+	}{
+		{time.Monday, 8, true, false},
+		{time.Sunday, 14, false, true},
+		{time.Saturday, 13, true, true},
+	}
+
+	sameWeek := func(a, b time.Time, start time.Weekday) bool {
+		first, _ := weekBounds(a, start)
+		other, _ := weekBounds(b, start)
+		return first.Equal(other)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.start.String(), func(t *testing.T) {
+			first, end := weekBounds(sunday, tt.start)
+			if first.Day() != tt.firstDay || end.Sub(first) != 7*24*time.Hour {
+				t.Errorf("Expected week of June %d, got %s to %s", tt.firstDay, first.Format(inputTimeFormShort), end.Format(inputTimeFormShort))
+			}
+			if got := sameWeek(saturday, sunday, tt.start); got != tt.satWithSun {
+				t.Errorf("Expected Saturday and Sunday in the same week: %v, got %v", tt.satWithSun, got)
+			}
+			if got := sameWeek(sunday, monday, tt.start); got != tt.sunWithMon {
+				t.Errorf("Expected Sunday and Monday in the same week: %v, got %v", tt.sunWithMon, got)
+			}
+		})
+	}
+
+	// From Saturday the 13th to Sunday the 21st, the week of the 14th lies
+	// wholly between them only when weeks start on Sunday.
+	nextSunday := sunday.AddDate(0, 0, 7)
+	if got := weeksBetween(saturday, nextSunday, time.Monday); got != 0 {
+		t.Errorf("Expected 0 whole Monday weeks, got %d", got)
+	}
+	if got := weeksBetween(saturday, nextSunday, time.Sunday); got != 1 {
+		t.Errorf("Expected 1 whole Sunday week, got %d", got)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// whatIfSpan returns how far ts is from at and whether it comes after,
+// or an empty span when they are under a minute apart.
+func whatIfSpan(ts int64, at time.Time) (string, bool) {
+	t := time.Unix(ts, 0).In(at.Location())
+	from, to := at, t
+	if t.Before(at) {
+		from, to = t, at
+	}
+	switch d := to.Sub(from); {
+	case d < time.Minute:
+		return "", false
+	case d < 24*time.Hour:
+		return formatLeadTime(d.Truncate(time.Minute)), t.After(at)
+	}
+	return formatSpan(from, to), t.After(at)
+}
+
+// formatWhatIf describes ts relative to the what-if date, e.g. "3 weeks
+// after".
+func formatWhatIf(ts int64, at time.Time) string {
+	span, after := whatIfSpan(ts, at)
+	switch {
+	case span == "":
+		return tr("whatif.same")
+	case after:
+		return trf("whatif.after", span)
+	}
+	return trf("whatif.before", span)
+}
+
+// whatIfDescription is e's list description in what-if mode.
+func (d eventDelegate) whatIfDescription(e Event) string {
+	return WhatIfStyle(formatWhatIf(e.Time, d.whatIf))
+}
+
+// startWhatIf measures the list from the date typed into the what-if prompt.
+func (m *MainModel) startWhatIf(input string) tea.Cmd {
+	date, err := parseDateInput(input, m.now())
+	if err != nil {
+		return m.events.NewStatusMessage(ErrStyle(err.Error()))
+	}
+	m.whatIf = date
+	return nil
+}
+
+// whatIfBanner takes the place of the list title in what-if mode.
+func (m MainModel) whatIfBanner() string {
+	text := trf("whatif.banner", m.whatIf.Format(m.config.dateLayout()))
+	return lipgloss.NewStyle().
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cWhatIf)).
+		Padding(0, 1).
+		Render(ansi.Truncate(text, max(m.listWidth-4, 1), "…"))
+}
+
+// whatIfLine is the detail column's line for e in what-if mode.
+func (m MainModel) whatIfLine(e Event) string {
+	date := m.whatIf.Format(m.config.dateLayout())
+	span, after := whatIfSpan(e.Time, m.whatIf)
+	text := trf("whatif.detail_same", date)
+	if span != "" && after {
+		text = trf("whatif.detail_after", span, date)
+	} else if span != "" {
+		text = trf("whatif.detail_before", span, date)
+	}
+	return lipgloss.NewStyle().Width(m.detailWidth-6).Render(NormalTextStyle("🧭 ")+WhatIfStyle(text)) + "\n"
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatWhatIf(t *testing.T) {
+	at := time.Date(2026, 6, 5, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		ts   time.Time
+		want string
+	}{
+		{at.AddDate(0, 0, 21), "3 weeks after"},
+		{at.AddDate(0, 0, -2), "2 days before"},
+		{at.Add(5*time.Hour + 30*time.Minute + 20*time.Second), "5h30m after"},
+		{at.AddDate(0, 4, 0), "4 months after"},
+		{at.Add(20 * time.Second), "same time"},
+	} {
+		if got := formatWhatIf(tt.ts.Unix(), at); got != tt.want {
+			t.Errorf("formatWhatIf(%s) = %q, want %q", tt.ts, got, tt.want)
+		}
+	}
+}
+
+func TestWhatIfMode(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	m := newRefreshTestModel(t, &now,
+		Event{Name: "Conference", Time: time.Date(2026, 6, 3, 0, 0, 0, 0, time.Local).Unix()},
+		Event{Name: "Wedding", Time: time.Date(2026, 6, 26, 0, 0, 0, 0, time.Local).Unix()},
+	)
+	update := func(msg tea.Msg) {
+		model, _ := m.Update(msg)
+		m = model.(MainModel)
+	}
+	update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	if m.state != showWhatIf {
+		t.Fatalf("Expected W to open the what-if prompt, got state %v", m.state)
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "What if it were:") {
+		t.Errorf("Expected the prompt in place of the title:\n%s", view)
+	}
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2026-06-05")})
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != showEvents || m.whatIf.IsZero() {
+		t.Fatalf("Expected the list measured from the date, got state %v", m.state)
+	}
+
+	view := stripANSI(m.View())
+	for _, want := range []string{"🧭 What if: Friday", "2 days before", "3 weeks after", "2 days before Friday, June 5,"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in:\n%s", want, view)
+		}
+	}
+
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := stripANSI(m.View()); !m.whatIf.IsZero() || strings.Contains(view, "What if") || strings.Contains(view, "weeks after") {
+		t.Errorf("Expected esc to return to countdowns from now:\n%s", view)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxWikiText is the most runes of an entry's text, an extract or a page
+// title kept for the panel, which shows two lines of it at most.
+const maxWikiText = 500
+
+// sanitizeWikiText makes text from the Wikipedia API safe to draw in the
+// panel. Control characters could move the cursor or clear the screen, and
+// format characters, such as zero-width joiners and bidi overrides, change
+// how the terminal lays out the rest of the line, so both are dropped.
+// Whitespace of any kind becomes single spaces, and text longer than
+// maxWikiText runes is cut off with an ellipsis.
+func sanitizeWikiText(s string) string {
+	var b strings.Builder
+	n := 0
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		case unicode.Is(unicode.Cc, r), unicode.Is(unicode.Cf, r):
+			continue
+		}
+		runes := 1
+		if space {
+			runes++
+		}
+		if n+runes > maxWikiText {
+			b.WriteString("…")
+			break
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+		n += runes
+	}
+	return b.String()
+}
+
+// sanitizeWikiEvents cleans a feed's text once, as it arrives, rather than
+// on every render. Page titles are kept as they came for their links, with
+// a cleaned label to show.
+func sanitizeWikiEvents(events []WikiEvent) []WikiEvent {
+	clean := make([]WikiEvent, len(events))
+	for i, e := range events {
+		e.Text = sanitizeWikiText(e.Text)
+		pages := make([]WikiPage, len(e.Pages))
+		for j, page := range e.Pages {
+			page.Extract = sanitizeWikiText(page.Extract)
+			page.label = sanitizeWikiText(strings.ReplaceAll(page.Title, "_", " "))
+			pages[j] = page
+		}
+		e.Pages = pages
+		clean[i] = e
+	}
+	return clean
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestSanitizeWikiText(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"Plain", "Apollo 11 lands on the Moon.", "Apollo 11 lands on the Moon."},
+		{"Escape sequences", "Moon\x1b[2J\x1b[H landing\a", "Moon[2J[H landing"},
+		{"C1 controls", "Moon\u009b2J\u0090 landing", "Moon2J landing"},
+		{"Whitespace", "\t Moon\n\r\nlanding   1969  ", "Moon landing 1969"},
+		{"Zero-width", "Mo\u200bon\u200d la\u2060nding\ufeff", "Moon landing"},
+		{"Bidi overrides", "\u202eMoon\u202c \u2067landing\u2069\u200f\u061c", "Moon landing"},
+		{"Wide characters kept", "月面着陸 🚀", "月面着陸 🚀"},
+		{"Only controls", "\x00\x1b\u202e", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeWikiText(tt.text); got != tt.want {
+				t.Errorf("sanitizeWikiText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	long := sanitizeWikiText(strings.Repeat("moon ", 1000))
+	if n := utf8.RuneCountInString(long); n > maxWikiText+1 || !strings.HasSuffix(long, "…") {
+		t.Errorf("Expected a long entry cut to %d runes and an ellipsis, got %d: %q", maxWikiText, n, long)
+	}
+}
+
+func TestWikiTextSanitizedOnArrival(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	now := time.Now()
+	m := newRefreshTestModel(t, &now, Event{Name: "Launch", Time: now.Add(48 * time.Hour).Unix()})
+	model, _ := m.Update(OnThisDayMsg{day: now, events: []WikiEvent{{
+		Year: 1969,
+		Text: "Moon\x1b[2J landing\u202e",
+		Pages: []WikiPage{
+			{Title: "Apollo_11\u200f", Extract: "First\x00 crewed\n landing"},
+			{Title: "Neil_Armstrong"},
+		},
+	}}})
+	m = model.(MainModel)
+
+	e := m.wiki().events[0]
+	if e.Text != "Moon[2J landing" || e.Pages[0].Extract != "First crewed landing" {
+		t.Errorf("Expected the text cleaned as it arrived, got %q and %q", e.Text, e.Pages[0].Extract)
+	}
+	if e.Pages[0].Title != "Apollo_11\u200f" || e.Pages[0].label != "Apollo 11" {
+		t.Errorf("Expected the raw title kept for the link beside a clean label, got %q and %q", e.Pages[0].Title, e.Pages[0].label)
+	}
+
+	m.focusPanel(int(sidePanel - m.panelFocus))
+	view := m.View()
+	if strings.Contains(view, "\x1b[2J") || strings.ContainsAny(view, "\u202e\u200f") {
+		t.Errorf("Expected no control characters from the feed in the view, got %q", view)
+	}
+	if plain := stripANSI(view); !strings.Contains(plain, "Moon[2J landing") || !strings.Contains(plain, "1 Apollo 11") {
+		t.Errorf("Expected the cleaned entry and page in the panel, got:\n%s", plain)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wizardField is a question of the first-run wizard, in the order asked.
+type wizardField int
+
+const (
+	wizardPathField wizardField = iota
+	wizardWikipediaField
+	wizardClockField
+	wizardFirstEventField
+	wizardStartField
+)
+
+// eventTemplate is an event the wizard offers to add, yearly from its next
+// anniversary.
+type eventTemplate struct {
+	ID    string // message ID of its name
+	Month time.Month
+	Day   int
+}
+
+var eventTemplates = []eventTemplate{
+	{"wizard.template.new_year", time.January, 1},
+	{"wizard.template.valentine", time.February, 14},
+	{"wizard.template.halloween", time.October, 31},
+	{"wizard.template.christmas", time.December, 25},
+}
+
+// The first event choices are the user's own, the templates, then nothing.
+const firstEventOwn = 0
+
+var firstEventNone = len(eventTemplates) + 1
+
+// wizardModel holds the answers given so far.
+type wizardModel struct {
+	field       wizardField
+	path        textinput.Model
+	defaultPath string
+	wikipedia   bool
+	clock24     bool
+	first       int
+	err         error
+}
+
+// wizardConfig is the config file the wizard writes.
+type wizardConfig struct {
+	EventsFile string `toml:"events_file,omitempty"`
+	SidePanel  string `toml:"side_panel"`
+	TimeFormat string `toml:"time_format"`
+}
+
+// isFirstRun reports whether countdown has not been set up yet: there is
+// no config file, no events file named in the environment, and no events
+// in the default events file.
+func isFirstRun() bool {
+	if os.Getenv(eventsFileEnv) != "" {
+		return false
+	}
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(configFile); !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	path, err := getEventsFilePath()
+	if err != nil {
+		// Starting up reports it.
+		return false
+	}
+	return holdsNoEvents(path)
+}
+
+// holdsNoEvents reports whether the events file at path is missing or
+// holds no events.
+func holdsNoEvents(path string) bool {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+	events, err := decodeEvents(path, data)
+	return err == nil && len(events) == 0
+}
+
+func (m *MainModel) openWizard() tea.Cmd {
+	path, _ := getEventsFilePath()
+	m.wizard = wizardModel{
+		path:        textinput.New(),
+		defaultPath: path,
+		wikipedia:   m.config.SidePanel == "onthisday",
+		clock24:     strings.Contains(m.config.timeLayout(), "15"),
+		first:       firstEventOwn,
+	}
+	m.wizard.path.Prompt = ""
+	m.wizard.path.SetValue(path)
+	m.state = showWizard
+	return m.wizard.path.Focus()
+}
+
+// updateWizard moves between the questions and changes their answers.
+// Enter on the last one or ctrl+s from anywhere applies them; esc keeps
+// the defaults.
+func (m *MainModel) updateWizard(msg tea.KeyMsg) tea.Cmd {
+	w := &m.wizard
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		return m.quit()
+	case key.Matches(msg, Keymap.Back):
+		// Skip the questions, keeping the defaults.
+		m.openWizard()
+		m.wizard.first = firstEventNone
+		return m.finishWizard()
+	case key.Matches(msg, Keymap.Submit):
+		return m.finishWizard()
+	case key.Matches(msg, Keymap.Enter) && w.field == wizardStartField:
+		return m.finishWizard()
+	case key.Matches(msg, Keymap.Enter, Keymap.Next, Keymap.StepDown):
+		return w.move(1)
+	case key.Matches(msg, Keymap.Prev, Keymap.StepUp):
+		return w.move(-1)
+	case w.field == wizardPathField:
+		var cmd tea.Cmd
+		w.path, cmd = w.path.Update(msg)
+		w.err = nil
+		return cmd
+	case key.Matches(msg, Keymap.OptionPrev):
+		w.change(-1)
+	case key.Matches(msg, Keymap.OptionNext):
+		w.change(1)
+	}
+	return nil
+}
+
+func (w *wizardModel) move(step int) tea.Cmd {
+	w.field = wizardField((int(w.field) + step + int(wizardStartField) + 1) % (int(wizardStartField) + 1))
+	if w.field == wizardPathField {
+		return w.path.Focus()
+	}
+	w.path.Blur()
+	return nil
+}
+
+func (w *wizardModel) change(step int) {
+	switch w.field {
+	case wizardWikipediaField:
+		w.wikipedia = !w.wikipedia
+	case wizardClockField:
+		w.clock24 = !w.clock24
+	case wizardFirstEventField:
+		w.first = (w.first + step + firstEventNone + 1) % (firstEventNone + 1)
+	}
+}
+
+// finishWizard writes the config and the events file for the answers,
+// then adds the first event or opens the form for it. A path that cannot
+// be set up is reported and nothing is written.
+func (m *MainModel) finishWizard() tea.Cmd {
+	w := &m.wizard
+	path := expandHome(strings.TrimSpace(w.path.Value()))
+	if path == "" {
+		path = w.defaultPath
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	cfg := wizardConfig{SidePanel: "timeline", TimeFormat: "12h"}
+	if w.wikipedia {
+		cfg.SidePanel = "onthisday"
+	}
+	if w.clock24 {
+		cfg.TimeFormat = "24h"
+	}
+	if path != w.defaultPath {
+		cfg.EventsFile = path
+		// Set up the events file before the config points at it.
+		_, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			_, err = seedEventsFile(path)
+		}
+		if err != nil {
+			w.err = err
+			return nil
+		}
+	}
+	if err := writeWizardConfig(cfg); err != nil {
+		w.err = err
+		return nil
+	}
+	m.config.SidePanel = cfg.SidePanel
+	m.config.TimeFormat = cfg.TimeFormat
+	m.side, _ = sideProviderIndex(cfg.SidePanel)
+
+	if cfg.EventsFile != "" {
+		// The session moves to the new file. An existing file there is
+		// kept, and its events shown.
+		if eventsFilePath != "" {
+			eventsFilePath = path
+		}
+		events, err := readEventsFile()
+		if err != nil {
+			w.err = err
+			return nil
+		}
+		if holdsNoEvents(w.defaultPath) {
+			os.Remove(w.defaultPath)
+		}
+		now := m.now()
+		rollForwardRecurring(events, now, m.config.completionHold())
+		events = append(events, virtualEvents(now, m.config)...)
+		m.config.sortOrder().sort(events, now)
+		m.setEvents(events)
+	}
+
+	switch {
+	case w.first == firstEventOwn:
+		m.openForm(showInput)
+	case w.first < firstEventNone:
+		t := eventTemplates[w.first-1]
+		return m.saveForm(Event{
+			Name:   tr(t.ID),
+			Time:   nextAnniversary(t.Month, t.Day, m.now()).Unix(),
+			Yearly: true,
+		}, false)
+	default:
+		m.returnToList()
+	}
+	return nil
+}
+
+func writeWizardConfig(cfg wizardConfig) error {
+	configFile, err := getConfigFilePath()
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("# Written by the first-run setup. The README lists every setting.\n")
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(configFile, buf.Bytes())
+}
+
+// firstEventLabel names a first event choice.
+func firstEventLabel(i int) string {
+	switch {
+	case i == firstEventOwn:
+		return tr("wizard.first_own")
+	case i < firstEventNone:
+		return tr(eventTemplates[i-1].ID)
+	default:
+		return tr("wizard.first_none")
+	}
+}
+
+func (m MainModel) wizardView() string {
+	w := m.wizard
+	width := min(64, m.windowWidth-8)
+
+	label := func(field wizardField, id string) string {
+		style := InputLabelStyle
+		if w.field == field {
+			style = style.Foreground(paletteColor(cPromptBorder))
+		}
+		return style.Render(tr(id)) + "\n"
+	}
+	options := func(field wizardField, labels []string, selected int) string {
+		parts := make([]string, len(labels))
+		for i, l := range labels {
+			switch {
+			case i == selected && w.field == field:
+				parts[i] = FocusedStyle.Render("● " + l)
+			case i == selected:
+				parts[i] = BrightTextStyle("● " + l)
+			default:
+				parts[i] = HintStyle("○ " + l)
+			}
+		}
+		return "  " + strings.Join(parts, "   ") + "\n"
+	}
+	choice := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Foreground(paletteColor(cTextLightGray)).
+		Background(paletteColor(cDetailTitle)).
+		Padding(0, 1).
+		Align(lipgloss.Center).
+		Render(tr("wizard.title")) + "\n\n")
+	b.WriteString(lipgloss.NewStyle().Width(width).Render(NormalTextStyle(tr("wizard.intro"))) + "\n")
+
+	b.WriteString(label(wizardPathField, "wizard.path"))
+	path := w.path
+	path.Width = width - 4
+	b.WriteString("  " + path.View() + "\n")
+	if w.field == wizardPathField && strings.TrimSpace(w.path.Value()) != w.defaultPath {
+		b.WriteString(DatePreviewStyle.Render(trf("wizard.path_default", w.defaultPath)) + "\n")
+	}
+
+	b.WriteString(label(wizardWikipediaField, "wizard.wikipedia"))
+	b.WriteString(options(wizardWikipediaField, []string{tr("wizard.show"), tr("wizard.hide")}, choice(!w.wikipedia)))
+	b.WriteString(label(wizardClockField, "wizard.clock"))
+	b.WriteString(options(wizardClockField, []string{tr("wizard.clock_12h"), tr("wizard.clock_24h")}, choice(w.clock24)))
+
+	b.WriteString(label(wizardFirstEventField, "wizard.first"))
+	first := "‹ " + firstEventLabel(w.first) + " ›"
+	if w.field == wizardFirstEventField {
+		first = FocusedStyle.Render(first)
+	} else {
+		first = BrightTextStyle(first)
+	}
+	b.WriteString("  " + first + "\n\n")
+
+	button := ButtonStyle
+	if w.field == wizardStartField {
+		button = ButtonFocusedStyle
+	}
+	b.WriteString(button.Render(tr("wizard.start")) + "\n")
+	if w.err != nil {
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(ErrStyle(w.err.Error())) + "\n")
+	}
+	b.WriteString("\n" + HintStyle(tr("wizard.help")))
+
+	box := lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(paletteColor(cPromptBorder)).
+		Render(b.String())
+	return lipgloss.Place(m.windowWidth, m.windowHeight, lipgloss.Center, lipgloss.Center, box)
+}
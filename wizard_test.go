@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIsFirstRun(t *testing.T) {
+	th := newTestHelper(t)
+	defer th.cleanup()
+
+	if !isFirstRun() {
+		t.Fatal("Expected a fresh install to be a first run")
+	}
+	if _, err := readEventsFile(); err != nil {
+		t.Fatal(err)
+	}
+	if !isFirstRun() {
+		t.Error("Expected an empty events file to still be a first run")
+	}
+
+	t.Setenv(eventsFileEnv, filepath.Join(t.TempDir(), "events.json"))
+	if isFirstRun() {
+		t.Error("Expected COUNTDOWN_EVENTS_FILE to skip the wizard")
+	}
+	os.Unsetenv(eventsFileEnv)
+
+	configFile, _ := getConfigFilePath()
+	os.MkdirAll(filepath.Dir(configFile), 0755)
+	if err := os.WriteFile(configFile, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if isFirstRun() {
+		t.Error("Expected an existing config to skip the wizard")
+	}
+}
+
+func TestWizard(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	start := func(t *testing.T) (*MainModel, func(tea.KeyMsg)) {
+		m, err := NewMainModel()
+		if err != nil {
+			t.Fatalf("Failed to create model: %v", err)
+		}
+		m.clock = func() time.Time { return now }
+		m.openWizard()
+		update := func(msg tea.KeyMsg) {
+			model, _ := m.Update(msg)
+			m = model.(MainModel)
+		}
+		return &m, update
+	}
+	key := func(k tea.KeyType) tea.KeyMsg { return tea.KeyMsg{Type: k} }
+
+	t.Run("Answers", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		// Resolved once for the session, as runTUI does.
+		eventsFilePath, _ = resolveEventsFilePath()
+		defer func() { eventsFilePath = "" }()
+		m, update := start(t)
+		defaultPath := m.wizard.defaultPath
+
+		view := stripANSI(m.View())
+		for _, want := range []string{"Welcome to countdown", defaultPath, "● Show", "● 12-hour", "‹ Add my own ›"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("Expected %q in:\n%s", want, view)
+			}
+		}
+
+		path := filepath.Join(t.TempDir(), "synced", "events.json")
+		m.wizard.path.SetValue(path)
+		update(key(tea.KeyTab))
+		update(key(tea.KeyRight)) // hide Wikipedia
+		update(key(tea.KeyDown))
+		update(key(tea.KeyRight)) // 24-hour clock
+		update(key(tea.KeyEnter))
+		for i := 0; i < len(eventTemplates); i++ {
+			update(key(tea.KeyRight))
+		}
+		update(key(tea.KeyCtrlS))
+		if m.state != showEvents {
+			t.Fatalf("Expected the list with the first event, got state %v (%v)", m.state, m.wizard.err)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.EventsFile != path || cfg.SidePanel != "timeline" || cfg.TimeFormat != "24h" {
+			t.Errorf("Expected the answers in the config, got %q %q %q", cfg.EventsFile, cfg.SidePanel, cfg.TimeFormat)
+		}
+		if got, _ := getEventsFilePath(); got != path {
+			t.Errorf("Expected the events kept in %s, got %s", path, got)
+		}
+		events, err := readEventsFile()
+		if err != nil || storedNames(events) != "Christmas" || !events[0].Yearly {
+			t.Fatalf("Expected Christmas added yearly, got %v (%v)", events, err)
+		}
+		if want := time.Date(2026, 12, 25, 0, 0, 0, 0, time.Local).Unix(); events[0].Time != want {
+			t.Errorf("Expected the next Christmas, got %v", time.Unix(events[0].Time, 0))
+		}
+		if _, err := os.Stat(defaultPath); !os.IsNotExist(err) {
+			t.Errorf("Expected the unused default events file removed, got %v", err)
+		}
+		if timeline, _ := sideProviderIndex("timeline"); m.config.TimeFormat != "24h" || m.side != timeline {
+			t.Errorf("Expected the answers applied, got %q and panel %d", m.config.TimeFormat, m.side)
+		}
+	})
+
+	t.Run("Add my own", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		m, update := start(t)
+		for i := 0; i < 4; i++ {
+			update(key(tea.KeyEnter))
+		}
+		update(key(tea.KeyEnter))
+		if m.state != showInput {
+			t.Errorf("Expected the form for the first event, got state %v", m.state)
+		}
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		m, update := start(t)
+		m.wizard.path.SetValue(filepath.Join(t.TempDir(), "ignored.json"))
+		update(key(tea.KeyEsc))
+		if m.state != noEvents {
+			t.Errorf("Expected esc to start with no events, got state %v", m.state)
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.EventsFile != "" || cfg.SidePanel != "onthisday" || cfg.TimeFormat != "12h" {
+			t.Errorf("Expected the defaults written, got %q %q %q", cfg.EventsFile, cfg.SidePanel, cfg.TimeFormat)
+		}
+		if isFirstRun() {
+			t.Error("Expected the wizard not to come back")
+		}
+	})
+
+	t.Run("Bad path", func(t *testing.T) {
+		th := newTestHelper(t)
+		defer th.cleanup()
+		m, update := start(t)
+		blocker := filepath.Join(t.TempDir(), "file")
+		os.WriteFile(blocker, nil, 0644)
+		m.wizard.path.SetValue(filepath.Join(blocker, "events.json"))
+		update(key(tea.KeyCtrlS))
+		if m.state != showWizard || m.wizard.err == nil {
+			t.Fatalf("Expected the wizard to stay with an error, got state %v", m.state)
+		}
+		if !isFirstRun() {
+			t.Error("Expected no config written for a path that cannot be set up")
+		}
+	})
+}